@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+	"github.com/aRustyDev/pcf-mcp/internal/observability"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// checkTimeout bounds each --check probe
+const checkTimeout = 5 * time.Second
+
+// skippedCheck is returned by a check that does not apply to the
+// configuration, giving the reason
+type skippedCheck string
+
+func (s skippedCheck) Error() string {
+	return string(s)
+}
+
+// startupCheck is one probe run by --check
+type startupCheck struct {
+	// name describes what passing the check means
+	name string
+
+	// run performs the probe; a skippedCheck error skips it
+	run func(ctx context.Context) error
+}
+
+// checkDependencies reach the live dependencies probed by --check; tests
+// replace them with stubs
+type checkDependencies struct {
+	// ping checks that PCF is reachable with the configured credentials
+	ping func(ctx context.Context, cfg config.PCFConfig) error
+
+	// listen opens a listener, to check a port is free
+	listen func(network, address string) (net.Listener, error)
+
+	// dial opens a connection, to check a collector is reachable
+	dial func(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// defaultCheckDependencies returns the dependencies that reach the network
+func defaultCheckDependencies() checkDependencies {
+	var dialer net.Dialer
+
+	return checkDependencies{
+		ping: func(ctx context.Context, cfg config.PCFConfig) error {
+			client, err := pcf.NewClient(cfg, pcf.WithVersion(mcp.Version))
+			if err != nil {
+				return err
+			}
+			return client.Ping(ctx)
+		},
+		listen: net.Listen,
+		dial:   dialer.DialContext,
+	}
+}
+
+// startupChecks returns the --check probes for cfg: the configuration, PCF
+// connectivity, the metrics port, and the tracing collector
+func startupChecks(cfg *config.Config, deps checkDependencies) []startupCheck {
+	return []startupCheck{
+		{
+			name: "Configuration is valid",
+			run: func(ctx context.Context) error {
+				return cfg.Validate()
+			},
+		},
+		{
+			name: fmt.Sprintf("PCF is reachable at %s", cfg.PCF.URL),
+			run: func(ctx context.Context) error {
+				return deps.ping(ctx, cfg.PCF)
+			},
+		},
+		{
+			name: fmt.Sprintf("Metrics port %d is available", cfg.Metrics.Port),
+			run: func(ctx context.Context) error {
+				if !cfg.Metrics.Enabled {
+					return skippedCheck("metrics are disabled")
+				}
+
+				listener, err := deps.listen("tcp", fmt.Sprintf(":%d", cfg.Metrics.Port))
+				if err != nil {
+					return err
+				}
+				return listener.Close()
+			},
+		},
+		{
+			name: fmt.Sprintf("Tracing collector is reachable at %s", cfg.Tracing.Endpoint),
+			run: func(ctx context.Context) error {
+				if !cfg.Tracing.Enabled {
+					return skippedCheck("tracing is disabled")
+				}
+
+				conn, err := deps.dial(ctx, "tcp", observability.TracingExporterAddress(cfg.Tracing))
+				if err != nil {
+					return err
+				}
+				return conn.Close()
+			},
+		},
+	}
+}
+
+// runChecks runs every check, printing a checklist to w, and reports whether
+// none failed. Checks run even after a failure so every problem is listed.
+func runChecks(ctx context.Context, w io.Writer, checks []startupCheck) bool {
+	var passed, failed, skipped int
+
+	for _, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+		err := check.run(checkCtx)
+		cancel()
+
+		var skip skippedCheck
+		switch {
+		case errors.As(err, &skip):
+			skipped++
+			fmt.Fprintf(w, "[SKIP] %s (%s)\n", check.name, skip)
+		case err != nil:
+			failed++
+			fmt.Fprintf(w, "[FAIL] %s: %v\n", check.name, err)
+		default:
+			passed++
+			fmt.Fprintf(w, "[PASS] %s\n", check.name)
+		}
+	}
+
+	fmt.Fprintf(w, "\n%d passed, %d failed, %d skipped\n", passed, failed, skipped)
+	return failed == 0
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+)
+
+// stubCheckDependencies returns dependencies that succeed without touching
+// the network, recording the addresses they are asked for
+func stubCheckDependencies(t *testing.T, addresses *[]string) checkDependencies {
+	t.Helper()
+
+	return checkDependencies{
+		ping: func(ctx context.Context, cfg config.PCFConfig) error {
+			*addresses = append(*addresses, cfg.URL)
+			return nil
+		},
+		listen: func(network, address string) (net.Listener, error) {
+			*addresses = append(*addresses, address)
+			return net.Listen("tcp", "127.0.0.1:0")
+		},
+		dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			*addresses = append(*addresses, address)
+			client, server := net.Pipe()
+			server.Close()
+			return client, nil
+		},
+	}
+}
+
+// checkConfig returns a valid configuration with metrics and tracing enabled
+func checkConfig() *config.Config {
+	cfg := config.New()
+	cfg.Server.Transport = "http"
+	cfg.PCF.URL = "http://pcf.internal:5000"
+	cfg.Metrics.Enabled = true
+	cfg.Metrics.Port = 9090
+	cfg.Tracing.Enabled = true
+	cfg.Tracing.Exporter = "otlp"
+	cfg.Tracing.Endpoint = "http://collector.internal:4318"
+	return cfg
+}
+
+// TestRunChecksAllPass tests that every probe runs against the configured
+// addresses and the run passes
+func TestRunChecksAllPass(t *testing.T) {
+	var addresses []string
+	var out bytes.Buffer
+
+	if !runChecks(context.Background(), &out, startupChecks(checkConfig(), stubCheckDependencies(t, &addresses))) {
+		t.Fatalf("Expected every check to pass:\n%s", out.String())
+	}
+
+	expected := []string{"http://pcf.internal:5000", ":9090", "collector.internal:4318"}
+	if strings.Join(addresses, " ") != strings.Join(expected, " ") {
+		t.Errorf("Expected probes of %v, got %v", expected, addresses)
+	}
+
+	if strings.Count(out.String(), "[PASS]") != 4 || !strings.Contains(out.String(), "4 passed, 0 failed, 0 skipped") {
+		t.Errorf("Expected four passing checks, got:\n%s", out.String())
+	}
+}
+
+// TestRunChecksPartialFailure tests that failing probes are reported, later
+// checks still run, and disabled features are skipped
+func TestRunChecksPartialFailure(t *testing.T) {
+	cfg := checkConfig()
+	cfg.Tracing.Enabled = false
+
+	var addresses []string
+	deps := stubCheckDependencies(t, &addresses)
+	deps.ping = func(ctx context.Context, cfg config.PCFConfig) error {
+		return errors.New("connection refused")
+	}
+	deps.listen = func(network, address string) (net.Listener, error) {
+		return nil, errors.New("address already in use")
+	}
+
+	var out bytes.Buffer
+	if runChecks(context.Background(), &out, startupChecks(cfg, deps)) {
+		t.Fatalf("Expected the run to fail:\n%s", out.String())
+	}
+
+	for _, line := range []string{
+		"[PASS] Configuration is valid",
+		"[FAIL] PCF is reachable at http://pcf.internal:5000: connection refused",
+		"[FAIL] Metrics port 9090 is available: address already in use",
+		"[SKIP] Tracing collector is reachable at http://collector.internal:4318 (tracing is disabled)",
+		"1 passed, 2 failed, 1 skipped",
+	} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("Expected %q in:\n%s", line, out.String())
+		}
+	}
+
+	// An invalid configuration fails its check without stopping the others
+	cfg.Logging.Level = "verbose"
+	out.Reset()
+	if runChecks(context.Background(), &out, startupChecks(cfg, stubCheckDependencies(t, &addresses))) {
+		t.Fatal("Expected an invalid configuration to fail the run")
+	}
+	if !strings.Contains(out.String(), "[FAIL] Configuration is valid: invalid log level: verbose") || !strings.Contains(out.String(), "[PASS] PCF is reachable") {
+		t.Errorf("Expected only the configuration check to fail, got:\n%s", out.String())
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// maxHealthyGoroutines is the goroutine count above which the server is
+// assumed to be leaking them and reported unhealthy, so that a liveness
+// probe restarts it
+const maxHealthyGoroutines = 10000
+
+// goroutineHealthCheck fails when more than limit goroutines are running
+func goroutineHealthCheck(limit int) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if count := runtime.NumGoroutine(); count > limit {
+			return fmt.Errorf("%d goroutines running, more than %d", count, limit)
+		}
+		return nil
+	}
+}
+
+// breakerHealthCheck fails while the PCF circuit breaker is open
+func breakerHealthCheck(breaker interface{ State() pcf.BreakerState }) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if state := breaker.State(); state == pcf.BreakerOpen {
+			return fmt.Errorf("PCF circuit breaker is %s", state)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// stubBreaker reports a fixed circuit breaker state
+type stubBreaker pcf.BreakerState
+
+func (b stubBreaker) State() pcf.BreakerState {
+	return pcf.BreakerState(b)
+}
+
+// TestGoroutineHealthCheck tests the goroutine leak check against its limit
+func TestGoroutineHealthCheck(t *testing.T) {
+	if err := goroutineHealthCheck(maxHealthyGoroutines)(context.Background()); err != nil {
+		t.Errorf("Expected the test process to pass, got %v", err)
+	}
+
+	if err := goroutineHealthCheck(0)(context.Background()); err == nil {
+		t.Error("Expected a failure with more goroutines than the limit")
+	}
+}
+
+// TestBreakerHealthCheck tests that only an open circuit fails the check
+func TestBreakerHealthCheck(t *testing.T) {
+	tests := []struct {
+		state   pcf.BreakerState
+		wantErr bool
+	}{
+		{state: pcf.BreakerClosed},
+		{state: pcf.BreakerHalfOpen},
+		{state: pcf.BreakerOpen, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state.String(), func(t *testing.T) {
+			err := breakerHealthCheck(stubBreaker(tt.state))(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
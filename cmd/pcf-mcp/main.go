@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -13,8 +14,12 @@ import (
 	"github.com/aRustyDev/pcf-mcp/internal/mcp/tools"
 	"github.com/aRustyDev/pcf-mcp/internal/observability"
 	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf/redact"
 )
 
+// startupPingTimeout bounds the PCF connectivity check made at startup
+const startupPingTimeout = 5 * time.Second
+
 // main is the entry point for the PCF-MCP server application
 func main() {
 	// Check if health check is requested
@@ -28,9 +33,10 @@ func main() {
 	cfg := config.New()
 
 	// Load configuration from various sources
-	// 1. Load from config file if specified
-	if configFile := os.Getenv("PCF_MCP_CONFIG_FILE"); configFile != "" {
-		if err := cfg.LoadFromFile(configFile); err != nil {
+	// 1. Load from config files if specified; a comma-separated list is
+	// merged in order, later files overriding earlier ones
+	if configFiles := os.Getenv("PCF_MCP_CONFIG_FILE"); configFiles != "" {
+		if err := cfg.LoadFromFiles(strings.Split(configFiles, ",")...); err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to load config file: %v\n", err)
 			os.Exit(1)
 		}
@@ -48,14 +54,39 @@ func main() {
 		os.Exit(1)
 	}
 
+	// --dump-config prints the resolved configuration without validating it,
+	// so precedence problems can be debugged even when startup would fail
+	if cfg.DumpRequested() {
+		if err := cfg.WriteYAML(os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to dump config: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// --check probes the configuration and live dependencies without starting
+	// the server, exiting non-zero if any check fails
+	if cfg.CheckRequested() {
+		if !runChecks(context.Background(), os.Stdout, startupChecks(cfg, defaultCheckDependencies())) {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Validate configuration
 	if err := cfg.Validate(); err != nil {
 		fmt.Fprintf(os.Stderr, "Invalid configuration: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Initialize logging
-	logger, err := observability.NewLogger(cfg.Logging)
+	// Initialize logging. The stdio transport owns stdout for JSON-RPC
+	// messages, so logs default to stderr in that mode.
+	logOutput, err := observability.OpenLogOutput(cfg.LogOutput())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+	logger, logLevel, err := observability.NewDynamicLogger(cfg.Logging, logOutput)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
 		os.Exit(1)
@@ -66,9 +97,14 @@ func main() {
 
 	logger.Info("PCF-MCP Server starting",
 		"version", mcp.Version,
+		"commit", mcp.GitCommit,
 		"transport", cfg.Server.Transport,
 	)
 
+	for _, warning := range cfg.Warnings() {
+		logger.Warn("Configuration warning", "warning", warning)
+	}
+
 	// Initialize metrics
 	metrics, err := observability.InitMetrics(cfg.Metrics)
 	if err != nil {
@@ -105,12 +141,32 @@ func main() {
 	}
 
 	// Create PCF client
-	pcfClient, err := pcf.NewClient(cfg.PCF)
+	pcfClient, err := pcf.NewClient(cfg.PCF, pcf.WithMetrics(metrics), pcf.WithVersion(mcp.Version))
 	if err != nil {
 		logger.Error("Failed to create PCF client", "error", err)
 		os.Exit(1)
 	}
 
+	// Mask credential fields in tool results and logged bodies
+	redactor, err := redact.New(cfg.PCF.RedactFields, cfg.PCF.RedactPlaceholder)
+	if err != nil {
+		logger.Error("Invalid redaction config", "error", err)
+		os.Exit(1)
+	}
+
+	// Check PCF connectivity up front so a bad URL or key shows up at startup
+	// rather than on the first tool call. The server still starts if PCF is down.
+	// The HTTP transport instead keeps polling PCF behind its readiness gate.
+	if cfg.Server.Transport != "http" {
+		pingCtx, pingCancel := context.WithTimeout(context.Background(), startupPingTimeout)
+		if err := pcfClient.Ping(pingCtx); err != nil {
+			logger.Warn("PCF is not reachable", "url", cfg.PCF.URL, "error", err)
+		} else {
+			logger.Info("Connected to PCF", "url", cfg.PCF.URL)
+		}
+		pingCancel()
+	}
+
 	// Create MCP server
 	mcpServer, err := mcp.NewServer(cfg.Server)
 	if err != nil {
@@ -120,15 +176,65 @@ func main() {
 
 	// Set metrics on server
 	mcpServer.SetMetrics(metrics)
+	mcpServer.SetMetricsRequireAuth(cfg.Metrics.RequireAuth)
+	mcpServer.SetStatsEndpoint(cfg.Metrics.EnableStatsEndpoint)
+	mcpServer.SetLogBodies(cfg.Logging.LogBodies, redactor)
+
+	// Report readiness based on PCF connectivity
+	mcpServer.SetReadinessChecker(pcfClient.Ping)
+
+	// Fail liveness on a goroutine leak; PCF outages are left to /ready
+	mcpServer.RegisterHealthCheck("goroutines", goroutineHealthCheck(maxHealthyGoroutines), true)
+
+	var toolClient pcf.API = pcfClient
+
+	// Optionally fail fast while PCF is down
+	if cfg.PCF.CircuitBreakerEnabled {
+		breaker := pcf.NewBreakerClient(toolClient, pcf.BreakerSettings{
+			FailureThreshold: cfg.PCF.CircuitBreakerThreshold,
+			Cooldown:         cfg.PCF.CircuitBreakerCooldown,
+			Metrics:          metrics,
+		})
+		toolClient = breaker
+		mcpServer.RegisterHealthCheck("pcf_circuit", breakerHealthCheck(breaker), false)
+		logger.Info("PCF circuit breaker enabled",
+			"threshold", cfg.PCF.CircuitBreakerThreshold,
+			"cooldown", cfg.PCF.CircuitBreakerCooldown,
+		)
+	}
+
+	// Optionally cache read-heavy listings
+	if cfg.PCF.CacheTTL > 0 {
+		toolClient = pcf.NewCachingClient(toolClient, cfg.PCF.CacheTTL)
+		logger.Info("PCF response cache enabled", "ttl", cfg.PCF.CacheTTL)
+	}
 
 	// Register all tools
-	if err := tools.RegisterAllTools(mcpServer, pcfClient); err != nil {
+	toolOptions := tools.Options{
+		DefaultProjectID:      cfg.PCF.DefaultProjectID,
+		ReportDir:             cfg.PCF.ReportDir,
+		Redactor:              redactor,
+		AllowCredentialExport: cfg.PCF.AllowCredentialExport,
+	}
+	if err := tools.RegisterAllTools(mcpServer, toolClient, toolOptions); err != nil {
 		logger.Error("Failed to register tools", "error", err)
 		os.Exit(1)
 	}
 
 	logger.Info("Registered MCP tools", "count", len(mcpServer.ListTools()))
 
+	// Expose read-only PCF data as resources
+	if err := tools.RegisterAllResources(mcpServer, toolClient, redactor); err != nil {
+		logger.Error("Failed to register resources", "error", err)
+		os.Exit(1)
+	}
+
+	// Register prompt templates
+	if err := tools.RegisterAllPrompts(mcpServer); err != nil {
+		logger.Error("Failed to register prompts", "error", err)
+		os.Exit(1)
+	}
+
 	// Set up signal handling for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -142,6 +248,36 @@ func main() {
 		cancel()
 	}()
 
+	// Apply log level and PCF timeout changes from the config file without a restart
+	if os.Getenv("PCF_MCP_CONFIG_FILE") != "" {
+		err := cfg.WatchFile(ctx, func(next *config.Config) {
+			if err := observability.SetLogLevel(logLevel, next.Logging.Level); err != nil {
+				logger.Error("Failed to apply log level", "error", err)
+			}
+			pcfClient.SetTimeout(next.PCF.Timeout)
+
+			logger.Info("Applied configuration change",
+				"log_level", next.Logging.Level,
+				"pcf_timeout", next.PCF.Timeout,
+			)
+		})
+		if err != nil {
+			logger.Warn("Config file hot-reload disabled", "error", err)
+		}
+	}
+
+	// Hold /ready at "starting" until PCF answers so orchestrators do not
+	// route traffic before PCF auth is confirmed
+	if cfg.Server.Transport == "http" {
+		go func() {
+			if err := mcpServer.WaitReady(ctx); err != nil {
+				logger.Warn("PCF is not reachable", "url", cfg.PCF.URL, "error", err)
+				return
+			}
+			logger.Info("Connected to PCF", "url", cfg.PCF.URL)
+		}()
+	}
+
 	// Start the server
 	logger.Info("Starting MCP server", "transport", cfg.Server.Transport)
 
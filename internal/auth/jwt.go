@@ -0,0 +1,316 @@
+// Package auth verifies JWT bearer tokens presented to the HTTP transport
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrInvalidToken is wrapped by every token validation failure
+var ErrInvalidToken = errors.New("invalid token")
+
+// clockSkew is the tolerance applied to exp and nbf for clock drift
+// between the token issuer and this server
+const clockSkew = 30 * time.Second
+
+// JWTOptions configures a JWTValidator. Exactly one of JWKSURL and
+// PublicKeyFile must be set.
+type JWTOptions struct {
+	// JWKSURL is fetched for the signing keys, selected by the token's kid
+	JWKSURL string
+
+	// PublicKeyFile is a PEM file holding the single signing key
+	PublicKeyFile string
+
+	// Audience, when set, must appear in the token's aud claim
+	Audience string
+
+	// Issuer, when set, must equal the token's iss claim
+	Issuer string
+
+	// HTTPClient fetches the JWKS; a client with a 10 second timeout is
+	// used when nil
+	HTTPClient *http.Client
+}
+
+// Claims holds the verified claims of a token
+type Claims struct {
+	// Subject is the sub claim, identifying the caller
+	Subject string
+
+	// Issuer is the iss claim
+	Issuer string
+
+	// Audience lists the aud claim values
+	Audience []string
+
+	// ExpiresAt is the exp claim
+	ExpiresAt time.Time
+
+	// Scopes lists the scope (space-separated) or scp claim values; nil
+	// when the token carries neither claim
+	Scopes []string
+
+	// Raw holds every claim as decoded from the payload
+	Raw map[string]interface{}
+}
+
+// JWTValidator verifies the signature and standard claims of JWTs
+type JWTValidator struct {
+	audience string
+	issuer   string
+	keys     keySource
+	now      func() time.Time
+}
+
+// NewJWTValidator creates a validator from opts. A PEM key file is read
+// immediately; a JWKS is fetched on first use and refreshed periodically.
+func NewJWTValidator(opts JWTOptions) (*JWTValidator, error) {
+	if (opts.JWKSURL == "") == (opts.PublicKeyFile == "") {
+		return nil, fmt.Errorf("exactly one of JWKS URL and public key file must be set")
+	}
+
+	v := &JWTValidator{
+		audience: opts.Audience,
+		issuer:   opts.Issuer,
+		now:      time.Now,
+	}
+
+	if opts.PublicKeyFile != "" {
+		key, err := loadPublicKeyFile(opts.PublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		v.keys = staticKey{publicKey: key}
+		return v, nil
+	}
+
+	client := opts.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: jwksFetchTimeout}
+	}
+	v.keys = newJWKSCache(opts.JWKSURL, client)
+
+	return v, nil
+}
+
+// jwtHeader is the JOSE header of a token
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// Validate verifies token and returns its claims. Failures wrap
+// ErrInvalidToken, except for errors fetching the JWKS.
+func (v *JWTValidator) Validate(ctx context.Context, token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: malformed header: %v", ErrInvalidToken, err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature: %v", ErrInvalidToken, err)
+	}
+
+	key, err := v.keys.key(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifySignature(header.Alg, key, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	var raw map[string]interface{}
+	if err := decodeSegment(parts[1], &raw); err != nil {
+		return nil, fmt.Errorf("%w: malformed payload: %v", ErrInvalidToken, err)
+	}
+
+	claims, err := v.checkClaims(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	return claims, nil
+}
+
+// checkClaims checks exp, nbf, aud, and iss and builds the Claims
+func (v *JWTValidator) checkClaims(raw map[string]interface{}) (*Claims, error) {
+	now := v.now()
+	claims := &Claims{Raw: raw}
+
+	exp, ok := raw["exp"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("missing exp claim")
+	}
+	claims.ExpiresAt = time.Unix(int64(exp), 0)
+	if now.After(claims.ExpiresAt.Add(clockSkew)) {
+		return nil, fmt.Errorf("token has expired")
+	}
+
+	if nbf, ok := raw["nbf"].(float64); ok && now.Add(clockSkew).Before(time.Unix(int64(nbf), 0)) {
+		return nil, fmt.Errorf("token is not valid yet")
+	}
+
+	claims.Subject, _ = raw["sub"].(string)
+	claims.Issuer, _ = raw["iss"].(string)
+
+	switch aud := raw["aud"].(type) {
+	case string:
+		claims.Audience = []string{aud}
+	case []interface{}:
+		for _, entry := range aud {
+			if s, ok := entry.(string); ok {
+				claims.Audience = append(claims.Audience, s)
+			}
+		}
+	}
+
+	claims.Scopes = parseScopes(raw)
+
+	if v.issuer != "" && claims.Issuer != v.issuer {
+		return nil, fmt.Errorf("unexpected issuer %q", claims.Issuer)
+	}
+
+	if v.audience != "" && !slices.Contains(claims.Audience, v.audience) {
+		return nil, fmt.Errorf("token is not intended for audience %q", v.audience)
+	}
+
+	return claims, nil
+}
+
+// parseScopes reads the OAuth scope claim, a space-separated string, or
+// the scp claim, which some issuers publish as a list
+func parseScopes(raw map[string]interface{}) []string {
+	if scope, ok := raw["scope"].(string); ok {
+		return append([]string{}, strings.Fields(scope)...)
+	}
+
+	switch scp := raw["scp"].(type) {
+	case string:
+		return append([]string{}, strings.Fields(scp)...)
+	case []interface{}:
+		scopes := make([]string, 0, len(scp))
+		for _, entry := range scp {
+			if s, ok := entry.(string); ok {
+				scopes = append(scopes, s)
+			}
+		}
+		return scopes
+	}
+
+	return nil
+}
+
+// verifySignature checks signature over signingInput with the algorithm
+// named in the token header. The algorithm must suit the key's type, so a
+// token cannot pick a weaker scheme than the key was issued for.
+func verifySignature(alg string, key crypto.PublicKey, signingInput string, signature []byte) error {
+	var hash crypto.Hash
+	switch alg {
+	case "RS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512", "ES512":
+		hash = crypto.SHA512
+	case "EdDSA":
+	default:
+		return fmt.Errorf("unsupported signing algorithm %q", alg)
+	}
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "RS") {
+			return fmt.Errorf("algorithm %s does not match RSA key", alg)
+		}
+		h := hash.New()
+		h.Write([]byte(signingInput))
+		if err := rsa.VerifyPKCS1v15(k, hash, h.Sum(nil), signature); err != nil {
+			return fmt.Errorf("signature verification failed")
+		}
+
+	case *ecdsa.PublicKey:
+		if !strings.HasPrefix(alg, "ES") || ecdsaAlgorithm(k) != alg {
+			return fmt.Errorf("algorithm %s does not match EC key", alg)
+		}
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return fmt.Errorf("signature verification failed")
+		}
+		h := hash.New()
+		h.Write([]byte(signingInput))
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(k, h.Sum(nil), r, s) {
+			return fmt.Errorf("signature verification failed")
+		}
+
+	case ed25519.PublicKey:
+		if alg != "EdDSA" {
+			return fmt.Errorf("algorithm %s does not match Ed25519 key", alg)
+		}
+		if !ed25519.Verify(k, []byte(signingInput), signature) {
+			return fmt.Errorf("signature verification failed")
+		}
+
+	default:
+		return fmt.Errorf("unsupported key type %T", key)
+	}
+
+	return nil
+}
+
+// ecdsaAlgorithm returns the JWS algorithm for an EC key's curve
+func ecdsaAlgorithm(key *ecdsa.PublicKey) string {
+	switch key.Curve.Params().BitSize {
+	case 256:
+		return "ES256"
+	case 384:
+		return "ES384"
+	case 521:
+		return "ES512"
+	}
+	return ""
+}
+
+// decodeSegment decodes a base64url-encoded JSON token segment into v
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// claimsKey is the context key for verified claims
+type claimsKey struct{}
+
+// WithClaims returns a context carrying the verified claims of the caller
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFromContext returns the claims stored by WithClaims, or nil
+func ClaimsFromContext(ctx context.Context) *Claims {
+	claims, _ := ctx.Value(claimsKey{}).(*Claims)
+	return claims
+}
@@ -0,0 +1,399 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// signToken builds a JWT signed with key. Supported keys are RSA (RS256),
+// P-256 ECDSA (ES256), and Ed25519 (EdDSA).
+func signToken(t *testing.T, key crypto.Signer, kid string, claims map[string]interface{}) string {
+	t.Helper()
+
+	var alg string
+	switch key.(type) {
+	case *rsa.PrivateKey:
+		alg = "RS256"
+	case *ecdsa.PrivateKey:
+		alg = "ES256"
+	case ed25519.PrivateKey:
+		alg = "EdDSA"
+	}
+
+	header := map[string]interface{}{"alg": alg, "typ": "JWT"}
+	if kid != "" {
+		header["kid"] = kid
+	}
+
+	signingInput := encodeSegment(t, header) + "." + encodeSegment(t, claims)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	var signature []byte
+	var err error
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+	case *ecdsa.PrivateKey:
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, k, digest[:])
+		if err == nil {
+			signature = make([]byte, 64)
+			r.FillBytes(signature[:32])
+			s.FillBytes(signature[32:])
+		}
+	case ed25519.PrivateKey:
+		signature = ed25519.Sign(k, []byte(signingInput))
+	}
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func encodeSegment(t *testing.T, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Failed to encode token segment: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// writePublicKey writes the PEM-encoded public half of key to a temp file
+func writePublicKey(t *testing.T, key crypto.Signer) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatalf("Failed to marshal public key: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "jwt.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("Failed to write public key: %v", err)
+	}
+	return path
+}
+
+// newRSAKey generates an RSA test key
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	return key
+}
+
+// validClaims returns claims that pass a validator for audience
+// "pcf-mcp" and issuer "https://issuer.example"
+func validClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"sub": "alice",
+		"iss": "https://issuer.example",
+		"aud": "pcf-mcp",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+// TestJWTValidatorPublicKeyFile tests validating tokens against a PEM key
+func TestJWTValidatorPublicKeyFile(t *testing.T) {
+	key := newRSAKey(t)
+	validator, err := NewJWTValidator(JWTOptions{
+		PublicKeyFile: writePublicKey(t, key),
+		Audience:      "pcf-mcp",
+		Issuer:        "https://issuer.example",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	with := func(key string, value interface{}) map[string]interface{} {
+		claims := validClaims()
+		if value == nil {
+			delete(claims, key)
+		} else {
+			claims[key] = value
+		}
+		return claims
+	}
+
+	otherKey := newRSAKey(t)
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr string
+	}{
+		{name: "valid", token: signToken(t, key, "", validClaims())},
+		{name: "audience list", token: signToken(t, key, "", with("aud", []string{"other", "pcf-mcp"}))},
+		{name: "within clock skew", token: signToken(t, key, "", with("exp", time.Now().Add(-10*time.Second).Unix()))},
+		{name: "expired", token: signToken(t, key, "", with("exp", time.Now().Add(-time.Hour).Unix())), wantErr: "expired"},
+		{name: "missing exp", token: signToken(t, key, "", with("exp", nil)), wantErr: "missing exp"},
+		{name: "not yet valid", token: signToken(t, key, "", with("nbf", time.Now().Add(time.Hour).Unix())), wantErr: "not valid yet"},
+		{name: "wrong audience", token: signToken(t, key, "", with("aud", "other-service")), wantErr: "audience"},
+		{name: "wrong issuer", token: signToken(t, key, "", with("iss", "https://evil.example")), wantErr: "issuer"},
+		{name: "wrong key", token: signToken(t, otherKey, "", validClaims()), wantErr: "signature verification failed"},
+		{name: "malformed", token: "not-a-jwt", wantErr: "malformed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := validator.Validate(context.Background(), tt.token)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if claims.Subject != "alice" {
+					t.Errorf("Expected subject 'alice', got %q", claims.Subject)
+				}
+				return
+			}
+
+			if !errors.Is(err, ErrInvalidToken) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected invalid token error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestJWTValidatorScopes tests reading the scope and scp claims
+func TestJWTValidatorScopes(t *testing.T) {
+	key := newRSAKey(t)
+	validator, err := NewJWTValidator(JWTOptions{PublicKeyFile: writePublicKey(t, key)})
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		claim string
+		value interface{}
+		want  []string
+	}{
+		{name: "no scope claim"},
+		{name: "scope string", claim: "scope", value: "read  write", want: []string{"read", "write"}},
+		{name: "scp list", claim: "scp", value: []string{"read"}, want: []string{"read"}},
+		{name: "empty scope", claim: "scope", value: "", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := validClaims()
+			if tt.claim != "" {
+				claims[tt.claim] = tt.value
+			}
+
+			got, err := validator.Validate(context.Background(), signToken(t, key, "", claims))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if (got.Scopes == nil) != (tt.want == nil) || strings.Join(got.Scopes, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("Expected scopes %#v, got %#v", tt.want, got.Scopes)
+			}
+		})
+	}
+}
+
+// TestJWTValidatorAlgorithms tests that the header algorithm must match the key
+func TestJWTValidatorAlgorithms(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate EC key: %v", err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate Ed25519 key: %v", err)
+	}
+
+	for _, key := range []crypto.Signer{ecKey, edKey} {
+		validator, err := NewJWTValidator(JWTOptions{PublicKeyFile: writePublicKey(t, key)})
+		if err != nil {
+			t.Fatalf("Failed to create validator: %v", err)
+		}
+
+		token := signToken(t, key, "", validClaims())
+		if _, err := validator.Validate(context.Background(), token); err != nil {
+			t.Errorf("Unexpected error for %T: %v", key, err)
+		}
+
+		// Swapping the header algorithm must not be accepted
+		parts := strings.Split(token, ".")
+		parts[0] = encodeSegment(t, map[string]string{"alg": "RS256"})
+		if _, err := validator.Validate(context.Background(), strings.Join(parts, ".")); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("Expected algorithm mismatch for %T, got %v", key, err)
+		}
+
+		parts[0] = encodeSegment(t, map[string]string{"alg": "none"})
+		if _, err := validator.Validate(context.Background(), parts[0]+"."+parts[1]+"."); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("Expected 'none' algorithm to be rejected for %T, got %v", key, err)
+		}
+	}
+}
+
+// TestJWTValidatorJWKS tests selecting keys from a JWKS by kid
+func TestJWTValidatorJWKS(t *testing.T) {
+	key := newRSAKey(t)
+
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{
+				{"kty": "oct", "kid": "symmetric", "k": "c2VjcmV0"},
+				{"kty": "RSA", "kid": "encryption", "use": "enc", "n": "AQAB", "e": "AQAB"},
+				{
+					"kty": "RSA",
+					"kid": "key-1",
+					"use": "sig",
+					"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+				},
+			},
+		})
+	}))
+	defer server.Close()
+
+	validator, err := NewJWTValidator(JWTOptions{JWKSURL: server.URL, Audience: "pcf-mcp"})
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	claims, err := validator.Validate(context.Background(), signToken(t, key, "key-1", validClaims()))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if claims.Subject != "alice" {
+		t.Errorf("Expected subject 'alice', got %q", claims.Subject)
+	}
+
+	// The only signing key is used for tokens without a kid
+	if _, err := validator.Validate(context.Background(), signToken(t, key, "", validClaims())); err != nil {
+		t.Errorf("Unexpected error for token without kid: %v", err)
+	}
+
+	// An unknown kid right after a fetch does not refetch the key set
+	_, err = validator.Validate(context.Background(), signToken(t, key, "key-2", validClaims()))
+	if !errors.Is(err, ErrInvalidToken) || !strings.Contains(err.Error(), "unknown signing key") {
+		t.Errorf("Expected unknown key error, got %v", err)
+	}
+	if got := fetches.Load(); got != 1 {
+		t.Errorf("Expected 1 JWKS fetch, got %d", got)
+	}
+
+	// Wrong audience is rejected with JWKS keys too
+	wrong := validClaims()
+	wrong["aud"] = "other-service"
+	if _, err := validator.Validate(context.Background(), signToken(t, key, "key-1", wrong)); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Expected audience error, got %v", err)
+	}
+}
+
+// TestJWKSCacheRefresh tests that stale and unknown-kid lookups refetch the key set
+func TestJWKSCacheRefresh(t *testing.T) {
+	first, second := newRSAKey(t), newRSAKey(t)
+
+	var current atomic.Pointer[rsa.PrivateKey]
+	current.Store(first)
+	var fail atomic.Bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		key := current.Load()
+		kid := "first"
+		if key == second {
+			kid = "second"
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": kid,
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	cache := newJWKSCache(server.URL, server.Client())
+	cache.now = func() time.Time { return now }
+
+	if _, err := cache.key(context.Background(), "first"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Rotated key: an unknown kid refetches once the minimum interval passed
+	current.Store(second)
+	if _, err := cache.key(context.Background(), "second"); err == nil {
+		t.Error("Expected unknown kid within the refetch interval to fail")
+	}
+	now = now.Add(jwksMinRefetchInterval)
+	if _, err := cache.key(context.Background(), "second"); err != nil {
+		t.Errorf("Expected rotated key after refetch, got %v", err)
+	}
+
+	// A failed refresh keeps serving the cached keys
+	fail.Store(true)
+	now = now.Add(jwksRefreshInterval)
+	if _, err := cache.key(context.Background(), "second"); err != nil {
+		t.Errorf("Expected cached key after failed refresh, got %v", err)
+	}
+}
+
+// TestNewJWTValidatorErrors tests validator construction errors
+func TestNewJWTValidatorErrors(t *testing.T) {
+	if _, err := NewJWTValidator(JWTOptions{}); err == nil {
+		t.Error("Expected error without a key source")
+	}
+
+	if _, err := NewJWTValidator(JWTOptions{JWKSURL: "https://issuer.example/jwks", PublicKeyFile: "key.pem"}); err == nil {
+		t.Error("Expected error with both key sources")
+	}
+
+	if _, err := NewJWTValidator(JWTOptions{PublicKeyFile: filepath.Join(t.TempDir(), "missing.pem")}); err == nil {
+		t.Error("Expected error for missing key file")
+	}
+
+	notPEM := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(notPEM, []byte("not a key"), 0o600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if _, err := NewJWTValidator(JWTOptions{PublicKeyFile: notPEM}); err == nil {
+		t.Error("Expected error for non-PEM key file")
+	}
+}
+
+// TestClaimsContext tests storing claims on a context
+func TestClaimsContext(t *testing.T) {
+	if claims := ClaimsFromContext(context.Background()); claims != nil {
+		t.Errorf("Expected no claims, got %v", claims)
+	}
+
+	claims := &Claims{Subject: "alice"}
+	if got := ClaimsFromContext(WithClaims(context.Background(), claims)); got != claims {
+		t.Errorf("Expected stored claims, got %v", got)
+	}
+}
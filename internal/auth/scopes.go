@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"context"
+	"slices"
+)
+
+// scopesKey is the context key for the caller's granted scopes
+type scopesKey struct{}
+
+// WithScopes returns a context recording the scopes granted to the caller
+func WithScopes(ctx context.Context, scopes []string) context.Context {
+	return context.WithValue(ctx, scopesKey{}, scopes)
+}
+
+// ScopesFromContext returns the scopes stored by WithScopes. ok is false
+// when the caller is unrestricted: its token has no configured scopes, or
+// the request did not go through authentication, as with stdio sessions.
+func ScopesFromContext(ctx context.Context) (scopes []string, ok bool) {
+	scopes, ok = ctx.Value(scopesKey{}).([]string)
+	return scopes, ok
+}
+
+// HasScope reports whether the caller on ctx may use scope. Unrestricted
+// callers have every scope.
+func HasScope(ctx context.Context, scope string) bool {
+	scopes, ok := ScopesFromContext(ctx)
+	return !ok || slices.Contains(scopes, scope)
+}
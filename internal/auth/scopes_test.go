@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"context"
+	"testing"
+)
+
+// TestHasScope tests scope checks for restricted and unrestricted callers
+func TestHasScope(t *testing.T) {
+	ctx := context.Background()
+	if !HasScope(ctx, "write") {
+		t.Error("Callers without recorded scopes should be unrestricted")
+	}
+
+	readOnly := WithScopes(ctx, []string{"read"})
+	if !HasScope(readOnly, "read") {
+		t.Error("Expected read scope to be granted")
+	}
+	if HasScope(readOnly, "write") {
+		t.Error("Expected write scope to be denied")
+	}
+
+	if HasScope(WithScopes(ctx, []string{}), "read") {
+		t.Error("Expected an empty scope list to deny every scope")
+	}
+}
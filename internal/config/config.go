@@ -1,9 +1,16 @@
 // Package config provides multi-source configuration management
-// using Viper. Configuration precedence: CLI > ENV > File > Defaults
+// using Viper. Configuration precedence: CLI > ENV > File > Defaults, where
+// several files may be merged in order with LoadFromFiles
 package config
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -18,6 +25,12 @@ type Config struct {
 	Logging LoggingConfig `mapstructure:"logging"`
 	Metrics MetricsConfig `mapstructure:"metrics"`
 	Tracing TracingConfig `mapstructure:"tracing"`
+
+	// dumpConfig is set when --dump-config was passed on the command line
+	dumpConfig bool
+
+	// checkRequested is set when --check was passed on the command line
+	checkRequested bool
 }
 
 // ServerConfig contains MCP server configuration
@@ -26,34 +39,230 @@ type ServerConfig struct {
 	Host string `mapstructure:"host"`
 	// Port is the server listen port
 	Port int `mapstructure:"port"`
+	// UnixSocket makes the HTTP transport listen on this Unix domain socket
+	// path instead of Host and Port
+	UnixSocket string `mapstructure:"unix_socket"`
 	// Transport specifies the MCP transport type (stdio or http)
 	Transport string `mapstructure:"transport"`
 	// ReadTimeout is the maximum duration for reading the entire request
 	ReadTimeout time.Duration `mapstructure:"read_timeout"`
 	// WriteTimeout is the maximum duration before timing out writes of the response
 	WriteTimeout time.Duration `mapstructure:"write_timeout"`
+	// IdleTimeout is how long an idle keep-alive connection is kept open
+	IdleTimeout time.Duration `mapstructure:"idle_timeout"`
+	// ReadHeaderTimeout is the maximum duration for reading request headers
+	ReadHeaderTimeout time.Duration `mapstructure:"read_header_timeout"`
 	// MaxConcurrentTools limits concurrent tool executions
 	MaxConcurrentTools int `mapstructure:"max_concurrent_tools"`
 	// ToolTimeout is the maximum duration for tool execution
 	ToolTimeout time.Duration `mapstructure:"tool_timeout"`
+	// HandlerTimeout bounds the processing of each HTTP request; tool
+	// endpoints get the larger of this and ToolTimeout (0 disables the limit)
+	HandlerTimeout time.Duration `mapstructure:"handler_timeout"`
 	// AuthRequired enables authentication for HTTP transport
 	AuthRequired bool `mapstructure:"auth_required"`
-	// AuthToken is the bearer token for authentication
+	// AuthToken is a single bearer token for authentication; kept for
+	// compatibility and treated as an additional entry in AuthTokens
 	AuthToken string `mapstructure:"auth_token"`
+	// AuthTokens lists the accepted bearer tokens, allowing rotation without downtime
+	AuthTokens []string `mapstructure:"auth_tokens"`
+	// TokenScopes restricts static bearer tokens to scopes such as "read"
+	// and "write"; tokens without an entry may call every tool
+	TokenScopes []TokenScope `mapstructure:"token_scopes"`
+	// AuthMode selects how bearer tokens are checked: static compares them
+	// against AuthToken and AuthTokens, jwt verifies them as signed JWTs
+	AuthMode string `mapstructure:"auth_mode"`
+	// JWTJWKSURL is the JWKS endpoint publishing the JWT signing keys
+	JWTJWKSURL string `mapstructure:"jwt_jwks_url"`
+	// JWTPublicKeyFile is a PEM public key or certificate that signs JWTs
+	JWTPublicKeyFile string `mapstructure:"jwt_public_key_file"`
+	// JWTAudience, when set, must appear in each JWT's aud claim
+	JWTAudience string `mapstructure:"jwt_audience"`
+	// JWTIssuer, when set, must equal each JWT's iss claim
+	JWTIssuer string `mapstructure:"jwt_issuer"`
+	// JWTDefaultScopes are granted to JWTs without a scope or scp claim;
+	// empty means such tokens may only call tools that require no scope
+	JWTDefaultScopes []string `mapstructure:"jwt_default_scopes"`
+	// TLSCertFile is the path to the PEM certificate for HTTPS (requires TLSKeyFile)
+	TLSCertFile string `mapstructure:"tls_cert_file"`
+	// TLSKeyFile is the path to the PEM private key for HTTPS (requires TLSCertFile)
+	TLSKeyFile string `mapstructure:"tls_key_file"`
+	// TLSMinVersion is the minimum accepted TLS version (1.2 or 1.3)
+	TLSMinVersion string `mapstructure:"tls_min_version"`
+	// EnabledTools, when set, lists the only tools that are registered
+	EnabledTools []string `mapstructure:"enabled_tools"`
+	// DisabledTools lists tools that are never registered
+	DisabledTools []string `mapstructure:"disabled_tools"`
+	// ToolNamePrefix, when set, namespaces the exposed tool names, e.g.
+	// "pcf" exposes list_projects as pcf_list_projects
+	ToolNamePrefix string `mapstructure:"tool_name_prefix"`
+	// ValidateToolInput rejects tool parameters that do not match the tool's InputSchema
+	ValidateToolInput bool `mapstructure:"validate_tool_input"`
+	// EnablePprof mounts net/http/pprof at /debug/pprof/ (requires AuthRequired)
+	EnablePprof bool `mapstructure:"enable_pprof"`
+	// RateLimitPerSecond is the sustained request rate allowed per client (0 disables rate limiting)
+	RateLimitPerSecond float64 `mapstructure:"rate_limit_per_second"`
+	// RateLimitBurst is the number of requests a client may make at once above the sustained rate
+	RateLimitBurst int `mapstructure:"rate_limit_burst"`
+	// StartupReadyTimeout is how long /ready waits for the first successful
+	// PCF contact after an HTTP server starts (0 disables the startup gate)
+	StartupReadyTimeout time.Duration `mapstructure:"startup_ready_timeout"`
+	// ShutdownTimeout bounds the whole HTTP server shutdown, including the drain
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+	// DrainTimeout is how long shutdown waits for in-flight requests to finish
+	DrainTimeout time.Duration `mapstructure:"drain_timeout"`
+}
+
+// TokenScope grants a static bearer token a set of scopes. It is a list
+// entry rather than a map keyed by token because configuration keys are
+// case-insensitive while tokens are not.
+type TokenScope struct {
+	// Token is one of the configured bearer tokens
+	Token string `mapstructure:"token"`
+	// Scopes lists the scopes the token grants
+	Scopes []string `mapstructure:"scopes"`
+}
+
+// validateTokenScopes checks that every scoped token is a configured,
+// distinct bearer token. Tokens are identified by index so they never
+// appear in error messages.
+func (c ServerConfig) validateTokenScopes() error {
+	valid := c.ValidAuthTokens()
+	seen := make(map[string]bool, len(c.TokenScopes))
+	for i, entry := range c.TokenScopes {
+		if !slices.Contains(valid, entry.Token) {
+			return fmt.Errorf("server token_scopes[%d] does not match any configured auth token", i)
+		}
+		if seen[entry.Token] {
+			return fmt.Errorf("server token_scopes[%d] repeats a token listed earlier", i)
+		}
+		seen[entry.Token] = true
+	}
+	return nil
+}
+
+// validateToolLists checks that no tool is both enabled and disabled. Tool
+// names themselves are checked when the tools are registered.
+func (c ServerConfig) validateToolLists() error {
+	for _, name := range c.DisabledTools {
+		if slices.Contains(c.EnabledTools, name) {
+			return fmt.Errorf("server tool %q is listed in both enabled_tools and disabled_tools", name)
+		}
+	}
+	return nil
+}
+
+// validateAuthMode checks the auth mode and the JWT settings it depends on.
+// An empty mode is treated as static.
+func (c ServerConfig) validateAuthMode() error {
+	switch c.AuthMode {
+	case "", "static":
+		return nil
+	case "jwt":
+	default:
+		return fmt.Errorf("invalid server auth mode: %s (must be 'static' or 'jwt')", c.AuthMode)
+	}
+
+	if !c.AuthRequired {
+		return fmt.Errorf("jwt auth mode requires server authentication to be enabled")
+	}
+
+	if (c.JWTJWKSURL == "") == (c.JWTPublicKeyFile == "") {
+		return fmt.Errorf("jwt auth mode requires exactly one of server jwt_jwks_url and jwt_public_key_file")
+	}
+
+	if c.JWTJWKSURL != "" {
+		u, err := url.Parse(c.JWTJWKSURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid server JWKS URL: %s", c.JWTJWKSURL)
+		}
+	}
+
+	return nil
+}
+
+// ValidAuthTokens returns every accepted bearer token: AuthTokens followed by
+// the legacy AuthToken. Empty entries are dropped.
+func (c ServerConfig) ValidAuthTokens() []string {
+	tokens := make([]string, 0, len(c.AuthTokens)+1)
+	for _, token := range c.AuthTokens {
+		if token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	if c.AuthToken != "" {
+		tokens = append(tokens, c.AuthToken)
+	}
+	return tokens
 }
 
 // PCFConfig contains Pentest Collaboration Framework client configuration
 type PCFConfig struct {
 	// URL is the base URL of the PCF instance
 	URL string `mapstructure:"url"`
+	// APIBasePath is the path prefix of every PCF API request, e.g. /api/v2
+	APIBasePath string `mapstructure:"api_base_path"`
 	// APIKey is the authentication key for PCF API
 	APIKey string `mapstructure:"api_key"`
+	// APIKeyHeader is the request header that carries APIKey
+	APIKeyHeader string `mapstructure:"api_key_header"`
+	// APIKeyScheme is an optional prefix for APIKey, e.g. "Bearer"
+	APIKeyScheme string `mapstructure:"api_key_scheme"`
+	// UserAgent overrides the User-Agent sent to PCF (default pcf-mcp/<version>)
+	UserAgent string `mapstructure:"user_agent"`
 	// Timeout is the HTTP client timeout for PCF requests
 	Timeout time.Duration `mapstructure:"timeout"`
 	// MaxRetries is the maximum number of retry attempts for failed requests
 	MaxRetries int `mapstructure:"max_retries"`
+	// RetryMaxDelay caps how long the client waits before retrying a
+	// rate-limited (429) request, whatever Retry-After asks for
+	RetryMaxDelay time.Duration `mapstructure:"retry_max_delay"`
 	// InsecureSkipVerify skips TLS certificate verification (not recommended for production)
 	InsecureSkipVerify bool `mapstructure:"insecure_skip_verify"`
+	// CAFile is a PEM CA bundle used to verify PCF instead of the system roots
+	CAFile string `mapstructure:"ca_file"`
+	// ClientCertFile is the PEM client certificate for mutual TLS (requires ClientKeyFile)
+	ClientCertFile string `mapstructure:"client_cert_file"`
+	// ClientKeyFile is the PEM client private key for mutual TLS (requires ClientCertFile)
+	ClientKeyFile string `mapstructure:"client_key_file"`
+	// BulkWorkers caps concurrent requests made by bulk operations such as AddHosts
+	BulkWorkers int `mapstructure:"bulk_workers"`
+	// MaxConcurrentRequests caps requests in flight to PCF across all
+	// callers (0 means unlimited)
+	MaxConcurrentRequests int `mapstructure:"max_concurrent_requests"`
+	// MaxIdleConns caps idle keep-alive connections kept open across all hosts
+	MaxIdleConns int `mapstructure:"max_idle_conns"`
+	// MaxIdleConnsPerHost caps idle keep-alive connections kept open to PCF
+	MaxIdleConnsPerHost int `mapstructure:"max_idle_conns_per_host"`
+	// IdleConnTimeout is how long an idle keep-alive connection is kept open
+	IdleConnTimeout time.Duration `mapstructure:"idle_conn_timeout"`
+	// RedactFields lists credential fields masked in tool output (value is always masked)
+	RedactFields []string `mapstructure:"redact_fields"`
+	// RedactPlaceholder replaces redacted credential fields
+	RedactPlaceholder string `mapstructure:"redact_placeholder"`
+	// CacheTTL caches project, host, and issue listings for this long (0 disables caching)
+	CacheTTL time.Duration `mapstructure:"cache_ttl"`
+	// ReportDir is the directory the download_report tool writes reports to
+	ReportDir string `mapstructure:"report_dir"`
+	// ProxyURL routes PCF requests through an HTTP, HTTPS, or SOCKS5 proxy
+	// (defaults to the HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables)
+	ProxyURL string `mapstructure:"proxy_url"`
+	// DefaultProjectID is used by host, issue, credential, and report tools
+	// when a call omits project_id, for single-engagement deployments
+	DefaultProjectID string `mapstructure:"default_project_id"`
+	// AllowCredentialExport lets export_project include raw credential
+	// values when a call asks for them
+	AllowCredentialExport bool `mapstructure:"allow_credential_export"`
+	// AllowedHosts restricts PCF requests, including redirects and report
+	// downloads, to these hosts ("host" or "host:port"); empty allows any host
+	AllowedHosts []string `mapstructure:"allowed_hosts"`
+	// CircuitBreakerEnabled stops calling PCF for a cooldown after
+	// consecutive failures, failing tool calls fast during an outage
+	CircuitBreakerEnabled bool `mapstructure:"circuit_breaker_enabled"`
+	// CircuitBreakerThreshold is the number of consecutive failures that opens the circuit
+	CircuitBreakerThreshold int `mapstructure:"circuit_breaker_threshold"`
+	// CircuitBreakerCooldown is how long an open circuit rejects calls before probing PCF
+	CircuitBreakerCooldown time.Duration `mapstructure:"circuit_breaker_cooldown"`
 }
 
 // LoggingConfig contains logging configuration
@@ -64,6 +273,16 @@ type LoggingConfig struct {
 	Format string `mapstructure:"format"`
 	// AddSource includes source code location in logs
 	AddSource bool `mapstructure:"add_source"`
+	// Output is where logs are written: stdout, stderr, or a file path
+	// appended to. Empty means stderr for the stdio transport and stdout
+	// otherwise (see Config.LogOutput).
+	Output string `mapstructure:"output"`
+	// LogBodies controls logging of HTTP request and response bodies, with
+	// credential fields redacted: off, on_error, or always
+	LogBodies string `mapstructure:"log_bodies"`
+	// RedactKeys lists log attribute keys, matched ignoring case, whose
+	// values are replaced by *** wherever they are logged
+	RedactKeys []string `mapstructure:"redact_keys"`
 }
 
 // MetricsConfig contains Prometheus metrics configuration
@@ -74,6 +293,18 @@ type MetricsConfig struct {
 	Port int `mapstructure:"port"`
 	// Path is the metrics endpoint path
 	Path string `mapstructure:"path"`
+	// RequireAuth subjects /metrics on the HTTP transport to bearer token
+	// authentication (requires server authentication to be enabled)
+	RequireAuth bool `mapstructure:"require_auth"`
+	// EnableStatsEndpoint tracks a rolling per-tool latency summary served
+	// as JSON at /stats on the HTTP transport
+	EnableStatsEndpoint bool `mapstructure:"enable_stats_endpoint"`
+	// RequestDurationBuckets are the histogram buckets in seconds for HTTP
+	// request durations (defaults to the Prometheus default buckets)
+	RequestDurationBuckets []float64 `mapstructure:"request_duration_buckets"`
+	// ToolDurationBuckets are the histogram buckets in seconds for tool
+	// execution durations (defaults to the Prometheus default buckets)
+	ToolDurationBuckets []float64 `mapstructure:"tool_duration_buckets"`
 }
 
 // TracingConfig contains OpenTelemetry tracing configuration
@@ -84,10 +315,25 @@ type TracingConfig struct {
 	Exporter string `mapstructure:"exporter"`
 	// Endpoint is the trace collector endpoint
 	Endpoint string `mapstructure:"endpoint"`
+	// Protocol is the OTLP transport protocol (http or grpc)
+	Protocol string `mapstructure:"protocol"`
+	// Insecure disables TLS when exporting OTLP traces
+	Insecure bool `mapstructure:"insecure"`
+	// CAFile is the PEM CA bundle used to verify the collector (defaults to system roots)
+	CAFile string `mapstructure:"ca_file"`
+	// Headers are extra headers sent with OTLP exports, e.g. collector API keys
+	Headers map[string]string `mapstructure:"headers"`
 	// SamplingRate is the trace sampling rate (0.0 to 1.0)
 	SamplingRate float64 `mapstructure:"sampling_rate"`
 	// ServiceName overrides the default service name in traces
 	ServiceName string `mapstructure:"service_name"`
+	// MaxQueueSize is how many finished spans are buffered for export; spans
+	// ending while the queue is full are dropped
+	MaxQueueSize int `mapstructure:"max_queue_size"`
+	// BatchTimeout is the longest a span waits before its batch is exported
+	BatchTimeout time.Duration `mapstructure:"batch_timeout"`
+	// MaxExportBatchSize is the most spans sent in one export
+	MaxExportBatchSize int `mapstructure:"max_export_batch_size"`
 }
 
 // viperInstance holds the global viper instance
@@ -104,37 +350,95 @@ func setDefaults() {
 	// Server defaults
 	viperInstance.SetDefault("server.host", "0.0.0.0")
 	viperInstance.SetDefault("server.port", 8080)
+	viperInstance.SetDefault("server.unix_socket", "")
 	viperInstance.SetDefault("server.transport", "stdio")
 	viperInstance.SetDefault("server.read_timeout", 30*time.Second)
 	viperInstance.SetDefault("server.write_timeout", 30*time.Second)
+	viperInstance.SetDefault("server.idle_timeout", 120*time.Second)
+	viperInstance.SetDefault("server.read_header_timeout", 10*time.Second)
 	viperInstance.SetDefault("server.max_concurrent_tools", 10)
 	viperInstance.SetDefault("server.tool_timeout", 60*time.Second)
+	viperInstance.SetDefault("server.handler_timeout", 30*time.Second)
 	viperInstance.SetDefault("server.auth_required", false)
 	viperInstance.SetDefault("server.auth_token", "")
+	viperInstance.SetDefault("server.auth_tokens", []string{})
+	viperInstance.SetDefault("server.auth_mode", "static")
+	viperInstance.SetDefault("server.jwt_jwks_url", "")
+	viperInstance.SetDefault("server.jwt_public_key_file", "")
+	viperInstance.SetDefault("server.jwt_audience", "")
+	viperInstance.SetDefault("server.jwt_issuer", "")
+	viperInstance.SetDefault("server.jwt_default_scopes", []string{})
+	viperInstance.SetDefault("server.tls_cert_file", "")
+	viperInstance.SetDefault("server.tls_key_file", "")
+	viperInstance.SetDefault("server.tls_min_version", "1.2")
+	viperInstance.SetDefault("server.validate_tool_input", false)
+	viperInstance.SetDefault("server.enable_pprof", false)
+	viperInstance.SetDefault("server.rate_limit_per_second", 0)
+	viperInstance.SetDefault("server.rate_limit_burst", 20)
+	viperInstance.SetDefault("server.startup_ready_timeout", 60*time.Second)
+	viperInstance.SetDefault("server.shutdown_timeout", 30*time.Second)
+	viperInstance.SetDefault("server.drain_timeout", 20*time.Second)
 
 	// PCF defaults
 	viperInstance.SetDefault("pcf.url", "http://localhost:5000")
 	viperInstance.SetDefault("pcf.api_key", "")
+	viperInstance.SetDefault("pcf.api_key_header", "X-API-Key")
+	viperInstance.SetDefault("pcf.api_base_path", "/api")
+	viperInstance.SetDefault("pcf.api_key_scheme", "")
+	viperInstance.SetDefault("pcf.user_agent", "")
 	viperInstance.SetDefault("pcf.timeout", 30*time.Second)
 	viperInstance.SetDefault("pcf.max_retries", 3)
+	viperInstance.SetDefault("pcf.retry_max_delay", 30*time.Second)
 	viperInstance.SetDefault("pcf.insecure_skip_verify", false)
+	viperInstance.SetDefault("pcf.ca_file", "")
+	viperInstance.SetDefault("pcf.client_cert_file", "")
+	viperInstance.SetDefault("pcf.client_key_file", "")
+	viperInstance.SetDefault("pcf.bulk_workers", 4)
+	viperInstance.SetDefault("pcf.max_concurrent_requests", 0)
+	viperInstance.SetDefault("pcf.max_idle_conns", 100)
+	viperInstance.SetDefault("pcf.max_idle_conns_per_host", 10)
+	viperInstance.SetDefault("pcf.idle_conn_timeout", 90*time.Second)
+	viperInstance.SetDefault("pcf.redact_fields", []string{"value"})
+	viperInstance.SetDefault("pcf.redact_placeholder", "***REDACTED***")
+	viperInstance.SetDefault("pcf.cache_ttl", time.Duration(0))
+	viperInstance.SetDefault("pcf.report_dir", filepath.Join(os.TempDir(), "pcf-mcp-reports"))
+	viperInstance.SetDefault("pcf.proxy_url", "")
+	viperInstance.SetDefault("pcf.default_project_id", "")
+	viperInstance.SetDefault("pcf.allow_credential_export", false)
+	viperInstance.SetDefault("pcf.allowed_hosts", []string{})
+	viperInstance.SetDefault("pcf.circuit_breaker_enabled", false)
+	viperInstance.SetDefault("pcf.circuit_breaker_threshold", 5)
+	viperInstance.SetDefault("pcf.circuit_breaker_cooldown", 30*time.Second)
 
 	// Logging defaults
 	viperInstance.SetDefault("logging.level", "info")
 	viperInstance.SetDefault("logging.format", "json")
 	viperInstance.SetDefault("logging.add_source", false)
+	viperInstance.SetDefault("logging.output", "")
+	viperInstance.SetDefault("logging.log_bodies", "off")
+	viperInstance.SetDefault("logging.redact_keys", []string{"value", "token", "password", "api_key"})
 
 	// Metrics defaults
 	viperInstance.SetDefault("metrics.enabled", true)
 	viperInstance.SetDefault("metrics.port", 9090)
 	viperInstance.SetDefault("metrics.path", "/metrics")
+	viperInstance.SetDefault("metrics.require_auth", false)
+	viperInstance.SetDefault("metrics.enable_stats_endpoint", false)
+	viperInstance.SetDefault("metrics.request_duration_buckets", []float64{})
+	viperInstance.SetDefault("metrics.tool_duration_buckets", []float64{})
 
 	// Tracing defaults
 	viperInstance.SetDefault("tracing.enabled", false)
 	viperInstance.SetDefault("tracing.exporter", "otlp")
 	viperInstance.SetDefault("tracing.endpoint", "http://localhost:4317")
+	viperInstance.SetDefault("tracing.protocol", "http")
+	viperInstance.SetDefault("tracing.insecure", false)
+	viperInstance.SetDefault("tracing.ca_file", "")
 	viperInstance.SetDefault("tracing.sampling_rate", 1.0)
 	viperInstance.SetDefault("tracing.service_name", "pcf-mcp")
+	viperInstance.SetDefault("tracing.max_queue_size", 2048)
+	viperInstance.SetDefault("tracing.batch_timeout", 5*time.Second)
+	viperInstance.SetDefault("tracing.max_export_batch_size", 512)
 }
 
 // New creates a new configuration instance with default values
@@ -154,13 +458,31 @@ func New() *Config {
 	return cfg
 }
 
+// loadedFiles lists the config files read by the last LoadFromFiles call,
+// in merge order, so reloads layer them the same way
+var loadedFiles []string
+
 // LoadFromFile loads configuration from a file
 func (c *Config) LoadFromFile(path string) error {
-	viperInstance.SetConfigFile(path)
+	return c.LoadFromFiles(path)
+}
+
+// LoadFromFiles loads configuration from several files merged in order, so
+// each file overrides the keys it sets in the files before it, e.g. a base
+// config followed by an environment overlay. Environment variables and CLI
+// flags still take precedence over every file.
+func (c *Config) LoadFromFiles(paths ...string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("no config files given")
+	}
+
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
 
-	if err := viperInstance.ReadInConfig(); err != nil {
-		return fmt.Errorf("failed to read config file: %w", err)
+	if err := readConfigFiles(paths); err != nil {
+		return err
 	}
+	loadedFiles = slices.Clone(paths)
 
 	if err := viperInstance.Unmarshal(c); err != nil {
 		return fmt.Errorf("failed to unmarshal config: %w", err)
@@ -169,6 +491,26 @@ func (c *Config) LoadFromFile(path string) error {
 	return nil
 }
 
+// readConfigFiles reads the first file, replacing any previously read
+// config, and merges the rest on top of it
+func readConfigFiles(paths []string) error {
+	for i, path := range paths {
+		viperInstance.SetConfigFile(path)
+		// MergeInConfig does not infer the format from the extension
+		viperInstance.SetConfigType(strings.TrimPrefix(filepath.Ext(path), "."))
+
+		read := viperInstance.MergeInConfig
+		if i == 0 {
+			read = viperInstance.ReadInConfig
+		}
+		if err := read(); err != nil {
+			return fmt.Errorf("failed to read config file %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
 // LoadFromEnvironment loads configuration from environment variables
 // Environment variables should be prefixed with PCF_MCP_ and use underscores
 // Example: PCF_MCP_SERVER_HOST maps to server.host
@@ -204,6 +546,10 @@ func (c *Config) LoadFromCLI(args []string) error {
 	flags.String("server-transport", "", "MCP transport type (stdio or http)")
 	flags.Bool("server-auth-required", false, "Enable authentication for HTTP transport")
 	flags.String("server-auth-token", "", "Bearer token for authentication")
+	flags.StringSlice("server-auth-tokens", nil, "Comma-separated list of accepted bearer tokens")
+	flags.String("server-auth-mode", "", "Bearer token check (static or jwt)")
+	flags.String("server-tls-cert-file", "", "TLS certificate file for HTTPS")
+	flags.String("server-tls-key-file", "", "TLS private key file for HTTPS")
 
 	// PCF flags
 	flags.String("pcf-url", "", "PCF base URL")
@@ -212,6 +558,11 @@ func (c *Config) LoadFromCLI(args []string) error {
 	// Logging flags
 	flags.String("log-level", "", "Log level (debug, info, warn, error)")
 	flags.String("log-format", "", "Log format (json or text)")
+	flags.String("log-output", "", "Log destination (stdout, stderr, or a file path)")
+
+	// Debugging flags
+	flags.Bool("dump-config", false, "Print the effective configuration as YAML and exit")
+	flags.Bool("check", false, "Check the configuration and dependencies, print the results, and exit")
 
 	// Bind flags to viper
 	_ = viperInstance.BindPFlag("server.host", flags.Lookup("server-host"))
@@ -219,10 +570,15 @@ func (c *Config) LoadFromCLI(args []string) error {
 	_ = viperInstance.BindPFlag("server.transport", flags.Lookup("server-transport"))
 	_ = viperInstance.BindPFlag("server.auth_required", flags.Lookup("server-auth-required"))
 	_ = viperInstance.BindPFlag("server.auth_token", flags.Lookup("server-auth-token"))
+	_ = viperInstance.BindPFlag("server.auth_tokens", flags.Lookup("server-auth-tokens"))
+	_ = viperInstance.BindPFlag("server.auth_mode", flags.Lookup("server-auth-mode"))
+	_ = viperInstance.BindPFlag("server.tls_cert_file", flags.Lookup("server-tls-cert-file"))
+	_ = viperInstance.BindPFlag("server.tls_key_file", flags.Lookup("server-tls-key-file"))
 	_ = viperInstance.BindPFlag("pcf.url", flags.Lookup("pcf-url"))
 	_ = viperInstance.BindPFlag("pcf.api_key", flags.Lookup("pcf-api-key"))
 	_ = viperInstance.BindPFlag("logging.level", flags.Lookup("log-level"))
 	_ = viperInstance.BindPFlag("logging.format", flags.Lookup("log-format"))
+	_ = viperInstance.BindPFlag("logging.output", flags.Lookup("log-output"))
 
 	// Parse arguments
 	cmd.SetArgs(args)
@@ -230,6 +586,9 @@ func (c *Config) LoadFromCLI(args []string) error {
 		return fmt.Errorf("failed to parse CLI arguments: %w", err)
 	}
 
+	c.dumpConfig, _ = flags.GetBool("dump-config")
+	c.checkRequested, _ = flags.GetBool("check")
+
 	// Unmarshal updated config
 	if err := viperInstance.Unmarshal(c); err != nil {
 		return fmt.Errorf("failed to unmarshal config from CLI: %w", err)
@@ -261,11 +620,101 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid log format: %s (must be 'json' or 'text')", c.Logging.Format)
 	}
 
+	// Validate body logging mode; empty means off
+	switch c.Logging.LogBodies {
+	case "", "off", "on_error", "always":
+	default:
+		return fmt.Errorf("invalid log bodies mode: %s (must be 'off', 'on_error', or 'always')", c.Logging.LogBodies)
+	}
+
+	// The stdio transport owns stdout for JSON-RPC messages
+	if c.Server.Transport == "stdio" && c.LogOutput() == "stdout" {
+		return fmt.Errorf("log output stdout conflicts with the stdio transport (use stderr or a file)")
+	}
+
+	// Profiling endpoints must never be exposed unauthenticated
+	if c.Server.EnablePprof && !c.Server.AuthRequired {
+		return fmt.Errorf("server pprof endpoint requires server authentication to be enabled")
+	}
+
+	if err := c.Server.validateAuthMode(); err != nil {
+		return err
+	}
+
+	if err := c.Server.validateTokenScopes(); err != nil {
+		return err
+	}
+
+	if err := c.Server.validateToolLists(); err != nil {
+		return err
+	}
+
+	// Validate rate limiting
+	if c.Server.RateLimitPerSecond < 0 {
+		return fmt.Errorf("invalid server rate limit: %v (must not be negative)", c.Server.RateLimitPerSecond)
+	}
+	if c.Server.RateLimitPerSecond > 0 && c.Server.RateLimitBurst < 1 {
+		return fmt.Errorf("invalid server rate limit burst: %d (must be at least 1)", c.Server.RateLimitBurst)
+	}
+
+	if c.Server.IdleTimeout < 0 || c.Server.ReadHeaderTimeout < 0 {
+		return fmt.Errorf("invalid server connection timeouts: idle %s, read header %s (must not be negative)", c.Server.IdleTimeout, c.Server.ReadHeaderTimeout)
+	}
+
+	if c.Server.HandlerTimeout < 0 {
+		return fmt.Errorf("invalid server handler timeout: %s (must not be negative)", c.Server.HandlerTimeout)
+	}
+
+	if c.Server.StartupReadyTimeout < 0 {
+		return fmt.Errorf("invalid server startup ready timeout: %s (must not be negative)", c.Server.StartupReadyTimeout)
+	}
+
+	if c.Server.ShutdownTimeout < 0 || c.Server.DrainTimeout < 0 {
+		return fmt.Errorf("invalid server shutdown timeouts: shutdown %s, drain %s (must not be negative)", c.Server.ShutdownTimeout, c.Server.DrainTimeout)
+	}
+
+	if c.Server.ShutdownTimeout > 0 && c.Server.DrainTimeout > c.Server.ShutdownTimeout {
+		return fmt.Errorf("server drain timeout %s exceeds shutdown timeout %s", c.Server.DrainTimeout, c.Server.ShutdownTimeout)
+	}
+
 	// Validate PCF configuration
 	if c.PCF.URL == "" {
 		return fmt.Errorf("PCF URL is required")
 	}
 
+	if c.PCF.APIBasePath != "" && !strings.HasPrefix(c.PCF.APIBasePath, "/") {
+		return fmt.Errorf("invalid PCF API base path: %q (must start with /)", c.PCF.APIBasePath)
+	}
+
+	if strings.ContainsAny(c.PCF.APIKeyHeader, " \t\r\n:") {
+		return fmt.Errorf("invalid PCF API key header: %q", c.PCF.APIKeyHeader)
+	}
+
+	if (c.PCF.ClientCertFile == "") != (c.PCF.ClientKeyFile == "") {
+		return fmt.Errorf("both PCF client cert file and key file must be provided")
+	}
+
+	if c.PCF.CacheTTL < 0 {
+		return fmt.Errorf("invalid PCF cache TTL: %s (must not be negative)", c.PCF.CacheTTL)
+	}
+
+	if c.PCF.RetryMaxDelay < 0 {
+		return fmt.Errorf("invalid PCF retry max delay: %s (must not be negative)", c.PCF.RetryMaxDelay)
+	}
+
+	if c.PCF.BulkWorkers < 0 {
+		return fmt.Errorf("invalid PCF bulk workers: %d (must not be negative)", c.PCF.BulkWorkers)
+	}
+
+	if c.PCF.MaxConcurrentRequests < 0 {
+		return fmt.Errorf("invalid PCF max concurrent requests: %d (must not be negative)", c.PCF.MaxConcurrentRequests)
+	}
+
+	if c.PCF.MaxIdleConns < 0 || c.PCF.MaxIdleConnsPerHost < 0 || c.PCF.IdleConnTimeout < 0 {
+		return fmt.Errorf("invalid PCF connection pool: max idle %d, per host %d, idle timeout %s (must not be negative)",
+			c.PCF.MaxIdleConns, c.PCF.MaxIdleConnsPerHost, c.PCF.IdleConnTimeout)
+	}
+
 	// Validate port numbers
 	if c.Server.Port < 1 || c.Server.Port > 65535 {
 		return fmt.Errorf("invalid server port: %d", c.Server.Port)
@@ -275,6 +724,32 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid metrics port: %d", c.Metrics.Port)
 	}
 
+	// The metrics server and the HTTP transport cannot share a listen port
+	if c.Metrics.Enabled && c.Server.Transport == "http" && c.Server.UnixSocket == "" && c.Metrics.Port == c.Server.Port {
+		return fmt.Errorf("metrics port %d collides with the server port (metrics are already served at /metrics on the HTTP transport; choose a different metrics.port)", c.Metrics.Port)
+	}
+
+	if c.Metrics.RequireAuth && !c.Server.AuthRequired {
+		return fmt.Errorf("metrics authentication requires server authentication to be enabled")
+	}
+
+	if err := validateBuckets("request duration", c.Metrics.RequestDurationBuckets); err != nil {
+		return err
+	}
+
+	if err := validateBuckets("tool duration", c.Metrics.ToolDurationBuckets); err != nil {
+		return err
+	}
+
+	// Validate TLS configuration
+	if (c.Server.TLSCertFile == "") != (c.Server.TLSKeyFile == "") {
+		return fmt.Errorf("both server TLS cert file and key file must be provided")
+	}
+
+	if _, err := ParseTLSVersion(c.Server.TLSMinVersion); err != nil {
+		return err
+	}
+
 	// Validate tracing configuration
 	if c.Tracing.Enabled {
 		validExporters := map[string]bool{
@@ -286,25 +761,143 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("invalid tracing exporter: %s", c.Tracing.Exporter)
 		}
 
+		if c.Tracing.Protocol != "http" && c.Tracing.Protocol != "grpc" {
+			return fmt.Errorf("invalid tracing protocol: %s (must be 'http' or 'grpc')", c.Tracing.Protocol)
+		}
+
+		// A secure OTLP exporter needs a CA to verify the collector against
+		if c.Tracing.Exporter != "zipkin" && !c.Tracing.Insecure {
+			if c.Tracing.CAFile != "" {
+				if _, err := os.Stat(c.Tracing.CAFile); err != nil {
+					return fmt.Errorf("invalid tracing CA file: %w", err)
+				}
+			} else if _, err := x509.SystemCertPool(); err != nil {
+				return fmt.Errorf("tracing requires a CA file or system CA certificates when insecure is false: %w", err)
+			}
+		}
+
 		if c.Tracing.SamplingRate < 0.0 || c.Tracing.SamplingRate > 1.0 {
 			return fmt.Errorf("invalid sampling rate: %f (must be between 0.0 and 1.0)", c.Tracing.SamplingRate)
 		}
+
+		if c.Tracing.MaxQueueSize < 0 || c.Tracing.MaxExportBatchSize < 0 || c.Tracing.BatchTimeout < 0 {
+			return fmt.Errorf("tracing max queue size, max export batch size, and batch timeout cannot be negative")
+		}
+
+		// Batches are taken from the queue, so cannot be larger than it
+		if c.Tracing.MaxQueueSize > 0 && c.Tracing.MaxExportBatchSize > c.Tracing.MaxQueueSize {
+			return fmt.Errorf("tracing max export batch size %d exceeds max queue size %d", c.Tracing.MaxExportBatchSize, c.Tracing.MaxQueueSize)
+		}
 	}
 
 	return nil
 }
 
-// String returns a string representation of the configuration (with sensitive data masked)
-func (c *Config) String() string {
-	maskedAPIKey := "***"
-	if c.PCF.APIKey != "" {
-		if len(c.PCF.APIKey) > 4 {
-			maskedAPIKey = c.PCF.APIKey[:2] + "***" + c.PCF.APIKey[len(c.PCF.APIKey)-2:]
+// LogOutput returns where logs are written: Logging.Output when set,
+// otherwise stderr for the stdio transport, whose stdout carries JSON-RPC
+// messages, and stdout for HTTP
+func (c *Config) LogOutput() string {
+	if c.Logging.Output != "" {
+		return c.Logging.Output
+	}
+	if c.Server.Transport == "stdio" {
+		return "stderr"
+	}
+	return "stdout"
+}
+
+// Warnings returns problems with a valid configuration that are worth
+// logging but should not prevent startup
+func (c *Config) Warnings() []string {
+	var warnings []string
+
+	if c.Metrics.Enabled && c.Server.Transport == "http" {
+		warnings = append(warnings, fmt.Sprintf("metrics are served both at /metrics on the HTTP transport and on metrics port %d", c.Metrics.Port))
+	}
+
+	return warnings
+}
+
+// CheckRequested reports whether --check was passed to LoadFromCLI
+func (c *Config) CheckRequested() bool {
+	return c.checkRequested
+}
+
+// validateBuckets checks that histogram buckets are positive and strictly increasing
+func validateBuckets(name string, buckets []float64) error {
+	for i, bucket := range buckets {
+		if bucket <= 0 {
+			return fmt.Errorf("invalid metrics %s buckets: %v (must be positive)", name, buckets)
+		}
+		if i > 0 && bucket <= buckets[i-1] {
+			return fmt.Errorf("invalid metrics %s buckets: %v (must be strictly increasing)", name, buckets)
 		}
 	}
+	return nil
+}
+
+// String returns a string representation of the configuration (with sensitive data masked)
+func (c *Config) String() string {
+	masked := c.masked()
 
 	return fmt.Sprintf(
 		"Config{Server:%+v, PCF:{URL:%s, APIKey:%s, Timeout:%s}, Logging:%+v, Metrics:%+v, Tracing:%+v}",
-		c.Server, c.PCF.URL, maskedAPIKey, c.PCF.Timeout, c.Logging, c.Metrics, c.Tracing,
+		masked.Server, masked.PCF.URL, masked.PCF.APIKey, masked.PCF.Timeout, masked.Logging, masked.Metrics, masked.Tracing,
 	)
 }
+
+// masked returns a copy of the configuration with the PCF API key, bearer
+// tokens, and tracing header values masked
+func (c *Config) masked() Config {
+	masked := *c
+
+	masked.PCF.APIKey = "***"
+	if len(c.PCF.APIKey) > 4 {
+		masked.PCF.APIKey = c.PCF.APIKey[:2] + "***" + c.PCF.APIKey[len(c.PCF.APIKey)-2:]
+	}
+
+	// Proxy URLs may embed credentials
+	if proxyURL, err := url.Parse(c.PCF.ProxyURL); err == nil && proxyURL.User != nil {
+		masked.PCF.ProxyURL = proxyURL.Redacted()
+	}
+
+	// Never print bearer tokens
+	if masked.Server.AuthToken != "" {
+		masked.Server.AuthToken = "***"
+	}
+	if len(c.Server.AuthTokens) > 0 {
+		masked.Server.AuthTokens = make([]string, len(c.Server.AuthTokens))
+		for i := range masked.Server.AuthTokens {
+			masked.Server.AuthTokens[i] = "***"
+		}
+	}
+	if len(c.Server.TokenScopes) > 0 {
+		masked.Server.TokenScopes = make([]TokenScope, len(c.Server.TokenScopes))
+		for i, entry := range c.Server.TokenScopes {
+			masked.Server.TokenScopes[i] = TokenScope{Token: "***", Scopes: entry.Scopes}
+		}
+	}
+
+	// Tracing headers usually carry collector API keys
+	if len(c.Tracing.Headers) > 0 {
+		masked.Tracing.Headers = make(map[string]string, len(c.Tracing.Headers))
+		for name := range c.Tracing.Headers {
+			masked.Tracing.Headers[name] = "***"
+		}
+	}
+
+	return masked
+}
+
+// ParseTLSVersion converts a TLS version string (1.2 or 1.3) to its crypto/tls
+// constant. An empty string defaults to TLS 1.2.
+func ParseTLSVersion(version string) (uint16, error) {
+	switch version {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("invalid TLS min version: %s (must be '1.2' or '1.3')", version)
+	}
+}
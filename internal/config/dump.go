@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DumpRequested reports whether --dump-config was passed to LoadFromCLI
+func (c *Config) DumpRequested() bool {
+	return c.dumpConfig
+}
+
+// WriteYAML writes the effective configuration to w as YAML, using the same
+// keys as config files. Secrets are masked as in String and durations are
+// written in time.Duration notation (e.g. "30s").
+func (c *Config) WriteYAML(w io.Writer) error {
+	masked := c.masked()
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(yamlValue(reflect.ValueOf(masked))); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	return encoder.Close()
+}
+
+// yamlValue converts a config value into plain maps, slices, and scalars keyed
+// by mapstructure tags so the output round-trips through LoadFromFile
+func yamlValue(v reflect.Value) interface{} {
+	if d, ok := v.Interface().(time.Duration); ok {
+		return d.String()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		out := make(map[string]interface{}, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			key, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+			if key == "" || !field.IsExported() {
+				continue
+			}
+			out[key] = yamlValue(v.Field(i))
+		}
+		return out
+
+	case reflect.Slice:
+		out := make([]interface{}, v.Len())
+		for i := range out {
+			out[i] = yamlValue(v.Index(i))
+		}
+		return out
+
+	default:
+		return v.Interface()
+	}
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadDebounce is how long the config file must be quiet before it is reloaded
+const reloadDebounce = 100 * time.Millisecond
+
+// reloadMu serializes config file loads and reloads, which share the global
+// viper instance and loadedFiles
+var reloadMu sync.Mutex
+
+// WatchFile watches the loaded config file and calls onChange with a freshly
+// loaded configuration each time the file changes and the new contents pass
+// Validate. When several files were merged, the last one is watched and a
+// change reloads them all. Invalid changes are logged and ignored. Environment variables and
+// CLI flags keep their precedence over the file. c itself is never modified.
+// Watching stops when ctx is canceled.
+func (c *Config) WatchFile(ctx context.Context, onChange func(*Config)) error {
+	path := viperInstance.ConfigFileUsed()
+	if path == "" {
+		return fmt.Errorf("no config file loaded")
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create config watcher: %w", err)
+	}
+
+	// Watch the directory rather than the file so editors that replace the
+	// file and Kubernetes ConfigMap symlink swaps are both seen
+	configFile := filepath.Clean(path)
+	if err := watcher.Add(filepath.Dir(configFile)); err != nil {
+		watcher.Close()
+		return fmt.Errorf("failed to watch config file: %w", err)
+	}
+
+	realConfigFile, _ := filepath.EvalSymlinks(configFile)
+
+	go func() {
+		defer watcher.Close()
+
+		// Editors often write a file in several steps, so reload only once
+		// events have settled
+		debounce := time.NewTimer(reloadDebounce)
+		debounce.Stop()
+		defer debounce.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				currentConfigFile, _ := filepath.EvalSymlinks(configFile)
+				written := filepath.Clean(event.Name) == configFile && event.Has(fsnotify.Write|fsnotify.Create)
+				relinked := currentConfigFile != "" && currentConfigFile != realConfigFile
+				if written || relinked {
+					realConfigFile = currentConfigFile
+					debounce.Reset(reloadDebounce)
+				}
+
+			case <-debounce.C:
+				next, err := reloadFile()
+				if err != nil {
+					slog.Error("Rejected config file change", "file", configFile, "error", err)
+					continue
+				}
+
+				slog.Info("Reloaded config file", "file", configFile)
+				onChange(next)
+
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				slog.Warn("Config watcher error", "file", configFile, "error", err)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// reloadFile re-reads the config files and returns the resulting validated configuration
+func reloadFile() (*Config, error) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	if err := readConfigFiles(loadedFiles); err != nil {
+		return nil, err
+	}
+
+	next := &Config{}
+	if err := viperInstance.Unmarshal(next); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	if err := next.Validate(); err != nil {
+		return nil, err
+	}
+
+	return next, nil
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestWatchFile tests that valid config file changes are delivered and invalid ones are rejected
+func TestWatchFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+
+	writeConfig := func(level string) {
+		content := "logging:\n  level: " + level + "\n  format: json\npcf:\n  timeout: 10s\n"
+		if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write config file: %v", err)
+		}
+	}
+
+	writeConfig("info")
+
+	cfg := New()
+	if err := cfg.LoadFromFile(configPath); err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan *Config, 10)
+	if err := cfg.WatchFile(ctx, func(next *Config) {
+		changes <- next
+	}); err != nil {
+		t.Fatalf("Failed to watch config file: %v", err)
+	}
+
+	// An invalid level is rejected without a callback
+	writeConfig("verbose")
+	select {
+	case next := <-changes:
+		t.Fatalf("Expected invalid change to be rejected, got level %q", next.Logging.Level)
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	// A valid change is delivered
+	writeConfig("debug")
+	select {
+	case next := <-changes:
+		if next.Logging.Level != "debug" {
+			t.Errorf("Expected reloaded level 'debug', got %q", next.Logging.Level)
+		}
+		if next.PCF.Timeout != 10*time.Second {
+			t.Errorf("Expected reloaded PCF timeout 10s, got %s", next.PCF.Timeout)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for config reload")
+	}
+
+	// The running config is untouched
+	if cfg.Logging.Level != "info" {
+		t.Errorf("Expected original config to keep level 'info', got %q", cfg.Logging.Level)
+	}
+}
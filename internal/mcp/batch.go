@@ -0,0 +1,79 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// batchPath is the endpoint that executes several tools in one request. It
+// shadows a tool named "batch" on the HTTP transport.
+const batchPath = "/tools/batch"
+
+// maxToolBatchSize caps the number of tool calls in one batch request
+const maxToolBatchSize = 100
+
+// toolBatchItem is one tool call in a batch request
+type toolBatchItem struct {
+	Tool   string                 `json:"tool"`
+	Params map[string]interface{} `json:"params"`
+}
+
+// handleToolBatch executes the tool calls in the request body in order. A
+// failing call is reported in its result and does not stop the batch.
+func (s *Server) handleToolBatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.writeMethodNotAllowed(w)
+		return
+	}
+
+	var items []toolBatchItem
+	if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+		s.writeError(w, http.StatusBadRequest, CodeValidation, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+
+	if len(items) == 0 {
+		s.writeError(w, http.StatusBadRequest, CodeValidation, "Batch must contain at least one tool call")
+		return
+	}
+
+	if len(items) > maxToolBatchSize {
+		s.writeError(w, http.StatusBadRequest, CodeValidation, fmt.Sprintf("Batch exceeds the maximum of %d tool calls", maxToolBatchSize))
+		return
+	}
+
+	// Calls run one after another, so a batch holds at most one
+	// MaxConcurrentTools slot at a time
+	results := make([]map[string]interface{}, 0, len(items))
+	successCount := 0
+
+	for _, item := range items {
+		params := item.Params
+		if params == nil {
+			params = map[string]interface{}{}
+		}
+
+		result, err := s.ExecuteToolWithMetrics(r.Context(), item.Tool, params)
+		if err != nil {
+			status, detail := classifyToolError(err)
+			results = append(results, map[string]interface{}{
+				"tool":   item.Tool,
+				"status": status,
+				"error":  detail,
+			})
+			continue
+		}
+
+		successCount++
+		results = append(results, map[string]interface{}{
+			"tool":   item.Tool,
+			"result": result,
+		})
+	}
+
+	s.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"results":       results,
+		"success_count": successCount,
+	})
+}
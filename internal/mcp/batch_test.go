@@ -0,0 +1,116 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+)
+
+// TestHTTPToolBatch tests executing a mixed batch of tool calls
+func TestHTTPToolBatch(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{Transport: "http", MaxConcurrentTools: 1})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	tools := []Tool{
+		{
+			Name:        "echo",
+			Description: "Echoes its message",
+			Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+				return map[string]interface{}{"message": params["message"]}, nil
+			},
+		},
+		{
+			Name:        "fail",
+			Description: "Always fails",
+			Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+				return nil, errors.New("PCF API error: internal server error")
+			},
+		},
+	}
+	for _, tool := range tools {
+		if err := server.RegisterTool(tool); err != nil {
+			t.Fatalf("Failed to register tool: %v", err)
+		}
+	}
+
+	handler := server.HTTPHandler()
+
+	body := `[
+		{"tool": "echo", "params": {"message": "first"}},
+		{"tool": "missing", "params": {}},
+		{"tool": "fail"},
+		{"tool": "echo", "params": {"message": "last"}}
+	]`
+	req := httptest.NewRequest("POST", "/tools/batch", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response struct {
+		Results []struct {
+			Tool   string                 `json:"tool"`
+			Result map[string]interface{} `json:"result"`
+			Error  ErrorDetail            `json:"error"`
+			Status int                    `json:"status"`
+		} `json:"results"`
+		SuccessCount int `json:"success_count"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if response.SuccessCount != 2 {
+		t.Errorf("Expected success_count 2, got %d", response.SuccessCount)
+	}
+
+	if len(response.Results) != 4 {
+		t.Fatalf("Expected 4 results, got %d", len(response.Results))
+	}
+
+	// Results keep the request order
+	if response.Results[0].Result["message"] != "first" || response.Results[3].Result["message"] != "last" {
+		t.Errorf("Unexpected successful results: %+v", response.Results)
+	}
+
+	if missing := response.Results[1]; missing.Tool != "missing" || missing.Status != http.StatusNotFound || missing.Error.Code != CodeToolNotFound {
+		t.Errorf("Expected 404 for unknown tool, got %+v", missing)
+	}
+
+	if failed := response.Results[2]; failed.Status != http.StatusInternalServerError || failed.Error.Code != CodeInternal || !strings.Contains(failed.Error.Message, "internal server error") {
+		t.Errorf("Expected 500 for failing tool, got %+v", failed)
+	}
+
+	// Invalid batches
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "Not an array", body: `{"tool": "echo"}`},
+		{name: "Empty batch", body: `[]`},
+		{name: "Too many calls", body: "[" + strings.Repeat(`{"tool": "echo"},`, maxToolBatchSize) + `{"tool": "echo"}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/tools/batch", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("Expected status 400, got %d", w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/aRustyDev/pcf-mcp/internal/pcf/redact"
+)
+
+// Body logging modes accepted by SetLogBodies
+const (
+	// LogBodiesOff never logs request or response bodies
+	LogBodiesOff = "off"
+
+	// LogBodiesOnError logs bodies of requests answered with a 4xx or 5xx status
+	LogBodiesOnError = "on_error"
+
+	// LogBodiesAlways logs the bodies of every request
+	LogBodiesAlways = "always"
+)
+
+// maxLoggedBodyBytes caps how much of each request and response body is
+// captured for logging
+const maxLoggedBodyBytes = 4096
+
+// SetLogBodies controls whether the HTTP transport logs request and response
+// bodies (LogBodiesOff, LogBodiesOnError, or LogBodiesAlways; anything else
+// disables it). Logged bodies are JSON with every field masked by redactor
+// replaced by its placeholder; a nil redactor masks credential values.
+func (s *Server) SetLogBodies(mode string, redactor *redact.Redactor) {
+	s.logBodies = mode
+	s.bodyRedactor = redactor
+}
+
+// capturesBodies reports whether requests must be captured for body logging
+func (s *Server) capturesBodies() bool {
+	return s.logBodies == LogBodiesOnError || s.logBodies == LogBodiesAlways
+}
+
+// logsBodiesFor reports whether bodies are logged for a response status
+func (s *Server) logsBodiesFor(status int) bool {
+	return s.logBodies == LogBodiesAlways || (s.logBodies == LogBodiesOnError && status >= http.StatusBadRequest)
+}
+
+// bodyCapture keeps the first maxLoggedBodyBytes written to it while counting
+// every byte
+type bodyCapture struct {
+	buf   bytes.Buffer
+	total int
+}
+
+// Write records p, never failing so the captured stream is unaffected
+func (c *bodyCapture) Write(p []byte) (int, error) {
+	c.total += len(p)
+	if room := maxLoggedBodyBytes - c.buf.Len(); room > 0 {
+		c.buf.Write(p[:min(len(p), room)])
+	}
+	return len(p), nil
+}
+
+// responseBodyKey is the context key for the capture of the response body as
+// the handlers write it, before compression
+type responseBodyKey struct{}
+
+// withResponseBodyCapture returns a context carrying capture for
+// bodyCaptureMiddleware to fill
+func withResponseBodyCapture(ctx context.Context, capture *bodyCapture) context.Context {
+	return context.WithValue(ctx, responseBodyKey{}, capture)
+}
+
+// bodyCaptureMiddleware records the response body the handlers write in the
+// capture loggingMiddleware stored on the request context. It sits between
+// the mux and compressionMiddleware, so gzip responses are captured as JSON
+// rather than as encoded bytes.
+func (s *Server) bodyCaptureMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capture, ok := r.Context().Value(responseBodyKey{}).(*bodyCapture)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(&responseWriter{ResponseWriter: w, statusCode: http.StatusOK, body: capture}, r)
+	})
+}
+
+// captureRequestBody tees the request body into a bodyCapture as the handler
+// reads it
+func captureRequestBody(r *http.Request) *bodyCapture {
+	capture := &bodyCapture{}
+	if r.Body != nil && r.Body != http.NoBody {
+		r.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.TeeReader(r.Body, capture), r.Body}
+	}
+	return capture
+}
+
+// redactedBody returns a captured body for logging. Only complete JSON bodies
+// are logged, with redacted fields masked at every level; truncated or other
+// bodies cannot be redacted reliably and are summarized by size.
+func (s *Server) redactedBody(capture *bodyCapture) string {
+	if capture.total == 0 {
+		return ""
+	}
+
+	if capture.total > capture.buf.Len() {
+		return fmt.Sprintf("[omitted: %d bytes exceeds the %d byte logging limit]", capture.total, maxLoggedBodyBytes)
+	}
+
+	var body interface{}
+	if err := json.Unmarshal(capture.buf.Bytes(), &body); err != nil {
+		return fmt.Sprintf("[omitted: %d bytes of non-JSON content]", capture.total)
+	}
+
+	redacted, err := json.Marshal(redactValue(body, s.bodyRedactor))
+	if err != nil {
+		return fmt.Sprintf("[omitted: %d bytes]", capture.total)
+	}
+	return string(redacted)
+}
+
+// redactValue masks redacted fields in every object nested in value
+func redactValue(value interface{}, redactor *redact.Redactor) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			v[key] = redactValue(field, redactor)
+		}
+		return redactor.Apply(v)
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item, redactor)
+		}
+	}
+	return value
+}
@@ -0,0 +1,180 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+	"github.com/aRustyDev/pcf-mcp/internal/observability"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf/redact"
+)
+
+// loggedBodies sends a request to a credential tool that fails when asked to
+// and returns the bodies on the resulting "HTTP request" log line
+func loggedBodies(t *testing.T, mode string, redactor *redact.Redactor, body string) (logLine map[string]interface{}, raw string) {
+	t.Helper()
+
+	return loggedRequest(t, mode, redactor, httptest.NewRequest(http.MethodPost, "/tools/add_credential", strings.NewReader(body)))
+}
+
+// loggedRequest is loggedBodies for a prepared request
+func loggedRequest(t *testing.T, mode string, redactor *redact.Redactor, req *http.Request) (logLine map[string]interface{}, raw string) {
+	t.Helper()
+
+	var logs bytes.Buffer
+	logger, err := observability.NewLoggerWithWriter(config.LoggingConfig{Level: "info", Format: "json"}, &logs)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(logger)
+
+	server, err := NewServer(config.ServerConfig{Transport: "http"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	server.SetLogBodies(mode, redactor)
+
+	err = server.RegisterTool(Tool{
+		Name:        "add_credential",
+		Description: "Add a credential",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			if params["fail"] == true {
+				return nil, errors.New("PCF rejected the credential")
+			}
+			// Echo the secret to check responses are redacted too
+			return map[string]interface{}{
+				"credential": map[string]interface{}{"username": params["username"], "value": params["value"]},
+			}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	server.HTTPHandler().ServeHTTP(w, req)
+
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err == nil && entry["msg"] == "HTTP request" {
+			return entry, logs.String()
+		}
+	}
+
+	t.Fatalf("No HTTP request log line in:\n%s", logs.String())
+	return nil, ""
+}
+
+// TestHTTPLogBodies tests when bodies are logged for each mode, and that
+// credential values never appear in the logs
+func TestHTTPLogBodies(t *testing.T) {
+	const secret = "hunter2-secret"
+	success := `{"username":"admin","value":"` + secret + `"}`
+	failure := `{"username":"admin","value":"` + secret + `","fail":true}`
+
+	tests := []struct {
+		name       string
+		mode       string
+		body       string
+		wantLogged bool
+	}{
+		{name: "off on success", mode: LogBodiesOff, body: success},
+		{name: "off on error", mode: LogBodiesOff, body: failure},
+		{name: "unset on error", mode: "", body: failure},
+		{name: "on_error on success", mode: LogBodiesOnError, body: success},
+		{name: "on_error on error", mode: LogBodiesOnError, body: failure, wantLogged: true},
+		{name: "always on success", mode: LogBodiesAlways, body: success, wantLogged: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, raw := loggedBodies(t, tt.mode, nil, tt.body)
+
+			if strings.Contains(raw, secret) {
+				t.Fatalf("Credential value leaked into the logs:\n%s", raw)
+			}
+
+			_, hasRequest := entry["request_body"]
+			_, hasResponse := entry["response_body"]
+			if hasRequest != tt.wantLogged || hasResponse != tt.wantLogged {
+				t.Fatalf("Expected bodies logged %v, got request %v and response %v", tt.wantLogged, hasRequest, hasResponse)
+			}
+			if !tt.wantLogged {
+				return
+			}
+
+			var request map[string]interface{}
+			if err := json.Unmarshal([]byte(entry["request_body"].(string)), &request); err != nil {
+				t.Fatalf("Expected the request body as JSON, got %q", entry["request_body"])
+			}
+			if request["username"] != "admin" || request["value"] != redact.DefaultPlaceholder {
+				t.Errorf("Expected only the value redacted, got %v", request)
+			}
+			if entry["response_body"] == "" {
+				t.Error("Expected the response body to be logged")
+			}
+		})
+	}
+}
+
+// TestHTTPLogBodiesRedaction tests that configured redact fields are masked
+// at every level and that bodies which cannot be redacted are summarized
+func TestHTTPLogBodiesRedaction(t *testing.T) {
+	redactor, err := redact.New([]string{"username"}, "[hidden]")
+	if err != nil {
+		t.Fatalf("Failed to create redactor: %v", err)
+	}
+
+	entry, raw := loggedBodies(t, LogBodiesAlways, redactor, `{"username":"admin","value":"hunter2-secret"}`)
+	if strings.Contains(raw, "hunter2-secret") || strings.Contains(raw, "admin") {
+		t.Fatalf("Redacted fields leaked into the logs:\n%s", raw)
+	}
+
+	expected := `{"result":{"credential":{"username":"[hidden]","value":"[hidden]"}}}`
+	if entry["response_body"] != expected {
+		t.Errorf("Expected nested response fields redacted as %s, got %v", expected, entry["response_body"])
+	}
+
+	// A body too large to capture whole is never logged verbatim
+	large := `{"value":"hunter2-secret","notes":"` + strings.Repeat("x", maxLoggedBodyBytes) + `"}`
+	entry, raw = loggedBodies(t, LogBodiesAlways, nil, large)
+	if strings.Contains(raw, "hunter2-secret") {
+		t.Fatalf("Credential value from a truncated body leaked into the logs:\n%s", raw)
+	}
+	if body, _ := entry["request_body"].(string); !strings.HasPrefix(body, "[omitted:") {
+		t.Errorf("Expected an oversized request body to be summarized, got %q", body)
+	}
+
+	entry, raw = loggedBodies(t, LogBodiesAlways, nil, "value=hunter2-secret")
+	if strings.Contains(raw, "hunter2-secret") {
+		t.Fatalf("Credential value from a non-JSON body leaked into the logs:\n%s", raw)
+	}
+	if body, _ := entry["request_body"].(string); !strings.HasPrefix(body, "[omitted:") {
+		t.Errorf("Expected a non-JSON request body to be summarized, got %q", body)
+	}
+}
+
+// TestHTTPLogBodiesGzip tests that responses compressed for the client are
+// logged as the JSON the handler wrote, still redacted
+func TestHTTPLogBodiesGzip(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/tools/add_credential", strings.NewReader(`{"username":"admin","value":"hunter2-secret"}`))
+	req.Header.Set(headerAcceptEncoding, encodingGzip)
+
+	entry, raw := loggedRequest(t, LogBodiesAlways, nil, req)
+	if strings.Contains(raw, "hunter2-secret") {
+		t.Fatalf("Credential value leaked into the logs:\n%s", raw)
+	}
+
+	expected := `{"result":{"credential":{"username":"admin","value":"` + redact.DefaultPlaceholder + `"}}}`
+	if entry["response_body"] != expected {
+		t.Errorf("Expected the uncompressed response body %s, got %v", expected, entry["response_body"])
+	}
+}
@@ -0,0 +1,151 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// ErrorCode is the machine-readable category of an HTTP error response
+type ErrorCode string
+
+// Error codes returned in the "code" field of HTTP error responses
+const (
+	// CodeValidation means the request body or tool parameters are invalid
+	CodeValidation ErrorCode = "validation"
+
+	// CodeInvalidParams means a tool handler rejected one of its parameters
+	CodeInvalidParams ErrorCode = "invalid_params"
+
+	// CodeUnauthorized means the bearer token is missing or invalid
+	CodeUnauthorized ErrorCode = "unauthorized"
+
+	// CodeForbidden means the caller lacks the scope the tool requires
+	CodeForbidden ErrorCode = "forbidden"
+
+	// CodeToolNotFound means no tool is registered under the requested name
+	CodeToolNotFound ErrorCode = "tool_not_found"
+
+	// CodeNotFound means a prompt, resource, or PCF object does not exist
+	CodeNotFound ErrorCode = "not_found"
+
+	// CodeMethodNotAllowed means the endpoint does not accept the HTTP method
+	CodeMethodNotAllowed ErrorCode = "method_not_allowed"
+
+	// CodeRateLimited means the client or the server's PCF calls were rate limited
+	CodeRateLimited ErrorCode = "rate_limited"
+
+	// CodeTimeout means the tool exceeded the tool timeout, or the request
+	// exceeded the handler timeout
+	CodeTimeout ErrorCode = "timeout"
+
+	// CodeUpstream means PCF could not be reached or failed with a server
+	// error, or the circuit breaker is rejecting calls while PCF is down
+	CodeUpstream ErrorCode = "upstream"
+
+	// CodeUnavailable means the server is shutting down
+	CodeUnavailable ErrorCode = "unavailable"
+
+	// CodeCanceled means the client went away before the tool finished
+	CodeCanceled ErrorCode = "canceled"
+
+	// CodeInternal is any other failure
+	CodeInternal ErrorCode = "internal"
+)
+
+// statusClientClosedRequest is the nginx convention for a request the
+// client abandoned; it is only ever seen in logs and metrics
+const statusClientClosedRequest = 499
+
+// ErrorDetail is the "error" object of an HTTP error response
+type ErrorDetail struct {
+	// Code categorizes the error
+	Code ErrorCode `json:"code"`
+
+	// Message describes the error for humans
+	Message string `json:"message"`
+
+	// Details carries structured context, such as the offending fields of
+	// a validation error
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// ErrorResponse is the body of every HTTP error response
+type ErrorResponse struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// ParamError is implemented by tool handler errors that reject a single
+// parameter, such as tools.ValidationError
+type ParamError interface {
+	error
+
+	// ParamField returns the rejected parameter and the reason
+	ParamField() FieldError
+}
+
+// classifyToolError maps a tool execution error to an HTTP status and error
+// detail. Validation errors list their offending fields in the details.
+func classifyToolError(err error) (int, ErrorDetail) {
+	detail := ErrorDetail{Message: err.Error()}
+	status := http.StatusInternalServerError
+
+	var inputErr *InputValidationError
+	var paramErr ParamError
+	switch {
+	case errors.As(err, &inputErr):
+		status, detail.Code = http.StatusBadRequest, CodeValidation
+		detail.Details = map[string]interface{}{"fields": inputErr.Fields}
+	case errors.As(err, &paramErr):
+		field := paramErr.ParamField()
+		status, detail.Code = http.StatusBadRequest, CodeInvalidParams
+		detail.Details = map[string]interface{}{"field": field.Field, "reason": field.Reason}
+	case errors.Is(err, ErrToolNotFound):
+		status, detail.Code = http.StatusNotFound, CodeToolNotFound
+	case errors.Is(err, ErrInsufficientScope):
+		status, detail.Code = http.StatusForbidden, CodeForbidden
+	case errors.Is(err, ErrToolTimeout):
+		status, detail.Code = http.StatusGatewayTimeout, CodeTimeout
+	case errors.Is(err, context.Canceled):
+		status, detail.Code = statusClientClosedRequest, CodeCanceled
+	case errors.Is(err, pcf.ErrRateLimited):
+		status, detail.Code = http.StatusTooManyRequests, CodeRateLimited
+	case errors.Is(err, pcf.ErrCircuitOpen):
+		status, detail.Code = http.StatusServiceUnavailable, CodeUpstream
+	case errors.Is(err, pcf.ErrUnavailable):
+		status, detail.Code = http.StatusBadGateway, CodeUpstream
+	case errors.Is(err, pcf.ErrNotFound):
+		status, detail.Code = http.StatusNotFound, CodeNotFound
+	default:
+		detail.Code = CodeInternal
+	}
+
+	return status, detail
+}
+
+// writeErrorResponse writes an error envelope with the given status
+func writeErrorResponse(w http.ResponseWriter, status int, detail ErrorDetail) {
+	w.Header().Set(headerContentType, contentTypeJSON)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(ErrorResponse{Error: detail})
+}
+
+// writeError writes an error envelope with the given status, code, and message
+func (s *Server) writeError(w http.ResponseWriter, status int, code ErrorCode, message string) {
+	writeErrorResponse(w, status, ErrorDetail{Code: code, Message: message})
+}
+
+// writeToolError writes the error envelope for a tool execution error
+func (s *Server) writeToolError(w http.ResponseWriter, err error) {
+	status, detail := classifyToolError(err)
+	writeErrorResponse(w, status, detail)
+}
+
+// writeMethodNotAllowed rejects a request whose HTTP method the endpoint
+// does not serve
+func (s *Server) writeMethodNotAllowed(w http.ResponseWriter) {
+	s.writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "Method not allowed")
+}
@@ -0,0 +1,188 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// decodeErrorResponse decodes an error envelope from a recorded response
+func decodeErrorResponse(t *testing.T, w *httptest.ResponseRecorder) ErrorDetail {
+	t.Helper()
+
+	var response ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode error response: %v", err)
+	}
+	if response.Error.Message == "" {
+		t.Error("Error response should include a message")
+	}
+
+	return response.Error
+}
+
+// TestHTTPToolErrorCodes tests that each tool error category yields its
+// code and HTTP status
+func TestHTTPToolErrorCodes(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{
+		Transport:         "http",
+		ToolTimeout:       20 * time.Millisecond,
+		ValidateToolInput: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	failing := map[string]error{
+		"pcf_missing":      fmt.Errorf("failed to get host: %w", pcf.ErrNotFound),
+		"pcf_down":         fmt.Errorf("failed to list hosts: %w", fmt.Errorf("%w: request failed: connection refused", pcf.ErrUnavailable)),
+		"pcf_rate_limited": fmt.Errorf("failed to list hosts: %w", pcf.ErrRateLimited),
+		"pcf_circuit_open": fmt.Errorf("failed to list hosts: %w", pcf.ErrCircuitOpen),
+		"broken":           errors.New("unexpected response"),
+		"file_missing":     errors.New("failed to save report: open /reports/q1.pdf: file not found"),
+	}
+	for name, toolErr := range failing {
+		toolErr := toolErr
+		err := server.RegisterTool(Tool{
+			Name:        name,
+			Description: "Fails with " + toolErr.Error(),
+			Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+				return nil, toolErr
+			},
+		})
+		if err != nil {
+			t.Fatalf("Failed to register tool: %v", err)
+		}
+	}
+
+	err = server.RegisterTool(Tool{
+		Name:        "slow",
+		Description: "Outlives the tool timeout",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	err = server.RegisterTool(Tool{
+		Name:        "strict",
+		Description: "Requires a project ID",
+		InputSchema: map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{"project_id": map[string]interface{}{"type": "string"}},
+			"required":   []string{"project_id"},
+		},
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return "ok", nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expectedCode   ErrorCode
+	}{
+		{name: "Unknown tool", path: "/tools/missing", body: "{}", expectedStatus: http.StatusNotFound, expectedCode: CodeToolNotFound},
+		{name: "Malformed body", path: "/tools/strict", body: "{", expectedStatus: http.StatusBadRequest, expectedCode: CodeValidation},
+		{name: "Invalid params", path: "/tools/strict", body: "{}", expectedStatus: http.StatusBadRequest, expectedCode: CodeValidation},
+		{name: "PCF not found", path: "/tools/pcf_missing", body: "{}", expectedStatus: http.StatusNotFound, expectedCode: CodeNotFound},
+		{name: "PCF unavailable", path: "/tools/pcf_down", body: "{}", expectedStatus: http.StatusBadGateway, expectedCode: CodeUpstream},
+		{name: "PCF rate limited", path: "/tools/pcf_rate_limited", body: "{}", expectedStatus: http.StatusTooManyRequests, expectedCode: CodeRateLimited},
+		{name: "PCF circuit open", path: "/tools/pcf_circuit_open", body: "{}", expectedStatus: http.StatusServiceUnavailable, expectedCode: CodeUpstream},
+		{name: "Timeout", path: "/tools/slow", body: "{}", expectedStatus: http.StatusGatewayTimeout, expectedCode: CodeTimeout},
+		{name: "Internal", path: "/tools/broken", body: "{}", expectedStatus: http.StatusInternalServerError, expectedCode: CodeInternal},
+		{name: "Not found text without ErrNotFound", path: "/tools/file_missing", body: "{}", expectedStatus: http.StatusInternalServerError, expectedCode: CodeInternal},
+		{name: "Method not allowed", method: http.MethodGet, path: "/tools/strict", expectedStatus: http.StatusMethodNotAllowed, expectedCode: CodeMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			req := httptest.NewRequest(method, tt.path, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			server.HTTPHandler().ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if detail := decodeErrorResponse(t, w); detail.Code != tt.expectedCode {
+				t.Errorf("Expected code %q, got %q", tt.expectedCode, detail.Code)
+			}
+		})
+	}
+}
+
+// TestHTTPErrorCodesOutsideTools tests the codes of auth and rate limit errors
+func TestHTTPErrorCodesOutsideTools(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{
+		Transport:          "http",
+		AuthRequired:       true,
+		AuthTokens:         []string{"read-token"},
+		TokenScopes:        []config.TokenScope{{Token: "read-token", Scopes: []string{ScopeRead}}},
+		RateLimitPerSecond: 1,
+		RateLimitBurst:     1,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	err = server.RegisterTool(Tool{
+		Name:          "writer",
+		Description:   "Requires the write scope",
+		RequiredScope: ScopeWrite,
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return "ok", nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	handler := server.HTTPHandler()
+	request := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/tools/writer", strings.NewReader("{}"))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request("")
+	if w.Code != http.StatusUnauthorized || decodeErrorResponse(t, w).Code != CodeUnauthorized {
+		t.Errorf("Expected 401 unauthorized, got %d", w.Code)
+	}
+
+	w = request("read-token")
+	if w.Code != http.StatusForbidden || decodeErrorResponse(t, w).Code != CodeForbidden {
+		t.Errorf("Expected 403 forbidden, got %d", w.Code)
+	}
+
+	w = request("read-token")
+	if w.Code != http.StatusTooManyRequests || decodeErrorResponse(t, w).Code != CodeRateLimited {
+		t.Errorf("Expected 429 rate_limited, got %d", w.Code)
+	}
+}
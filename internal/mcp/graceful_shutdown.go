@@ -12,6 +12,12 @@ import (
 	"time"
 )
 
+// Shutdown timeouts used when ServerConfig leaves them unset
+const (
+	defaultShutdownTimeout = 30 * time.Second
+	defaultDrainTimeout    = 20 * time.Second
+)
+
 // GracefulServer provides graceful shutdown capabilities
 type GracefulServer struct {
 	server         *Server
@@ -52,16 +58,16 @@ func (gs *GracefulServer) Run(ctx context.Context) error {
 
 // runHTTP runs the HTTP server with graceful shutdown
 func (gs *GracefulServer) runHTTP(ctx context.Context, sigChan chan os.Signal) error {
-	addr := fmt.Sprintf("%s:%d", gs.server.config.Host, gs.server.config.Port)
-
-	gs.httpServer = &http.Server{
-		Addr:         addr,
-		Handler:      gs.wrapHandler(gs.server.HTTPHandler()),
-		ReadTimeout:  gs.server.config.ReadTimeout,
-		WriteTimeout: gs.server.config.WriteTimeout,
-		IdleTimeout:  120 * time.Second,
+	addr := gs.server.listenAddress()
+
+	tlsConfig, err := gs.server.tlsConfig()
+	if err != nil {
+		return err
 	}
 
+	gs.httpServer = gs.newHTTPServer(addr)
+	gs.httpServer.TLSConfig = tlsConfig
+
 	// Start server in goroutine
 	serverErr := make(chan error, 1)
 	gs.wg.Add(1)
@@ -70,8 +76,9 @@ func (gs *GracefulServer) runHTTP(ctx context.Context, sigChan chan os.Signal) e
 		slog.Info("Starting HTTP server",
 			"address", addr,
 			"transport", "http",
+			"tls", tlsConfig != nil,
 		)
-		if err := gs.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		if err := gs.server.listenAndServe(gs.httpServer); err != nil && err != http.ErrServerClosed {
 			serverErr <- err
 		}
 	}()
@@ -90,15 +97,27 @@ func (gs *GracefulServer) runHTTP(ctx context.Context, sigChan chan os.Signal) e
 	return gs.shutdown()
 }
 
+// newHTTPServer creates the server's http.Server for addr with its handler
+// wrapped to track in-flight requests
+func (gs *GracefulServer) newHTTPServer(addr string) *http.Server {
+	httpServer := gs.server.newHTTPServer(addr)
+	httpServer.Handler = gs.wrapHandler(httpServer.Handler)
+	return httpServer
+}
+
 // runStdio runs the stdio server with graceful shutdown
 func (gs *GracefulServer) runStdio(ctx context.Context, sigChan chan os.Signal) error {
+	// Cancelling serveCtx makes the stdio transport drain in-flight requests
+	serveCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	// Start stdio server in goroutine
 	serverErr := make(chan error, 1)
 	gs.wg.Add(1)
 	go func() {
 		defer gs.wg.Done()
 		slog.Info("Starting stdio server", "transport", "stdio")
-		if err := gs.server.Start(ctx); err != nil {
+		if err := gs.server.Start(serveCtx); err != nil {
 			serverErr <- err
 		}
 	}()
@@ -113,8 +132,9 @@ func (gs *GracefulServer) runStdio(ctx context.Context, sigChan chan os.Signal)
 		return fmt.Errorf("server error: %w", err)
 	}
 
-	// Signal shutdown
+	// Signal shutdown and let the transport drain
 	close(gs.shutdownChan)
+	cancel()
 
 	// Wait for server to finish
 	gs.wg.Wait()
@@ -127,7 +147,7 @@ func (gs *GracefulServer) shutdown() error {
 	slog.Info("Starting graceful shutdown")
 
 	// Create shutdown context with timeout
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), gs.server.shutdownTimeout())
 	defer cancel()
 
 	// Signal shutdown
@@ -145,7 +165,7 @@ func (gs *GracefulServer) shutdown() error {
 		select {
 		case <-done:
 			slog.Info("All active requests completed")
-		case <-time.After(20 * time.Second):
+		case <-time.After(gs.server.drainTimeout()):
 			slog.Warn("Timeout waiting for active requests")
 		}
 
@@ -173,13 +193,29 @@ func (gs *GracefulServer) shutdown() error {
 	}
 }
 
+// shutdownTimeout returns the configured overall shutdown timeout
+func (s *Server) shutdownTimeout() time.Duration {
+	if s.config.ShutdownTimeout > 0 {
+		return s.config.ShutdownTimeout
+	}
+	return defaultShutdownTimeout
+}
+
+// drainTimeout returns how long shutdown waits for in-flight requests
+func (s *Server) drainTimeout() time.Duration {
+	if s.config.DrainTimeout > 0 {
+		return s.config.DrainTimeout
+	}
+	return defaultDrainTimeout
+}
+
 // wrapHandler wraps the HTTP handler to track active requests
 func (gs *GracefulServer) wrapHandler(handler http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Check if shutdown is in progress
 		select {
 		case <-gs.shutdownChan:
-			http.Error(w, "Server is shutting down", http.StatusServiceUnavailable)
+			writeErrorResponse(w, http.StatusServiceUnavailable, ErrorDetail{Code: CodeUnavailable, Message: "Server is shutting down"})
 			return
 		default:
 		}
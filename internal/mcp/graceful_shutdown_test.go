@@ -0,0 +1,120 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+)
+
+// TestGracefulShutdownDrainTimeout tests that shutdown gives up on a slow
+// in-flight request after the configured drain and shutdown timeouts
+func TestGracefulShutdownDrainTimeout(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{
+		Transport:       "http",
+		ShutdownTimeout: 200 * time.Millisecond,
+		DrainTimeout:    50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	gs := NewGracefulServer(server)
+
+	// The handler ignores cancellation, as a slow report download might
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	gs.httpServer = &http.Server{
+		Handler: gs.wrapHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+		})),
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	go gs.httpServer.Serve(listener)
+
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String() + "/slow")
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-started
+
+	start := time.Now()
+	err = gs.shutdown()
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected shutdown to time out on the in-flight request, got %v", err)
+	}
+
+	if elapsed < 200*time.Millisecond || elapsed > 2*time.Second {
+		t.Errorf("Expected shutdown to take about the 200ms shutdown timeout, took %v", elapsed)
+	}
+}
+
+// TestShutdownTimeoutDefaults tests the fallback timeouts
+func TestShutdownTimeoutDefaults(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{Transport: "http"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	if server.shutdownTimeout() != 30*time.Second || server.drainTimeout() != 20*time.Second {
+		t.Errorf("Expected 30s/20s defaults, got %v/%v", server.shutdownTimeout(), server.drainTimeout())
+	}
+}
+
+// TestHTTPServerConstructionParity tests that StartHTTP and GracefulServer
+// build servers with identical timeouts, differing only in request tracking
+func TestHTTPServerConstructionParity(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{
+		Transport:         "http",
+		ReadTimeout:       15 * time.Second,
+		WriteTimeout:      45 * time.Second,
+		IdleTimeout:       90 * time.Second,
+		ReadHeaderTimeout: 5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	gs := NewGracefulServer(server)
+
+	plain := server.newHTTPServer("127.0.0.1:8080")
+	graceful := gs.newHTTPServer("127.0.0.1:8080")
+
+	if plain.Addr != graceful.Addr ||
+		plain.ReadTimeout != graceful.ReadTimeout ||
+		plain.ReadHeaderTimeout != graceful.ReadHeaderTimeout ||
+		plain.WriteTimeout != graceful.WriteTimeout ||
+		plain.IdleTimeout != graceful.IdleTimeout {
+		t.Errorf("Expected identical server settings, got %+v and %+v", plain, graceful)
+	}
+
+	// The graceful handler refuses requests once shutdown starts
+	close(gs.shutdownChan)
+	for name, handler := range map[string]http.Handler{"StartHTTP": plain.Handler, "GracefulServer": graceful.Handler} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+		want := http.StatusOK
+		if name == "GracefulServer" {
+			want = http.StatusServiceUnavailable
+		}
+		if w.Code != want {
+			t.Errorf("%s: expected status %d, got %d", name, want, w.Code)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package mcp
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aRustyDev/pcf-mcp/internal/observability"
+)
+
+// healthCheckTimeout bounds each check registered with RegisterHealthCheck
+const healthCheckTimeout = 5 * time.Second
+
+// Health check results reported under "checks". /health is unauthenticated,
+// so a failing check's error is only logged, never returned.
+const (
+	healthCheckOK   = "ok"
+	healthCheckFail = "fail"
+)
+
+// healthCheck is a check registered with RegisterHealthCheck
+type healthCheck struct {
+	// check reports a problem as an error
+	check func(ctx context.Context) error
+
+	// required checks make /health respond 503 when they fail
+	required bool
+}
+
+// RegisterHealthCheck adds a named check to the /health endpoint, such as
+// disk space or goroutine count. Every check runs on each request and is
+// reported under "checks" as "ok" or "fail", with the error logged. A failing
+// required check makes /health respond 503 with status "unhealthy"; a failing
+// optional check only reports status "degraded". Registering a name again
+// replaces the earlier check.
+func (s *Server) RegisterHealthCheck(name string, check func(ctx context.Context) error, required bool) {
+	s.healthChecksMutex.Lock()
+	defer s.healthChecksMutex.Unlock()
+
+	if s.healthChecks == nil {
+		s.healthChecks = make(map[string]healthCheck)
+	}
+	s.healthChecks[name] = healthCheck{check: check, required: required}
+}
+
+// runHealthChecks runs the registered health checks concurrently, returning
+// each result by name, whether every required check passed, and whether every
+// check passed. Failures are logged with their error. It returns nil results
+// when no checks are registered.
+func (s *Server) runHealthChecks(ctx context.Context) (results map[string]string, healthy, allPassed bool) {
+	s.healthChecksMutex.RLock()
+	checks := make(map[string]healthCheck, len(s.healthChecks))
+	for name, check := range s.healthChecks {
+		checks[name] = check
+	}
+	s.healthChecksMutex.RUnlock()
+
+	if len(checks) == 0 {
+		return nil, true, true
+	}
+
+	logger := observability.FromContext(ctx)
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results = make(map[string]string, len(checks))
+	healthy, allPassed = true, true
+
+	for name, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := check.check(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				logger.WarnContext(ctx, "Health check failed", "check", name, "required", check.required, "error", err)
+				results[name] = healthCheckFail
+				allPassed = false
+				if check.required {
+					healthy = false
+				}
+				return
+			}
+			results[name] = healthCheckOK
+		}()
+	}
+	wg.Wait()
+
+	return results, healthy, allPassed
+}
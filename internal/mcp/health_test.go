@@ -0,0 +1,134 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+	"github.com/aRustyDev/pcf-mcp/internal/observability"
+)
+
+// healthResponse fetches /health and returns the status code and decoded body
+func healthResponse(t *testing.T, server *Server) (int, map[string]interface{}) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	server.HTTPHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	var body map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	return w.Code, body
+}
+
+// TestHealthChecksPass tests that /health reports every registered check
+func TestHealthChecksPass(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{Transport: "http"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	// Without checks the payload is unchanged
+	code, body := healthResponse(t, server)
+	if code != http.StatusOK || body["status"] != "healthy" {
+		t.Errorf("Expected 200 'healthy', got %d %v", code, body)
+	}
+	if _, ok := body["checks"]; ok {
+		t.Errorf("Expected no checks without registered checks, got %v", body["checks"])
+	}
+
+	server.RegisterHealthCheck("pcf", func(ctx context.Context) error { return nil }, false)
+	server.RegisterHealthCheck("mem", func(ctx context.Context) error {
+		if _, ok := ctx.Deadline(); !ok {
+			return errors.New("expected a deadline")
+		}
+		return nil
+	}, true)
+
+	code, body = healthResponse(t, server)
+	if code != http.StatusOK || body["status"] != "healthy" {
+		t.Errorf("Expected 200 'healthy', got %d %v", code, body)
+	}
+
+	expected := map[string]interface{}{"pcf": "ok", "mem": "ok"}
+	if !reflect.DeepEqual(body["checks"], expected) {
+		t.Errorf("Expected checks %v, got %v", expected, body["checks"])
+	}
+}
+
+// TestHealthChecksFailing tests that one failing required check makes /health
+// respond 503 while still reporting the others, without exposing its error
+func TestHealthChecksFailing(t *testing.T) {
+	var logs bytes.Buffer
+	logger, err := observability.NewLoggerWithWriter(config.LoggingConfig{Level: "info", Format: "json"}, &logs)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(logger)
+
+	server, err := NewServer(config.ServerConfig{Transport: "http"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	server.RegisterHealthCheck("pcf", func(ctx context.Context) error { return nil }, false)
+	server.RegisterHealthCheck("disk", func(ctx context.Context) error {
+		return errors.New("95% of /var used")
+	}, true)
+
+	code, body := healthResponse(t, server)
+	if code != http.StatusServiceUnavailable || body["status"] != "unhealthy" {
+		t.Errorf("Expected 503 'unhealthy', got %d %v", code, body)
+	}
+
+	expected := map[string]interface{}{"pcf": "ok", "disk": "fail"}
+	if !reflect.DeepEqual(body["checks"], expected) {
+		t.Errorf("Expected checks %v, got %v", expected, body["checks"])
+	}
+
+	// The error is logged for operators instead
+	if !strings.Contains(logs.String(), "95% of /var used") {
+		t.Errorf("Expected the check error to be logged, got:\n%s", logs.String())
+	}
+
+	// Registering the name again replaces the failing check
+	server.RegisterHealthCheck("disk", func(ctx context.Context) error { return nil }, true)
+	if code, body := healthResponse(t, server); code != http.StatusOK || body["status"] != "healthy" {
+		t.Errorf("Expected 200 'healthy' after replacing the check, got %d %v", code, body)
+	}
+}
+
+// TestHealthChecksOptional tests that a failing optional check reports the
+// server as degraded without failing /health
+func TestHealthChecksOptional(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{Transport: "http"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	server.RegisterHealthCheck("goroutines", func(ctx context.Context) error { return nil }, true)
+	server.RegisterHealthCheck("pcf_circuit", func(ctx context.Context) error {
+		return errors.New("circuit open")
+	}, false)
+
+	code, body := healthResponse(t, server)
+	if code != http.StatusOK || body["status"] != "degraded" {
+		t.Errorf("Expected 200 'degraded', got %d %v", code, body)
+	}
+
+	expected := map[string]interface{}{"goroutines": "ok", "pcf_circuit": "fail"}
+	if !reflect.DeepEqual(body["checks"], expected) {
+		t.Errorf("Expected checks %v, got %v", expected, body["checks"])
+	}
+}
@@ -1,14 +1,30 @@
 package mcp
 
 import (
+	"bufio"
+	"compress/gzip"
 	"context"
+	"crypto/subtle"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"net"
 	"net/http"
+	"net/http/pprof"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/aRustyDev/pcf-mcp/internal/auth"
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+	"github.com/aRustyDev/pcf-mcp/internal/observability"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/otel"
@@ -18,14 +34,40 @@ import (
 
 const (
 	// HTTP header names
-	headerContentType   = "Content-Type"
-	headerAuthorization = "Authorization"
+	headerContentType     = "Content-Type"
+	headerAuthorization   = "Authorization"
+	headerAcceptEncoding  = "Accept-Encoding"
+	headerContentEncoding = "Content-Encoding"
+	headerContentLength   = "Content-Length"
+	headerVary            = "Vary"
+	headerLogLevel        = "X-Log-Level"
+	headerDebug           = "X-Debug"
+
+	// Content encodings
+	encodingGzip = "gzip"
+
+	// pprofPathPrefix is where the profiling endpoints are mounted
+	pprofPathPrefix = "/debug/pprof/"
 
 	// Content types
 	contentTypeJSON = "application/json"
 
 	// Bearer token prefix
 	bearerPrefix = "Bearer "
+
+	// readinessTimeout bounds the backend check performed by /ready
+	readinessTimeout = 5 * time.Second
+
+	// readinessUnavailable is the reason /ready gives for a failed backend
+	// check in place of the error
+	readinessUnavailable = "unavailable"
+
+	// maxRequestIDLength caps the length of a client-supplied X-Request-ID
+	maxRequestIDLength = 128
+
+	// Connection timeouts used when the configuration leaves them unset
+	defaultIdleTimeout       = 120 * time.Second
+	defaultReadHeaderTimeout = 10 * time.Second
 )
 
 // httpMetrics holds HTTP-specific Prometheus metrics
@@ -39,25 +81,22 @@ type httpMetrics struct {
 func newHTTPMetrics() *httpMetrics {
 	registry := prometheus.NewRegistry()
 
-	requestsTotal := prometheus.NewCounterVec(
+	requestsTotal := registerCollector(registry, prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "http_requests_total",
 			Help: "Total number of HTTP requests",
 		},
 		[]string{"method", "path", "status"},
-	)
+	))
 
-	requestDuration := prometheus.NewHistogramVec(
+	requestDuration := registerCollector(registry, prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "http_request_duration_seconds",
 			Help:    "HTTP request duration in seconds",
 			Buckets: prometheus.DefBuckets,
 		},
 		[]string{"method", "path", "status"},
-	)
-
-	registry.MustRegister(requestsTotal)
-	registry.MustRegister(requestDuration)
+	))
 
 	return &httpMetrics{
 		requestsTotal:   requestsTotal,
@@ -66,42 +105,117 @@ func newHTTPMetrics() *httpMetrics {
 	}
 }
 
+// registerCollector registers collector with registry. If an identical
+// collector is already registered, that one is returned so both record into
+// the same series. Any other failure is logged and collector is returned
+// unregistered: it still works, but is missing from /metrics.
+func registerCollector[T prometheus.Collector](registry prometheus.Registerer, collector T) T {
+	err := registry.Register(collector)
+	if err == nil {
+		return collector
+	}
+
+	var alreadyRegistered prometheus.AlreadyRegisteredError
+	if errors.As(err, &alreadyRegistered) {
+		if existing, ok := alreadyRegistered.ExistingCollector.(T); ok {
+			return existing
+		}
+	}
+
+	slog.Warn("Failed to register HTTP metric", observability.FieldError, err)
+	return collector
+}
+
 // HTTPHandler returns an HTTP handler for the MCP server
 func (s *Server) HTTPHandler() http.Handler {
-	mux := http.NewServeMux()
+	handler, _ := s.newHTTPHandler()
+	return handler
+}
 
-	// Initialize HTTP metrics
-	httpMetrics := newHTTPMetrics()
+// newHTTPHandler returns the HTTP handler for the MCP server and a function
+// that stops its background work, to be called on shutdown
+func (s *Server) newHTTPHandler() (http.Handler, func()) {
+	mux := http.NewServeMux()
 
-	// Health check endpoint
+	// Health check endpoint (liveness)
 	mux.HandleFunc("/health", s.handleHealth)
 
+	// Readiness endpoint (verifies PCF connectivity)
+	mux.HandleFunc("/ready", s.handleReady)
+
 	// Server info endpoint
 	mux.HandleFunc("/info", s.handleInfo)
 
+	// Build metadata endpoint
+	mux.HandleFunc("/version", s.handleVersion)
+
 	// List tools endpoint
 	mux.HandleFunc("/tools", s.handleTools)
 
-	// Tool execution endpoint (pattern matches /tools/{toolName})
+	// OpenAPI document generated from the registered tools
+	mux.HandleFunc(openAPIPath, s.handleOpenAPI)
+
+	// Sequential execution of several tools in one request
+	mux.HandleFunc(batchPath, s.handleToolBatch)
+
+	// Tool execution endpoint (pattern matches /tools/{toolName} and /tools/{toolName}/stream)
 	mux.HandleFunc("/tools/", s.handleToolExecution)
 
-	// Metrics endpoint with custom registry
-	mux.Handle("/metrics", promhttp.HandlerFor(httpMetrics.registry, promhttp.HandlerOpts{}))
+	// Prompt templates
+	mux.HandleFunc("/prompts", s.handlePrompts)
+	mux.HandleFunc("/prompts/", s.handlePromptRender)
+
+	// Read-only resources
+	mux.HandleFunc("/resources", s.handleResources)
+	mux.HandleFunc("/resources/read", s.handleResourceRead)
+
+	// WebSocket MCP sessions
+	mux.HandleFunc(wsPath, s.handleWebSocket)
+
+	// Rolling per-tool latency summaries
+	if s.statsEndpoint {
+		mux.HandleFunc("/stats", s.handleStats)
+	}
+
+	// Metrics endpoint serving the HTTP and application registries
+	mux.Handle("/metrics", promhttp.HandlerFor(s.metricsGatherer(), promhttp.HandlerOpts{}))
+
+	// Profiling endpoints, only ever served behind authentication
+	if s.config.EnablePprof && s.config.AuthRequired {
+		mux.HandleFunc(pprofPathPrefix, pprof.Index)
+		mux.HandleFunc(pprofPathPrefix+"cmdline", pprof.Cmdline)
+		mux.HandleFunc(pprofPathPrefix+"profile", pprof.Profile)
+		mux.HandleFunc(pprofPathPrefix+"symbol", pprof.Symbol)
+		mux.HandleFunc(pprofPathPrefix+"trace", pprof.Trace)
+	}
 
 	// Wrap with middleware
-	handler := s.corsMiddleware(mux)
-	handler = s.authMiddleware(handler)
-	handler = s.metricsMiddleware(handler, httpMetrics)
+	handler := s.bodyCaptureMiddleware(mux)
+	handler = s.compressionMiddleware(handler)
+	handler = s.timeoutMiddleware(handler)
+	handler = s.corsMiddleware(handler)
+
+	stop := func() {}
+	limiter := s.newRateLimiter()
+	if limiter != nil {
+		handler = limiter.Middleware(handler)
+		stop = limiter.Stop
+	}
+
+	handler = s.authMiddleware(handler, limiter)
+	handler = s.metricsMiddleware(handler, s.httpMetrics)
 	handler = s.tracingMiddleware(handler)
 	handler = s.loggingMiddleware(handler)
+	handler = s.requestIDMiddleware(handler)
 
-	return handler
+	return handler, stop
 }
 
-// handleHealth handles health check requests
+// handleHealth handles health check requests, running any checks added
+// with RegisterHealthCheck; only failing required checks make it respond 503
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		s.writeMethodNotAllowed(w)
 		return
 	}
 
@@ -111,13 +225,75 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 		"version":   Version,
 	}
 
+	checks, healthy, allPassed := s.runHealthChecks(r.Context())
+	if checks != nil {
+		response["checks"] = checks
+	}
+
+	if !healthy {
+		response["status"] = "unhealthy"
+		s.writeJSON(w, http.StatusServiceUnavailable, response)
+		return
+	}
+	if !allPassed {
+		response["status"] = "degraded"
+	}
+
+	s.writeJSON(w, http.StatusOK, response)
+}
+
+// handleReady handles readiness check requests by verifying backend connectivity
+func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeMethodNotAllowed(w)
+		return
+	}
+
+	response := map[string]interface{}{
+		"status":    "ready",
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+		"version":   Version,
+	}
+
+	if !s.startupGateOpen() {
+		response["status"] = "starting"
+		s.writeJSON(w, http.StatusServiceUnavailable, response)
+		return
+	}
+
+	if s.readinessChecker != nil {
+		ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+		defer cancel()
+
+		if err := s.readinessChecker(ctx); err != nil {
+			// The endpoint is unauthenticated, so the error, which may name
+			// the PCF URL or echo its response, is only logged
+			observability.FromContext(ctx).WarnContext(ctx, "Readiness check failed", "error", err)
+			response["status"] = "not_ready"
+			response["checks"] = map[string]interface{}{
+				"pcf": map[string]interface{}{
+					"status": "unreachable",
+					"error":  readinessUnavailable,
+				},
+			}
+			s.writeJSON(w, http.StatusServiceUnavailable, response)
+			return
+		}
+
+		response["checks"] = map[string]interface{}{
+			"pcf": map[string]interface{}{
+				"status": "ok",
+			},
+		}
+	}
+
 	s.writeJSON(w, http.StatusOK, response)
 }
 
 // handleInfo handles server info requests
 func (s *Server) handleInfo(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		s.writeMethodNotAllowed(w)
 		return
 	}
 
@@ -130,22 +306,55 @@ func (s *Server) handleInfo(w http.ResponseWriter, r *http.Request) {
 			"resources": caps.Resources,
 			"prompts":   caps.Prompts,
 		},
+		"build": BuildInfo(),
 	}
 
 	s.writeJSON(w, http.StatusOK, response)
 }
 
+// handleVersion handles build metadata requests
+func (s *Server) handleVersion(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeMethodNotAllowed(w)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, BuildInfo())
+}
+
 // handleTools handles tool listing requests
 func (s *Server) handleTools(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		s.writeMethodNotAllowed(w)
+		return
+	}
+
+	// Optionally filter by tag, e.g. /tools?tag=hosts
+	query := r.URL.Query()
+	tag := query.Get("tag")
+
+	// Optionally page through the tools, e.g. /tools?limit=20&offset=40
+	limit, err := queryInt(query, "limit", 1)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, CodeValidation, err.Error())
+		return
+	}
+	offset, err := queryInt(query, "offset", 0)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, CodeValidation, err.Error())
 		return
 	}
 
+	// Sort by name so pages are stable
 	tools := s.ListTools()
+	sort.Slice(tools, func(i, j int) bool { return tools[i].Name < tools[j].Name })
 	toolList := make([]map[string]interface{}, 0, len(tools))
 
 	for _, tool := range tools {
+		if tag != "" && !slices.Contains(tool.Tags, tag) {
+			continue
+		}
+
 		toolInfo := map[string]interface{}{
 			"name":        tool.Name,
 			"description": tool.Description,
@@ -153,18 +362,160 @@ func (s *Server) handleTools(w http.ResponseWriter, r *http.Request) {
 		if tool.InputSchema != nil {
 			toolInfo["inputSchema"] = tool.InputSchema
 		}
+		if tool.Category != "" {
+			toolInfo["category"] = tool.Category
+		}
+		if len(tool.Tags) > 0 {
+			toolInfo["tags"] = tool.Tags
+		}
 		toolList = append(toolList, toolInfo)
 	}
 
 	response := map[string]interface{}{
-		"tools": toolList,
+		"total": len(toolList),
+	}
+
+	// Without limit the rest of the list is returned, as before paging
+	end := len(toolList)
+	if limit > 0 {
+		end = min(offset+limit, len(toolList))
+	}
+	start := min(offset, len(toolList))
+	if end < len(toolList) {
+		response["next_offset"] = end
 	}
+	response["tools"] = toolList[start:end]
 
 	s.writeJSON(w, http.StatusOK, response)
 }
 
+// queryInt parses the named query parameter as an integer of at least minimum,
+// returning 0 when it is absent
+func queryInt(query url.Values, name string, minimum int) (int, error) {
+	raw := query.Get(name)
+	if raw == "" {
+		return 0, nil
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < minimum {
+		return 0, fmt.Errorf("invalid %s: %q (must be an integer of at least %d)", name, raw, minimum)
+	}
+	return value, nil
+}
+
+// handlePrompts lists the registered prompt templates
+func (s *Server) handlePrompts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeMethodNotAllowed(w)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, s.promptsListResult())
+}
+
+// handlePromptRender renders a prompt template with the arguments in the
+// request body, a JSON object of strings
+func (s *Server) handlePromptRender(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.writeMethodNotAllowed(w)
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/prompts/")
+	if name == "" || strings.Contains(name, "/") {
+		s.writeError(w, http.StatusNotFound, CodeNotFound, "Prompt not found")
+		return
+	}
+
+	var args map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&args); err != nil && !errors.Is(err, io.EOF) {
+		s.writeError(w, http.StatusBadRequest, CodeValidation, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+
+	result, err := s.promptGetResult(name, args)
+	if err != nil {
+		if errors.Is(err, ErrPromptNotFound) {
+			s.writeError(w, http.StatusNotFound, CodeNotFound, err.Error())
+		} else {
+			s.writeError(w, http.StatusBadRequest, CodeValidation, err.Error())
+		}
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, result)
+}
+
+// handleResources lists the registered resources, including URI templates
+func (s *Server) handleResources(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeMethodNotAllowed(w)
+		return
+	}
+
+	resources := s.ListResources()
+	resourceList := make([]map[string]interface{}, 0, len(resources))
+	for _, resource := range resources {
+		resourceList = append(resourceList, resourceInfo(resource))
+	}
+
+	s.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"resources": resourceList,
+	})
+}
+
+// handleResourceRead reads the resource named by the uri query parameter
+func (s *Server) handleResourceRead(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeMethodNotAllowed(w)
+		return
+	}
+
+	uri := r.URL.Query().Get("uri")
+	if uri == "" {
+		s.writeError(w, http.StatusBadRequest, CodeValidation, "uri query parameter is required")
+		return
+	}
+
+	contents, err := s.ReadResource(r.Context(), uri)
+	if err != nil {
+		if errors.Is(err, ErrInsufficientScope) {
+			s.writeError(w, http.StatusForbidden, CodeForbidden, err.Error())
+		} else if errors.Is(err, ErrResourceNotFound) || errors.Is(err, pcf.ErrNotFound) {
+			s.writeError(w, http.StatusNotFound, CodeNotFound, err.Error())
+		} else {
+			s.writeError(w, http.StatusInternalServerError, CodeInternal, err.Error())
+		}
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"contents": []*ResourceContents{contents},
+	})
+}
+
+// handleStats returns min/avg/percentile latency summaries per tool over
+// each tool's most recent executions
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeMethodNotAllowed(w)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"tools": s.toolLatencyStats(),
+	})
+}
+
 // handleToolExecution handles tool execution requests
 func (s *Server) handleToolExecution(w http.ResponseWriter, r *http.Request) {
+	// Route streaming requests to the SSE handler
+	if strings.HasSuffix(strings.TrimPrefix(r.URL.Path, "/tools/"), streamSuffix) {
+		s.handleToolStream(w, r)
+		return
+	}
+
 	// Only allow POST and OPTIONS
 	if r.Method == http.MethodOptions {
 		// CORS preflight handled by middleware
@@ -173,32 +524,40 @@ func (s *Server) handleToolExecution(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		s.writeMethodNotAllowed(w)
 		return
 	}
 
 	// Extract tool name from path
 	path := strings.TrimPrefix(r.URL.Path, "/tools/")
 	if path == "" || strings.Contains(path, "/") {
-		s.writeError(w, http.StatusNotFound, "Tool not found")
+		s.writeError(w, http.StatusNotFound, CodeToolNotFound, "Tool not found")
 		return
 	}
 
-	// Parse request body
+	// Parse request body; tools without params may be called with an empty
+	// body or null, which are treated as {}
 	var params map[string]interface{}
-	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
-		s.writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil && !errors.Is(err, io.EOF) {
+		s.writeError(w, http.StatusBadRequest, CodeValidation, fmt.Sprintf("Invalid request body: %v", err))
 		return
 	}
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+
+	// Execute tool, recording the params it ran with for the _debug block
+	debug := s.debugRequested(r)
+	ctx := r.Context()
+	var executed map[string]interface{}
+	if debug {
+		ctx = withExecutedParams(ctx, &executed)
+	}
 
-	// Execute tool
-	result, err := s.ExecuteTool(r.Context(), path, params)
+	start := time.Now()
+	result, err := s.ExecuteToolWithMetrics(ctx, path, params)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			s.writeError(w, http.StatusNotFound, err.Error())
-		} else {
-			s.writeError(w, http.StatusInternalServerError, err.Error())
-		}
+		s.writeToolError(w, err)
 		return
 	}
 
@@ -206,9 +565,30 @@ func (s *Server) handleToolExecution(w http.ResponseWriter, r *http.Request) {
 		"result": result,
 	}
 
+	// Echo what the server actually ran, after defaults and middleware, so
+	// clients can spot params that were handled differently than they intended
+	if debug {
+		response["_debug"] = map[string]interface{}{
+			"tool":        path,
+			"params":      executed,
+			"duration_ms": time.Since(start).Milliseconds(),
+		}
+	}
+
 	s.writeJSON(w, http.StatusOK, response)
 }
 
+// debugRequested reports whether an authenticated request asked for the
+// _debug block with ?debug=1 or an X-Debug: true header
+func (s *Server) debugRequested(r *http.Request) bool {
+	debug, _ := strconv.ParseBool(r.URL.Query().Get("debug"))
+	if !debug {
+		debug, _ = strconv.ParseBool(r.Header.Get(headerDebug))
+	}
+
+	return debug && requestAuthenticated(r)
+}
+
 // corsMiddleware adds CORS headers
 func (s *Server) corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -228,8 +608,10 @@ func (s *Server) corsMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-// authMiddleware handles authentication if enabled
-func (s *Server) authMiddleware(next http.Handler) http.Handler {
+// authMiddleware handles authentication if enabled. Rejected requests count
+// against their IP's limit in limiter, if any, so made-up tokens cannot be
+// tried without limit.
+func (s *Server) authMiddleware(next http.Handler, limiter *RateLimiter) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Skip auth if not required
 		if !s.config.AuthRequired {
@@ -237,8 +619,9 @@ func (s *Server) authMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
-		// Skip auth for health and metrics endpoints
-		if r.URL.Path == "/health" || r.URL.Path == "/metrics" {
+		// Skip auth for health and readiness probes, and for metrics unless
+		// metrics authentication is enabled
+		if r.URL.Path == "/health" || r.URL.Path == "/ready" || (r.URL.Path == "/metrics" && !s.metricsRequireAuth) {
 			next.ServeHTTP(w, r)
 			return
 		}
@@ -246,26 +629,295 @@ func (s *Server) authMiddleware(next http.Handler) http.Handler {
 		// Check Authorization header
 		authHeader := r.Header.Get(headerAuthorization)
 		if authHeader == "" {
-			s.writeError(w, http.StatusUnauthorized, "Authorization header required")
+			s.rejectUnauthorized(w, r, limiter, "Authorization header required")
 			return
 		}
 
 		// Validate Bearer token
 		if !strings.HasPrefix(authHeader, bearerPrefix) {
-			s.writeError(w, http.StatusUnauthorized, "Invalid authorization format")
+			s.rejectUnauthorized(w, r, limiter, "Invalid authorization format")
 			return
 		}
 
 		token := strings.TrimPrefix(authHeader, bearerPrefix)
-		if token != s.config.AuthToken {
-			s.writeError(w, http.StatusUnauthorized, "Invalid authorization token")
+		claims, err := s.authenticateToken(r.Context(), token)
+		if err != nil {
+			logger := observability.FromContext(r.Context())
+			if errors.Is(err, auth.ErrInvalidToken) {
+				logger.DebugContext(r.Context(), "Rejected bearer token", observability.FieldError, err)
+			} else {
+				logger.WarnContext(r.Context(), "Failed to verify bearer token", observability.FieldError, err)
+			}
+			s.rejectUnauthorized(w, r, limiter, "Invalid authorization token")
 			return
 		}
 
+		// Record the result for debugRequested, rather than verifying the
+		// token again. Authenticated clients may raise or lower the log
+		// level for just their request; tools log through the context
+		// logger.
+		ctx := withAuthenticated(r.Context())
+		if level := r.Header.Get(headerLogLevel); level != "" {
+			if override, err := observability.WithLevel(observability.FromContext(ctx), level); err == nil {
+				ctx = observability.WithLogger(ctx, override)
+			}
+		}
+		r = r.WithContext(ctx)
+
+		// Verified JWT claims identify the caller in tool logs
+		if claims != nil {
+			ctx := auth.WithClaims(r.Context(), claims)
+			if claims.Subject != "" {
+				ctx = observability.WithLogger(ctx, observability.FromContext(ctx).With(observability.FieldUserID, claims.Subject))
+			}
+			r = r.WithContext(ctx)
+		}
+
+		// Callers with scopes are limited to the tools those scopes allow
+		if scopes, ok := s.callerScopes(token, claims); ok {
+			r = r.WithContext(auth.WithScopes(r.Context(), scopes))
+		}
+
 		next.ServeHTTP(w, r)
 	})
 }
 
+// rejectUnauthorized writes a 401 response with message, or a 429 response
+// when the client's IP has exceeded its limit in limiter
+func (s *Server) rejectUnauthorized(w http.ResponseWriter, r *http.Request, limiter *RateLimiter, message string) {
+	if limiter != nil && !limiter.admit(w, r, remoteIPKey(r)) {
+		return
+	}
+
+	s.writeError(w, http.StatusUnauthorized, CodeUnauthorized, message)
+}
+
+// authenticatedKey marks a request context whose bearer token authMiddleware
+// verified
+type authenticatedKey struct{}
+
+// withAuthenticated marks ctx as carrying a verified bearer token
+func withAuthenticated(ctx context.Context) context.Context {
+	return context.WithValue(ctx, authenticatedKey{}, true)
+}
+
+// requestAuthenticated reports whether authentication is enabled and
+// authMiddleware verified the request's bearer token
+func requestAuthenticated(r *http.Request) bool {
+	authenticated, _ := r.Context().Value(authenticatedKey{}).(bool)
+	return authenticated
+}
+
+// callerScopes returns the scopes granted to an authenticated caller: the
+// scope claim of a JWT, or JWTDefaultScopes when it has none, or the
+// configured scopes of a static token. ok is false when the caller is
+// unrestricted, which JWT callers never are.
+func (s *Server) callerScopes(token string, claims *auth.Claims) ([]string, bool) {
+	if claims != nil {
+		if claims.Scopes != nil {
+			return claims.Scopes, true
+		}
+		return append([]string{}, s.config.JWTDefaultScopes...), true
+	}
+
+	scopes, ok := s.tokenScopes[token]
+	return scopes, ok
+}
+
+// authenticateToken checks a bearer token according to the auth mode. In
+// jwt mode it returns the token's verified claims; in static mode the
+// claims are nil. Rejected tokens wrap auth.ErrInvalidToken.
+func (s *Server) authenticateToken(ctx context.Context, token string) (*auth.Claims, error) {
+	if s.jwtValidator != nil {
+		return s.jwtValidator.Validate(ctx, token)
+	}
+
+	if !s.validAuthToken(token) {
+		return nil, auth.ErrInvalidToken
+	}
+
+	return nil, nil
+}
+
+// validAuthToken reports whether token matches any configured bearer token.
+// Every token is compared in constant time so timing does not reveal which
+// token, or how much of it, matched.
+func (s *Server) validAuthToken(token string) bool {
+	if token == "" {
+		return false
+	}
+
+	valid := 0
+	for _, candidate := range s.config.ValidAuthTokens() {
+		valid |= subtle.ConstantTimeCompare([]byte(token), []byte(candidate))
+	}
+
+	return valid == 1
+}
+
+// compressionMiddleware gzips responses for clients that accept it. The
+// metrics endpoint is left uncompressed for Prometheus scrapers, pprof
+// profiles are already gzipped, and WebSocket upgrades are passed through.
+func (s *Server) compressionMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/metrics" || r.URL.Path == wsPath || strings.HasPrefix(r.URL.Path, pprofPathPrefix) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add(headerVary, headerAcceptEncoding)
+
+		if !acceptsGzip(r.Header.Get(headerAcceptEncoding)) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		gzw := &gzipResponseWriter{ResponseWriter: w}
+		defer gzw.Close()
+
+		next.ServeHTTP(gzw, r)
+	})
+}
+
+// acceptsGzip reports whether an Accept-Encoding header value allows gzip
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		coding = strings.TrimSpace(coding)
+		if coding != encodingGzip && coding != "*" {
+			continue
+		}
+
+		// An explicit q=0 means the coding is not acceptable
+		if name, value, ok := strings.Cut(params, "="); ok && strings.TrimSpace(name) == "q" {
+			if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil && q == 0 {
+				continue
+			}
+		}
+
+		return true
+	}
+	return false
+}
+
+// gzipResponseWriter wraps http.ResponseWriter to gzip the response body.
+// Responses that cannot carry a body or are already encoded pass through.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz          *gzip.Writer
+	wroteHeader bool
+}
+
+func (gw *gzipResponseWriter) WriteHeader(code int) {
+	if gw.wroteHeader {
+		return
+	}
+	gw.wroteHeader = true
+
+	header := gw.Header()
+	if code != http.StatusNoContent && code != http.StatusNotModified && header.Get(headerContentEncoding) == "" {
+		header.Del(headerContentLength)
+		header.Set(headerContentEncoding, encodingGzip)
+		gw.gz = gzip.NewWriter(gw.ResponseWriter)
+	}
+
+	gw.ResponseWriter.WriteHeader(code)
+}
+
+func (gw *gzipResponseWriter) Write(b []byte) (int, error) {
+	if !gw.wroteHeader {
+		gw.WriteHeader(http.StatusOK)
+	}
+
+	if gw.gz == nil {
+		return gw.ResponseWriter.Write(b)
+	}
+	return gw.gz.Write(b)
+}
+
+// Flush implements http.Flusher so streaming responses are sent as they are written
+func (gw *gzipResponseWriter) Flush() {
+	if gw.gz != nil {
+		if err := gw.gz.Flush(); err != nil {
+			return
+		}
+	}
+
+	if flusher, ok := gw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Close writes any buffered compressed data and the gzip footer
+func (gw *gzipResponseWriter) Close() {
+	if gw.gz == nil {
+		return
+	}
+
+	if err := gw.gz.Close(); err != nil {
+		slog.Debug("Failed to close gzip writer", "error", err)
+	}
+}
+
+// toolTimeoutGrace is how long past ToolTimeout timeoutMiddleware waits on a
+// tool execution, so the tool timeout fires first and is answered with 504
+const toolTimeoutGrace = 5 * time.Second
+
+// timeoutMiddleware bounds how long a handler may take to respond,
+// including decoding the request body, and answers 503 with a timeout error
+// once HandlerTimeout passes. Tool executions get the larger of
+// HandlerTimeout and ToolTimeout plus toolTimeoutGrace, so the tool timeout,
+// answered with 504, fires first; they are unbounded when ToolTimeout is 0.
+// Batches pass
+// through, since each of their calls runs sequentially under its own tool
+// timeout and one budget cannot fit up to maxToolBatchSize calls. Tool
+// streams, WebSocket sessions, and pprof profiles are long-lived and pass
+// through too.
+func (s *Server) timeoutMiddleware(next http.Handler) http.Handler {
+	timeout := s.config.HandlerTimeout
+	if timeout <= 0 {
+		return next
+	}
+
+	body, _ := json.Marshal(ErrorResponse{Error: ErrorDetail{
+		Code:    CodeTimeout,
+		Message: "Request processing timed out",
+	}})
+	bounded := http.TimeoutHandler(next, timeout, string(body)+"\n")
+
+	toolHandler := next
+	if s.config.ToolTimeout > 0 {
+		toolHandler = http.TimeoutHandler(next, max(timeout, s.config.ToolTimeout+toolTimeoutGrace), string(body)+"\n")
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		switch {
+		case path == wsPath || path == batchPath || strings.HasPrefix(path, pprofPathPrefix):
+			next.ServeHTTP(w, r)
+		case strings.HasPrefix(path, "/tools/") && strings.HasSuffix(strings.TrimPrefix(path, "/tools/"), streamSuffix):
+			next.ServeHTTP(w, r)
+		case strings.HasPrefix(path, "/tools/"):
+			toolHandler.ServeHTTP(&timeoutResponseWriter{ResponseWriter: w}, r)
+		default:
+			bounded.ServeHTTP(&timeoutResponseWriter{ResponseWriter: w}, r)
+		}
+	})
+}
+
+// timeoutResponseWriter labels the body http.TimeoutHandler writes on
+// expiry as JSON. Completed responses carry the handler's own headers.
+type timeoutResponseWriter struct {
+	http.ResponseWriter
+}
+
+func (tw *timeoutResponseWriter) WriteHeader(code int) {
+	if code == http.StatusServiceUnavailable && tw.Header().Get(headerContentType) == "" {
+		tw.Header().Set(headerContentType, contentTypeJSON)
+	}
+	tw.ResponseWriter.WriteHeader(code)
+}
+
 // metricsMiddleware records HTTP metrics
 func (s *Server) metricsMiddleware(next http.Handler, metrics *httpMetrics) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -286,16 +938,28 @@ func (s *Server) metricsMiddleware(next http.Handler, metrics *httpMetrics) http
 	})
 }
 
-// tracingMiddleware adds distributed tracing
+// tracingMiddleware adds distributed tracing. Spans record the request path
+// but never the query string, which can carry tool params such as the
+// credential value streamed tools receive.
 func (s *Server) tracingMiddleware(next http.Handler) http.Handler {
 	tracer := otel.Tracer("pcf-mcp-http")
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx, span := tracer.Start(r.Context(), fmt.Sprintf("%s %s", r.Method, r.URL.Path),
+		// Join the caller's trace when it sent a traceparent
+		carrier := make(map[string]string)
+		for _, field := range otel.GetTextMapPropagator().Fields() {
+			if value := r.Header.Get(field); value != "" {
+				carrier[field] = value
+			}
+		}
+		ctx := observability.ExtractHTTPHeaders(r.Context(), carrier)
+
+		ctx, span := tracer.Start(ctx, fmt.Sprintf("%s %s", r.Method, r.URL.Path),
 			trace.WithAttributes(
 				attribute.String("http.method", r.Method),
-				attribute.String("http.url", r.URL.String()),
+				attribute.String("http.target", r.URL.Path),
 				attribute.String("http.user_agent", r.UserAgent()),
+				attribute.String(observability.AttributeRequestID, observability.RequestIDFromContext(r.Context())),
 			),
 		)
 		defer span.End()
@@ -321,28 +985,97 @@ func (s *Server) loggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 
+		logger := observability.FromContext(r.Context())
+
 		// Wrap response writer to capture status code
 		wrapped := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
 
+		// Capture bodies when they may be logged. The response is captured
+		// both as sent and, by bodyCaptureMiddleware, before compression.
+		var requestBody, decodedBody *bodyCapture
+		if s.capturesBodies() {
+			requestBody = captureRequestBody(r)
+			wrapped.body = &bodyCapture{}
+			decodedBody = &bodyCapture{}
+			r = r.WithContext(withResponseBodyCapture(r.Context(), decodedBody))
+		}
+
 		// Handle request
 		next.ServeHTTP(wrapped, r)
 
-		// Log request
+		// Log request; duration_ms is numeric so log pipelines can aggregate
+		// latency, and request_id is added by the context logger
 		duration := time.Since(start)
-		slog.Info("HTTP request",
+		attrs := []any{
 			"method", r.Method,
 			"path", r.URL.Path,
 			"status", wrapped.statusCode,
-			"duration", duration,
+			"duration", duration.String(),
+			"duration_ms", float64(duration) / float64(time.Millisecond),
+			"bytes_written", wrapped.bytesWritten,
 			"remote_addr", r.RemoteAddr,
-		)
+			"user_agent", r.UserAgent(),
+		}
+
+		if requestBody != nil && s.logsBodiesFor(wrapped.statusCode) {
+			// Encoded responses are logged as the handler wrote them
+			responseBody := wrapped.body
+			if wrapped.Header().Get(headerContentEncoding) != "" {
+				responseBody = decodedBody
+			}
+			attrs = append(attrs,
+				"request_body", s.redactedBody(requestBody),
+				"response_body", s.redactedBody(responseBody),
+			)
+		}
+
+		logger.InfoContext(r.Context(), "HTTP request", attrs...)
+	})
+}
+
+// requestIDMiddleware assigns each request a correlation ID, taken from the
+// X-Request-ID header when it is well-formed and generated otherwise. The ID
+// is stored on the request context, where the logger, tracing, and PCF client
+// pick it up, and is echoed in the response header.
+func (s *Server) requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(observability.HeaderRequestID)
+		if !validRequestID(requestID) {
+			requestID = observability.NewRequestID()
+		}
+
+		w.Header().Set(observability.HeaderRequestID, requestID)
+		next.ServeHTTP(w, r.WithContext(observability.WithRequestID(r.Context(), requestID)))
 	})
 }
 
-// responseWriter wraps http.ResponseWriter to capture status code
+// validRequestID reports whether a client-supplied request ID is safe to log
+// and forward: non-empty, bounded, and limited to URL-safe characters
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+
+	for _, c := range requestID {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// responseWriter wraps http.ResponseWriter to capture status code, the
+// number of body bytes written and, when body is set, the start of the
+// response body
 type responseWriter struct {
 	http.ResponseWriter
-	statusCode int
+	statusCode   int
+	bytesWritten int
+	body         *bodyCapture
 }
 
 func (rw *responseWriter) WriteHeader(code int) {
@@ -350,6 +1083,34 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
+func (rw *responseWriter) Write(p []byte) (int, error) {
+	n, err := rw.ResponseWriter.Write(p)
+	rw.bytesWritten += n
+	if rw.body != nil {
+		rw.body.Write(p[:n])
+	}
+	return n, err
+}
+
+// Flush implements http.Flusher so streaming responses pass through middleware
+func (rw *responseWriter) Flush() {
+	if flusher, ok := rw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker so WebSocket upgrades pass through middleware
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := rw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+
+	// A hijacked connection reports 101 Switching Protocols
+	rw.statusCode = http.StatusSwitchingProtocols
+	return hijacker.Hijack()
+}
+
 // writeJSON writes a JSON response
 func (s *Server) writeJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set(headerContentType, contentTypeJSON)
@@ -360,33 +1121,104 @@ func (s *Server) writeJSON(w http.ResponseWriter, status int, data interface{})
 	}
 }
 
-// writeError writes an error response
-func (s *Server) writeError(w http.ResponseWriter, status int, message string) {
-	response := map[string]interface{}{
-		"error": message,
+// tlsConfig returns the TLS configuration for the HTTP server, or nil when
+// no certificate is configured
+func (s *Server) tlsConfig() (*tls.Config, error) {
+	if s.config.TLSCertFile == "" && s.config.TLSKeyFile == "" {
+		return nil, nil
+	}
+
+	if s.config.TLSCertFile == "" || s.config.TLSKeyFile == "" {
+		return nil, fmt.Errorf("both TLS cert file and key file must be provided")
+	}
+
+	minVersion, err := config.ParseTLSVersion(s.config.TLSMinVersion)
+	if err != nil {
+		return nil, err
 	}
-	s.writeJSON(w, status, response)
+
+	return &tls.Config{MinVersion: minVersion}, nil
+}
+
+// listenAddress returns where the HTTP transport listens: the Unix socket
+// when one is configured, otherwise host:port
+func (s *Server) listenAddress() string {
+	if s.config.UnixSocket != "" {
+		return "unix:" + s.config.UnixSocket
+	}
+	return fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
+}
+
+// listenAndServe starts httpServer on the configured Unix socket or TCP
+// address, serving HTTPS when TLS is configured
+func (s *Server) listenAndServe(httpServer *http.Server) error {
+	if s.config.UnixSocket == "" {
+		if httpServer.TLSConfig != nil {
+			return httpServer.ListenAndServeTLS(s.config.TLSCertFile, s.config.TLSKeyFile)
+		}
+		return httpServer.ListenAndServe()
+	}
+
+	// Closing the listener, which Shutdown does, removes the socket file
+	listener, err := listenUnixSocket(s.config.UnixSocket)
+	if err != nil {
+		return err
+	}
+
+	if httpServer.TLSConfig != nil {
+		return httpServer.ServeTLS(listener, s.config.TLSCertFile, s.config.TLSKeyFile)
+	}
+	return httpServer.Serve(listener)
+}
+
+// newHTTPServer creates the http.Server for addr serving HTTPHandler with
+// the configured timeouts, falling back to defaults for unset connection
+// timeouts. The handler's background work stops when the server is shut
+// down. Callers add the TLS configuration.
+func (s *Server) newHTTPServer(addr string) *http.Server {
+	idleTimeout := defaultIdleTimeout
+	if s.config.IdleTimeout > 0 {
+		idleTimeout = s.config.IdleTimeout
+	}
+
+	readHeaderTimeout := defaultReadHeaderTimeout
+	if s.config.ReadHeaderTimeout > 0 {
+		readHeaderTimeout = s.config.ReadHeaderTimeout
+	}
+
+	handler, stop := s.newHTTPHandler()
+
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadTimeout:       s.config.ReadTimeout,
+		ReadHeaderTimeout: readHeaderTimeout,
+		WriteTimeout:      s.config.WriteTimeout,
+		IdleTimeout:       idleTimeout,
+	}
+	httpServer.RegisterOnShutdown(stop)
+
+	return httpServer
 }
 
 // StartHTTP starts the HTTP server
 func (s *Server) StartHTTP(ctx context.Context) error {
-	// Build address from host and port
-	addr := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
+	addr := s.listenAddress()
 
-	// Create HTTP server
-	httpServer := &http.Server{
-		Addr:         addr,
-		Handler:      s.HTTPHandler(),
-		ReadTimeout:  s.config.ReadTimeout,
-		WriteTimeout: s.config.WriteTimeout,
-		IdleTimeout:  120 * time.Second,
+	tlsConfig, err := s.tlsConfig()
+	if err != nil {
+		return err
 	}
 
+	// Create HTTP server
+	httpServer := s.newHTTPServer(addr)
+	httpServer.TLSConfig = tlsConfig
+
 	// Start server in goroutine
 	errCh := make(chan error, 1)
 	go func() {
-		slog.Info("Starting HTTP server", "address", addr)
-		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		slog.Info("Starting HTTP server", "address", addr, "tls", tlsConfig != nil)
+		if err := s.listenAndServe(httpServer); err != nil && err != http.ErrServerClosed {
 			errCh <- fmt.Errorf("HTTP server error: %w", err)
 		}
 	}()
@@ -395,7 +1227,7 @@ func (s *Server) StartHTTP(ctx context.Context) error {
 	select {
 	case <-ctx.Done():
 		// Graceful shutdown
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout())
 		defer cancel()
 
 		slog.Info("Shutting down HTTP server")
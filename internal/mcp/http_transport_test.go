@@ -2,14 +2,41 @@ package mcp
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/aRustyDev/pcf-mcp/internal/auth"
 	"github.com/aRustyDev/pcf-mcp/internal/config"
+	"github.com/aRustyDev/pcf-mcp/internal/observability"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // TestHTTPTransport tests the HTTP transport functionality
@@ -102,6 +129,33 @@ func TestHTTPTransport(t *testing.T) {
 				if resp["version"] != Version {
 					t.Errorf("Expected version '%s', got %v", Version, resp["version"])
 				}
+				if _, ok := resp["build"].(map[string]interface{}); !ok {
+					t.Errorf("Expected build metadata, got %v", resp["build"])
+				}
+			},
+		},
+		{
+			name:           "GET /version",
+			method:         "GET",
+			path:           "/version",
+			body:           nil,
+			expectedStatus: http.StatusOK,
+			validateBody: func(t *testing.T, body []byte) {
+				var resp map[string]string
+				if err := json.Unmarshal(body, &resp); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				for _, key := range []string{"version", "git_commit", "build_date", "go_version"} {
+					if resp[key] == "" {
+						t.Errorf("Expected %s in response, got %v", key, resp)
+					}
+				}
+				if resp["git_commit"] != "unknown" {
+					t.Errorf("Expected git_commit 'unknown' without ldflags, got %q", resp["git_commit"])
+				}
+				if resp["go_version"] != runtime.Version() {
+					t.Errorf("Expected go_version %q, got %q", runtime.Version(), resp["go_version"])
+				}
 			},
 		},
 		{
@@ -278,177 +332,1912 @@ func TestHTTPTransportCORS(t *testing.T) {
 	}
 }
 
-// TestHTTPTransportAuthentication tests authentication if enabled
-func TestHTTPTransportAuthentication(t *testing.T) {
-	cfg := config.ServerConfig{
-		Transport:    "http",
-		Host:         "localhost",
-		Port:         0,
-		ReadTimeout:  30 * time.Second,
-		WriteTimeout: 30 * time.Second,
-		AuthRequired: true,
-		AuthToken:    "test-token-123",
+// TestHTTPToolsTags tests that tool categories and tags are listed and that
+// /tools?tag= filters the listing
+func TestHTTPToolsTags(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{Transport: "http"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
 	}
 
-	server, err := NewServer(cfg)
+	noop := func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	tools := []Tool{
+		{Name: "list_hosts", Category: "hosts", Tags: []string{"hosts", "read"}, Handler: noop},
+		{Name: "add_host", Category: "hosts", Tags: []string{"hosts", "write"}, Handler: noop},
+		{Name: "create_issue", Category: "issues", Tags: []string{"issues", "write"}, Handler: noop},
+		{Name: "untagged", Handler: noop},
+	}
+	for _, tool := range tools {
+		if err := server.RegisterTool(tool); err != nil {
+			t.Fatalf("Failed to register tool: %v", err)
+		}
+	}
+
+	handler := server.HTTPHandler()
+
+	list := func(path string) map[string]map[string]interface{} {
+		t.Helper()
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+
+		var resp struct {
+			Tools []map[string]interface{} `json:"tools"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+
+		byName := make(map[string]map[string]interface{}, len(resp.Tools))
+		for _, tool := range resp.Tools {
+			byName[tool["name"].(string)] = tool
+		}
+		return byName
+	}
+
+	all := list("/tools")
+	if len(all) != 4 {
+		t.Fatalf("Expected 4 tools, got %d", len(all))
+	}
+
+	addHost := all["add_host"]
+	if addHost["category"] != "hosts" {
+		t.Errorf("Expected category 'hosts', got %v", addHost["category"])
+	}
+	if tags, ok := addHost["tags"].([]interface{}); !ok || len(tags) != 2 || tags[0] != "hosts" || tags[1] != "write" {
+		t.Errorf("Expected tags [hosts write], got %v", addHost["tags"])
+	}
+	if _, ok := all["untagged"]["tags"]; ok {
+		t.Error("Tools without tags should omit the tags field")
+	}
+
+	tests := []struct {
+		tag      string
+		expected []string
+	}{
+		{"write", []string{"add_host", "create_issue"}},
+		{"hosts", []string{"list_hosts", "add_host"}},
+		{"reports", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			filtered := list("/tools?tag=" + tt.tag)
+			if len(filtered) != len(tt.expected) {
+				t.Errorf("Expected %d tools tagged %s, got %d", len(tt.expected), tt.tag, len(filtered))
+			}
+			for _, name := range tt.expected {
+				if _, ok := filtered[name]; !ok {
+					t.Errorf("Expected %s in tools tagged %s", name, tt.tag)
+				}
+			}
+		})
+	}
+}
+
+// TestHTTPToolsPagination tests that /tools?limit=&offset= pages through the
+// tools sorted by name, and that the default listing is complete
+func TestHTTPToolsPagination(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{Transport: "http"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	noop := func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	for _, name := range []string{"list_projects", "add_host", "list_hosts", "create_issue", "generate_report"} {
+		if err := server.RegisterTool(Tool{Name: name, Handler: noop}); err != nil {
+			t.Fatalf("Failed to register tool: %v", err)
+		}
+	}
+
+	handler := server.HTTPHandler()
+
+	type page struct {
+		Tools []struct {
+			Name string `json:"name"`
+		} `json:"tools"`
+		Total      int  `json:"total"`
+		NextOffset *int `json:"next_offset"`
+	}
+
+	list := func(path string) page {
+		t.Helper()
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200 for %s, got %d: %s", path, w.Code, w.Body.String())
+		}
+
+		var resp page
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		return resp
+	}
+
+	names := func(p page) string {
+		var out []string
+		for _, tool := range p.Tools {
+			out = append(out, tool.Name)
+		}
+		return strings.Join(out, ",")
+	}
+
+	all := list("/tools")
+	if all.Total != 5 || names(all) != "add_host,create_issue,generate_report,list_hosts,list_projects" {
+		t.Errorf("Expected all 5 tools sorted by name, got %d: %s", all.Total, names(all))
+	}
+	if all.NextOffset != nil {
+		t.Errorf("Expected no next_offset for the full listing, got %d", *all.NextOffset)
+	}
+
+	first := list("/tools?limit=2&offset=1")
+	if first.Total != 5 || names(first) != "create_issue,generate_report" {
+		t.Errorf("Expected tools 2-3 of 5, got %d: %s", first.Total, names(first))
+	}
+	if first.NextOffset == nil || *first.NextOffset != 3 {
+		t.Errorf("Expected next_offset 3, got %v", first.NextOffset)
+	}
+
+	last := list("/tools?limit=2&offset=3")
+	if names(last) != "list_hosts,list_projects" || last.NextOffset != nil {
+		t.Errorf("Expected the last page without next_offset, got %s and %v", names(last), last.NextOffset)
+	}
+
+	if past := list("/tools?offset=10"); len(past.Tools) != 0 || past.Total != 5 {
+		t.Errorf("Expected an empty page past the end, got %d tools of %d", len(past.Tools), past.Total)
+	}
+
+	for _, query := range []string{"limit=0", "limit=abc", "offset=-1"} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", "/tools?"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for %s, got %d", query, w.Code)
+		}
+	}
+}
+
+// TestHTTPTransportCompression tests gzip compression of responses
+func TestHTTPTransportCompression(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{Transport: "http"})
 	if err != nil {
 		t.Fatalf("Failed to create server: %v", err)
 	}
 
+	server.RegisterTool(Tool{
+		Name:        "test_tool",
+		Description: "A test tool",
+		InputSchema: map[string]interface{}{"type": "object"},
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return nil, nil
+		},
+	})
+
 	handler := server.HTTPHandler()
-	ts := httptest.NewServer(handler)
-	defer ts.Close()
+
+	t.Run("gzip-capable client", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/tools", nil)
+		req.Header.Set("Accept-Encoding", "gzip, deflate")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+
+		if enc := w.Header().Get("Content-Encoding"); enc != "gzip" {
+			t.Fatalf("Expected Content-Encoding gzip, got %q", enc)
+		}
+
+		gz, err := gzip.NewReader(w.Body)
+		if err != nil {
+			t.Fatalf("Response is not gzip encoded: %v", err)
+		}
+		defer gz.Close()
+
+		var response map[string]interface{}
+		if err := json.NewDecoder(gz).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode decompressed response: %v", err)
+		}
+
+		tools, ok := response["tools"].([]interface{})
+		if !ok || len(tools) != 1 {
+			t.Errorf("Expected 1 tool in decompressed response, got %v", response["tools"])
+		}
+	})
 
 	tests := []struct {
 		name           string
 		path           string
-		authHeader     string
-		expectedStatus int
+		acceptEncoding string
 	}{
-		{
-			name:           "Valid token",
-			path:           "/info",
-			authHeader:     "Bearer test-token-123",
-			expectedStatus: http.StatusOK,
-		},
-		{
-			name:           "Invalid token",
-			path:           "/info",
-			authHeader:     "Bearer wrong-token",
-			expectedStatus: http.StatusUnauthorized,
-		},
-		{
-			name:           "Missing token",
-			path:           "/info",
-			authHeader:     "",
-			expectedStatus: http.StatusUnauthorized,
-		},
-		{
-			name:           "Invalid format",
-			path:           "/info",
-			authHeader:     "test-token-123",
-			expectedStatus: http.StatusUnauthorized,
-		},
-		{
-			name:           "Health endpoint without auth",
-			path:           "/health",
-			authHeader:     "",
-			expectedStatus: http.StatusOK,
-		},
-		{
-			name:           "Metrics endpoint without auth",
-			path:           "/metrics",
-			authHeader:     "",
-			expectedStatus: http.StatusOK,
-		},
+		{name: "No Accept-Encoding", path: "/tools"},
+		{name: "gzip refused", path: "/tools", acceptEncoding: "gzip;q=0"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			req, err := http.NewRequest("GET", ts.URL+tt.path, nil)
-			if err != nil {
-				t.Fatalf("Failed to create request: %v", err)
+			req := httptest.NewRequest("GET", tt.path, nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
 			}
+			w := httptest.NewRecorder()
 
-			if tt.authHeader != "" {
-				req.Header.Set("Authorization", tt.authHeader)
+			handler.ServeHTTP(w, req)
+
+			if enc := w.Header().Get("Content-Encoding"); enc != "" {
+				t.Errorf("Expected uncompressed response, got Content-Encoding %q", enc)
 			}
+		})
+	}
 
-			client := &http.Client{Timeout: 5 * time.Second}
-			resp, err := client.Do(req)
+	// The metrics handler negotiates its own encoding and must not be compressed twice
+	t.Run("Metrics endpoint", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/metrics", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		body := w.Body.Bytes()
+		if w.Header().Get("Content-Encoding") == "gzip" {
+			gz, err := gzip.NewReader(w.Body)
 			if err != nil {
-				t.Fatalf("Failed to send request: %v", err)
+				t.Fatalf("Metrics response is not gzip encoded: %v", err)
 			}
-			defer resp.Body.Close()
+			defer gz.Close()
 
-			if resp.StatusCode != tt.expectedStatus {
-				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			if body, err = io.ReadAll(gz); err != nil {
+				t.Fatalf("Failed to decompress metrics response: %v", err)
 			}
-		})
-	}
-}
+		}
 
-// TestHTTPTransportMetrics tests that metrics are properly recorded
-func TestHTTPTransportMetrics(t *testing.T) {
-	cfg := config.ServerConfig{
-		Transport:    "http",
-		Host:         "localhost",
-		Port:         0,
-		ReadTimeout:  30 * time.Second,
-		WriteTimeout: 30 * time.Second,
-	}
+		if !bytes.Contains(body, []byte("# HELP")) {
+			t.Errorf("Expected Prometheus text format, got %q", body)
+		}
+	})
+}
 
-	server, err := NewServer(cfg)
+// TestHTTPToolRequestBody tests that a tool without params may be called
+// with an empty body, while malformed JSON is rejected
+func TestHTTPToolRequestBody(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{Transport: "http"})
 	if err != nil {
 		t.Fatalf("Failed to create server: %v", err)
 	}
 
-	// Register a test tool
-	testTool := Tool{
-		Name:        "metrics_test",
-		Description: "Tool for testing metrics",
+	var received map[string]interface{}
+	err = server.RegisterTool(Tool{
+		Name:        "list_projects",
+		Description: "List projects",
 		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
-			return map[string]interface{}{"status": "ok"}, nil
+			received = params
+			return []string{}, nil
 		},
-	}
-
-	err = server.RegisterTool(testTool)
+	})
 	if err != nil {
 		t.Fatalf("Failed to register tool: %v", err)
 	}
 
 	handler := server.HTTPHandler()
-	ts := httptest.NewServer(handler)
-	defer ts.Close()
 
-	// Make several requests
-	client := &http.Client{Timeout: 5 * time.Second}
-	for i := 0; i < 5; i++ {
-		req, err := http.NewRequest("POST", ts.URL+"/tools/metrics_test", bytes.NewReader([]byte("{}")))
-		if err != nil {
-			t.Fatalf("Failed to create request: %v", err)
-		}
-		req.Header.Set("Content-Type", "application/json")
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{name: "Empty body", body: "", expectedStatus: http.StatusOK},
+		{name: "Empty object", body: "{}", expectedStatus: http.StatusOK},
+		{name: "Null", body: "null", expectedStatus: http.StatusOK},
+		{name: "Malformed JSON", body: `{"project_id":`, expectedStatus: http.StatusBadRequest},
+		{name: "Not an object", body: `["proj-1"]`, expectedStatus: http.StatusBadRequest},
+	}
 
-		resp, err := client.Do(req)
-		if err != nil {
-			t.Fatalf("Failed to send request: %v", err)
-		}
-		resp.Body.Close()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received = nil
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/tools/list_projects", strings.NewReader(tt.body)))
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+
+			if tt.expectedStatus == http.StatusOK {
+				if received == nil || len(received) != 0 {
+					t.Errorf("Expected the tool to receive empty params, got %v", received)
+				}
+				return
+			}
+
+			var resp ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Failed to unmarshal error response: %v", err)
+			}
+			if resp.Error.Code != CodeValidation {
+				t.Errorf("Expected error code %q, got %q", CodeValidation, resp.Error.Code)
+			}
+		})
 	}
+}
 
-	// Check metrics endpoint
-	req, err := http.NewRequest("GET", ts.URL+"/metrics", nil)
+// TestHTTPTransportNotFound tests that PCF 404s surface as HTTP 404
+func TestHTTPTransportNotFound(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{Transport: "http"})
 	if err != nil {
-		t.Fatalf("Failed to create request: %v", err)
+		t.Fatalf("Failed to create server: %v", err)
 	}
 
-	resp, err := client.Do(req)
+	err = server.RegisterTool(Tool{
+		Name:        "get_thing",
+		Description: "Tool whose resource is missing",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return nil, fmt.Errorf("failed to get thing: %w", pcf.ErrNotFound)
+		},
+	})
 	if err != nil {
-		t.Fatalf("Failed to send request: %v", err)
+		t.Fatalf("Failed to register tool: %v", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		t.Errorf("Expected status 200, got %d", resp.StatusCode)
-	}
+	req := httptest.NewRequest(http.MethodPost, "/tools/get_thing", bytes.NewReader([]byte("{}")))
+	w := httptest.NewRecorder()
 
-	// Verify that metrics are present
-	var body bytes.Buffer
-	_, err = body.ReadFrom(resp.Body)
-	if err != nil {
-		t.Fatalf("Failed to read response body: %v", err)
-	}
+	server.HTTPHandler().ServeHTTP(w, req)
 
-	bodyStr := body.String()
-	expectedMetrics := []string{
-		"http_requests_total",
-		"http_request_duration_seconds",
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
 	}
+}
 
-	for _, metric := range expectedMetrics {
-		if !bytes.Contains([]byte(bodyStr), []byte(metric)) {
-			t.Errorf("Expected metric '%s' not found in response", metric)
-		}
+// TestHTTPTransportPprof tests that profiling endpoints are mounted only when
+// enabled and always require authentication
+func TestHTTPTransportPprof(t *testing.T) {
+	tests := []struct {
+		name           string
+		enablePprof    bool
+		authRequired   bool
+		authHeader     string
+		expectedStatus int
+	}{
+		{
+			name:           "Enabled with valid token",
+			enablePprof:    true,
+			authRequired:   true,
+			authHeader:     "Bearer test-token",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Enabled without token",
+			enablePprof:    true,
+			authRequired:   true,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Disabled",
+			authRequired:   true,
+			authHeader:     "Bearer test-token",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Enabled without authentication",
+			enablePprof:    true,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, err := NewServer(config.ServerConfig{
+				Transport:    "http",
+				AuthRequired: tt.authRequired,
+				AuthToken:    "test-token",
+				EnablePprof:  tt.enablePprof,
+			})
+			if err != nil {
+				t.Fatalf("Failed to create server: %v", err)
+			}
+
+			req := httptest.NewRequest("GET", "/debug/pprof/heap", nil)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			w := httptest.NewRecorder()
+
+			server.HTTPHandler().ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+// TestHTTPTransportMultipleAuthTokens tests that every configured token is accepted
+func TestHTTPTransportMultipleAuthTokens(t *testing.T) {
+	tests := []struct {
+		name           string
+		authToken      string
+		authTokens     []string
+		authHeader     string
+		expectedStatus int
+	}{
+		{
+			name:           "Current token",
+			authTokens:     []string{"old-token", "new-token"},
+			authHeader:     "Bearer new-token",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Previous token during rotation",
+			authTokens:     []string{"old-token", "new-token"},
+			authHeader:     "Bearer old-token",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Legacy auth_token is appended",
+			authToken:      "legacy-token",
+			authTokens:     []string{"new-token"},
+			authHeader:     "Bearer legacy-token",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Unknown token",
+			authTokens:     []string{"old-token", "new-token"},
+			authHeader:     "Bearer other-token",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Prefix of a valid token",
+			authTokens:     []string{"new-token"},
+			authHeader:     "Bearer new",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "No tokens configured rejects empty bearer",
+			authHeader:     "Bearer ",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "No tokens configured rejects any bearer",
+			authTokens:     []string{""},
+			authHeader:     "Bearer anything",
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, err := NewServer(config.ServerConfig{
+				Transport:    "http",
+				AuthRequired: true,
+				AuthToken:    tt.authToken,
+				AuthTokens:   tt.authTokens,
+			})
+			if err != nil {
+				t.Fatalf("Failed to create server: %v", err)
+			}
+
+			req := httptest.NewRequest("GET", "/info", nil)
+			req.Header.Set("Authorization", tt.authHeader)
+			w := httptest.NewRecorder()
+
+			server.HTTPHandler().ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+// TestHTTPTransportAuthentication tests authentication if enabled
+func TestHTTPTransportAuthentication(t *testing.T) {
+	cfg := config.ServerConfig{
+		Transport:    "http",
+		Host:         "localhost",
+		Port:         0,
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 30 * time.Second,
+		AuthRequired: true,
+		AuthToken:    "test-token-123",
+	}
+
+	server, err := NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	handler := server.HTTPHandler()
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	tests := []struct {
+		name           string
+		path           string
+		authHeader     string
+		expectedStatus int
+	}{
+		{
+			name:           "Valid token",
+			path:           "/info",
+			authHeader:     "Bearer test-token-123",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid token",
+			path:           "/info",
+			authHeader:     "Bearer wrong-token",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Missing token",
+			path:           "/info",
+			authHeader:     "",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Invalid format",
+			path:           "/info",
+			authHeader:     "test-token-123",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Health endpoint without auth",
+			path:           "/health",
+			authHeader:     "",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Metrics endpoint without auth",
+			path:           "/metrics",
+			authHeader:     "",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", ts.URL+tt.path, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+
+			client := &http.Client{Timeout: 5 * time.Second}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+// signTestJWT returns an RS256 token for claims signed with key
+func signTestJWT(t *testing.T, key *rsa.PrivateKey, claims map[string]interface{}) string {
+	t.Helper()
+
+	encode := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("Failed to encode token segment: %v", err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+
+	signingInput := encode(map[string]string{"alg": "RS256", "typ": "JWT"}) + "." + encode(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// TestHTTPTransportJWTAuth tests the jwt auth mode and that verified
+// claims reach tool handlers
+func TestHTTPTransportJWTAuth(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal public key: %v", err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "jwt.pem")
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("Failed to write public key: %v", err)
+	}
+
+	server, err := NewServer(config.ServerConfig{
+		Transport:        "http",
+		AuthRequired:     true,
+		AuthToken:        "static-token",
+		AuthMode:         "jwt",
+		JWTPublicKeyFile: keyFile,
+		JWTAudience:      "pcf-mcp",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	err = server.RegisterTool(Tool{
+		Name:        "whoami",
+		Description: "Returns the caller's subject",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			claims := auth.ClaimsFromContext(ctx)
+			if claims == nil {
+				return nil, fmt.Errorf("no claims on context")
+			}
+			return map[string]string{"subject": claims.Subject}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	err = server.RegisterTool(Tool{
+		Name:          "create_thing",
+		Description:   "Creates a thing",
+		RequiredScope: ScopeWrite,
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return map[string]bool{"ok": true}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	claims := func(aud string, exp time.Duration) map[string]interface{} {
+		return map[string]interface{}{
+			"sub": "alice",
+			"aud": aud,
+			"exp": time.Now().Add(exp).Unix(),
+		}
+	}
+
+	scoped := func(scope string) map[string]interface{} {
+		c := claims("pcf-mcp", time.Hour)
+		c["scope"] = scope
+		return c
+	}
+
+	tests := []struct {
+		name           string
+		token          string
+		path           string
+		expectedStatus int
+	}{
+		{name: "Valid token", token: signTestJWT(t, key, claims("pcf-mcp", time.Hour)), expectedStatus: http.StatusOK},
+		{name: "Expired token", token: signTestJWT(t, key, claims("pcf-mcp", -time.Hour)), expectedStatus: http.StatusUnauthorized},
+		{name: "Wrong audience", token: signTestJWT(t, key, claims("other-service", time.Hour)), expectedStatus: http.StatusUnauthorized},
+		{name: "Static token not accepted", token: "static-token", expectedStatus: http.StatusUnauthorized},
+		{name: "Scope claim limits tools", token: signTestJWT(t, key, scoped("read")), path: "/tools/create_thing", expectedStatus: http.StatusForbidden},
+		{name: "Scope claim grants tools", token: signTestJWT(t, key, scoped("read write")), path: "/tools/create_thing", expectedStatus: http.StatusOK},
+		{name: "Missing scope claim grants nothing", token: signTestJWT(t, key, claims("pcf-mcp", time.Hour)), path: "/tools/create_thing", expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := tt.path
+			if path == "" {
+				path = "/tools/whoami"
+			}
+			req := httptest.NewRequest(http.MethodPost, path, strings.NewReader("{}"))
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			w := httptest.NewRecorder()
+
+			server.HTTPHandler().ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+
+			if tt.expectedStatus == http.StatusOK && path == "/tools/whoami" && !strings.Contains(w.Body.String(), `"subject":"alice"`) {
+				t.Errorf("Expected subject in response, got %s", w.Body.String())
+			}
+		})
+	}
+}
+
+// TestHTTPTransportTokenScopes tests that a read-scoped token may list but
+// is forbidden from tools that require the write scope
+func TestHTTPTransportTokenScopes(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{
+		Transport:    "http",
+		AuthRequired: true,
+		AuthTokens:   []string{"read-token", "operator-token", "admin-token"},
+		TokenScopes: []config.TokenScope{
+			{Token: "read-token", Scopes: []string{ScopeRead}},
+			{Token: "operator-token", Scopes: []string{ScopeRead, ScopeWrite}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	for _, tool := range []Tool{
+		{Name: "list_things", Description: "Lists things", RequiredScope: ScopeRead},
+		{Name: "create_thing", Description: "Creates a thing", RequiredScope: ScopeWrite},
+	} {
+		tool.Handler = func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return map[string]bool{"ok": true}, nil
+		}
+		if err := server.RegisterTool(tool); err != nil {
+			t.Fatalf("Failed to register tool: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name           string
+		token          string
+		tool           string
+		expectedStatus int
+	}{
+		{name: "Read token lists", token: "read-token", tool: "list_things", expectedStatus: http.StatusOK},
+		{name: "Read token cannot create", token: "read-token", tool: "create_thing", expectedStatus: http.StatusForbidden},
+		{name: "Operator token creates", token: "operator-token", tool: "create_thing", expectedStatus: http.StatusOK},
+		{name: "Unscoped token is unrestricted", token: "admin-token", tool: "create_thing", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/tools/"+tt.tool, strings.NewReader("{}"))
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			w := httptest.NewRecorder()
+
+			server.HTTPHandler().ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus == http.StatusForbidden && !strings.Contains(w.Body.String(), "requires scope 'write'") {
+				t.Errorf("Expected scope error, got %s", w.Body.String())
+			}
+		})
+	}
+}
+
+// TestCallerScopesJWTDefault tests that JWTs without a scope claim get the
+// configured default scopes rather than being unrestricted
+func TestCallerScopesJWTDefault(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{Transport: "http"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	scopes, ok := server.callerScopes("jwt", &auth.Claims{Subject: "alice"})
+	if !ok || len(scopes) != 0 {
+		t.Errorf("Expected no scopes, got %v (restricted %v)", scopes, ok)
+	}
+
+	server.config.JWTDefaultScopes = []string{ScopeRead}
+	scopes, ok = server.callerScopes("jwt", &auth.Claims{Subject: "alice"})
+	if !ok || len(scopes) != 1 || scopes[0] != ScopeRead {
+		t.Errorf("Expected default read scope, got %v (restricted %v)", scopes, ok)
+	}
+
+	scopes, ok = server.callerScopes("jwt", &auth.Claims{Subject: "alice", Scopes: []string{ScopeWrite}})
+	if !ok || len(scopes) != 1 || scopes[0] != ScopeWrite {
+		t.Errorf("Expected the token's scope claim, got %v (restricted %v)", scopes, ok)
+	}
+}
+
+// TestHTTPTransportMetricsAuth tests that /metrics requires a token when
+// metrics authentication is enabled while /health stays public
+func TestHTTPTransportMetricsAuth(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{
+		Transport:    "http",
+		AuthRequired: true,
+		AuthToken:    "test-token-123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	server.SetMetricsRequireAuth(true)
+
+	handler := server.HTTPHandler()
+
+	tests := []struct {
+		name           string
+		path           string
+		authHeader     string
+		expectedStatus int
+	}{
+		{name: "Metrics without token", path: "/metrics", expectedStatus: http.StatusUnauthorized},
+		{name: "Metrics with invalid token", path: "/metrics", authHeader: "Bearer wrong-token", expectedStatus: http.StatusUnauthorized},
+		{name: "Metrics with token", path: "/metrics", authHeader: "Bearer test-token-123", expectedStatus: http.StatusOK},
+		{name: "Health without token", path: "/health", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+// TestHTTPStats tests the /stats latency summary endpoint
+func TestHTTPStats(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{Transport: "http"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	metrics, err := observability.InitMetrics(config.MetricsConfig{Enabled: true, EnableStatsEndpoint: true})
+	if err != nil {
+		t.Fatalf("Failed to initialize metrics: %v", err)
+	}
+	server.SetMetrics(metrics)
+
+	err = server.RegisterTool(Tool{
+		Name:        "stats_test",
+		Description: "Tool for testing stats",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return map[string]interface{}{"status": "ok"}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	// Disabled by default
+	req := httptest.NewRequest("GET", "/stats", nil)
+	w := httptest.NewRecorder()
+	server.HTTPHandler().ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 with stats disabled, got %d", w.Code)
+	}
+
+	server.SetStatsEndpoint(true)
+	handler := server.HTTPHandler()
+
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest("POST", "/tools/stats_test", strings.NewReader("{}"))
+		req.Header.Set("Content-Type", "application/json")
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	// Unknown tool names, as batch and stdio callers can send, are not tracked
+	if _, err := server.ExecuteToolWithMetrics(context.Background(), "no_such_tool", nil); err == nil {
+		t.Fatal("Expected an error calling an unknown tool")
+	}
+
+	req = httptest.NewRequest("GET", "/stats", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var response struct {
+		Tools map[string]observability.LatencySummary `json:"tools"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if stats, ok := response.Tools["stats_test"]; !ok || stats.Count != 3 {
+		t.Errorf("Expected 3 samples for stats_test, got %+v", response.Tools)
+	}
+	if _, ok := response.Tools["no_such_tool"]; ok {
+		t.Error("Expected no stats for an unknown tool")
+	}
+}
+
+// TestHTTPTransportMetrics tests that metrics are properly recorded
+func TestHTTPTransportMetrics(t *testing.T) {
+	cfg := config.ServerConfig{
+		Transport:    "http",
+		Host:         "localhost",
+		Port:         0,
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 30 * time.Second,
+	}
+
+	server, err := NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	// Register a test tool
+	testTool := Tool{
+		Name:        "metrics_test",
+		Description: "Tool for testing metrics",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return map[string]interface{}{"status": "ok"}, nil
+		},
+	}
+
+	err = server.RegisterTool(testTool)
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	handler := server.HTTPHandler()
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	// Make several requests
+	client := &http.Client{Timeout: 5 * time.Second}
+	for i := 0; i < 5; i++ {
+		req, err := http.NewRequest("POST", ts.URL+"/tools/metrics_test", bytes.NewReader([]byte("{}")))
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	// Check metrics endpoint
+	req, err := http.NewRequest("GET", ts.URL+"/metrics", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	// Verify that metrics are present
+	var body bytes.Buffer
+	_, err = body.ReadFrom(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read response body: %v", err)
+	}
+
+	bodyStr := body.String()
+	expectedMetrics := []string{
+		"http_requests_total",
+		"http_request_duration_seconds",
+	}
+
+	for _, metric := range expectedMetrics {
+		if !bytes.Contains([]byte(bodyStr), []byte(metric)) {
+			t.Errorf("Expected metric '%s' not found in response", metric)
+		}
+	}
+}
+
+// TestHTTPHandlerSharesMetrics tests that handlers from repeated HTTPHandler
+// calls share one set of HTTP metrics
+func TestHTTPHandlerSharesMetrics(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{Transport: "http"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	first := server.HTTPHandler()
+	second := server.HTTPHandler()
+
+	for _, handler := range []http.Handler{first, second} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200 from /health, got %d", w.Code)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	first.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 from /metrics, got %d", w.Code)
+	}
+
+	expected := `http_requests_total{method="GET",path="/health",status="200"} 2`
+	if !strings.Contains(w.Body.String(), expected) {
+		t.Errorf("Expected both handlers to record into %q, got:\n%s", expected, w.Body.String())
+	}
+}
+
+// TestHTTPMetricsMergesApplicationMetrics tests that /metrics serves the
+// application metrics set with SetMetrics alongside the HTTP metrics
+func TestHTTPMetricsMergesApplicationMetrics(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{Transport: "http"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	err = server.RegisterTool(Tool{
+		Name:        "list_projects",
+		Description: "List projects",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return []string{}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	// Metrics set after the handler is built are still served
+	handler := server.HTTPHandler()
+	metrics, err := observability.InitMetrics(config.MetricsConfig{Enabled: true})
+	if err != nil {
+		t.Fatalf("Failed to init metrics: %v", err)
+	}
+	server.SetMetrics(metrics)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/tools/list_projects", strings.NewReader("{}")))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 from the tool, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 from /metrics, got %d", w.Code)
+	}
+
+	for _, expected := range []string{
+		`http_requests_total{method="POST",path="/tools/list_projects",status="200"} 1`,
+		`pcf_mcp_tool_executions_total{status="success",tool="list_projects"} 1`,
+	} {
+		if !strings.Contains(w.Body.String(), expected) {
+			t.Errorf("Expected %q on /metrics, got:\n%s", expected, w.Body.String())
+		}
+	}
+}
+
+// TestRegisterCollector tests that registering a duplicate collector returns
+// the one already registered instead of panicking
+func TestRegisterCollector(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	newCounter := func() prometheus.Counter {
+		return prometheus.NewCounter(prometheus.CounterOpts{Name: "test_total", Help: "Test counter"})
+	}
+
+	original := registerCollector(registry, newCounter())
+	duplicate := registerCollector(registry, newCounter())
+	if duplicate != original {
+		t.Error("Expected the already registered collector to be returned")
+	}
+
+	// A conflicting collector is returned unregistered, still usable
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_total", Help: "Test gauge"})
+	if conflicting := registerCollector(registry, gauge); conflicting != gauge {
+		t.Error("Expected a conflicting collector to be returned as is")
+	}
+	gauge.Inc()
+}
+
+// TestNewHTTPServerTimeouts tests that the HTTP server gets the configured
+// timeouts, with defaults for unset connection timeouts
+func TestNewHTTPServerTimeouts(t *testing.T) {
+	tests := []struct {
+		name                  string
+		config                config.ServerConfig
+		wantIdleTimeout       time.Duration
+		wantReadHeaderTimeout time.Duration
+	}{
+		{
+			name: "configured",
+			config: config.ServerConfig{
+				Transport:         "http",
+				ReadTimeout:       15 * time.Second,
+				WriteTimeout:      45 * time.Second,
+				IdleTimeout:       90 * time.Second,
+				ReadHeaderTimeout: 5 * time.Second,
+			},
+			wantIdleTimeout:       90 * time.Second,
+			wantReadHeaderTimeout: 5 * time.Second,
+		},
+		{
+			name:                  "defaults",
+			config:                config.ServerConfig{Transport: "http", ReadTimeout: 15 * time.Second, WriteTimeout: 45 * time.Second},
+			wantIdleTimeout:       defaultIdleTimeout,
+			wantReadHeaderTimeout: defaultReadHeaderTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, err := NewServer(tt.config)
+			if err != nil {
+				t.Fatalf("Failed to create server: %v", err)
+			}
+
+			httpServer := server.newHTTPServer("127.0.0.1:0")
+
+			if httpServer.ReadTimeout != 15*time.Second || httpServer.WriteTimeout != 45*time.Second {
+				t.Errorf("Expected read/write timeouts 15s/45s, got %s/%s", httpServer.ReadTimeout, httpServer.WriteTimeout)
+			}
+			if httpServer.IdleTimeout != tt.wantIdleTimeout {
+				t.Errorf("Expected idle timeout %s, got %s", tt.wantIdleTimeout, httpServer.IdleTimeout)
+			}
+			if httpServer.ReadHeaderTimeout != tt.wantReadHeaderTimeout {
+				t.Errorf("Expected read header timeout %s, got %s", tt.wantReadHeaderTimeout, httpServer.ReadHeaderTimeout)
+			}
+		})
+	}
+}
+
+// TestHTTPTransportReadiness tests the /ready endpoint with a readiness checker
+func TestHTTPTransportReadiness(t *testing.T) {
+	cfg := config.ServerConfig{
+		Transport:    "http",
+		AuthRequired: true,
+		AuthToken:    "secret",
+	}
+
+	server, err := NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	var checkErr error
+	server.SetReadinessChecker(func(ctx context.Context) error {
+		if _, ok := ctx.Deadline(); !ok {
+			t.Error("Readiness check should run with a deadline")
+		}
+		return checkErr
+	})
+
+	ts := httptest.NewServer(server.HTTPHandler())
+	defer ts.Close()
+
+	// PCF reachable (no auth needed for probes)
+	resp, err := http.Get(ts.URL + "/ready")
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	// PCF unreachable
+	checkErr = errors.New("connection refused")
+	resp, err = http.Get(ts.URL + "/ready")
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", resp.StatusCode)
+	}
+
+	var body map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if body["status"] != "not_ready" {
+		t.Errorf("Expected status 'not_ready', got %v", body["status"])
+	}
+
+	checks, _ := body["checks"].(map[string]interface{})
+	pcfCheck, _ := checks["pcf"].(map[string]interface{})
+	if pcfCheck["error"] != "unavailable" {
+		t.Errorf("Expected pcf error 'unavailable', got %v", pcfCheck["error"])
+	}
+
+	// Liveness is unaffected by PCF availability
+	resp, err = http.Get(ts.URL + "/health")
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected /health status 200, got %d", resp.StatusCode)
+	}
+}
+
+// TestHTTPLogLevelHeader tests that an authenticated X-Log-Level header raises
+// the log level for that request only
+func TestHTTPLogLevelHeader(t *testing.T) {
+	var logs bytes.Buffer
+	logger, err := observability.NewLoggerWithWriter(config.LoggingConfig{Level: "info", Format: "json"}, &logs)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(logger)
+
+	server, err := NewServer(config.ServerConfig{
+		Transport:    "http",
+		AuthRequired: true,
+		AuthToken:    "secret",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	err = server.RegisterTool(Tool{
+		Name:        "list_projects",
+		Description: "List projects",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			observability.FromContext(ctx).DebugContext(ctx, "Listing projects")
+			return []string{}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	handler := server.HTTPHandler()
+
+	send := func(requestID, token, level string) int {
+		req := httptest.NewRequest("POST", "/tools/list_projects", bytes.NewBufferString("{}"))
+		req.Header.Set("X-Request-ID", requestID)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		if level != "" {
+			req.Header.Set("X-Log-Level", level)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := send("req-debug", "secret", "debug"); code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", code)
+	}
+	if code := send("req-info", "secret", ""); code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", code)
+	}
+	if code := send("req-anon", "", "debug"); code != http.StatusUnauthorized {
+		t.Fatalf("Expected status 401, got %d", code)
+	}
+
+	debugLines := make(map[string][]string)
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			continue
+		}
+		if entry["level"] == "DEBUG" {
+			requestID, _ := entry["request_id"].(string)
+			debugLines[requestID] = append(debugLines[requestID], entry["msg"].(string))
+		}
+	}
+
+	for _, msg := range []string{"Executing tool", "Listing projects", "Tool completed"} {
+		if !slices.Contains(debugLines["req-debug"], msg) {
+			t.Errorf("Expected debug line %q for the debug request, got %v", msg, debugLines["req-debug"])
+		}
+	}
+	if lines := debugLines["req-info"]; len(lines) != 0 {
+		t.Errorf("Expected no debug lines for the info request, got %v", lines)
+	}
+	if lines := debugLines["req-anon"]; len(lines) != 0 {
+		t.Errorf("Expected unauthenticated requests to ignore X-Log-Level, got %v", lines)
+	}
+}
+
+// TestHTTPTransportRequestID tests that the request ID ties together the
+// inbound request log and the outbound PCF request
+func TestHTTPTransportRequestID(t *testing.T) {
+	var logs bytes.Buffer
+	logger, err := observability.NewLoggerWithWriter(config.LoggingConfig{Level: "info", Format: "json"}, &logs)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(logger)
+
+	outbound := make(chan string, 1)
+	pcfServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		outbound <- r.Header.Get("X-Request-ID")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("[]"))
+	}))
+	defer pcfServer.Close()
+
+	client, err := pcf.NewClient(config.PCFConfig{URL: pcfServer.URL, Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("Failed to create PCF client: %v", err)
+	}
+
+	server, err := NewServer(config.ServerConfig{Transport: "http"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	err = server.RegisterTool(Tool{
+		Name:        "list_projects",
+		Description: "List projects",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return client.ListProjects(ctx)
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	handler := server.HTTPHandler()
+
+	tests := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{name: "Client-supplied ID", header: "req-abc.123", expected: "req-abc.123"},
+		{name: "Generated ID"},
+		{name: "Malformed ID replaced", header: "bad id\r\ninjected"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs.Reset()
+
+			req := httptest.NewRequest("POST", "/tools/list_projects", bytes.NewBufferString("{}"))
+			if tt.header != "" {
+				req.Header.Set("X-Request-ID", tt.header)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+
+			requestID := w.Header().Get("X-Request-ID")
+			if tt.expected != "" && requestID != tt.expected {
+				t.Errorf("Expected response request ID '%s', got '%s'", tt.expected, requestID)
+			}
+			if tt.expected == "" && (requestID == "" || requestID == tt.header) {
+				t.Fatalf("Expected a generated request ID, got '%s'", requestID)
+			}
+
+			if sent := <-outbound; sent != requestID {
+				t.Errorf("Expected PCF request to carry request ID '%s', got '%s'", requestID, sent)
+			}
+
+			found := false
+			for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+				var entry map[string]interface{}
+				if err := json.Unmarshal([]byte(line), &entry); err != nil {
+					continue
+				}
+				if entry["msg"] == "HTTP request" {
+					found = true
+					if entry["request_id"] != requestID {
+						t.Errorf("Expected request log to have request_id '%s', got %v", requestID, entry["request_id"])
+					}
+				}
+			}
+			if !found {
+				t.Errorf("Expected an HTTP request log line, got: %s", logs.String())
+			}
+		})
+	}
+}
+
+// TestHTTPHandlerTimeout tests that slow handlers are cut off with a 503
+// timeout error, that tool endpoints are bounded by the larger of the
+// handler and tool timeouts, and that batches are not cut off as a whole
+func TestHTTPHandlerTimeout(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{
+		Transport:      "http",
+		HandlerTimeout: 20 * time.Millisecond,
+		ToolTimeout:    500 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	// A readiness check that ignores its context stands in for a handler
+	// stuck in slow work
+	release := make(chan struct{})
+	defer close(release)
+	server.SetReadinessChecker(func(ctx context.Context) error {
+		select {
+		case <-release:
+		case <-time.After(time.Second):
+		}
+		return nil
+	})
+
+	err = server.RegisterTool(Tool{
+		Name:        "slow_tool",
+		Description: "Outlives the handler timeout but not the tool timeout",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			time.Sleep(50 * time.Millisecond)
+			return "done", nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	handler := server.HTTPHandler()
+
+	t.Run("Slow handler", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/ready", nil)
+		w := httptest.NewRecorder()
+
+		start := time.Now()
+		handler.ServeHTTP(w, req)
+
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("Expected the handler timeout to cut the request off, took %s", elapsed)
+		}
+		if w.Code != http.StatusServiceUnavailable {
+			t.Errorf("Expected status 503, got %d: %s", w.Code, w.Body.String())
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Expected JSON content type, got %q", ct)
+		}
+		if detail := decodeErrorResponse(t, w); detail.Code != CodeTimeout {
+			t.Errorf("Expected code %q, got %q", CodeTimeout, detail.Code)
+		}
+	})
+
+	t.Run("Tool gets the tool timeout", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/tools/slow_tool", strings.NewReader("{}"))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("Batch outlives a single tool timeout", func(t *testing.T) {
+		// Each call fits the tool timeout, but together they exceed it
+		items := make([]string, 12)
+		for i := range items {
+			items[i] = `{"tool":"slow_tool"}`
+		}
+		req := httptest.NewRequest(http.MethodPost, batchPath, strings.NewReader("["+strings.Join(items, ",")+"]"))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+
+		var response struct {
+			SuccessCount int `json:"success_count"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if response.SuccessCount != len(items) {
+			t.Errorf("Expected %d successful calls, got %d", len(items), response.SuccessCount)
+		}
+	})
+
+	t.Run("Fast handler", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+	})
+}
+
+// TestHTTPToolTimeoutWithinHandlerTimeout tests that a tool overrunning a
+// tool timeout at least as long as the handler timeout gets the tool
+// timeout's 504 rather than the handler timeout's 503
+func TestHTTPToolTimeoutWithinHandlerTimeout(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{
+		Transport:      "http",
+		HandlerTimeout: 50 * time.Millisecond,
+		ToolTimeout:    50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	// The tool ignores its context, as a handler stuck in slow work would
+	release := make(chan struct{})
+	defer close(release)
+	err = server.RegisterTool(Tool{
+		Name:        "stuck_tool",
+		Description: "Overruns the tool timeout",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			<-release
+			return "done", nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/tools/stuck_tool", strings.NewReader("{}"))
+	w := httptest.NewRecorder()
+	server.HTTPHandler().ServeHTTP(w, req)
+
+	if w.Code != http.StatusGatewayTimeout {
+		t.Fatalf("Expected status 504, got %d: %s", w.Code, w.Body.String())
+	}
+	if detail := decodeErrorResponse(t, w); detail.Code != CodeTimeout || !strings.Contains(detail.Message, ErrToolTimeout.Error()) {
+		t.Errorf("Expected the tool timeout error, got %+v", detail)
+	}
+}
+
+// TestHTTPToolCancellation tests that a tool sees the request context
+// cancelled when the client goes away and the handler answers promptly
+func TestHTTPToolCancellation(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{Transport: "http"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	err = server.RegisterTool(Tool{
+		Name:        "slow_tool",
+		Description: "Runs until its context is cancelled",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			close(started)
+			select {
+			case <-ctx.Done():
+				close(cancelled)
+				return nil, ctx.Err()
+			case <-time.After(5 * time.Second):
+				return "done", nil
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodPost, "/tools/slow_tool", strings.NewReader("{}")).WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	start := time.Now()
+	server.HTTPHandler().ServeHTTP(w, req)
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the handler to return once the client went away, took %s", elapsed)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("Expected the tool to observe the cancellation")
+	}
+	if w.Code != statusClientClosedRequest {
+		t.Errorf("Expected status %d, got %d: %s", statusClientClosedRequest, w.Code, w.Body.String())
+	}
+	if detail := decodeErrorResponse(t, w); detail.Code != CodeCanceled {
+		t.Errorf("Expected code %q, got %q", CodeCanceled, detail.Code)
+	}
+}
+
+// TestHTTPToolDebugBlockExecutedParams tests that the _debug block shows the
+// params the handler ran with, including defaults and middleware rewrites
+func TestHTTPToolDebugBlockExecutedParams(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{
+		Transport:    "http",
+		AuthRequired: true,
+		AuthToken:    "secret",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	var handled map[string]interface{}
+	err = server.RegisterTool(Tool{
+		Name:          "list_hosts",
+		Description:   "List hosts",
+		ParamDefaults: map[string]interface{}{"project_id": "proj-default"},
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			handled = params
+			return "ok", nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+	server.Use(func(next ToolHandler) ToolHandler {
+		return func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			params = maps.Clone(params)
+			params["os"] = strings.ToLower(params["os"].(string))
+			return next(ctx, params)
+		}
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/tools/list_hosts?debug=1", strings.NewReader(`{"os":"Linux"}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	w := httptest.NewRecorder()
+	server.HTTPHandler().ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Debug struct {
+			Params map[string]interface{} `json:"params"`
+		} `json:"_debug"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	expected := map[string]interface{}{"project_id": "proj-default", "os": "linux"}
+	if !reflect.DeepEqual(resp.Debug.Params, expected) {
+		t.Errorf("Expected the executed params %v, got %v", expected, resp.Debug.Params)
+	}
+	if !reflect.DeepEqual(handled, expected) {
+		t.Errorf("Expected the handler to run with %v, got %v", expected, handled)
+	}
+}
+
+// TestRequestAuthenticatedFromAuthMiddleware tests that requests count as
+// authenticated only once authMiddleware has verified their token, which is
+// not verified again
+func TestRequestAuthenticatedFromAuthMiddleware(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{
+		Transport:    "http",
+		AuthRequired: true,
+		AuthToken:    "secret",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/tools/list_projects", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	if requestAuthenticated(req) {
+		t.Error("Expected a token authMiddleware has not verified to be ignored")
+	}
+
+	var authenticated bool
+	handler := server.authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authenticated = requestAuthenticated(r)
+	}), nil)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if !authenticated {
+		t.Error("Expected the request to be authenticated after authMiddleware")
+	}
+
+	// Without authentication enabled no request is authenticated
+	server.config.AuthRequired = false
+	authenticated = true
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if authenticated {
+		t.Error("Expected no authentication with auth disabled")
+	}
+}
+
+// TestHTTPToolDebugBlock tests that authenticated requests can opt in to the
+// _debug block and that it is absent otherwise
+func TestHTTPToolDebugBlock(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{
+		Transport:    "http",
+		AuthRequired: true,
+		AuthToken:    "secret",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	err = server.RegisterTool(Tool{
+		Name:        "generate_report",
+		Description: "Generate a report",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return "ok", nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	handler := server.HTTPHandler()
+
+	tests := []struct {
+		name      string
+		path      string
+		header    string
+		wantDebug bool
+	}{
+		{name: "Default", path: "/tools/generate_report"},
+		{name: "Query parameter", path: "/tools/generate_report?debug=1", wantDebug: true},
+		{name: "Header", path: "/tools/generate_report", header: "true", wantDebug: true},
+		{name: "Disabled", path: "/tools/generate_report?debug=0", header: "false"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := `{"project_id":"proj-1","sections":["summary"]}`
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(body))
+			req.Header.Set("Authorization", "Bearer secret")
+			if tt.header != "" {
+				req.Header.Set("X-Debug", tt.header)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+
+			var resp struct {
+				Result interface{} `json:"result"`
+				Debug  *struct {
+					Tool       string                 `json:"tool"`
+					Params     map[string]interface{} `json:"params"`
+					DurationMS *int64                 `json:"duration_ms"`
+				} `json:"_debug"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+
+			if !tt.wantDebug {
+				if resp.Debug != nil {
+					t.Errorf("Expected no _debug block, got %+v", resp.Debug)
+				}
+				return
+			}
+
+			if resp.Debug == nil {
+				t.Fatal("Expected a _debug block")
+			}
+			if resp.Debug.Tool != "generate_report" {
+				t.Errorf("Expected tool 'generate_report', got %q", resp.Debug.Tool)
+			}
+			if sections, ok := resp.Debug.Params["sections"].([]interface{}); !ok || len(sections) != 1 {
+				t.Errorf("Expected the decoded sections param, got %v", resp.Debug.Params["sections"])
+			}
+			if resp.Debug.DurationMS == nil {
+				t.Error("Expected duration_ms in the _debug block")
+			}
+		})
+	}
+
+	// The block is never shown to unauthenticated callers
+	server.config.AuthRequired = false
+	req := httptest.NewRequest(http.MethodPost, "/tools/generate_report?debug=1", strings.NewReader("{}"))
+	w := httptest.NewRecorder()
+	server.HTTPHandler().ServeHTTP(w, req)
+	if strings.Contains(w.Body.String(), "_debug") {
+		t.Errorf("Expected no _debug block without authentication, got %s", w.Body.String())
+	}
+}
+
+// TestHTTPTracingExtractsTraceparent tests that a request's traceparent
+// makes the server span, and the tool spans below it, part of the caller's trace
+func TestHTTPTracingExtractsTraceparent(t *testing.T) {
+	provider := sdktrace.NewTracerProvider()
+	defer provider.Shutdown(context.Background())
+
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	}()
+
+	server, err := NewServer(config.ServerConfig{Transport: "http"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	var toolSpan trace.SpanContext
+	err = server.RegisterTool(Tool{
+		Name:        "traced",
+		Description: "Records its span",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			toolSpan = trace.SpanContextFromContext(ctx)
+			return "ok", nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(http.MethodPost, "/tools/traced", strings.NewReader("{}"))
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	w := httptest.NewRecorder()
+
+	server.HTTPHandler().ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := toolSpan.TraceID().String(); got != traceID {
+		t.Errorf("Expected the tool span in trace %s, got %s", traceID, got)
+	}
+}
+
+// TestHTTPRequestLogFields tests that the request log line carries numeric
+// latency and response size fields for log-based dashboards
+func TestHTTPRequestLogFields(t *testing.T) {
+	var logs bytes.Buffer
+	logger, err := observability.NewLoggerWithWriter(config.LoggingConfig{Level: "info", Format: "json"}, &logs)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(logger)
+
+	server, err := NewServer(config.ServerConfig{Transport: "http"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.Header.Set("User-Agent", "dashboard-probe/1.0")
+	req.Header.Set(observability.HeaderRequestID, "req-123")
+	w := httptest.NewRecorder()
+	server.HTTPHandler().ServeHTTP(w, req)
+
+	var entry map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		if err := json.Unmarshal([]byte(line), &entry); err == nil && entry["msg"] == "HTTP request" {
+			break
+		}
+		entry = nil
+	}
+	if entry == nil {
+		t.Fatalf("No HTTP request log line in:\n%s", logs.String())
+	}
+
+	if durationMS, ok := entry["duration_ms"].(float64); !ok || durationMS < 0 {
+		t.Errorf("Expected a numeric duration_ms, got %#v", entry["duration_ms"])
+	}
+	if _, ok := entry["duration"].(string); !ok {
+		t.Errorf("Expected a human-readable duration, got %#v", entry["duration"])
+	}
+	if written, ok := entry["bytes_written"].(float64); !ok || int(written) != w.Body.Len() {
+		t.Errorf("Expected bytes_written %d, got %#v", w.Body.Len(), entry["bytes_written"])
+	}
+	if entry["user_agent"] != "dashboard-probe/1.0" || entry["request_id"] != "req-123" {
+		t.Errorf("Expected the user agent and request ID, got %v", entry)
 	}
 }
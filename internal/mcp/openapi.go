@@ -0,0 +1,187 @@
+package mcp
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+)
+
+// openAPIPath is where the generated OpenAPI document is served
+const openAPIPath = "/openapi.json"
+
+// openAPIVersion is the OpenAPI release the document follows; 3.1 schemas
+// are JSON Schema, so tool input schemas are used unchanged
+const openAPIVersion = "3.1.0"
+
+// OpenAPISpec returns an OpenAPI document describing the HTTP API: the
+// fixed endpoints and one POST /tools/{name} operation per registered tool,
+// with the tool's input schema as its request body.
+func (s *Server) OpenAPISpec() map[string]interface{} {
+	listTools := openAPIOperation("listTools", "List tools", "Lists the registered tools with their input schemas", objectResponse(), false)
+	listTools["parameters"] = []interface{}{map[string]interface{}{
+		"name":        "tag",
+		"in":          "query",
+		"description": "Only list tools with this tag",
+		"schema":      map[string]interface{}{"type": "string"},
+	}, map[string]interface{}{
+		"name":        "limit",
+		"in":          "query",
+		"description": "Return at most this many tools",
+		"schema":      map[string]interface{}{"type": "integer", "minimum": 1},
+	}, map[string]interface{}{
+		"name":        "offset",
+		"in":          "query",
+		"description": "Skip this many tools, sorted by name",
+		"schema":      map[string]interface{}{"type": "integer", "minimum": 0},
+	}}
+
+	paths := map[string]interface{}{
+		"/health": map[string]interface{}{
+			"get": openAPIOperation("health", "Liveness check", "Reports that the server is running and the result of each registered health check; responds 503 when a required check fails", objectResponse(), true),
+		},
+		"/ready": map[string]interface{}{
+			"get": openAPIOperation("ready", "Readiness check", "Reports whether PCF is reachable; responds 503 when it is not", objectResponse(), true),
+		},
+		"/info": map[string]interface{}{
+			"get": openAPIOperation("info", "Server info", "Returns the server name, version, capabilities, and build metadata", objectResponse(), false),
+		},
+		"/version": map[string]interface{}{
+			"get": openAPIOperation("version", "Build metadata", "Returns the version, git commit, build date, and Go version", objectResponse(), false),
+		},
+		"/tools": map[string]interface{}{
+			"get": listTools,
+		},
+		openAPIPath: map[string]interface{}{
+			"get": openAPIOperation("openapi", "OpenAPI document", "Returns this document", objectResponse(), false),
+		},
+	}
+
+	tools := s.ListTools()
+	sort.Slice(tools, func(i, j int) bool { return tools[i].Name < tools[j].Name })
+
+	var categories []string
+	for _, tool := range tools {
+		schema := tool.InputSchema
+		if schema == nil {
+			schema = map[string]interface{}{"type": "object"}
+		}
+
+		operation := openAPIOperation(tool.Name, tool.Name, tool.Description, map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"result": map[string]interface{}{"description": "The tool's output"},
+			},
+			"required": []string{"result"},
+		}, false)
+		operation["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				contentTypeJSON: map[string]interface{}{"schema": schema},
+			},
+		}
+		if tool.Category != "" {
+			operation["tags"] = []string{tool.Category}
+			if !slices.Contains(categories, tool.Category) {
+				categories = append(categories, tool.Category)
+			}
+		}
+
+		paths["/tools/"+tool.Name] = map[string]interface{}{"post": operation}
+	}
+
+	spec := map[string]interface{}{
+		"openapi": openAPIVersion,
+		"info": map[string]interface{}{
+			"title":       s.Name(),
+			"version":     s.Version(),
+			"description": "HTTP API of the PCF MCP server",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": map[string]interface{}{
+				"ErrorResponse": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"error": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"code":    map[string]interface{}{"type": "string"},
+								"message": map[string]interface{}{"type": "string"},
+								"details": map[string]interface{}{"type": "object"},
+							},
+							"required": []string{"code", "message"},
+						},
+					},
+					"required": []string{"error"},
+				},
+			},
+		},
+	}
+
+	if len(categories) > 0 {
+		sort.Strings(categories)
+		tags := make([]interface{}, 0, len(categories))
+		for _, category := range categories {
+			tags = append(tags, map[string]interface{}{"name": category})
+		}
+		spec["tags"] = tags
+	}
+
+	if s.config.AuthRequired {
+		components := spec["components"].(map[string]interface{})
+		components["securitySchemes"] = map[string]interface{}{
+			"bearerAuth": map[string]interface{}{"type": "http", "scheme": "bearer"},
+		}
+		spec["security"] = []interface{}{map[string]interface{}{"bearerAuth": []string{}}}
+	}
+
+	return spec
+}
+
+// openAPIOperation builds an operation whose 200 response has the given
+// schema and whose errors use the error envelope. Public operations, such
+// as the health probes, are exempt from authentication.
+func openAPIOperation(id, summary, description string, responseSchema map[string]interface{}, public bool) map[string]interface{} {
+	operation := map[string]interface{}{
+		"operationId": id,
+		"summary":     summary,
+		"description": description,
+		"responses": map[string]interface{}{
+			"200": map[string]interface{}{
+				"description": "Success",
+				"content": map[string]interface{}{
+					contentTypeJSON: map[string]interface{}{"schema": responseSchema},
+				},
+			},
+			"default": map[string]interface{}{
+				"description": "Error",
+				"content": map[string]interface{}{
+					contentTypeJSON: map[string]interface{}{
+						"schema": map[string]interface{}{"$ref": "#/components/schemas/ErrorResponse"},
+					},
+				},
+			},
+		},
+	}
+
+	if public {
+		operation["security"] = []interface{}{}
+	}
+
+	return operation
+}
+
+// objectResponse is the schema of a response described only as a JSON object
+func objectResponse() map[string]interface{} {
+	return map[string]interface{}{"type": "object"}
+}
+
+// handleOpenAPI serves the OpenAPI document for the registered tools
+func (s *Server) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeMethodNotAllowed(w)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, s.OpenAPISpec())
+}
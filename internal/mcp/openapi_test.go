@@ -0,0 +1,133 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+)
+
+// openAPIDocument is the subset of an OpenAPI 3 document the tests inspect
+type openAPIDocument struct {
+	OpenAPI string `json:"openapi"`
+	Info    struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	} `json:"info"`
+	Paths map[string]map[string]struct {
+		OperationID string   `json:"operationId"`
+		Tags        []string `json:"tags"`
+		RequestBody *struct {
+			Content map[string]struct {
+				Schema map[string]interface{} `json:"schema"`
+			} `json:"content"`
+		} `json:"requestBody"`
+		Responses map[string]interface{} `json:"responses"`
+		Security  []map[string][]string  `json:"security"`
+	} `json:"paths"`
+	Components struct {
+		SecuritySchemes map[string]interface{} `json:"securitySchemes"`
+	} `json:"components"`
+	Security []map[string][]string `json:"security"`
+}
+
+// TestHTTPOpenAPI tests that /openapi.json describes the fixed endpoints and
+// every registered tool
+func TestHTTPOpenAPI(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{
+		Transport:    "http",
+		AuthRequired: true,
+		AuthToken:    "test-token",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	handler := func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	tools := []Tool{
+		{
+			Name:        "list_hosts",
+			Description: "List hosts",
+			Category:    "hosts",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{"project_id": map[string]interface{}{"type": "string"}},
+				"required":   []string{"project_id"},
+			},
+			Handler: handler,
+		},
+		{Name: "ping", Description: "Takes no parameters", Handler: handler},
+	}
+	for _, tool := range tools {
+		if err := server.RegisterTool(tool); err != nil {
+			t.Fatalf("Failed to register tool: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	req.Header.Set("Authorization", "Bearer test-token")
+	w := httptest.NewRecorder()
+	server.HTTPHandler().ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var doc openAPIDocument
+	if err := json.NewDecoder(w.Body).Decode(&doc); err != nil {
+		t.Fatalf("Failed to decode OpenAPI document: %v", err)
+	}
+
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		t.Errorf("Expected an OpenAPI 3 document, got version %q", doc.OpenAPI)
+	}
+	if doc.Info.Title != "pcf-mcp" || doc.Info.Version != Version {
+		t.Errorf("Unexpected info: %+v", doc.Info)
+	}
+
+	for _, path := range []string{"/health", "/ready", "/info", "/version", "/tools", "/openapi.json"} {
+		if _, ok := doc.Paths[path]["get"]; !ok {
+			t.Errorf("Expected a GET operation for %s", path)
+		}
+	}
+
+	for _, tool := range tools {
+		operation, ok := doc.Paths["/tools/"+tool.Name]["post"]
+		if !ok {
+			t.Errorf("Expected a POST operation for tool %s", tool.Name)
+			continue
+		}
+		if operation.OperationID != tool.Name {
+			t.Errorf("Expected operation ID %q, got %q", tool.Name, operation.OperationID)
+		}
+		if operation.RequestBody == nil || operation.RequestBody.Content[contentTypeJSON].Schema["type"] != "object" {
+			t.Errorf("Expected an object request body for tool %s", tool.Name)
+		}
+		if _, ok := operation.Responses["200"]; !ok {
+			t.Errorf("Expected a 200 response for tool %s", tool.Name)
+		}
+	}
+
+	listHosts := doc.Paths["/tools/list_hosts"]["post"]
+	required, _ := listHosts.RequestBody.Content[contentTypeJSON].Schema["required"].([]interface{})
+	if len(required) != 1 || required[0] != "project_id" {
+		t.Errorf("Expected the tool's input schema as the request body, got required %v", required)
+	}
+	if len(listHosts.Tags) != 1 || listHosts.Tags[0] != "hosts" {
+		t.Errorf("Expected the tool's category as its tag, got %v", listHosts.Tags)
+	}
+
+	// Bearer authentication applies everywhere except the health probes
+	if _, ok := doc.Components.SecuritySchemes["bearerAuth"]; !ok || len(doc.Security) != 1 {
+		t.Error("Expected a global bearer security requirement")
+	}
+	if health := doc.Paths["/health"]["get"]; health.Security == nil || len(health.Security) != 0 {
+		t.Errorf("Expected /health to opt out of authentication, got %v", health.Security)
+	}
+}
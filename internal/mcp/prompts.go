@@ -0,0 +1,177 @@
+package mcp
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// ErrPromptNotFound is returned when rendering a prompt that is not registered
+var ErrPromptNotFound = errors.New("prompt not found")
+
+// Prompt is a reusable message template that MCP clients can list and render
+type Prompt struct {
+	// Name is the unique identifier for the prompt
+	Name string
+
+	// Description explains what the prompt is for
+	Description string
+
+	// Arguments lists the values the template accepts
+	Arguments []PromptArgument
+
+	// Template is a text/template rendered with the arguments keyed by
+	// name, e.g. "Summarize project {{.project_id}}"
+	Template string
+}
+
+// PromptArgument describes a value substituted into a prompt template
+type PromptArgument struct {
+	// Name is the key used in the template
+	Name string
+
+	// Description explains the argument
+	Description string
+
+	// Required arguments must be provided when rendering
+	Required bool
+}
+
+// registeredPrompt is a prompt with its parsed template
+type registeredPrompt struct {
+	prompt   Prompt
+	template *template.Template
+}
+
+// RegisterPrompt registers a prompt template with the server
+func (s *Server) RegisterPrompt(prompt Prompt) error {
+	if prompt.Name == "" {
+		return fmt.Errorf("prompt name is required")
+	}
+
+	if !toolNameRegex.MatchString(prompt.Name) {
+		return fmt.Errorf("prompt name must contain only alphanumeric characters, underscores, and hyphens")
+	}
+
+	if prompt.Template == "" {
+		return fmt.Errorf("prompt template is required")
+	}
+
+	tmpl, err := template.New(prompt.Name).Option("missingkey=error").Parse(prompt.Template)
+	if err != nil {
+		return fmt.Errorf("invalid template for prompt '%s': %w", prompt.Name, err)
+	}
+
+	s.promptsMutex.Lock()
+	defer s.promptsMutex.Unlock()
+
+	if _, exists := s.prompts[prompt.Name]; exists {
+		return fmt.Errorf("prompt '%s' is already registered", prompt.Name)
+	}
+
+	s.prompts[prompt.Name] = registeredPrompt{prompt: prompt, template: tmpl}
+	return nil
+}
+
+// ListPrompts returns all registered prompts sorted by name
+func (s *Server) ListPrompts() []Prompt {
+	s.promptsMutex.RLock()
+	defer s.promptsMutex.RUnlock()
+
+	prompts := make([]Prompt, 0, len(s.prompts))
+	for _, registered := range s.prompts {
+		prompts = append(prompts, registered.prompt)
+	}
+
+	sort.Slice(prompts, func(i, j int) bool {
+		return prompts[i].Name < prompts[j].Name
+	})
+
+	return prompts
+}
+
+// RenderPrompt renders the named prompt with the given arguments. Required
+// arguments must be present; optional ones default to empty strings.
+func (s *Server) RenderPrompt(name string, args map[string]string) (Prompt, string, error) {
+	s.promptsMutex.RLock()
+	registered, exists := s.prompts[name]
+	s.promptsMutex.RUnlock()
+
+	if !exists {
+		return Prompt{}, "", fmt.Errorf("%w: '%s'", ErrPromptNotFound, name)
+	}
+
+	data := make(map[string]string, len(registered.prompt.Arguments))
+	for _, arg := range registered.prompt.Arguments {
+		value := args[arg.Name]
+		if arg.Required && value == "" {
+			return Prompt{}, "", fmt.Errorf("missing required argument '%s' for prompt '%s'", arg.Name, name)
+		}
+		data[arg.Name] = value
+	}
+
+	for key := range args {
+		if _, ok := data[key]; !ok {
+			return Prompt{}, "", fmt.Errorf("unknown argument '%s' for prompt '%s'", key, name)
+		}
+	}
+
+	var text strings.Builder
+	if err := registered.template.Execute(&text, data); err != nil {
+		return Prompt{}, "", fmt.Errorf("failed to render prompt '%s': %w", name, err)
+	}
+
+	return registered.prompt, text.String(), nil
+}
+
+// promptInfo describes a prompt in the shape used by MCP prompts/list
+func promptInfo(prompt Prompt) map[string]interface{} {
+	arguments := make([]map[string]interface{}, 0, len(prompt.Arguments))
+	for _, arg := range prompt.Arguments {
+		arguments = append(arguments, map[string]interface{}{
+			"name":        arg.Name,
+			"description": arg.Description,
+			"required":    arg.Required,
+		})
+	}
+
+	return map[string]interface{}{
+		"name":        prompt.Name,
+		"description": prompt.Description,
+		"arguments":   arguments,
+	}
+}
+
+// promptsListResult builds the response to a prompts/list request
+func (s *Server) promptsListResult() map[string]interface{} {
+	prompts := s.ListPrompts()
+	promptList := make([]map[string]interface{}, 0, len(prompts))
+
+	for _, prompt := range prompts {
+		promptList = append(promptList, promptInfo(prompt))
+	}
+
+	return map[string]interface{}{
+		"prompts": promptList,
+	}
+}
+
+// promptGetResult renders a prompt in the shape used by MCP prompts/get
+func (s *Server) promptGetResult(name string, args map[string]string) (map[string]interface{}, error) {
+	prompt, text, err := s.RenderPrompt(name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"description": prompt.Description,
+		"messages": []map[string]interface{}{
+			{
+				"role":    "user",
+				"content": map[string]interface{}{"type": "text", "text": text},
+			},
+		},
+	}, nil
+}
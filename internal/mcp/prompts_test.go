@@ -0,0 +1,182 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+)
+
+// newPromptServer returns a server with a single registered prompt
+func newPromptServer(t *testing.T) *Server {
+	t.Helper()
+
+	server, err := NewServer(config.ServerConfig{Transport: "http"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	err = server.RegisterPrompt(Prompt{
+		Name:        "greet_project",
+		Description: "Greets a project",
+		Arguments: []PromptArgument{
+			{Name: "project_id", Description: "Project ID", Required: true},
+			{Name: "tone", Description: "Optional tone"},
+		},
+		Template: "Hello {{.project_id}}{{if .tone}} ({{.tone}}){{end}}",
+	})
+	if err != nil {
+		t.Fatalf("Failed to register prompt: %v", err)
+	}
+
+	return server
+}
+
+// TestRegisterPrompt tests prompt registration and validation
+func TestRegisterPrompt(t *testing.T) {
+	server := newPromptServer(t)
+
+	tests := []struct {
+		name   string
+		prompt Prompt
+	}{
+		{name: "Duplicate", prompt: Prompt{Name: "greet_project", Template: "Hi"}},
+		{name: "Missing name", prompt: Prompt{Template: "Hi"}},
+		{name: "Invalid name", prompt: Prompt{Name: "bad name", Template: "Hi"}},
+		{name: "Missing template", prompt: Prompt{Name: "empty"}},
+		{name: "Invalid template", prompt: Prompt{Name: "broken", Template: "{{.project_id"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := server.RegisterPrompt(tt.prompt); err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+
+	if prompts := server.ListPrompts(); len(prompts) != 1 || prompts[0].Name != "greet_project" {
+		t.Errorf("Expected only greet_project to be registered, got %v", prompts)
+	}
+}
+
+// TestRenderPrompt tests argument substitution and validation
+func TestRenderPrompt(t *testing.T) {
+	server := newPromptServer(t)
+
+	tests := []struct {
+		name        string
+		prompt      string
+		args        map[string]string
+		want        string
+		errContains string
+	}{
+		{name: "Required argument", prompt: "greet_project", args: map[string]string{"project_id": "proj-1"}, want: "Hello proj-1"},
+		{name: "Optional argument", prompt: "greet_project", args: map[string]string{"project_id": "proj-1", "tone": "formal"}, want: "Hello proj-1 (formal)"},
+		{name: "Missing required argument", prompt: "greet_project", args: map[string]string{"tone": "formal"}, errContains: "missing required argument 'project_id'"},
+		{name: "Unknown argument", prompt: "greet_project", args: map[string]string{"project_id": "proj-1", "extra": "x"}, errContains: "unknown argument 'extra'"},
+		{name: "Unknown prompt", prompt: "missing", errContains: "prompt not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, text, err := server.RenderPrompt(tt.prompt, tt.args)
+
+			if tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("Expected error containing '%s', got %v", tt.errContains, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if text != tt.want {
+				t.Errorf("Expected '%s', got '%s'", tt.want, text)
+			}
+		})
+	}
+}
+
+// TestHTTPPrompts tests the /prompts endpoints
+func TestHTTPPrompts(t *testing.T) {
+	handler := newPromptServer(t).HTTPHandler()
+
+	// List prompts
+	req := httptest.NewRequest("GET", "/prompts", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var list struct {
+		Prompts []struct {
+			Name      string `json:"name"`
+			Arguments []struct {
+				Name     string `json:"name"`
+				Required bool   `json:"required"`
+			} `json:"arguments"`
+		} `json:"prompts"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&list); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(list.Prompts) != 1 || list.Prompts[0].Name != "greet_project" || len(list.Prompts[0].Arguments) != 2 || !list.Prompts[0].Arguments[0].Required {
+		t.Errorf("Unexpected prompt list: %+v", list)
+	}
+
+	// Render a prompt
+	req = httptest.NewRequest("POST", "/prompts/greet_project", bytes.NewBufferString(`{"project_id":"proj-1"}`))
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var rendered struct {
+		Messages []struct {
+			Role    string `json:"role"`
+			Content struct {
+				Text string `json:"text"`
+			} `json:"content"`
+		} `json:"messages"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&rendered); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(rendered.Messages) != 1 || rendered.Messages[0].Role != "user" || rendered.Messages[0].Content.Text != "Hello proj-1" {
+		t.Errorf("Unexpected rendered prompt: %+v", rendered)
+	}
+
+	// Errors
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		expectedStatus int
+	}{
+		{name: "Missing argument", path: "/prompts/greet_project", body: `{}`, expectedStatus: http.StatusBadRequest},
+		{name: "Non-string argument", path: "/prompts/greet_project", body: `{"project_id":1}`, expectedStatus: http.StatusBadRequest},
+		{name: "Unknown prompt", path: "/prompts/missing", body: `{}`, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", tt.path, bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
@@ -2,24 +2,45 @@ package mcp
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
-	"log/slog"
+	"math"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/aRustyDev/pcf-mcp/internal/auth"
 	"github.com/aRustyDev/pcf-mcp/internal/config"
+	"github.com/aRustyDev/pcf-mcp/internal/observability"
 	"golang.org/x/time/rate"
 )
 
+const (
+	// headerRetryAfter tells rate-limited clients how long to wait
+	headerRetryAfter = "Retry-After"
+
+	// rateLimitIdleTTL is how long an unused client bucket is kept
+	rateLimitIdleTTL = 10 * time.Minute
+)
+
 // RateLimiter provides rate limiting functionality
 type RateLimiter struct {
 	visitors map[string]*visitor
 	mu       sync.RWMutex
-	rate     int           // requests per second
+	rate     rate.Limit    // requests per second
 	burst    int           // burst size
 	ttl      time.Duration // time to live for visitor entries
+
+	// keyFunc identifies the client of a request; defaults to getClientIP
+	keyFunc func(r *http.Request) string
+
+	// stop ends cleanupVisitors; closed once by Stop
+	stop     chan struct{}
+	stopOnce sync.Once
 }
 
 // visitor tracks rate limiting for a specific client
@@ -29,12 +50,14 @@ type visitor struct {
 }
 
 // NewRateLimiter creates a new rate limiter
-func NewRateLimiter(rps int, burst int, ttl time.Duration) *RateLimiter {
+func NewRateLimiter(rps float64, burst int, ttl time.Duration) *RateLimiter {
 	rl := &RateLimiter{
 		visitors: make(map[string]*visitor),
-		rate:     rps,
+		rate:     rate.Limit(rps),
 		burst:    burst,
 		ttl:      ttl,
+		keyFunc:  getClientIP,
+		stop:     make(chan struct{}),
 	}
 
 	// Start cleanup goroutine
@@ -43,15 +66,23 @@ func NewRateLimiter(rps int, burst int, ttl time.Duration) *RateLimiter {
 	return rl
 }
 
-// getVisitor returns the rate limiter for a given IP
-func (rl *RateLimiter) getVisitor(ip string) *rate.Limiter {
+// Stop ends the goroutine that removes idle clients. It is safe to call
+// more than once.
+func (rl *RateLimiter) Stop() {
+	rl.stopOnce.Do(func() {
+		close(rl.stop)
+	})
+}
+
+// getVisitor returns the rate limiter for a given client key
+func (rl *RateLimiter) getVisitor(key string) *rate.Limiter {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	v, exists := rl.visitors[ip]
+	v, exists := rl.visitors[key]
 	if !exists {
-		limiter := rate.NewLimiter(rate.Limit(rl.rate), rl.burst)
-		rl.visitors[ip] = &visitor{limiter: limiter, lastSeen: time.Now()}
+		limiter := rate.NewLimiter(rl.rate, rl.burst)
+		rl.visitors[key] = &visitor{limiter: limiter, lastSeen: time.Now()}
 		return limiter
 	}
 
@@ -59,12 +90,35 @@ func (rl *RateLimiter) getVisitor(ip string) *rate.Limiter {
 	return v.limiter
 }
 
-// cleanupVisitors removes old entries from the visitors map
+// allow reports whether the client identified by key may make a request now.
+// If not, it also returns how long the client should wait before retrying.
+func (rl *RateLimiter) allow(key string) (bool, time.Duration) {
+	limiter := rl.getVisitor(key)
+
+	now := time.Now()
+	reservation := limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		// Do not consume a token for a rejected request
+		reservation.CancelAt(now)
+		return false, delay
+	}
+
+	return true, 0
+}
+
+// cleanupVisitors removes old entries from the visitors map until Stop is
+// called
 func (rl *RateLimiter) cleanupVisitors() {
 	ticker := time.NewTicker(time.Minute)
 	defer ticker.Stop()
 
-	for range ticker.C {
+	for {
+		select {
+		case <-rl.stop:
+			return
+		case <-ticker.C:
+		}
+
 		rl.mu.Lock()
 		for ip, v := range rl.visitors {
 			if time.Since(v.lastSeen) > rl.ttl {
@@ -75,27 +129,17 @@ func (rl *RateLimiter) cleanupVisitors() {
 	}
 }
 
-// Middleware returns an HTTP middleware for rate limiting
+// Middleware returns an HTTP middleware for rate limiting. Rejected requests
+// get 429 Too Many Requests with a Retry-After header.
 func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Skip rate limiting for health and metrics endpoints
-		if r.URL.Path == "/health" || r.URL.Path == "/metrics" {
+		// Skip rate limiting for health, readiness, and metrics endpoints
+		if r.URL.Path == "/health" || r.URL.Path == "/ready" || r.URL.Path == "/metrics" {
 			next.ServeHTTP(w, r)
 			return
 		}
 
-		// Get client IP
-		ip := getClientIP(r)
-		limiter := rl.getVisitor(ip)
-
-		if !limiter.Allow() {
-			slog.Warn("Rate limit exceeded",
-				"ip", ip,
-				"path", r.URL.Path,
-				"method", r.Method,
-			)
-
-			http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+		if !rl.admit(w, r, rl.keyFunc(r)) {
 			return
 		}
 
@@ -103,6 +147,84 @@ func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 	})
 }
 
+// admit reports whether the client identified by key may make the request.
+// If not, it writes the 429 response.
+func (rl *RateLimiter) admit(w http.ResponseWriter, r *http.Request, key string) bool {
+	allowed, retryAfter := rl.allow(key)
+	if allowed {
+		return true
+	}
+
+	observability.FromContext(r.Context()).WarnContext(r.Context(), "Rate limit exceeded",
+		"client", rateLimitLogKey(key),
+		"path", r.URL.Path,
+		"method", r.Method,
+	)
+
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	w.Header().Set(headerRetryAfter, strconv.Itoa(seconds))
+	writeErrorResponse(w, http.StatusTooManyRequests, ErrorDetail{Code: CodeRateLimited, Message: "Rate limit exceeded"})
+	return false
+}
+
+// newRateLimiter returns the limiter for the per-client limits from
+// RateLimitPerSecond and RateLimitBurst, or nil when rate limiting is
+// disabled. Its middleware runs after authMiddleware, which limits rejected
+// requests by IP itself. The caller stops the limiter on shutdown.
+func (s *Server) newRateLimiter() *RateLimiter {
+	if s.config.RateLimitPerSecond <= 0 {
+		return nil
+	}
+
+	limiter := NewRateLimiter(s.config.RateLimitPerSecond, s.config.RateLimitBurst, rateLimitIdleTTL)
+	limiter.keyFunc = s.rateLimitKey
+
+	return limiter
+}
+
+// rateLimitKey identifies the client of a request that passed
+// authMiddleware. Only verified tokens are used as keys, so clients cannot
+// escape their IP's limit by sending made-up tokens.
+func (s *Server) rateLimitKey(r *http.Request) string {
+	// JWT callers share a limit across their tokens
+	if claims := auth.ClaimsFromContext(r.Context()); claims != nil && claims.Subject != "" {
+		return "subject:" + claims.Subject
+	}
+
+	// With authentication required, authMiddleware only lets requests
+	// through with a valid token
+	if s.config.AuthRequired {
+		if token, ok := strings.CutPrefix(r.Header.Get(headerAuthorization), bearerPrefix); ok {
+			return "token:" + token
+		}
+	}
+
+	return remoteIPKey(r)
+}
+
+// rateLimitLogKey returns a rate limit key as it may be logged. Token keys
+// hold the bearer token itself, so only a short hash of it is logged.
+func rateLimitLogKey(key string) string {
+	token, ok := strings.CutPrefix(key, "token:")
+	if !ok {
+		return key
+	}
+
+	sum := sha256.Sum256([]byte(token))
+	return "token:" + hex.EncodeToString(sum[:6])
+}
+
+// remoteIPKey identifies a client by remote IP for rate limiting.
+// Forwarding headers are ignored because clients can set them freely.
+func remoteIPKey(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	return "ip:" + host
+}
+
 // ToolRateLimiter provides rate limiting for tool execution
 type ToolRateLimiter struct {
 	limiters map[string]*rate.Limiter
@@ -191,7 +313,7 @@ func NewRateLimitedServer(cfg config.ServerConfig, httpRPS int, toolRPM int) (*R
 
 	return &RateLimitedServer{
 		Server:      server,
-		httpLimiter: NewRateLimiter(httpRPS, httpRPS*2, 10*time.Minute),
+		httpLimiter: NewRateLimiter(float64(httpRPS), httpRPS*2, rateLimitIdleTTL),
 		toolLimiter: NewToolRateLimiter(toolRPM, 5),
 	}, nil
 }
@@ -0,0 +1,197 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aRustyDev/pcf-mcp/internal/auth"
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+	"github.com/aRustyDev/pcf-mcp/internal/observability"
+)
+
+// TestRateLimitMiddleware tests that a burst above the limit is rejected with 429
+func TestRateLimitMiddleware(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{
+		Transport:          "http",
+		AuthRequired:       true,
+		AuthTokens:         []string{"token-a", "token-b"},
+		RateLimitPerSecond: 1,
+		RateLimitBurst:     5,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	handler := server.HTTPHandler()
+
+	do := func(path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	// Fire a burst; only the first RateLimitBurst requests get through
+	var ok, limited int
+	for i := 0; i < 20; i++ {
+		w := do("/tools", "token-a")
+		switch w.Code {
+		case http.StatusOK:
+			ok++
+		case http.StatusTooManyRequests:
+			limited++
+			retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+			if err != nil || retryAfter < 1 {
+				t.Errorf("Expected positive Retry-After header, got %q", w.Header().Get("Retry-After"))
+			}
+		default:
+			t.Fatalf("Unexpected status %d", w.Code)
+		}
+	}
+
+	if ok != 5 {
+		t.Errorf("Expected 5 requests to succeed, got %d", ok)
+	}
+	if limited != 15 {
+		t.Errorf("Expected 15 requests to be rate limited, got %d", limited)
+	}
+
+	// Each valid token has its own bucket
+	if w := do("/tools", "token-b"); w.Code != http.StatusOK {
+		t.Errorf("Expected a different token to be allowed, got %d", w.Code)
+	}
+
+	// Health and metrics endpoints are exempt
+	for _, path := range []string{"/health", "/metrics"} {
+		for i := 0; i < 10; i++ {
+			if w := do(path, ""); w.Code == http.StatusTooManyRequests {
+				t.Fatalf("Expected %s to be exempt from rate limiting", path)
+			}
+		}
+	}
+
+	// Invalid tokens share their IP's bucket, so they cannot bypass the limit
+	for i := 0; i < 5; i++ {
+		do("/tools", "made-up-"+strconv.Itoa(i))
+	}
+	if w := do("/tools", "another-made-up"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected requests with invalid tokens to be limited by IP, got %d", w.Code)
+	}
+}
+
+// TestRateLimitKey tests that clients are keyed by the identity authMiddleware
+// verified rather than by re-validating their token
+func TestRateLimitKey(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{
+		Transport:          "http",
+		AuthRequired:       true,
+		AuthTokens:         []string{"token-a"},
+		RateLimitPerSecond: 1,
+		RateLimitBurst:     1,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/tools", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	req.Header.Set("Authorization", "Bearer jwt-token")
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: "alice"}))
+	if key := server.rateLimitKey(req); key != "subject:alice" {
+		t.Errorf("Expected JWT subject key, got %q", key)
+	}
+
+	req = httptest.NewRequest("GET", "/tools", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	req.Header.Set("Authorization", "Bearer token-a")
+	if key := server.rateLimitKey(req); key != "token:token-a" {
+		t.Errorf("Expected token key, got %q", key)
+	}
+
+	req = httptest.NewRequest("GET", "/tools", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	if key := server.rateLimitKey(req); key != "ip:192.0.2.1" {
+		t.Errorf("Expected IP key, got %q", key)
+	}
+}
+
+// TestRateLimitExceededLog tests that rejections log the key the limiter
+// used, with tokens hashed and forwarding headers ignored
+func TestRateLimitExceededLog(t *testing.T) {
+	var logs bytes.Buffer
+	logger, err := observability.NewLoggerWithWriter(config.LoggingConfig{Level: "info", Format: "json"}, &logs)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(logger)
+
+	server, err := NewServer(config.ServerConfig{
+		Transport:          "http",
+		AuthRequired:       true,
+		AuthTokens:         []string{"token-a"},
+		RateLimitPerSecond: 1,
+		RateLimitBurst:     1,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	handler := server.HTTPHandler()
+
+	do := func(token string) {
+		req := httptest.NewRequest("GET", "/tools", nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		req.Header.Set("X-Forwarded-For", "203.0.113.9")
+		req.Header.Set("Authorization", "Bearer "+token)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	for i := 0; i < 2; i++ {
+		do("token-a")
+		do("made-up")
+	}
+
+	var clients []string
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil || entry["msg"] != "Rate limit exceeded" {
+			continue
+		}
+		client, _ := entry["client"].(string)
+		clients = append(clients, client)
+	}
+
+	want := []string{rateLimitLogKey("token:token-a"), "ip:192.0.2.1"}
+	if len(clients) != len(want) || clients[0] != want[0] || clients[1] != want[1] {
+		t.Fatalf("Expected rejections logged for %v, got %v", want, clients)
+	}
+	if strings.Contains(logs.String(), "token-a") || strings.Contains(logs.String(), "203.0.113.9") {
+		t.Errorf("Expected no raw token or forwarded IP in logs: %s", logs.String())
+	}
+}
+
+// TestRateLimiterStop tests that Stop ends the cleanup goroutine and may be
+// called more than once
+func TestRateLimiterStop(t *testing.T) {
+	limiter := NewRateLimiter(1, 1, time.Minute)
+
+	limiter.Stop()
+	limiter.Stop()
+
+	select {
+	case <-limiter.stop:
+	default:
+		t.Fatal("Expected Stop to close the stop channel")
+	}
+}
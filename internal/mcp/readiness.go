@@ -0,0 +1,59 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const (
+	// readinessInitialBackoff is the delay before the second startup check
+	readinessInitialBackoff = 250 * time.Millisecond
+
+	// readinessMaxBackoff caps the delay between startup checks
+	readinessMaxBackoff = 5 * time.Second
+)
+
+// startupGateOpen reports whether /ready may run its live checks. While an
+// HTTP server with a StartupReadyTimeout is still waiting for its first
+// successful backend check, /ready reports "starting" instead.
+func (s *Server) startupGateOpen() bool {
+	return s.config.StartupReadyTimeout <= 0 || s.startupReady.Load()
+}
+
+// WaitReady polls the readiness checker with exponential backoff until it
+// succeeds or StartupReadyTimeout elapses, then opens the startup gate so
+// /ready reports live backend status. It returns an error if the deadline
+// passed without a successful check; the gate is opened either way so the
+// server can become ready once the backend recovers.
+func (s *Server) WaitReady(ctx context.Context) error {
+	defer s.startupReady.Store(true)
+
+	if s.readinessChecker == nil || s.config.StartupReadyTimeout <= 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.StartupReadyTimeout)
+	defer cancel()
+
+	backoff := readinessInitialBackoff
+	for attempt := 1; ; attempt++ {
+		checkCtx, checkCancel := context.WithTimeout(ctx, readinessTimeout)
+		err := s.readinessChecker(checkCtx)
+		checkCancel()
+		if err == nil {
+			return nil
+		}
+
+		slog.DebugContext(ctx, "Startup readiness check failed", "attempt", attempt, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("backend not ready after %s (%d attempts): %w", s.config.StartupReadyTimeout, attempt, err)
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, readinessMaxBackoff)
+	}
+}
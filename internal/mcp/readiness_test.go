@@ -0,0 +1,127 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+)
+
+// readyStatus fetches /ready and returns the status code and reported status
+func readyStatus(t *testing.T, url string) (int, string) {
+	t.Helper()
+
+	resp, err := http.Get(url + "/ready")
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var body map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	status, _ := body["status"].(string)
+	return resp.StatusCode, status
+}
+
+// TestWaitReady tests that /ready stays at "starting" until PCF comes up
+func TestWaitReady(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{
+		Transport:           "http",
+		StartupReadyTimeout: 10 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	// PCF comes up after a delay
+	upAt := time.Now().Add(300 * time.Millisecond)
+	var calls atomic.Int32
+	server.SetReadinessChecker(func(ctx context.Context) error {
+		calls.Add(1)
+		if time.Now().Before(upAt) {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+
+	ts := httptest.NewServer(server.HTTPHandler())
+	defer ts.Close()
+
+	if code, status := readyStatus(t, ts.URL); code != http.StatusServiceUnavailable || status != "starting" {
+		t.Errorf("Expected 503 'starting' before WaitReady, got %d '%s'", code, status)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- server.WaitReady(context.Background()) }()
+
+	if code, status := readyStatus(t, ts.URL); code != http.StatusServiceUnavailable || status != "starting" {
+		t.Errorf("Expected 503 'starting' while PCF is down, got %d '%s'", code, status)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Expected WaitReady to succeed, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("WaitReady did not return after PCF came up")
+	}
+
+	if n := calls.Load(); n < 2 {
+		t.Errorf("Expected PCF to be polled more than once, got %d calls", n)
+	}
+
+	if code, status := readyStatus(t, ts.URL); code != http.StatusOK || status != "ready" {
+		t.Errorf("Expected 200 'ready' once PCF is up, got %d '%s'", code, status)
+	}
+}
+
+// TestWaitReadyDeadline tests that the gate opens after the deadline even if PCF never answers
+func TestWaitReadyDeadline(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{
+		Transport:           "http",
+		StartupReadyTimeout: 100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	var down atomic.Bool
+	down.Store(true)
+	server.SetReadinessChecker(func(ctx context.Context) error {
+		if down.Load() {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+
+	ts := httptest.NewServer(server.HTTPHandler())
+	defer ts.Close()
+
+	start := time.Now()
+	if err := server.WaitReady(context.Background()); err == nil {
+		t.Fatal("Expected WaitReady to fail while PCF is down")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("WaitReady overran its deadline: %s", elapsed)
+	}
+
+	// Past the deadline /ready reports live status and recovers with PCF
+	if code, status := readyStatus(t, ts.URL); code != http.StatusServiceUnavailable || status != "not_ready" {
+		t.Errorf("Expected 503 'not_ready' after the deadline, got %d '%s'", code, status)
+	}
+
+	down.Store(false)
+	if code, status := readyStatus(t, ts.URL); code != http.StatusOK || status != "ready" {
+		t.Errorf("Expected 200 'ready' after PCF recovered, got %d '%s'", code, status)
+	}
+}
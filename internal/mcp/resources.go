@@ -0,0 +1,198 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aRustyDev/pcf-mcp/internal/auth"
+)
+
+// ErrResourceNotFound is returned when reading a URI that matches no registered resource
+var ErrResourceNotFound = errors.New("resource not found")
+
+// resourceMimeType is the MIME type of every resource's contents
+const resourceMimeType = "application/json"
+
+// ResourceHandler reads a resource. params holds the values of the URI
+// template's placeholders, e.g. {"project_id": "proj-1"}.
+type ResourceHandler func(ctx context.Context, params map[string]string) (interface{}, error)
+
+// Resource is read-only data exposed to MCP clients under a URI template
+type Resource struct {
+	// URITemplate identifies the resource; path segments written as
+	// {name} match any single segment, e.g. "pcf://projects/{project_id}/hosts"
+	URITemplate string
+
+	// Name is a short human-readable name
+	Name string
+
+	// Description explains what the resource contains
+	Description string
+
+	// Handler returns the resource data, which is encoded as JSON
+	Handler ResourceHandler
+}
+
+// ResourceContents is the result of reading a resource
+type ResourceContents struct {
+	// URI is the URI that was read
+	URI string `json:"uri"`
+
+	// MimeType is the MIME type of Text
+	MimeType string `json:"mimeType"`
+
+	// Text is the JSON-encoded resource data
+	Text string `json:"text"`
+}
+
+// templated reports whether the resource URI has placeholders
+func (r Resource) templated() bool {
+	return strings.Contains(r.URITemplate, "{")
+}
+
+// match reports whether uri matches the resource's URI template and returns
+// the placeholder values
+func (r Resource) match(uri string) (map[string]string, bool) {
+	pattern := strings.Split(r.URITemplate, "/")
+	parts := strings.Split(uri, "/")
+	if len(pattern) != len(parts) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for i, segment := range pattern {
+		if name, ok := strings.CutPrefix(segment, "{"); ok && strings.HasSuffix(name, "}") {
+			if parts[i] == "" {
+				return nil, false
+			}
+			params[strings.TrimSuffix(name, "}")] = parts[i]
+			continue
+		}
+		if segment != parts[i] {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+// RegisterResource registers a resource with the server
+func (s *Server) RegisterResource(resource Resource) error {
+	if resource.URITemplate == "" {
+		return fmt.Errorf("resource URI template is required")
+	}
+
+	if !strings.Contains(resource.URITemplate, "://") {
+		return fmt.Errorf("resource URI template must include a scheme, e.g. pcf://")
+	}
+
+	if resource.Handler == nil {
+		return fmt.Errorf("resource handler is required")
+	}
+
+	s.resourcesMutex.Lock()
+	defer s.resourcesMutex.Unlock()
+
+	if _, exists := s.resources[resource.URITemplate]; exists {
+		return fmt.Errorf("resource '%s' is already registered", resource.URITemplate)
+	}
+
+	s.resources[resource.URITemplate] = resource
+	return nil
+}
+
+// ListResources returns all registered resources sorted by URI template
+func (s *Server) ListResources() []Resource {
+	s.resourcesMutex.RLock()
+	defer s.resourcesMutex.RUnlock()
+
+	resources := make([]Resource, 0, len(s.resources))
+	for _, resource := range s.resources {
+		resources = append(resources, resource)
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].URITemplate < resources[j].URITemplate
+	})
+
+	return resources
+}
+
+// ReadResource reads the resource matching uri and returns its contents as
+// JSON. Resources expose the same PCF data as the read tools, so the caller
+// needs ScopeRead on every transport.
+func (s *Server) ReadResource(ctx context.Context, uri string) (*ResourceContents, error) {
+	if !auth.HasScope(ctx, ScopeRead) {
+		return nil, fmt.Errorf("%w: reading resources requires scope '%s'", ErrInsufficientScope, ScopeRead)
+	}
+
+	var (
+		resource Resource
+		params   map[string]string
+		found    bool
+	)
+	for _, candidate := range s.ListResources() {
+		if params, found = candidate.match(uri); found {
+			resource = candidate
+			break
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: '%s'", ErrResourceNotFound, uri)
+	}
+
+	data, err := resource.Handler(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	text, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode resource '%s': %w", uri, err)
+	}
+
+	return &ResourceContents{URI: uri, MimeType: resourceMimeType, Text: string(text)}, nil
+}
+
+// resourceInfo describes a resource in the shape used by MCP resources/list
+// and resources/templates/list
+func resourceInfo(resource Resource) map[string]interface{} {
+	info := map[string]interface{}{
+		"name":        resource.Name,
+		"description": resource.Description,
+		"mimeType":    resourceMimeType,
+	}
+
+	if resource.templated() {
+		info["uriTemplate"] = resource.URITemplate
+	} else {
+		info["uri"] = resource.URITemplate
+	}
+
+	return info
+}
+
+// resourcesListResult builds the response to a resources/list request
+// (templated is false) or a resources/templates/list request (templated is true)
+func (s *Server) resourcesListResult(templated bool) map[string]interface{} {
+	resourceList := make([]map[string]interface{}, 0)
+	for _, resource := range s.ListResources() {
+		if resource.templated() == templated {
+			resourceList = append(resourceList, resourceInfo(resource))
+		}
+	}
+
+	key := "resources"
+	if templated {
+		key = "resourceTemplates"
+	}
+
+	return map[string]interface{}{
+		key: resourceList,
+	}
+}
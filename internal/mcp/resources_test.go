@@ -0,0 +1,220 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+)
+
+// newResourceServer returns a server with a plain and a templated resource
+func newResourceServer(t *testing.T) *Server {
+	t.Helper()
+
+	server, err := NewServer(config.ServerConfig{Transport: "http"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	resources := []Resource{
+		{
+			URITemplate: "pcf://projects",
+			Name:        "Projects",
+			Handler: func(ctx context.Context, params map[string]string) (interface{}, error) {
+				return []string{"proj-1"}, nil
+			},
+		},
+		{
+			URITemplate: "pcf://projects/{project_id}/hosts",
+			Name:        "Project hosts",
+			Handler: func(ctx context.Context, params map[string]string) (interface{}, error) {
+				if params["project_id"] == "broken" {
+					return nil, errors.New("PCF unavailable")
+				}
+				return map[string]string{"project": params["project_id"]}, nil
+			},
+		},
+	}
+	for _, resource := range resources {
+		if err := server.RegisterResource(resource); err != nil {
+			t.Fatalf("Failed to register resource: %v", err)
+		}
+	}
+
+	return server
+}
+
+// TestRegisterResource tests resource registration and validation
+func TestRegisterResource(t *testing.T) {
+	server := newResourceServer(t)
+	handler := func(ctx context.Context, params map[string]string) (interface{}, error) { return nil, nil }
+
+	tests := []struct {
+		name     string
+		resource Resource
+	}{
+		{name: "Duplicate", resource: Resource{URITemplate: "pcf://projects", Handler: handler}},
+		{name: "Missing URI", resource: Resource{Handler: handler}},
+		{name: "Missing scheme", resource: Resource{URITemplate: "projects", Handler: handler}},
+		{name: "Missing handler", resource: Resource{URITemplate: "pcf://hosts"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := server.RegisterResource(tt.resource); err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+
+	if resources := server.ListResources(); len(resources) != 2 {
+		t.Errorf("Expected 2 resources, got %d", len(resources))
+	}
+}
+
+// TestReadResource tests matching URIs against resource templates
+func TestReadResource(t *testing.T) {
+	server := newResourceServer(t)
+
+	contents, err := server.ReadResource(context.Background(), "pcf://projects/proj-7/hosts")
+	if err != nil {
+		t.Fatalf("Failed to read resource: %v", err)
+	}
+	if contents.Text != `{"project":"proj-7"}` || contents.MimeType != "application/json" {
+		t.Errorf("Unexpected contents: %+v", contents)
+	}
+
+	for _, uri := range []string{"pcf://projects//hosts", "pcf://projects/proj-7/issues", "pcf://projects/proj-7/hosts/extra"} {
+		if _, err := server.ReadResource(context.Background(), uri); !errors.Is(err, ErrResourceNotFound) {
+			t.Errorf("Expected ErrResourceNotFound for %s, got %v", uri, err)
+		}
+	}
+}
+
+// TestHTTPResources tests the /resources endpoints
+func TestHTTPResources(t *testing.T) {
+	handler := newResourceServer(t).HTTPHandler()
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	// List resources, templated ones by uriTemplate
+	w := get("/resources")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var list struct {
+		Resources []map[string]interface{} `json:"resources"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&list); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(list.Resources) != 2 || list.Resources[0]["uri"] != "pcf://projects" || list.Resources[1]["uriTemplate"] != "pcf://projects/{project_id}/hosts" {
+		t.Errorf("Unexpected resource list: %v", list.Resources)
+	}
+
+	// Read a resource
+	w = get("/resources/read?uri=" + url.QueryEscape("pcf://projects/proj-1/hosts"))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var read struct {
+		Contents []ResourceContents `json:"contents"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&read); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(read.Contents) != 1 || read.Contents[0].URI != "pcf://projects/proj-1/hosts" || read.Contents[0].Text != `{"project":"proj-1"}` {
+		t.Errorf("Unexpected contents: %+v", read.Contents)
+	}
+
+	// Errors
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+	}{
+		{name: "Missing uri", path: "/resources/read", expectedStatus: http.StatusBadRequest},
+		{name: "Unknown uri", path: "/resources/read?uri=" + url.QueryEscape("pcf://unknown"), expectedStatus: http.StatusNotFound},
+		{name: "Handler failure", path: "/resources/read?uri=" + url.QueryEscape("pcf://projects/broken/hosts"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := get(tt.path); w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+// TestHTTPResourceReadScope tests that reading a resource requires the read
+// scope
+func TestHTTPResourceReadScope(t *testing.T) {
+	server := newResourceServer(t)
+	server.config.AuthRequired = true
+	server.config.AuthTokens = []string{"read-token", "write-token"}
+	server.tokenScopes = map[string][]string{
+		"read-token":  {ScopeRead},
+		"write-token": {ScopeWrite},
+	}
+	handler := server.HTTPHandler()
+
+	path := "/resources/read?uri=" + url.QueryEscape("pcf://projects")
+	for token, expectedStatus := range map[string]int{
+		"read-token":  http.StatusOK,
+		"write-token": http.StatusForbidden,
+	} {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != expectedStatus {
+			t.Errorf("Expected status %d for %s, got %d: %s", expectedStatus, token, w.Code, w.Body.String())
+		}
+	}
+}
+
+// TestStdioResources tests resources/list, resources/templates/list, and resources/read
+func TestStdioResources(t *testing.T) {
+	server := newResourceServer(t)
+
+	responses := runStdio(t, server,
+		`{"jsonrpc":"2.0","id":1,"method":"resources/list"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"resources/templates/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"resources/read","params":{"uri":"pcf://projects"}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"resources/read","params":{"uri":"pcf://unknown"}}`,
+	)
+
+	if len(responses) != 4 {
+		t.Fatalf("Expected 4 responses, got %d", len(responses))
+	}
+
+	if resources := responses[0]["result"].(map[string]interface{})["resources"].([]interface{}); len(resources) != 1 {
+		t.Errorf("Expected 1 concrete resource, got %d", len(resources))
+	}
+	if templates := responses[1]["result"].(map[string]interface{})["resourceTemplates"].([]interface{}); len(templates) != 1 {
+		t.Errorf("Expected 1 resource template, got %d", len(templates))
+	}
+
+	contents := responses[2]["result"].(map[string]interface{})["contents"].([]interface{})
+	if text := contents[0].(map[string]interface{})["text"]; text != `["proj-1"]` {
+		t.Errorf("Expected projects JSON, got %v", text)
+	}
+
+	if _, ok := responses[3]["error"]; !ok {
+		t.Error("Expected error for unknown resource")
+	}
+}
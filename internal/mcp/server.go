@@ -5,13 +5,27 @@ package mcp
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"os"
 	"regexp"
+	"runtime"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/aRustyDev/pcf-mcp/internal/auth"
 	"github.com/aRustyDev/pcf-mcp/internal/config"
+	"github.com/aRustyDev/pcf-mcp/internal/observability"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf/redact"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Server represents the MCP server instance
@@ -25,12 +39,69 @@ type Server struct {
 	// tools stores registered MCP tools
 	tools map[string]Tool
 
-	// toolsMutex protects concurrent access to tools map
+	// schemas caches compiled input schemas when ValidateToolInput is enabled
+	schemas map[string]*jsonschema.Schema
+
+	// toolsMutex protects concurrent access to tools and schemas maps
 	toolsMutex sync.RWMutex
 
+	// prompts stores registered prompt templates
+	prompts map[string]registeredPrompt
+
+	// promptsMutex protects concurrent access to the prompts map
+	promptsMutex sync.RWMutex
+
+	// resources stores registered resources keyed by URI template
+	resources map[string]Resource
+
+	// resourcesMutex protects concurrent access to the resources map
+	resourcesMutex sync.RWMutex
+
+	// toolSlots bounds concurrent tool executions to MaxConcurrentTools;
+	// nil means unlimited
+	toolSlots chan struct{}
+
 	// metrics for observability
 	metrics interface{} // Will be *observability.Metrics but avoiding import cycle
 
+	// httpMetrics holds the HTTP request metrics, shared by every handler
+	// HTTPHandler returns
+	httpMetrics *httpMetrics
+
+	// readinessChecker verifies backend dependencies for the /ready endpoint
+	readinessChecker func(ctx context.Context) error
+
+	// healthChecks are the named checks run by the /health endpoint
+	healthChecks map[string]healthCheck
+
+	// healthChecksMutex protects concurrent access to healthChecks
+	healthChecksMutex sync.RWMutex
+
+	// jwtValidator verifies bearer tokens when AuthMode is jwt
+	jwtValidator *auth.JWTValidator
+
+	// tokenScopes maps static bearer tokens to their configured scopes
+	tokenScopes map[string][]string
+
+	// metricsRequireAuth subjects /metrics to bearer token authentication
+	metricsRequireAuth bool
+
+	// statsEndpoint serves per-tool latency summaries at /stats
+	statsEndpoint bool
+
+	// logBodies is the HTTP body logging mode set with SetLogBodies
+	logBodies string
+
+	// bodyRedactor masks credential fields in logged bodies
+	bodyRedactor *redact.Redactor
+
+	// toolMiddleware wraps every tool execution, outermost first; protected
+	// by toolsMutex
+	toolMiddleware []ToolMiddleware
+
+	// startupReady is set once WaitReady finishes, opening the /ready startup gate
+	startupReady atomic.Bool
+
 	// logger for server operations
 	// Will be added when we integrate logging
 }
@@ -43,16 +114,43 @@ type Tool struct {
 	// Description explains what the tool does
 	Description string
 
+	// Category groups related tools, e.g. "hosts" or "issues"
+	Category string
+
+	// Tags label the tool for filtering, e.g. its category and "read" or "write"
+	Tags []string
+
+	// RequiredScope is the scope a caller needs to execute the tool, e.g.
+	// ScopeRead or ScopeWrite; empty means any authenticated caller may
+	RequiredScope string
+
 	// InputSchema defines the expected parameters using JSON Schema
 	InputSchema map[string]interface{}
 
+	// ParamDefaults supplies the value of a param a call omits or leaves as
+	// an empty string. Defaults are filled in before middleware, validation,
+	// and the handler see the params.
+	ParamDefaults map[string]interface{}
+
 	// Handler is the function that executes the tool logic
 	Handler ToolHandler
+
+	// StreamingHandler optionally executes the tool while reporting progress.
+	// When set, it is used instead of Handler.
+	StreamingHandler StreamingToolHandler
 }
 
 // ToolHandler is the function signature for tool execution
 type ToolHandler func(ctx context.Context, params map[string]interface{}) (interface{}, error)
 
+// ProgressEvent is an intermediate update emitted by a streaming tool
+type ProgressEvent map[string]interface{}
+
+// StreamingToolHandler is the function signature for tools that report progress.
+// Handlers send events on progress and return the final result; they must not
+// close the channel.
+type StreamingToolHandler func(ctx context.Context, params map[string]interface{}, progress chan<- ProgressEvent) (interface{}, error)
+
 // Capabilities represents the server's MCP capabilities
 type Capabilities struct {
 	// Tools indicates if the server supports tool execution
@@ -65,12 +163,54 @@ type Capabilities struct {
 	Prompts bool
 }
 
+// ErrToolTimeout is returned when a tool runs longer than the configured ToolTimeout
+var ErrToolTimeout = errors.New("tool execution timed out")
+
+// ErrToolNotFound is returned when executing or changing a tool that is not registered
+var ErrToolNotFound = errors.New("tool not found")
+
+// ErrInsufficientScope is returned when the caller's scopes do not include
+// the tool's RequiredScope
+var ErrInsufficientScope = errors.New("insufficient scope")
+
+// Scopes granted to bearer tokens and required by tools
+const (
+	// ScopeRead allows tools that only read PCF data
+	ScopeRead = "read"
+
+	// ScopeWrite allows tools that create, change, or delete PCF data
+	ScopeWrite = "write"
+)
+
 // toolNameRegex validates tool names (alphanumeric, underscore, hyphen)
 var toolNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
 
+// toolNamePrefixSeparator joins ServerConfig.ToolNamePrefix to tool names
+const toolNamePrefixSeparator = "_"
+
 // Version of the MCP server
 const Version = "0.1.0"
 
+// Build metadata, injected at build time with
+// -ldflags "-X github.com/aRustyDev/pcf-mcp/internal/mcp.GitCommit=..."
+var (
+	// GitCommit is the commit the binary was built from
+	GitCommit = "unknown"
+
+	// BuildDate is when the binary was built, in RFC 3339 format
+	BuildDate = "unknown"
+)
+
+// BuildInfo returns the server version and build metadata
+func BuildInfo() map[string]string {
+	return map[string]string{
+		"version":    Version,
+		"git_commit": GitCommit,
+		"build_date": BuildDate,
+		"go_version": runtime.Version(),
+	}
+}
+
 // NewServer creates a new MCP server instance with the given configuration
 func NewServer(cfg config.ServerConfig) (*Server, error) {
 	// Validate transport type
@@ -78,13 +218,54 @@ func NewServer(cfg config.ServerConfig) (*Server, error) {
 		return nil, fmt.Errorf("invalid transport type: %s (must be 'stdio' or 'http')", cfg.Transport)
 	}
 
+	if cfg.ToolNamePrefix != "" && !toolNameRegex.MatchString(cfg.ToolNamePrefix) {
+		return nil, fmt.Errorf("invalid tool name prefix: %s (must contain only alphanumeric characters, underscores, and hyphens)", cfg.ToolNamePrefix)
+	}
+
 	// Create MCP server
 	mcpServer := server.NewMCPServer("pcf-mcp", Version)
 
 	s := &Server{
-		config:    cfg,
-		tools:     make(map[string]Tool),
-		mcpServer: mcpServer,
+		config:      cfg,
+		tools:       make(map[string]Tool),
+		schemas:     make(map[string]*jsonschema.Schema),
+		prompts:     make(map[string]registeredPrompt),
+		resources:   make(map[string]Resource),
+		mcpServer:   mcpServer,
+		httpMetrics: newHTTPMetrics(),
+	}
+
+	if cfg.MaxConcurrentTools > 0 {
+		s.toolSlots = make(chan struct{}, cfg.MaxConcurrentTools)
+	}
+
+	if len(cfg.TokenScopes) > 0 {
+		s.tokenScopes = make(map[string][]string, len(cfg.TokenScopes))
+		for _, entry := range cfg.TokenScopes {
+			s.tokenScopes[entry.Token] = append([]string{}, entry.Scopes...)
+		}
+	}
+
+	// Fail fast on incomplete TLS configuration
+	if _, err := s.tlsConfig(); err != nil {
+		return nil, fmt.Errorf("invalid TLS configuration: %w", err)
+	}
+
+	switch cfg.AuthMode {
+	case "", "static":
+	case "jwt":
+		validator, err := auth.NewJWTValidator(auth.JWTOptions{
+			JWKSURL:       cfg.JWTJWKSURL,
+			PublicKeyFile: cfg.JWTPublicKeyFile,
+			Audience:      cfg.JWTAudience,
+			Issuer:        cfg.JWTIssuer,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWT auth configuration: %w", err)
+		}
+		s.jwtValidator = validator
+	default:
+		return nil, fmt.Errorf("invalid auth mode: %s (must be 'static' or 'jwt')", cfg.AuthMode)
 	}
 
 	return s, nil
@@ -100,6 +281,25 @@ func (s *Server) Version() string {
 	return Version
 }
 
+// SetReadinessChecker sets the function used by the /ready endpoint to
+// verify that backend dependencies such as PCF are reachable
+func (s *Server) SetReadinessChecker(checker func(ctx context.Context) error) {
+	s.readinessChecker = checker
+}
+
+// SetMetricsRequireAuth controls whether the /metrics endpoint requires a
+// bearer token when authentication is enabled. By default it is public.
+func (s *Server) SetMetricsRequireAuth(required bool) {
+	s.metricsRequireAuth = required
+}
+
+// SetStatsEndpoint controls whether the HTTP transport serves per-tool
+// latency summaries at /stats. The metrics set with SetMetrics must
+// implement LatencyStatsProvider for the endpoint to report anything.
+func (s *Server) SetStatsEndpoint(enabled bool) {
+	s.statsEndpoint = enabled
+}
+
 // Capabilities returns the server's MCP capabilities
 func (s *Server) Capabilities() Capabilities {
 	return Capabilities{
@@ -111,6 +311,35 @@ func (s *Server) Capabilities() Capabilities {
 
 // RegisterTool registers a new tool with the server
 func (s *Server) RegisterTool(tool Tool) error {
+	return s.addTool(tool, false)
+}
+
+// ReplaceTool swaps the definition and handler of an already registered
+// tool. Calls that started before the swap finish with the old handler.
+func (s *Server) ReplaceTool(tool Tool) error {
+	return s.addTool(tool, true)
+}
+
+// UnregisterTool removes a registered tool. Later calls to it fail with a
+// not found error; calls already running are unaffected.
+func (s *Server) UnregisterTool(name string) error {
+	s.toolsMutex.Lock()
+	defer s.toolsMutex.Unlock()
+
+	if _, exists := s.tools[name]; !exists {
+		return fmt.Errorf("%w: '%s'", ErrToolNotFound, name)
+	}
+
+	delete(s.tools, name)
+	delete(s.schemas, name)
+	s.mcpServer.DeleteTools(s.exposedToolName(name))
+
+	return nil
+}
+
+// addTool registers tool, requiring that a tool with the same name already
+// exists when replace is true and that none does otherwise
+func (s *Server) addTool(tool Tool, replace bool) error {
 	// Validate tool
 	if err := s.validateTool(tool); err != nil {
 		return fmt.Errorf("tool validation failed: %w", err)
@@ -119,17 +348,37 @@ func (s *Server) RegisterTool(tool Tool) error {
 	s.toolsMutex.Lock()
 	defer s.toolsMutex.Unlock()
 
-	// Check for duplicate
-	if _, exists := s.tools[tool.Name]; exists {
+	// Check for duplicate, or for the tool being replaced
+	_, exists := s.tools[tool.Name]
+	if exists && !replace {
 		return fmt.Errorf("tool '%s' is already registered", tool.Name)
 	}
+	if !exists && replace {
+		return fmt.Errorf("%w: '%s'", ErrToolNotFound, tool.Name)
+	}
+
+	// Compile the input schema up front so invalid schemas fail registration
+	var schema *jsonschema.Schema
+	if s.config.ValidateToolInput && tool.InputSchema != nil {
+		var err error
+		schema, err = compileInputSchema(tool.Name, tool.InputSchema)
+		if err != nil {
+			return fmt.Errorf("invalid input schema for tool '%s': %w", tool.Name, err)
+		}
+	}
+
+	if schema != nil {
+		s.schemas[tool.Name] = schema
+	} else {
+		delete(s.schemas, tool.Name)
+	}
 
 	// Register the tool internally
 	s.tools[tool.Name] = tool
 
 	// Create MCP tool definition
 	mcpTool := mcp.Tool{
-		Name:        tool.Name,
+		Name:        s.exposedToolName(tool.Name),
 		Description: tool.Description,
 	}
 
@@ -145,7 +394,7 @@ func (s *Server) RegisterTool(tool Tool) error {
 	// Add tool to MCP server with handler
 	s.mcpServer.AddTool(mcpTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Use ExecuteToolWithMetrics to track metrics
-		result, err := s.ExecuteToolWithMetrics(ctx, tool.Name, request.Params.Arguments.(map[string]interface{}))
+		result, err := s.ExecuteToolWithMetrics(ctx, mcpTool.Name, request.Params.Arguments.(map[string]interface{}))
 		if err != nil {
 			return nil, err
 		}
@@ -171,52 +420,330 @@ func (s *Server) validateTool(tool Tool) error {
 		return fmt.Errorf("tool name is required")
 	}
 
-	// Validate name format
-	if !toolNameRegex.MatchString(tool.Name) {
+	// Validate name format, as registered and as exposed
+	if !toolNameRegex.MatchString(tool.Name) || !toolNameRegex.MatchString(s.exposedToolName(tool.Name)) {
 		return fmt.Errorf("tool name must contain only alphanumeric characters, underscores, and hyphens")
 	}
 
 	// Check handler
-	if tool.Handler == nil {
+	if tool.Handler == nil && tool.StreamingHandler == nil {
 		return fmt.Errorf("tool handler is required")
 	}
 
 	return nil
 }
 
-// ListTools returns all registered tools
+// SelectTools returns the tools permitted by the EnabledTools and
+// DisabledTools configuration, in their original order. It fails if either
+// list names a tool that is not among tools.
+func (s *Server) SelectTools(tools []Tool) ([]Tool, error) {
+	known := make(map[string]bool, len(tools))
+	for _, tool := range tools {
+		known[tool.Name] = true
+	}
+	for _, list := range []struct {
+		key   string
+		names []string
+	}{
+		{"enabled_tools", s.config.EnabledTools},
+		{"disabled_tools", s.config.DisabledTools},
+	} {
+		for _, name := range list.names {
+			if !known[name] {
+				return nil, fmt.Errorf("%w: '%s' in %s", ErrToolNotFound, name, list.key)
+			}
+		}
+	}
+
+	selected := make([]Tool, 0, len(tools))
+	for _, tool := range tools {
+		if len(s.config.EnabledTools) > 0 && !slices.Contains(s.config.EnabledTools, tool.Name) {
+			continue
+		}
+		if slices.Contains(s.config.DisabledTools, tool.Name) {
+			continue
+		}
+		selected = append(selected, tool)
+	}
+
+	return selected, nil
+}
+
+// ListTools returns all registered tools under their exposed names (see
+// ServerConfig.ToolNamePrefix)
 func (s *Server) ListTools() []Tool {
 	s.toolsMutex.RLock()
 	defer s.toolsMutex.RUnlock()
 
 	tools := make([]Tool, 0, len(s.tools))
 	for _, tool := range s.tools {
+		tool.Name = s.exposedToolName(tool.Name)
 		tools = append(tools, tool)
 	}
 
 	return tools
 }
 
-// ExecuteTool executes a tool by name with the given parameters
-func (s *Server) ExecuteTool(ctx context.Context, name string, params map[string]interface{}) (interface{}, error) {
+// exposedToolName returns the name clients use for the tool registered as
+// name: the name with ToolNamePrefix prepended, when one is configured
+func (s *Server) exposedToolName(name string) string {
+	if s.config.ToolNamePrefix == "" {
+		return name
+	}
+	return s.config.ToolNamePrefix + toolNamePrefixSeparator + name
+}
+
+// lookupTool returns the tool a client calls by its exposed name. With a
+// ToolNamePrefix configured, names without the prefix are not found.
+func (s *Server) lookupTool(name string) (Tool, bool) {
+	if s.config.ToolNamePrefix != "" {
+		base, ok := strings.CutPrefix(name, s.config.ToolNamePrefix+toolNamePrefixSeparator)
+		if !ok {
+			return Tool{}, false
+		}
+		name = base
+	}
+
 	s.toolsMutex.RLock()
+	defer s.toolsMutex.RUnlock()
+
 	tool, exists := s.tools[name]
-	s.toolsMutex.RUnlock()
+	return tool, exists
+}
 
+// ExecuteTool executes a tool by its exposed name with the given parameters
+func (s *Server) ExecuteTool(ctx context.Context, name string, params map[string]interface{}) (interface{}, error) {
+	tool, exists := s.lookupTool(name)
 	if !exists {
-		return nil, fmt.Errorf("tool '%s' not found", name)
+		return nil, fmt.Errorf("%w: '%s'", ErrToolNotFound, name)
 	}
 
-	// Execute the tool handler
-	return tool.Handler(ctx, params)
+	// Execute the tool handler, discarding progress from streaming tools
+	return s.executeTool(ctx, tool, params, nil)
+}
+
+// ExecuteToolStream executes a tool by name, passing each progress event
+// emitted by a streaming tool to onProgress
+func (s *Server) ExecuteToolStream(ctx context.Context, name string, params map[string]interface{}, onProgress func(ProgressEvent)) (interface{}, error) {
+	tool, exists := s.lookupTool(name)
+	if !exists {
+		return nil, fmt.Errorf("%w: '%s'", ErrToolNotFound, name)
+	}
+
+	return s.executeTool(ctx, tool, params, onProgress)
+}
+
+// executedParamsKey is the context key for where executeTool records the
+// params it passes to the tool handler
+type executedParamsKey struct{}
+
+// withExecutedParams returns a context in which executeTool stores the params
+// the tool handler ran with, after defaults and middleware, in *params
+func withExecutedParams(ctx context.Context, params *map[string]interface{}) context.Context {
+	return context.WithValue(ctx, executedParamsKey{}, params)
+}
+
+// withParamDefaults returns params with the tool's ParamDefaults filled in,
+// copying params rather than modifying the caller's map
+func (t Tool) withParamDefaults(params map[string]interface{}) map[string]interface{} {
+	if len(t.ParamDefaults) == 0 {
+		return params
+	}
+
+	filled := maps.Clone(params)
+	if filled == nil {
+		filled = make(map[string]interface{}, len(t.ParamDefaults))
+	}
+	for name, value := range t.ParamDefaults {
+		if current, ok := filled[name]; !ok || current == "" {
+			filled[name] = value
+		}
+	}
+	return filled
+}
+
+// executeTool validates params and runs the tool inside a "tool.<name>" span
+// so that PCF requests made by the handler appear as child spans
+func (s *Server) executeTool(ctx context.Context, tool Tool, params map[string]interface{}, onProgress func(ProgressEvent)) (interface{}, error) {
+	params = tool.withParamDefaults(params)
+
+	attrs := []attribute.KeyValue{
+		observability.StringAttribute(observability.AttributeToolName, tool.Name),
+	}
+	if projectID, ok := params["project_id"].(string); ok && projectID != "" {
+		attrs = append(attrs, observability.StringAttribute(observability.AttributeProjectID, projectID))
+	}
+	if requestID := observability.RequestIDFromContext(ctx); requestID != "" {
+		attrs = append(attrs, observability.StringAttribute(observability.AttributeRequestID, requestID))
+	}
+
+	ctx, span := observability.StartSpan(ctx, "tool."+tool.Name, trace.WithAttributes(attrs...))
+	defer span.End()
+
+	if tool.RequiredScope != "" && !auth.HasScope(ctx, tool.RequiredScope) {
+		err := fmt.Errorf("%w: tool '%s' requires scope '%s'", ErrInsufficientScope, tool.Name, tool.RequiredScope)
+		observability.RecordError(span, err)
+		return nil, err
+	}
+
+	// Middleware registered with Use runs around validation and execution,
+	// so it sees, and may rewrite, the params before they are validated
+	run := s.toolChain(func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+		if err := s.validateInput(tool.Name, params); err != nil {
+			return nil, err
+		}
+
+		release, err := s.acquireToolSlot(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+
+		if executed, ok := ctx.Value(executedParamsKey{}).(*map[string]interface{}); ok {
+			*executed = params
+		}
+
+		logger := observability.FromContext(ctx).With(observability.FieldTool, tool.Name)
+		logger.DebugContext(ctx, "Executing tool")
+
+		start := time.Now()
+		result, err := s.runToolWithTimeout(ctx, tool, params, onProgress)
+
+		if err != nil {
+			logger.DebugContext(ctx, "Tool failed", "duration", time.Since(start), observability.FieldError, err)
+		} else {
+			logger.DebugContext(ctx, "Tool completed", "duration", time.Since(start))
+		}
+
+		return result, err
+	})
+
+	result, err := run(withToolName(ctx, tool.Name), params)
+	observability.RecordError(span, err)
+
+	return result, err
+}
+
+// acquireToolSlot waits for one of the MaxConcurrentTools execution slots
+// and returns the function that frees it
+func (s *Server) acquireToolSlot(ctx context.Context) (func(), error) {
+	if s.toolSlots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case s.toolSlots <- struct{}{}:
+		return func() { <-s.toolSlots }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for a tool execution slot: %w", ctx.Err())
+	}
+}
+
+// runToolWithTimeout runs a tool, bounding its execution by ToolTimeout when set.
+// Handlers that ignore context cancellation are abandoned once the deadline
+// fires or the caller goes away, so a disconnected client is answered promptly.
+func (s *Server) runToolWithTimeout(ctx context.Context, tool Tool, params map[string]interface{}, onProgress func(ProgressEvent)) (interface{}, error) {
+	timeout := s.config.ToolTimeout
+
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	// Drop progress events from a handler that outlives the deadline
+	var mu sync.Mutex
+	finished := false
+	defer func() {
+		mu.Lock()
+		finished = true
+		mu.Unlock()
+	}()
+
+	guardedProgress := func(event ProgressEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !finished && onProgress != nil {
+			onProgress(event)
+		}
+	}
+
+	type outcome struct {
+		result interface{}
+		err    error
+	}
+
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := runTool(ctx, tool, params, guardedProgress)
+		done <- outcome{result: result, err: err}
+	}()
+
+	select {
+	case out := <-done:
+		if out.err != nil && timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%w: tool '%s' exceeded %s", ErrToolTimeout, tool.Name, timeout)
+		}
+		return out.result, out.err
+	case <-ctx.Done():
+		if timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%w: tool '%s' exceeded %s", ErrToolTimeout, tool.Name, timeout)
+		}
+		return nil, fmt.Errorf("tool '%s' cancelled: %w", tool.Name, ctx.Err())
+	}
+}
+
+// runTool invokes the tool's streaming handler if set, otherwise its plain handler
+func runTool(ctx context.Context, tool Tool, params map[string]interface{}, onProgress func(ProgressEvent)) (interface{}, error) {
+	if tool.StreamingHandler == nil {
+		return tool.Handler(ctx, params)
+	}
+
+	type outcome struct {
+		result interface{}
+		err    error
+	}
+
+	progress := make(chan ProgressEvent, 16)
+	done := make(chan outcome, 1)
+
+	go func() {
+		result, err := tool.StreamingHandler(ctx, params, progress)
+		done <- outcome{result: result, err: err}
+	}()
+
+	emit := func(event ProgressEvent) {
+		if onProgress != nil {
+			onProgress(event)
+		}
+	}
+
+	for {
+		select {
+		case event := <-progress:
+			emit(event)
+		case out := <-done:
+			// Deliver events sent just before the handler returned
+			for {
+				select {
+				case event := <-progress:
+					emit(event)
+				default:
+					return out.result, out.err
+				}
+			}
+		}
+	}
 }
 
 // Start starts the MCP server
 func (s *Server) Start(ctx context.Context) error {
 	switch s.config.Transport {
 	case "stdio":
-		// Start stdio server
-		return server.ServeStdio(s.mcpServer)
+		// Serve JSON-RPC over stdin/stdout
+		return s.ServeStdio(ctx, os.Stdin, os.Stdout)
 	case "http":
 		// Start HTTP server
 		return s.StartHTTP(ctx)
@@ -3,6 +3,10 @@ package mcp
 import (
 	"context"
 	"time"
+
+	"github.com/aRustyDev/pcf-mcp/internal/observability"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 )
 
 // MetricsRecorder interface defines the metrics recording methods we need
@@ -10,6 +14,18 @@ type MetricsRecorder interface {
 	RecordToolExecution(toolName string, success bool, duration time.Duration)
 }
 
+// LatencyStatsProvider is implemented by metrics that keep rolling per-tool
+// latency summaries for the /stats endpoint
+type LatencyStatsProvider interface {
+	ToolLatencyStats() map[string]observability.LatencySummary
+}
+
+// MetricsGatherer is implemented by metrics with a Prometheus registry of
+// their own, which /metrics serves alongside the HTTP metrics
+type MetricsGatherer interface {
+	Gatherer() prometheus.Gatherer
+}
+
 // SetMetrics sets the metrics instance for the server
 func (s *Server) SetMetrics(metrics MetricsRecorder) {
 	s.metrics = metrics
@@ -23,13 +39,62 @@ func (s *Server) ExecuteToolWithMetrics(ctx context.Context, name string, params
 	result, err := s.ExecuteTool(ctx, name, params)
 
 	// Record metrics
-	if s.metrics != nil {
-		duration := time.Since(start)
-		success := err == nil
-		if recorder, ok := s.metrics.(MetricsRecorder); ok {
-			recorder.RecordToolExecution(name, success, duration)
+	s.recordToolExecution(name, err == nil, time.Since(start))
+
+	return result, err
+}
+
+// ExecuteToolStreamWithMetrics wraps ExecuteToolStream to record metrics
+func (s *Server) ExecuteToolStreamWithMetrics(ctx context.Context, name string, params map[string]interface{}, onProgress func(ProgressEvent)) (interface{}, error) {
+	start := time.Now()
+
+	// Execute the tool
+	result, err := s.ExecuteToolStream(ctx, name, params, onProgress)
+
+	// Record metrics
+	s.recordToolExecution(name, err == nil, time.Since(start))
+
+	return result, err
+}
+
+// recordToolExecution forwards a tool execution to the metrics recorder if
+// set. Calls to unknown tools are not recorded, since their names come from
+// callers and each would add a metric series and latency window for good.
+func (s *Server) recordToolExecution(name string, success bool, duration time.Duration) {
+	if s.metrics == nil {
+		return
+	}
+
+	if _, ok := s.lookupTool(name); !ok {
+		return
+	}
+
+	if recorder, ok := s.metrics.(MetricsRecorder); ok {
+		recorder.RecordToolExecution(name, success, duration)
+	}
+}
+
+// toolLatencyStats returns the per-tool latency summaries, or an empty map
+// if the metrics do not track them
+func (s *Server) toolLatencyStats() map[string]observability.LatencySummary {
+	if provider, ok := s.metrics.(LatencyStatsProvider); ok {
+		if stats := provider.ToolLatencyStats(); stats != nil {
+			return stats
 		}
 	}
 
-	return result, err
+	return map[string]observability.LatencySummary{}
+}
+
+// metricsGatherer returns the gatherer served on /metrics: the HTTP metrics
+// merged with those set with SetMetrics, looked up on every scrape so metrics
+// set after the handler is built are still served
+func (s *Server) metricsGatherer() prometheus.Gatherer {
+	return prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		gatherers := prometheus.Gatherers{s.httpMetrics.registry}
+		if metrics, ok := s.metrics.(MetricsGatherer); ok {
+			gatherers = append(gatherers, metrics.Gatherer())
+		}
+		return gatherers.Gather()
+	})
 }
@@ -2,6 +2,11 @@ package mcp
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -147,6 +152,114 @@ func TestRegisterDuplicateTool(t *testing.T) {
 	}
 }
 
+// TestReplaceAndUnregisterTool tests swapping and removing tools at runtime
+func TestReplaceAndUnregisterTool(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{Transport: "http"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	versionTool := func(version string) Tool {
+		return Tool{
+			Name:        "versioned_tool",
+			Description: "Reports its implementation version",
+			Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+				return version, nil
+			},
+		}
+	}
+
+	// Replacing or unregistering an unknown tool fails
+	if err := server.ReplaceTool(versionTool("v1")); err == nil {
+		t.Error("Expected error when replacing an unregistered tool")
+	}
+	if err := server.UnregisterTool("versioned_tool"); err == nil {
+		t.Error("Expected error when unregistering an unregistered tool")
+	}
+
+	if err := server.RegisterTool(versionTool("v1")); err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+	if err := server.ReplaceTool(versionTool("v2")); err != nil {
+		t.Fatalf("Failed to replace tool: %v", err)
+	}
+
+	result, err := server.ExecuteTool(context.Background(), "versioned_tool", nil)
+	if err != nil {
+		t.Fatalf("Failed to execute tool: %v", err)
+	}
+	if result != "v2" {
+		t.Errorf("Expected replaced handler result 'v2', got %v", result)
+	}
+	if tools := server.ListTools(); len(tools) != 1 {
+		t.Errorf("Expected 1 tool after replacement, got %d", len(tools))
+	}
+
+	if err := server.UnregisterTool("versioned_tool"); err != nil {
+		t.Fatalf("Failed to unregister tool: %v", err)
+	}
+	if tools := server.ListTools(); len(tools) != 0 {
+		t.Errorf("Expected no tools after unregistering, got %d", len(tools))
+	}
+
+	req := httptest.NewRequest("POST", "/tools/versioned_tool", strings.NewReader("{}"))
+	w := httptest.NewRecorder()
+	server.HTTPHandler().ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 after unregistering, got %d", w.Code)
+	}
+}
+
+// TestConcurrentToolRegistry tests that listing and executing tools is safe
+// while tools are being replaced and unregistered
+func TestConcurrentToolRegistry(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{Transport: "stdio"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	tool := Tool{
+		Name:        "flapping_tool",
+		Description: "Registered and removed repeatedly",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return "ok", nil
+		},
+	}
+	if err := server.RegisterTool(tool); err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			_ = server.ReplaceTool(tool)
+			_ = server.UnregisterTool(tool.Name)
+			_ = server.RegisterTool(tool)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			server.ListTools()
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			// Either outcome is fine; the race detector checks the access
+			_, _ = server.ExecuteTool(context.Background(), tool.Name, nil)
+		}
+	}()
+
+	wg.Wait()
+}
+
 // TestExecuteTool tests tool execution
 func TestExecuteTool(t *testing.T) {
 	cfg := config.ServerConfig{
@@ -230,6 +343,133 @@ func TestExecuteNonExistentTool(t *testing.T) {
 	}
 }
 
+// TestToolNamePrefix tests that a configured prefix namespaces the listed
+// and callable tool names while tools keep their registered names
+func TestToolNamePrefix(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{Transport: "http", ToolNamePrefix: "pcf"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	var executing string
+	err = server.RegisterTool(Tool{
+		Name:        "list_projects",
+		Description: "List projects",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			executing = ToolNameFromContext(ctx)
+			return "projects", nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	tools := server.ListTools()
+	if len(tools) != 1 || tools[0].Name != "pcf_list_projects" {
+		t.Fatalf("Expected the tool listed as pcf_list_projects, got %v", tools)
+	}
+
+	result, err := server.ExecuteTool(context.Background(), "pcf_list_projects", map[string]interface{}{})
+	if err != nil || result != "projects" {
+		t.Fatalf("Expected the prefixed name to execute, got %v, %v", result, err)
+	}
+	if executing != "list_projects" {
+		t.Errorf("Expected the tool to run under its registered name, got %q", executing)
+	}
+
+	if _, err := server.ExecuteTool(context.Background(), "list_projects", map[string]interface{}{}); !errors.Is(err, ErrToolNotFound) {
+		t.Errorf("Expected the unprefixed name not to be found, got %v", err)
+	}
+
+	handler := server.HTTPHandler()
+	for path, expected := range map[string]int{
+		"/tools/pcf_list_projects": http.StatusOK,
+		"/tools/list_projects":     http.StatusNotFound,
+	} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, strings.NewReader("{}")))
+		if w.Code != expected {
+			t.Errorf("Expected status %d for %s, got %d", expected, path, w.Code)
+		}
+	}
+
+	// Tools are still unregistered by their registered name
+	if err := server.UnregisterTool("list_projects"); err != nil {
+		t.Fatalf("Failed to unregister tool: %v", err)
+	}
+	if _, err := server.ExecuteTool(context.Background(), "pcf_list_projects", map[string]interface{}{}); !errors.Is(err, ErrToolNotFound) {
+		t.Errorf("Expected the unregistered tool not to be found, got %v", err)
+	}
+
+	if _, err := NewServer(config.ServerConfig{Transport: "http", ToolNamePrefix: "pcf.v1"}); err == nil {
+		t.Error("Expected a prefix outside the tool name format to be rejected")
+	}
+}
+
+// TestExecuteToolTimeout tests that ToolTimeout bounds tool execution
+func TestExecuteToolTimeout(t *testing.T) {
+	cfg := config.ServerConfig{
+		Transport:   "stdio",
+		ToolTimeout: 50 * time.Millisecond,
+	}
+
+	server, err := NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	// Handler ignores its context and sleeps past the timeout
+	err = server.RegisterTool(Tool{
+		Name:        "slow_tool",
+		Description: "Tool that hangs",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			time.Sleep(time.Second)
+			return "too late", nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	// Handler returns an ordinary error before the timeout
+	err = server.RegisterTool(Tool{
+		Name:        "failing_tool",
+		Description: "Tool that fails",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return nil, errors.New("boom")
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	start := time.Now()
+	_, err = server.ExecuteTool(context.Background(), "slow_tool", map[string]interface{}{})
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, ErrToolTimeout) {
+		t.Fatalf("Expected ErrToolTimeout, got %v", err)
+	}
+
+	if elapsed > 500*time.Millisecond {
+		t.Errorf("Expected deadline to fire after ~50ms, took %s", elapsed)
+	}
+
+	_, err = server.ExecuteTool(context.Background(), "failing_tool", map[string]interface{}{})
+	if err == nil || errors.Is(err, ErrToolTimeout) {
+		t.Errorf("Expected ordinary error, got %v", err)
+	}
+
+	// The HTTP layer maps timeouts to 504
+	req := httptest.NewRequest(http.MethodPost, "/tools/slow_tool", strings.NewReader("{}"))
+	rec := httptest.NewRecorder()
+	server.HTTPHandler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusGatewayTimeout {
+		t.Errorf("Expected status 504, got %d", rec.Code)
+	}
+}
+
 // TestServerStart tests starting the server
 func TestServerStart(t *testing.T) {
 	// Test with stdio transport
@@ -367,3 +607,51 @@ func TestToolValidation(t *testing.T) {
 		})
 	}
 }
+
+// TestMaxConcurrentTools tests that tool executions beyond MaxConcurrentTools wait for a slot
+func TestMaxConcurrentTools(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{Transport: "stdio", MaxConcurrentTools: 1})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	err = server.RegisterTool(Tool{
+		Name:        "blocking",
+		Description: "Blocks until released",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			started <- struct{}{}
+			<-unblock
+			return "done", nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := server.ExecuteTool(context.Background(), "blocking", map[string]interface{}{})
+		done <- err
+	}()
+	<-started
+
+	// The only slot is taken, so a second call times out waiting
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := server.ExecuteTool(ctx, "blocking", map[string]interface{}{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline error while waiting for a slot, got %v", err)
+	}
+
+	close(unblock)
+	if err := <-done; err != nil {
+		t.Fatalf("First execution failed: %v", err)
+	}
+
+	// The slot is free again
+	go func() { <-started }()
+	if _, err := server.ExecuteTool(context.Background(), "blocking", map[string]interface{}{}); err != nil {
+		t.Errorf("Expected execution after slot was released, got %v", err)
+	}
+}
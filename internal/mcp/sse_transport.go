@@ -0,0 +1,86 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aRustyDev/pcf-mcp/internal/observability"
+)
+
+const (
+	// streamSuffix is the path suffix selecting streamed tool execution
+	streamSuffix = "/stream"
+
+	// contentTypeEventStream is the Server-Sent Events content type
+	contentTypeEventStream = "text/event-stream"
+)
+
+// handleToolStream executes a tool and streams its progress as Server-Sent Events.
+//
+// Tool parameters are passed as a URL-encoded JSON object in the "params" query
+// argument, which proxies and browsers may record; tracing records only the
+// path. Each progress event is sent as an "progress" event, followed by a
+// single "result" or "error" event once the tool completes.
+func (s *Server) handleToolStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeMethodNotAllowed(w)
+		return
+	}
+
+	// Extract tool name from path
+	name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/tools/"), streamSuffix)
+	if name == "" || strings.Contains(name, "/") {
+		s.writeError(w, http.StatusNotFound, CodeToolNotFound, "Tool not found")
+		return
+	}
+
+	// Parse parameters from the query string
+	params := map[string]interface{}{}
+	if raw := r.URL.Query().Get("params"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &params); err != nil {
+			s.writeError(w, http.StatusBadRequest, CodeValidation, fmt.Sprintf("Invalid params: %v", err))
+			return
+		}
+	}
+
+	// Reject unknown tools before committing to an event stream
+	if _, exists := s.lookupTool(name); !exists {
+		s.writeError(w, http.StatusNotFound, CodeToolNotFound, fmt.Sprintf("tool '%s' not found", name))
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		s.writeError(w, http.StatusInternalServerError, CodeInternal, "Streaming not supported")
+		return
+	}
+
+	w.Header().Set(headerContentType, contentTypeEventStream)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	send := func(event string, data interface{}) {
+		payload, err := json.Marshal(data)
+		if err != nil {
+			observability.FromContext(r.Context()).ErrorContext(r.Context(), "Failed to encode SSE event", "event", event, "error", err)
+			return
+		}
+		fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+		flusher.Flush()
+	}
+
+	result, err := s.ExecuteToolStreamWithMetrics(r.Context(), name, params, func(event ProgressEvent) {
+		send("progress", event)
+	})
+	if err != nil {
+		_, detail := classifyToolError(err)
+		send("error", ErrorResponse{Error: detail})
+		return
+	}
+
+	send("result", map[string]interface{}{"result": result})
+}
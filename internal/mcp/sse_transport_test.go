@@ -0,0 +1,169 @@
+package mcp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// TestToolStream tests streaming tool execution over Server-Sent Events
+func TestToolStream(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{Transport: "http"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	// Register a streaming tool that reports two progress events
+	err = server.RegisterTool(Tool{
+		Name:        "slow_tool",
+		Description: "A tool that reports progress",
+		StreamingHandler: func(ctx context.Context, params map[string]interface{}, progress chan<- ProgressEvent) (interface{}, error) {
+			progress <- ProgressEvent{"status": "in_progress", "step": 1}
+			progress <- ProgressEvent{"status": "in_progress", "step": 2}
+			return map[string]interface{}{"echo": params["message"]}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	// Register a plain tool to check it can also be streamed
+	err = server.RegisterTool(Tool{
+		Name:        "plain_tool",
+		Description: "A non-streaming tool",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return "done", nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	ts := httptest.NewServer(server.HTTPHandler())
+	defer ts.Close()
+
+	t.Run("streaming tool", func(t *testing.T) {
+		query := url.Values{"params": {`{"message":"hi"}`}}
+		resp, err := http.Get(ts.URL + "/tools/slow_tool/stream?" + query.Encode())
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+		}
+
+		if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+			t.Errorf("Expected Content-Type 'text/event-stream', got '%s'", ct)
+		}
+
+		body, _ := io.ReadAll(resp.Body)
+		stream := string(body)
+
+		if strings.Count(stream, "event: progress") != 2 {
+			t.Errorf("Expected 2 progress events, got stream: %s", stream)
+		}
+
+		if !strings.Contains(stream, `event: result`) || !strings.Contains(stream, `"echo":"hi"`) {
+			t.Errorf("Expected final result event, got stream: %s", stream)
+		}
+
+		if strings.Index(stream, "event: result") < strings.LastIndex(stream, "event: progress") {
+			t.Error("Result event should follow all progress events")
+		}
+	})
+
+	t.Run("plain tool", func(t *testing.T) {
+		resp, err := http.Get(ts.URL + "/tools/plain_tool/stream")
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		defer resp.Body.Close()
+
+		body, _ := io.ReadAll(resp.Body)
+		if !strings.Contains(string(body), `event: result`) {
+			t.Errorf("Expected result event, got: %s", body)
+		}
+	})
+
+	t.Run("unknown tool", func(t *testing.T) {
+		resp, err := http.Get(ts.URL + "/tools/missing/stream")
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("POST still executes streaming tool", func(t *testing.T) {
+		resp, err := http.Post(ts.URL+"/tools/slow_tool", "application/json", strings.NewReader(`{"message":"hi"}`))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", resp.StatusCode)
+		}
+	})
+}
+
+// TestToolStreamSpanOmitsParams tests that the request span of a streamed
+// tool records its path but not the params in the query string
+func TestToolStreamSpanOmitsParams(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	defer provider.Shutdown(context.Background())
+
+	prevProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(prevProvider)
+
+	server, err := NewServer(config.ServerConfig{Transport: "http"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	err = server.RegisterTool(Tool{
+		Name:        "add_credential",
+		Description: "Add a credential",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return "added", nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	const secret = "hunter2-secret"
+	query := url.Values{"params": {`{"value":"` + secret + `"}`}}
+	req := httptest.NewRequest(http.MethodGet, "/tools/add_credential/stream?"+query.Encode(), nil)
+	server.HTTPHandler().ServeHTTP(httptest.NewRecorder(), req)
+
+	var found bool
+	for _, span := range recorder.Ended() {
+		for _, attr := range span.Attributes() {
+			if strings.Contains(attr.Value.Emit(), secret) {
+				t.Errorf("Span %q leaks the params in %s", span.Name(), attr.Key)
+			}
+			if attr.Key == "http.target" && attr.Value.AsString() == "/tools/add_credential/stream" {
+				found = true
+			}
+		}
+	}
+	if !found {
+		t.Error("Expected a span recording the stream path")
+	}
+}
@@ -0,0 +1,430 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// JSON-RPC 2.0 error codes
+const (
+	jsonRPCParseError     = -32700
+	jsonRPCInvalidRequest = -32600
+	jsonRPCMethodNotFound = -32601
+	jsonRPCInvalidParams  = -32602
+	jsonRPCInternalError  = -32603
+
+	// jsonRPCServerShuttingDown is returned for requests received while the
+	// server is draining
+	jsonRPCServerShuttingDown = -32001
+
+	// jsonRPCForbidden is returned when the caller lacks the scope a method
+	// requires
+	jsonRPCForbidden = -32003
+)
+
+// mcpProtocolVersion is the MCP protocol revision implemented by the stdio transport
+const mcpProtocolVersion = "2024-11-05"
+
+// jsonRPCRequest represents an incoming JSON-RPC 2.0 request or notification
+type jsonRPCRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// jsonRPCResponse represents an outgoing JSON-RPC 2.0 response
+type jsonRPCResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *jsonRPCError   `json:"error,omitempty"`
+}
+
+// jsonRPCError represents a JSON-RPC 2.0 error object
+type jsonRPCError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// toolCallParams represents the params of a tools/call request
+type toolCallParams struct {
+	Name      string                 `json:"name"`
+	Arguments map[string]interface{} `json:"arguments"`
+}
+
+// promptGetParams are the params of a prompts/get request
+type promptGetParams struct {
+	Name      string            `json:"name"`
+	Arguments map[string]string `json:"arguments"`
+}
+
+// resourceReadParams are the params of a resources/read request
+type resourceReadParams struct {
+	URI string `json:"uri"`
+}
+
+// stdioSession holds the state of a single stdio connection
+type stdioSession struct {
+	server *Server
+
+	// out is the output stream
+	out io.Writer
+
+	// encoder writes newline-delimited responses to the output stream
+	encoder *json.Encoder
+
+	// writeMu serializes writes to the output stream
+	writeMu sync.Mutex
+}
+
+// ServeStdio serves newline-delimited JSON-RPC 2.0 requests read from in,
+// writing responses to out. Requests are handled one at a time in the order
+// they arrive. It returns nil when in reaches EOF, or once draining completes
+// after ctx is cancelled, waiting at most server.drain_timeout.
+func (s *Server) ServeStdio(ctx context.Context, in io.Reader, out io.Writer) error {
+	return s.serveStdio(ctx, in, out, s.drainTimeout())
+}
+
+// serveStdio implements ServeStdio. When ctx is cancelled the session starts
+// draining: the request in flight may run for up to drainTimeout, and every
+// request that has not started yet is answered with a shutdown error. EOF on
+// in means the client closed the pipe: requests already read still run, then
+// the session ends cleanly. Other read errors end it with an error.
+func (s *Server) serveStdio(ctx context.Context, in io.Reader, out io.Writer, drainTimeout time.Duration) error {
+	session := &stdioSession{
+		server:  s,
+		out:     out,
+		encoder: json.NewEncoder(out),
+	}
+
+	// Requests outlive ctx so the one in flight can finish while draining;
+	// execCancel aborts it once the drain deadline passes
+	execCtx, execCancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer execCancel()
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	// Read lines in a goroutine so cancellation is not blocked on stdin
+	lines := make(chan []byte)
+	readErr := make(chan error, 1)
+	go func() {
+		reader := bufio.NewReader(in)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if len(bytes.TrimSpace(line)) > 0 {
+				select {
+				case lines <- line:
+				case <-stop:
+					return
+				}
+			}
+			if err != nil {
+				readErr <- err
+				return
+			}
+		}
+	}()
+
+	var (
+		pending  [][]byte
+		busy     bool
+		finished = make(chan struct{})
+		ctxDone  = ctx.Done()
+		deadline <-chan time.Time
+		draining bool
+		inputErr error
+		inputEOF bool
+	)
+
+	for {
+		// Start the next queued request once the previous one has finished
+		if !busy && len(pending) > 0 {
+			line := pending[0]
+			pending = pending[1:]
+			busy = true
+			go func() {
+				session.handleLine(execCtx, line)
+				finished <- struct{}{}
+			}()
+		}
+
+		if !busy && (draining || inputEOF) {
+			switch {
+			case draining:
+				slog.InfoContext(ctx, "Stdio transport drained")
+			case inputErr != nil:
+				slog.ErrorContext(ctx, "Stdio transport stopped", "error", inputErr)
+			default:
+				slog.InfoContext(ctx, "Stdin closed, stopping stdio transport")
+			}
+			session.flush()
+			return inputErr
+		}
+
+		select {
+		case <-ctxDone:
+			slog.InfoContext(ctx, "Draining stdio transport", "in_flight", busy, "timeout", drainTimeout)
+			draining = true
+			ctxDone = nil
+			deadline = time.After(drainTimeout)
+			for _, line := range pending {
+				session.rejectLine(line)
+			}
+			pending = nil
+
+		case <-deadline:
+			slog.WarnContext(ctx, "Timeout draining stdio transport, cancelling in-flight request")
+			execCancel()
+			deadline = nil
+
+		case <-finished:
+			busy = false
+
+		case line := <-lines:
+			if draining {
+				session.rejectLine(line)
+				continue
+			}
+			pending = append(pending, line)
+
+		case err := <-readErr:
+			inputEOF = true
+			if !errors.Is(err, io.EOF) {
+				inputErr = fmt.Errorf("failed to read from stdin: %w", err)
+			}
+		}
+	}
+}
+
+// handleLine decodes and dispatches a single JSON-RPC message
+func (ss *stdioSession) handleLine(ctx context.Context, line []byte) {
+	if resp := ss.server.handleJSONRPC(ctx, line); resp != nil {
+		ss.write(*resp)
+	}
+}
+
+// rejectLine answers a request received while draining with a shutdown
+// error. Notifications and unparseable lines are dropped.
+func (ss *stdioSession) rejectLine(line []byte) {
+	var req jsonRPCRequest
+	if err := json.Unmarshal(line, &req); err != nil || len(req.ID) == 0 {
+		return
+	}
+	ss.write(*jsonRPCErrorResponse(req.ID, jsonRPCServerShuttingDown, "server shutting down", nil))
+}
+
+// handleJSONRPC decodes and dispatches a single JSON-RPC message, returning
+// the response to send or nil for notifications
+func (s *Server) handleJSONRPC(ctx context.Context, data []byte) *jsonRPCResponse {
+	var req jsonRPCRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return jsonRPCErrorResponse(nil, jsonRPCParseError, "Parse error", err.Error())
+	}
+
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return jsonRPCErrorResponse(req.ID, jsonRPCInvalidRequest, "Invalid request", nil)
+	}
+
+	result, rpcErr := s.dispatchJSONRPC(ctx, req)
+
+	// Notifications carry no ID and never receive a response
+	if len(req.ID) == 0 {
+		return nil
+	}
+
+	if rpcErr != nil {
+		return &jsonRPCResponse{JSONRPC: "2.0", ID: req.ID, Error: rpcErr}
+	}
+
+	return &jsonRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: result}
+}
+
+// dispatchJSONRPC routes a request to the matching MCP method
+func (s *Server) dispatchJSONRPC(ctx context.Context, req jsonRPCRequest) (interface{}, *jsonRPCError) {
+	switch req.Method {
+	case "initialize":
+		return s.initializeResult(), nil
+
+	case "notifications/initialized", "ping":
+		return map[string]interface{}{}, nil
+
+	case "tools/list":
+		return s.toolsListResult(), nil
+
+	case "tools/call":
+		var params toolCallParams
+		if err := json.Unmarshal(req.Params, &params); err != nil || params.Name == "" {
+			return nil, &jsonRPCError{Code: jsonRPCInvalidParams, Message: "Invalid params: tool name is required"}
+		}
+		return s.callToolResult(ctx, params)
+
+	case "prompts/list":
+		return s.promptsListResult(), nil
+
+	case "prompts/get":
+		var params promptGetParams
+		if err := json.Unmarshal(req.Params, &params); err != nil || params.Name == "" {
+			return nil, &jsonRPCError{Code: jsonRPCInvalidParams, Message: "Invalid params: prompt name is required"}
+		}
+		result, err := s.promptGetResult(params.Name, params.Arguments)
+		if err != nil {
+			return nil, &jsonRPCError{Code: jsonRPCInvalidParams, Message: err.Error()}
+		}
+		return result, nil
+
+	case "resources/list":
+		return s.resourcesListResult(false), nil
+
+	case "resources/templates/list":
+		return s.resourcesListResult(true), nil
+
+	case "resources/read":
+		var params resourceReadParams
+		if err := json.Unmarshal(req.Params, &params); err != nil || params.URI == "" {
+			return nil, &jsonRPCError{Code: jsonRPCInvalidParams, Message: "Invalid params: resource uri is required"}
+		}
+		contents, err := s.ReadResource(ctx, params.URI)
+		if errors.Is(err, ErrInsufficientScope) {
+			return nil, &jsonRPCError{Code: jsonRPCForbidden, Message: err.Error()}
+		}
+		if errors.Is(err, ErrResourceNotFound) {
+			return nil, &jsonRPCError{Code: jsonRPCInvalidParams, Message: err.Error()}
+		}
+		if err != nil {
+			return nil, &jsonRPCError{Code: jsonRPCInternalError, Message: err.Error()}
+		}
+		return map[string]interface{}{"contents": []*ResourceContents{contents}}, nil
+
+	default:
+		return nil, &jsonRPCError{Code: jsonRPCMethodNotFound, Message: fmt.Sprintf("Method not found: %s", req.Method)}
+	}
+}
+
+// jsonRPCErrorResponse builds a JSON-RPC error response, using a null ID when
+// the request ID is unknown
+func jsonRPCErrorResponse(id json.RawMessage, code int, message string, data interface{}) *jsonRPCResponse {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return &jsonRPCResponse{
+		JSONRPC: "2.0",
+		ID:      id,
+		Error:   &jsonRPCError{Code: code, Message: message, Data: data},
+	}
+}
+
+// write encodes a response as a single line on the output stream
+func (ss *stdioSession) write(resp jsonRPCResponse) {
+	ss.writeMu.Lock()
+	defer ss.writeMu.Unlock()
+
+	if err := ss.encoder.Encode(resp); err != nil {
+		slog.Error("Failed to write stdio response", "error", err)
+	}
+}
+
+// flush flushes the output stream if it buffers writes, so no response is
+// lost when the session ends
+func (ss *stdioSession) flush() {
+	ss.writeMu.Lock()
+	defer ss.writeMu.Unlock()
+
+	if f, ok := ss.out.(interface{ Flush() error }); ok {
+		if err := f.Flush(); err != nil {
+			slog.Error("Failed to flush stdio output", "error", err)
+		}
+	}
+}
+
+// initializeResult builds the response to the MCP initialize handshake
+func (s *Server) initializeResult() map[string]interface{} {
+	caps := s.Capabilities()
+	capabilities := map[string]interface{}{}
+	if caps.Tools {
+		capabilities["tools"] = map[string]interface{}{}
+	}
+	if caps.Resources {
+		capabilities["resources"] = map[string]interface{}{}
+	}
+	if caps.Prompts {
+		capabilities["prompts"] = map[string]interface{}{}
+	}
+
+	return map[string]interface{}{
+		"protocolVersion": mcpProtocolVersion,
+		"capabilities":    capabilities,
+		"serverInfo": map[string]interface{}{
+			"name":    s.Name(),
+			"version": s.Version(),
+		},
+	}
+}
+
+// toolsListResult builds the response to a tools/list request
+func (s *Server) toolsListResult() map[string]interface{} {
+	tools := s.ListTools()
+	toolList := make([]map[string]interface{}, 0, len(tools))
+
+	for _, tool := range tools {
+		toolInfo := map[string]interface{}{
+			"name":        tool.Name,
+			"description": tool.Description,
+		}
+		if tool.InputSchema != nil {
+			toolInfo["inputSchema"] = tool.InputSchema
+		} else {
+			toolInfo["inputSchema"] = map[string]interface{}{"type": "object"}
+		}
+		toolList = append(toolList, toolInfo)
+	}
+
+	return map[string]interface{}{
+		"tools": toolList,
+	}
+}
+
+// callToolResult executes a tool and wraps its output in an MCP tool result
+func (s *Server) callToolResult(ctx context.Context, params toolCallParams) (interface{}, *jsonRPCError) {
+	if _, exists := s.lookupTool(params.Name); !exists {
+		return nil, &jsonRPCError{Code: jsonRPCInvalidParams, Message: fmt.Sprintf("tool '%s' not found", params.Name)}
+	}
+
+	arguments := params.Arguments
+	if arguments == nil {
+		arguments = map[string]interface{}{}
+	}
+
+	// Tool failures are reported in the result so the model can see them
+	result, err := s.ExecuteToolWithMetrics(ctx, params.Name, arguments)
+	if err != nil {
+		return map[string]interface{}{
+			"content": []map[string]interface{}{
+				{"type": "text", "text": err.Error()},
+			},
+			"isError": true,
+		}, nil
+	}
+
+	text, err := json.Marshal(result)
+	if err != nil {
+		return nil, &jsonRPCError{Code: jsonRPCInternalError, Message: fmt.Sprintf("failed to encode tool result: %v", err)}
+	}
+
+	return map[string]interface{}{
+		"content": []map[string]interface{}{
+			{"type": "text", "text": string(text)},
+		},
+		"isError": false,
+	}, nil
+}
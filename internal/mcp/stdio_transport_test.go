@@ -0,0 +1,455 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+)
+
+// newStdioTestServer creates a stdio server with a fake list_projects tool
+func newStdioTestServer(t *testing.T) *Server {
+	t.Helper()
+
+	server, err := NewServer(config.ServerConfig{Transport: "stdio"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	err = server.RegisterTool(Tool{
+		Name:        "list_projects",
+		Description: "List projects",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return map[string]interface{}{
+				"projects":    []string{"proj-1"},
+				"total_count": 1,
+			}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	err = server.RegisterTool(Tool{
+		Name:        "failing_tool",
+		Description: "Always fails",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return nil, errors.New("boom")
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	return server
+}
+
+// runStdio feeds the given lines to the stdio transport and returns decoded responses
+func runStdio(t *testing.T, server *Server, lines ...string) []map[string]interface{} {
+	t.Helper()
+
+	in := strings.NewReader(strings.Join(lines, "\n") + "\n")
+	var out bytes.Buffer
+
+	if err := server.ServeStdio(context.Background(), in, &out); err != nil {
+		t.Fatalf("ServeStdio returned error: %v", err)
+	}
+
+	var responses []map[string]interface{}
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		var resp map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			t.Fatalf("Invalid JSON response line %q: %v", scanner.Text(), err)
+		}
+		responses = append(responses, resp)
+	}
+
+	return responses
+}
+
+// TestStdioToolsCall tests that tools/call produces a valid JSON-RPC result envelope
+func TestStdioToolsCall(t *testing.T) {
+	server := newStdioTestServer(t)
+
+	responses := runStdio(t, server,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"list_projects","arguments":{}}}`,
+	)
+
+	if len(responses) != 1 {
+		t.Fatalf("Expected 1 response, got %d", len(responses))
+	}
+
+	resp := responses[0]
+	if resp["jsonrpc"] != "2.0" {
+		t.Errorf("Expected jsonrpc '2.0', got %v", resp["jsonrpc"])
+	}
+
+	if resp["id"] != float64(1) {
+		t.Errorf("Expected id 1, got %v", resp["id"])
+	}
+
+	if _, ok := resp["error"]; ok {
+		t.Fatalf("Unexpected error in response: %v", resp["error"])
+	}
+
+	result, ok := resp["result"].(map[string]interface{})
+	if !ok {
+		t.Fatal("Response should contain a result object")
+	}
+
+	if result["isError"] != false {
+		t.Errorf("Expected isError false, got %v", result["isError"])
+	}
+
+	content, ok := result["content"].([]interface{})
+	if !ok || len(content) != 1 {
+		t.Fatalf("Expected 1 content item, got %v", result["content"])
+	}
+
+	item := content[0].(map[string]interface{})
+	if item["type"] != "text" {
+		t.Errorf("Expected content type 'text', got %v", item["type"])
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal([]byte(item["text"].(string)), &payload); err != nil {
+		t.Fatalf("Tool result text should be JSON: %v", err)
+	}
+
+	if payload["total_count"] != float64(1) {
+		t.Errorf("Expected total_count 1, got %v", payload["total_count"])
+	}
+}
+
+// TestStdioProtocol tests the initialize handshake, tools/list, and error handling
+func TestStdioProtocol(t *testing.T) {
+	server := newStdioTestServer(t)
+
+	responses := runStdio(t, server,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"failing_tool"}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"missing"}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"unknown/method"}`,
+		`not json`,
+	)
+
+	// The notification must not produce a response
+	if len(responses) != 6 {
+		t.Fatalf("Expected 6 responses, got %d", len(responses))
+	}
+
+	// initialize
+	initResult := responses[0]["result"].(map[string]interface{})
+	serverInfo := initResult["serverInfo"].(map[string]interface{})
+	if serverInfo["name"] != "pcf-mcp" {
+		t.Errorf("Expected server name 'pcf-mcp', got %v", serverInfo["name"])
+	}
+	capabilities := initResult["capabilities"].(map[string]interface{})
+	if _, ok := capabilities["tools"]; !ok {
+		t.Error("Capabilities should advertise tools")
+	}
+
+	// tools/list
+	listResult := responses[1]["result"].(map[string]interface{})
+	if tools := listResult["tools"].([]interface{}); len(tools) != 2 {
+		t.Errorf("Expected 2 tools, got %d", len(tools))
+	}
+
+	// Handler errors are reported in the tool result
+	callResult := responses[2]["result"].(map[string]interface{})
+	if callResult["isError"] != true {
+		t.Errorf("Expected isError true, got %v", callResult["isError"])
+	}
+
+	// Protocol errors are reported as JSON-RPC errors
+	expectedCodes := []float64{jsonRPCInvalidParams, jsonRPCMethodNotFound, jsonRPCParseError}
+	for i, code := range expectedCodes {
+		rpcErr, ok := responses[3+i]["error"].(map[string]interface{})
+		if !ok {
+			t.Errorf("Response %d should contain an error", 3+i)
+			continue
+		}
+		if rpcErr["code"] != code {
+			t.Errorf("Expected error code %v, got %v", code, rpcErr["code"])
+		}
+	}
+}
+
+// TestStdioPrompts tests prompts/list and prompts/get
+func TestStdioPrompts(t *testing.T) {
+	server := newPromptServer(t)
+
+	responses := runStdio(t, server,
+		`{"jsonrpc":"2.0","id":1,"method":"prompts/list"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"prompts/get","params":{"name":"greet_project","arguments":{"project_id":"proj-1"}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"prompts/get","params":{"name":"greet_project"}}`,
+	)
+
+	if len(responses) != 3 {
+		t.Fatalf("Expected 3 responses, got %d", len(responses))
+	}
+
+	listResult := responses[0]["result"].(map[string]interface{})
+	if prompts := listResult["prompts"].([]interface{}); len(prompts) != 1 {
+		t.Errorf("Expected 1 prompt, got %d", len(prompts))
+	}
+
+	getResult := responses[1]["result"].(map[string]interface{})
+	message := getResult["messages"].([]interface{})[0].(map[string]interface{})
+	if text := message["content"].(map[string]interface{})["text"]; text != "Hello proj-1" {
+		t.Errorf("Expected rendered text 'Hello proj-1', got %v", text)
+	}
+
+	rpcErr, ok := responses[2]["error"].(map[string]interface{})
+	if !ok || rpcErr["code"] != float64(jsonRPCInvalidParams) {
+		t.Errorf("Expected invalid params error for missing argument, got %v", responses[2])
+	}
+}
+
+// TestStdioDrain tests that shutdown finishes the in-flight request and
+// rejects requests received while draining
+func TestStdioDrain(t *testing.T) {
+	server := newStdioTestServer(t)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	err := server.RegisterTool(Tool{
+		Name:        "slow_tool",
+		Description: "Blocks until released",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			close(started)
+			select {
+			case <-release:
+				return "done", nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	defer inW.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.serveStdio(ctx, inR, outW, 5*time.Second)
+		outW.Close()
+	}()
+
+	send := func(msg string) {
+		if _, err := io.WriteString(inW, msg+"\n"); err != nil {
+			t.Fatalf("Failed to write request: %v", err)
+		}
+	}
+
+	scanner := bufio.NewScanner(outR)
+	receive := func() map[string]interface{} {
+		if !scanner.Scan() {
+			t.Fatalf("Expected a response line: %v", scanner.Err())
+		}
+		var resp map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			t.Fatalf("Invalid JSON response line %q: %v", scanner.Text(), err)
+		}
+		return resp
+	}
+
+	send(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"slow_tool"}}`)
+	<-started
+
+	// Begin draining, then inject a request into the draining window
+	cancel()
+	send(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"list_projects"}}`)
+
+	rejected := receive()
+	if rejected["id"] != float64(2) {
+		t.Fatalf("Expected shutdown error for id 2 first, got %v", rejected)
+	}
+	rpcErr, ok := rejected["error"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected an error response, got %v", rejected)
+	}
+	if rpcErr["code"] != float64(jsonRPCServerShuttingDown) || rpcErr["message"] != "server shutting down" {
+		t.Errorf("Expected -32001 server shutting down, got %v", rpcErr)
+	}
+
+	// The in-flight request still completes
+	close(release)
+	completed := receive()
+	if completed["id"] != float64(1) || completed["error"] != nil {
+		t.Fatalf("Expected result for id 1, got %v", completed)
+	}
+	if result := completed["result"].(map[string]interface{}); result["isError"] != false {
+		t.Errorf("Expected in-flight call to succeed, got %v", result)
+	}
+
+	select {
+	case err := <-serveErr:
+		if err != nil {
+			t.Errorf("serveStdio returned error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("serveStdio did not return after draining")
+	}
+}
+
+// TestStdioDrainTimeout tests that the in-flight request is cancelled once
+// the configured drain deadline passes
+func TestStdioDrainTimeout(t *testing.T) {
+	server := newStdioTestServer(t)
+	server.config.DrainTimeout = 50 * time.Millisecond
+
+	started := make(chan struct{})
+	err := server.RegisterTool(Tool{
+		Name:        "stuck_tool",
+		Description: "Blocks until cancelled",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	inR, inW := io.Pipe()
+	defer inW.Close()
+	var out bytes.Buffer
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.ServeStdio(ctx, inR, &out)
+	}()
+
+	if _, err := io.WriteString(inW, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"stuck_tool"}}`+"\n"); err != nil {
+		t.Fatalf("Failed to write request: %v", err)
+	}
+	<-started
+	cancel()
+
+	select {
+	case err := <-serveErr:
+		if err != nil {
+			t.Errorf("serveStdio returned error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("serveStdio did not return after the drain timeout")
+	}
+
+	var resp map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &resp); err != nil {
+		t.Fatalf("Invalid JSON response %q: %v", out.String(), err)
+	}
+	if result := resp["result"].(map[string]interface{}); result["isError"] != true {
+		t.Errorf("Expected cancelled call to report an error, got %v", result)
+	}
+}
+
+// TestStdioStartEOF tests that Start returns cleanly once the client closes
+// stdin mid-session
+func TestStdioStartEOF(t *testing.T) {
+	server := newStdioTestServer(t)
+
+	inR, inW, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create stdin pipe: %v", err)
+	}
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create stdout pipe: %v", err)
+	}
+	defer inR.Close()
+	defer outR.Close()
+
+	stdin, stdout := os.Stdin, os.Stdout
+	os.Stdin, os.Stdout = inR, outW
+	defer func() { os.Stdin, os.Stdout = stdin, stdout }()
+
+	startErr := make(chan error, 1)
+	go func() {
+		startErr <- server.Start(context.Background())
+		outW.Close()
+	}()
+
+	if _, err := io.WriteString(inW, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"list_projects"}}`+"\n"); err != nil {
+		t.Fatalf("Failed to write request: %v", err)
+	}
+
+	scanner := bufio.NewScanner(outR)
+	if !scanner.Scan() {
+		t.Fatalf("Expected a response line: %v", scanner.Err())
+	}
+	var resp map[string]interface{}
+	if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil || resp["id"] != float64(1) {
+		t.Fatalf("Expected a response for id 1, got %q: %v", scanner.Text(), err)
+	}
+
+	// The client goes away
+	inW.Close()
+
+	select {
+	case err := <-startErr:
+		if err != nil {
+			t.Errorf("Expected Start to return nil on EOF, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Start did not return after stdin was closed")
+	}
+}
+
+// TestStdioReadError tests that read errors other than EOF are returned and
+// that buffered output is flushed before the session ends
+func TestStdioReadError(t *testing.T) {
+	server := newStdioTestServer(t)
+
+	inR, inW := io.Pipe()
+	var out bytes.Buffer
+	buffered := bufio.NewWriter(&out)
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.ServeStdio(context.Background(), inR, buffered)
+	}()
+
+	if _, err := io.WriteString(inW, `{"jsonrpc":"2.0","id":1,"method":"ping"}`+"\n"); err != nil {
+		t.Fatalf("Failed to write request: %v", err)
+	}
+
+	readErr := errors.New("stdin broken")
+	inW.CloseWithError(readErr)
+
+	select {
+	case err := <-serveErr:
+		if !errors.Is(err, readErr) {
+			t.Errorf("Expected the read error to be returned, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ServeStdio did not return after the read error")
+	}
+
+	if !strings.Contains(out.String(), `"id":1`) {
+		t.Errorf("Expected the buffered response to be flushed, got %q", out.String())
+	}
+}
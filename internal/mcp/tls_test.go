@@ -0,0 +1,162 @@
+package mcp
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+)
+
+// writeSelfSignedCert generates a self-signed certificate for 127.0.0.1 and
+// returns the paths of the PEM cert and key files
+func writeSelfSignedCert(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "pcf-mcp-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatalf("Failed to write cert: %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+
+	return certFile, keyFile
+}
+
+// freePort returns a TCP port that is currently free on 127.0.0.1
+func freePort(t *testing.T) int {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to find free port: %v", err)
+	}
+	defer ln.Close()
+
+	return ln.Addr().(*net.TCPAddr).Port
+}
+
+// TestStartHTTPWithTLS tests that the HTTP transport serves HTTPS when configured
+func TestStartHTTPWithTLS(t *testing.T) {
+	certFile, keyFile := writeSelfSignedCert(t)
+	port := freePort(t)
+
+	server, err := NewServer(config.ServerConfig{
+		Transport:     "http",
+		Host:          "127.0.0.1",
+		Port:          port,
+		ReadTimeout:   5 * time.Second,
+		WriteTimeout:  5 * time.Second,
+		TLSCertFile:   certFile,
+		TLSKeyFile:    keyFile,
+		TLSMinVersion: "1.2",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.StartHTTP(ctx)
+	}()
+
+	client := &http.Client{
+		Timeout: time.Second,
+		Transport: &http.Transport{
+			// Self-signed certificate
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+
+	// Poll until the server is listening
+	url := fmt.Sprintf("https://127.0.0.1:%d/health", port)
+	var resp *http.Response
+	for i := 0; i < 50; i++ {
+		resp, err = client.Get(url)
+		if err == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("HTTPS request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	if resp.TLS == nil || resp.TLS.Version < tls.VersionTLS12 {
+		t.Error("Expected response over TLS 1.2 or newer")
+	}
+
+	cancel()
+	if err := <-errCh; err != nil {
+		t.Errorf("Server stopped with error: %v", err)
+	}
+}
+
+// TestTLSConfigValidation tests that partial TLS configuration is rejected
+func TestTLSConfigValidation(t *testing.T) {
+	_, err := NewServer(config.ServerConfig{
+		Transport:   "http",
+		TLSCertFile: "/tmp/tls.crt",
+	})
+	if err == nil {
+		t.Error("Expected error when only TLS cert file is set")
+	}
+
+	_, err = NewServer(config.ServerConfig{
+		Transport:     "http",
+		TLSCertFile:   "/tmp/tls.crt",
+		TLSKeyFile:    "/tmp/tls.key",
+		TLSMinVersion: "1.1",
+	})
+	if err == nil {
+		t.Error("Expected error for unsupported TLS min version")
+	}
+}
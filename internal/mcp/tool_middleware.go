@@ -0,0 +1,47 @@
+package mcp
+
+import "context"
+
+// ToolMiddleware wraps tool execution to add cross-cutting behavior such as
+// auditing or parameter normalization. It may inspect or replace the params,
+// short-circuit the call without invoking next, or post-process the result.
+type ToolMiddleware func(next ToolHandler) ToolHandler
+
+// toolNameKey is the context key for the name of the executing tool
+type toolNameKey struct{}
+
+// withToolName returns a context recording the name of the executing tool
+func withToolName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, toolNameKey{}, name)
+}
+
+// ToolNameFromContext returns the name of the tool being executed, or "" when
+// ctx does not belong to a tool execution. Middleware uses it to tell tools
+// apart.
+func ToolNameFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(toolNameKey{}).(string)
+	return name
+}
+
+// Use adds middleware around every tool execution, through ExecuteTool and
+// every transport. Middleware runs in the order it was added, the first
+// outermost, after the caller's scope is checked and before the params are
+// validated.
+func (s *Server) Use(mw ToolMiddleware) {
+	s.toolsMutex.Lock()
+	defer s.toolsMutex.Unlock()
+
+	s.toolMiddleware = append(s.toolMiddleware, mw)
+}
+
+// toolChain wraps handler in the registered middleware
+func (s *Server) toolChain(handler ToolHandler) ToolHandler {
+	s.toolsMutex.RLock()
+	middleware := s.toolMiddleware
+	s.toolsMutex.RUnlock()
+
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+	return handler
+}
@@ -0,0 +1,127 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+)
+
+// TestToolMiddleware tests that middleware wraps tool calls in the order it
+// was added and may rewrite params before validation
+func TestToolMiddleware(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{Transport: "stdio", ValidateToolInput: true})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	var received map[string]interface{}
+	err = server.RegisterTool(Tool{
+		Name:        "get_project",
+		Description: "Get a project",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"project_id": map[string]interface{}{"type": "string", "minLength": 1},
+			},
+			"required": []string{"project_id"},
+		},
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			received = params
+			return "project " + params["project_id"].(string), nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	var calls []string
+	record := func(label string) ToolMiddleware {
+		return func(next ToolHandler) ToolHandler {
+			return func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+				calls = append(calls, label+" before "+ToolNameFromContext(ctx))
+				result, err := next(ctx, params)
+				calls = append(calls, label+" after")
+				return result, err
+			}
+		}
+	}
+
+	server.Use(record("outer"))
+	server.Use(func(next ToolHandler) ToolHandler {
+		return func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			// Trim the ID, filling in a default when it is missing
+			normalized := map[string]interface{}{"project_id": "proj-1"}
+			if id, ok := params["project_id"].(string); ok {
+				normalized["project_id"] = strings.TrimSpace(id)
+			}
+			return next(ctx, normalized)
+		}
+	})
+	server.Use(record("inner"))
+
+	result, err := server.ExecuteTool(context.Background(), "get_project", map[string]interface{}{"project_id": "  proj-2  "})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != "project proj-2" || received["project_id"] != "proj-2" {
+		t.Errorf("Expected the handler to receive the normalized params, got %v and result %v", received, result)
+	}
+
+	expected := []string{"outer before get_project", "inner before get_project", "inner after", "outer after"}
+	if strings.Join(calls, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("Expected calls %v, got %v", expected, calls)
+	}
+
+	// Params missing the required project_id pass validation once rewritten
+	if result, err := server.ExecuteTool(context.Background(), "get_project", map[string]interface{}{}); err != nil || result != "project proj-1" {
+		t.Errorf("Expected validation to see the rewritten params, got %v, %v", result, err)
+	}
+
+	if name := ToolNameFromContext(context.Background()); name != "" {
+		t.Errorf("Expected no tool name outside a tool execution, got %q", name)
+	}
+}
+
+// TestToolMiddlewareShortCircuit tests that middleware may answer a call
+// without running the tool
+func TestToolMiddlewareShortCircuit(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{Transport: "stdio"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	ran := false
+	err = server.RegisterTool(Tool{
+		Name:        "delete_project",
+		Description: "Delete a project",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			ran = true
+			return "deleted", nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	server.Use(func(next ToolHandler) ToolHandler {
+		return func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			if params["dry_run"] == true {
+				return map[string]interface{}{"dry_run": true, "tool": ToolNameFromContext(ctx)}, nil
+			}
+			return next(ctx, params)
+		}
+	})
+
+	result, err := server.ExecuteTool(context.Background(), "delete_project", map[string]interface{}{"dry_run": true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ran {
+		t.Error("Expected the tool not to run")
+	}
+	if res, ok := result.(map[string]interface{}); !ok || res["tool"] != "delete_project" {
+		t.Errorf("Expected the middleware's result, got %v", result)
+	}
+}
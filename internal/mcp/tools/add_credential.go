@@ -6,6 +6,7 @@ import (
 
 	"github.com/aRustyDev/pcf-mcp/internal/mcp"
 	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf/redact"
 )
 
 // AddCredentialClient defines the interface for adding credentials
@@ -13,11 +14,16 @@ type AddCredentialClient interface {
 	AddCredential(ctx context.Context, projectID string, req pcf.AddCredentialRequest) (*pcf.Credential, error)
 }
 
-// NewAddCredentialTool creates an MCP tool for adding credentials to a PCF project
-func NewAddCredentialTool(client AddCredentialClient) mcp.Tool {
+// NewAddCredentialTool creates an MCP tool for adding credentials to a PCF
+// project. The stored credential is returned masked by redactor; nil masks
+// the default fields.
+func NewAddCredentialTool(client AddCredentialClient, redactor *redact.Redactor) mcp.Tool {
 	return mcp.Tool{
-		Name:        "add_credential",
-		Description: "Add a new credential to a PCF project",
+		Name:          "add_credential",
+		Description:   "Add a new credential to a PCF project",
+		Category:      categoryCredentials,
+		Tags:          []string{categoryCredentials, tagWrite},
+		RequiredScope: mcp.ScopeWrite,
 		InputSchema: map[string]interface{}{
 			"type": "object",
 			"properties": map[string]interface{}{
@@ -56,27 +62,28 @@ func NewAddCredentialTool(client AddCredentialClient) mcp.Tool {
 			"required":             []string{"project_id", "type", "username", "value"},
 			"additionalProperties": false,
 		},
-		Handler: createAddCredentialHandler(client),
+		Handler: createAddCredentialHandler(client, redactor),
 	}
 }
 
 // createAddCredentialHandler creates the handler function for adding credentials
-func createAddCredentialHandler(client AddCredentialClient) mcp.ToolHandler {
+func createAddCredentialHandler(client AddCredentialClient, redactor *redact.Redactor) mcp.ToolHandler {
+
 	return func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
 		// Extract and validate project_id
 		projectID, ok := params["project_id"].(string)
 		if !ok {
-			return nil, fmt.Errorf("project_id parameter must be a string")
+			return nil, invalidParam("project_id", "must be a string")
 		}
 
 		if projectID == "" {
-			return nil, fmt.Errorf("project_id cannot be empty")
+			return nil, invalidParam("project_id", "cannot be empty")
 		}
 
 		// Extract and validate type
 		credType, ok := params["type"].(string)
 		if !ok {
-			return nil, fmt.Errorf("type parameter must be a string")
+			return nil, invalidParam("type", "must be a string")
 		}
 
 		// Validate credential type
@@ -89,27 +96,27 @@ func createAddCredentialHandler(client AddCredentialClient) mcp.ToolHandler {
 		}
 
 		if !validTypes[credType] {
-			return nil, fmt.Errorf("invalid credential type: %s. Must be one of: password, hash, key, token, certificate", credType)
+			return nil, invalidParam("type", "must be one of password, hash, key, token, certificate, got %q", credType)
 		}
 
 		// Extract and validate username
 		username, ok := params["username"].(string)
 		if !ok {
-			return nil, fmt.Errorf("username parameter must be a string")
+			return nil, invalidParam("username", "must be a string")
 		}
 
 		if username == "" {
-			return nil, fmt.Errorf("username cannot be empty")
+			return nil, invalidParam("username", "cannot be empty")
 		}
 
 		// Extract and validate value
 		value, ok := params["value"].(string)
 		if !ok {
-			return nil, fmt.Errorf("value parameter must be a string")
+			return nil, invalidParam("value", "must be a string")
 		}
 
 		if value == "" {
-			return nil, fmt.Errorf("credential value cannot be empty")
+			return nil, invalidParam("value", "cannot be empty")
 		}
 
 		// Create request
@@ -140,31 +147,12 @@ func createAddCredentialHandler(client AddCredentialClient) mcp.ToolHandler {
 			return nil, fmt.Errorf("failed to add credential: %w", err)
 		}
 
-		// Build response - always redact the credential value
-		credMap := map[string]interface{}{
-			"id":         credential.ID,
-			"project_id": credential.ProjectID,
-			"type":       credential.Type,
-			"username":   credential.Username,
-			"value":      "***REDACTED***", // Never expose the actual value
-		}
-
-		// Add optional fields if present
-		if credential.HostID != "" {
-			credMap["host_id"] = credential.HostID
-		}
-
-		if credential.Service != "" {
-			credMap["service"] = credential.Service
-		}
-
-		if credential.Notes != "" {
-			credMap["notes"] = credential.Notes
-		}
+		// Build response with sensitive fields redacted
+		credMap := formatCredential(*credential, redactor)
 
 		response := map[string]interface{}{
 			"credential": credMap,
-			"message":    fmt.Sprintf("Credential for user '%s' added successfully to project %s", credential.Username, projectID),
+			"message":    fmt.Sprintf("Credential for user '%v' added successfully to project %s", credMap["username"], projectID),
 		}
 
 		return response, nil
@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/aRustyDev/pcf-mcp/internal/pcf"
@@ -25,7 +26,7 @@ func (m *MockAddCredentialClient) AddCredential(ctx context.Context, projectID s
 func TestNewAddCredentialTool(t *testing.T) {
 	mockClient := &MockAddCredentialClient{}
 
-	tool := NewAddCredentialTool(mockClient)
+	tool := NewAddCredentialTool(mockClient, nil)
 
 	if tool.Name != "add_credential" {
 		t.Errorf("Expected tool name 'add_credential', got '%s'", tool.Name)
@@ -316,7 +317,7 @@ func TestAddCredentialHandler(t *testing.T) {
 			}
 
 			// Create tool
-			tool := NewAddCredentialTool(mockClient)
+			tool := NewAddCredentialTool(mockClient, nil)
 
 			// Execute handler
 			ctx := context.Background()
@@ -374,3 +375,58 @@ func TestAddCredentialHandler(t *testing.T) {
 		})
 	}
 }
+
+// TestAddCredentialValidationErrors tests that rejected parameters are
+// reported as ValidationErrors naming the field
+func TestAddCredentialValidationErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+		field  string
+		reason string
+	}{
+		{
+			name:   "Empty project_id",
+			params: map[string]interface{}{"project_id": "", "type": "password", "username": "admin", "value": "secret"},
+			field:  "project_id",
+			reason: "cannot be empty",
+		},
+		{
+			name:   "Unknown type",
+			params: map[string]interface{}{"project_id": "proj-123", "type": "cookie", "username": "admin", "value": "secret"},
+			field:  "type",
+			reason: "must be one of",
+		},
+		{
+			name:   "Missing username",
+			params: map[string]interface{}{"project_id": "proj-123", "type": "password", "value": "secret"},
+			field:  "username",
+			reason: "must be a string",
+		},
+		{
+			name:   "Empty value",
+			params: map[string]interface{}{"project_id": "proj-123", "type": "password", "username": "admin", "value": ""},
+			field:  "value",
+			reason: "cannot be empty",
+		},
+	}
+
+	tool := NewAddCredentialTool(&MockAddCredentialClient{}, nil)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tool.Handler(context.Background(), tt.params)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected a ValidationError, got %v", err)
+			}
+			if validationErr.Field != tt.field {
+				t.Errorf("Expected field %q, got %q", tt.field, validationErr.Field)
+			}
+			if !strings.Contains(validationErr.Reason, tt.reason) {
+				t.Errorf("Expected reason containing %q, got %q", tt.reason, validationErr.Reason)
+			}
+		})
+	}
+}
@@ -3,10 +3,11 @@ package tools
 import (
 	"context"
 	"fmt"
-	"net"
+	"strings"
 
 	"github.com/aRustyDev/pcf-mcp/internal/mcp"
 	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf/netutil"
 )
 
 // AddHostClient defines the interface for adding hosts
@@ -17,8 +18,11 @@ type AddHostClient interface {
 // NewAddHostTool creates an MCP tool for adding hosts to a PCF project
 func NewAddHostTool(client AddHostClient) mcp.Tool {
 	return mcp.Tool{
-		Name:        "add_host",
-		Description: "Add a new host to a PCF project",
+		Name:          "add_host",
+		Description:   "Add a new host to a PCF project",
+		Category:      categoryHosts,
+		Tags:          []string{categoryHosts, tagWrite},
+		RequiredScope: mcp.ScopeWrite,
 		InputSchema: map[string]interface{}{
 			"type": "object",
 			"properties": map[string]interface{}{
@@ -38,13 +42,8 @@ func NewAddHostTool(client AddHostClient) mcp.Tool {
 					"type":        "string",
 					"description": "The operating system of the host (optional)",
 				},
-				"services": map[string]interface{}{
-					"type":        "array",
-					"description": "List of services running on the host (optional)",
-					"items": map[string]interface{}{
-						"type": "string",
-					},
-				},
+				"services": servicesSchema(),
+				"tags":     hostTagsSchema(),
 			},
 			"required":             []string{"project_id", "ip"},
 			"additionalProperties": false,
@@ -66,59 +65,9 @@ func createAddHostHandler(client AddHostClient) mcp.ToolHandler {
 			return nil, fmt.Errorf("project_id cannot be empty")
 		}
 
-		// Extract and validate IP address
-		ip, ok := params["ip"].(string)
-		if !ok {
-			return nil, fmt.Errorf("ip parameter must be a string")
-		}
-
-		if ip == "" {
-			return nil, fmt.Errorf("ip address cannot be empty")
-		}
-
-		// Validate IP address format
-		if net.ParseIP(ip) == nil {
-			return nil, fmt.Errorf("invalid IP address format: %s", ip)
-		}
-
-		// Create request
-		req := pcf.CreateHostRequest{
-			IP: ip,
-		}
-
-		// Extract optional hostname
-		if hostname, ok := params["hostname"].(string); ok && hostname != "" {
-			req.Hostname = hostname
-		}
-
-		// Extract optional OS
-		if os, ok := params["os"].(string); ok && os != "" {
-			req.OS = os
-		}
-
-		// Extract optional notes
-		// Note: CreateHostRequest doesn't have a Notes field, so we'll ignore it
-
-		// Extract optional services
-		if servicesRaw, ok := params["services"]; ok {
-			// Handle different types that might come from JSON
-			switch services := servicesRaw.(type) {
-			case []string:
-				req.Services = services
-			case []interface{}:
-				// Convert []interface{} to []string
-				serviceList := make([]string, 0, len(services))
-				for _, service := range services {
-					if serviceStr, ok := service.(string); ok {
-						serviceList = append(serviceList, serviceStr)
-					} else {
-						return nil, fmt.Errorf("services must be strings")
-					}
-				}
-				req.Services = serviceList
-			default:
-				return nil, fmt.Errorf("services parameter must be an array of strings")
-			}
+		req, err := parseCreateHostRequest(params)
+		if err != nil {
+			return nil, err
 		}
 
 		// Call PCF client to add host
@@ -144,9 +93,8 @@ func createAddHostHandler(client AddHostClient) mcp.ToolHandler {
 			hostMap["os"] = host.OS
 		}
 
-		if len(host.Services) > 0 {
-			hostMap["services"] = host.Services
-		}
+		addServices(hostMap, host)
+		addHostTags(hostMap, host)
 
 		response := map[string]interface{}{
 			"host":    hostMap,
@@ -156,3 +104,252 @@ func createAddHostHandler(client AddHostClient) mcp.ToolHandler {
 		return response, nil
 	}
 }
+
+// parseCreateHostRequest extracts and validates host fields from tool parameters
+func parseCreateHostRequest(params map[string]interface{}) (pcf.CreateHostRequest, error) {
+	// Extract and validate IP address
+	ip, ok := params["ip"].(string)
+	if !ok {
+		return pcf.CreateHostRequest{}, fmt.Errorf("ip parameter must be a string")
+	}
+
+	// Validate the address and store it in canonical form, so the same
+	// host is not added twice under different spellings
+	ip, err := netutil.NormalizeIP(ip)
+	if err != nil {
+		return pcf.CreateHostRequest{}, err
+	}
+
+	// Create request
+	req := pcf.CreateHostRequest{
+		IP: ip,
+	}
+
+	// Extract optional hostname
+	if hostname, ok := params["hostname"].(string); ok && hostname != "" {
+		if !netutil.IsValidHost(hostname) {
+			return pcf.CreateHostRequest{}, fmt.Errorf("invalid hostname: %s", hostname)
+		}
+		req.Hostname = hostname
+	}
+
+	// Extract optional OS
+	if os, ok := params["os"].(string); ok && os != "" {
+		req.OS = os
+	}
+
+	// Extract optional services, given as names or structured objects
+	if servicesRaw, ok := params["services"]; ok {
+		names, details, err := parseServices(servicesRaw)
+		if err != nil {
+			return pcf.CreateHostRequest{}, err
+		}
+		req.Services = names
+		req.ServiceDetails = details
+	}
+
+	// Extract optional tags
+	if tagsRaw, ok := params["tags"]; ok {
+		tags, err := parseHostTags(tagsRaw)
+		if err != nil {
+			return pcf.CreateHostRequest{}, err
+		}
+		req.Tags = tags
+	}
+
+	return req, nil
+}
+
+// hostTagsSchema describes the tags parameter of tools adding hosts
+func hostTagsSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "array",
+		"description": "Labels grouping the host, e.g. dmz or domain-controller (optional)",
+		"items": map[string]interface{}{
+			"type":      "string",
+			"minLength": 1,
+		},
+	}
+}
+
+// parseHostTags converts a tags parameter into a list of trimmed tags,
+// dropping repeats of the same tag in any case
+func parseHostTags(raw interface{}) ([]string, error) {
+	var items []interface{}
+	switch tags := raw.(type) {
+	case []string:
+		items = make([]interface{}, len(tags))
+		for i, tag := range tags {
+			items[i] = tag
+		}
+	case []interface{}:
+		items = tags
+	default:
+		return nil, invalidParam("tags", "must be an array of strings")
+	}
+
+	tags := make([]string, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		tag, ok := item.(string)
+		if !ok {
+			return nil, invalidParam("tags", "must be an array of strings")
+		}
+
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			return nil, invalidParam("tags", "cannot contain empty tags")
+		}
+
+		if key := strings.ToLower(tag); !seen[key] {
+			seen[key] = true
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags, nil
+}
+
+// servicesSchema describes the services parameter: service names such as
+// "ssh", structured services with a port such as {"name": "ssh", "port":
+// 2222}, or a mix of both
+func servicesSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "array",
+		"description": "Services running on the host, as names or {name, port, protocol} objects (optional)",
+		"items": map[string]interface{}{
+			"anyOf": []interface{}{
+				map[string]interface{}{
+					"type": "string",
+				},
+				map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"name": map[string]interface{}{
+							"type":        "string",
+							"description": "The service name, e.g. ssh",
+						},
+						"port": map[string]interface{}{
+							"type":        "integer",
+							"description": "The port the service listens on",
+							"minimum":     1,
+							"maximum":     65535,
+						},
+						"protocol": map[string]interface{}{
+							"type":        "string",
+							"description": "The transport protocol (default tcp)",
+							"enum":        []string{"tcp", "udp", "sctp"},
+						},
+					},
+					"required":             []string{"port"},
+					"additionalProperties": false,
+				},
+			},
+		},
+	}
+}
+
+// parseServices splits a services parameter into service names and
+// structured services. Names of structured services are also included in
+// the name list so clients that only read services still see them.
+func parseServices(raw interface{}) ([]string, []pcf.Service, error) {
+	// Handle different types that might come from JSON
+	var items []interface{}
+	switch services := raw.(type) {
+	case []string:
+		return services, nil, nil
+	case []interface{}:
+		items = services
+	default:
+		return nil, nil, fmt.Errorf("services parameter must be an array of strings or service objects")
+	}
+
+	names := make([]string, 0, len(items))
+	var details []pcf.Service
+	seen := make(map[string]bool)
+
+	addName := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	for _, item := range items {
+		switch service := item.(type) {
+		case string:
+			addName(service)
+		case map[string]interface{}:
+			detail, err := parseService(service)
+			if err != nil {
+				return nil, nil, err
+			}
+			details = append(details, detail)
+			addName(detail.Name)
+		default:
+			return nil, nil, fmt.Errorf("services must be strings or service objects")
+		}
+	}
+
+	return names, details, nil
+}
+
+// parseService validates a structured service object
+func parseService(service map[string]interface{}) (pcf.Service, error) {
+	// Handle both float64 and int types
+	var port int
+	switch v := service["port"].(type) {
+	case float64:
+		if v != float64(int(v)) {
+			return pcf.Service{}, fmt.Errorf("service port must be an integer")
+		}
+		port = int(v)
+	case int:
+		port = v
+	default:
+		return pcf.Service{}, fmt.Errorf("service port must be an integer")
+	}
+
+	if port < 1 || port > 65535 {
+		return pcf.Service{}, fmt.Errorf("service port must be between 1 and 65535, got %d", port)
+	}
+
+	detail := pcf.Service{Port: port, Protocol: "tcp"}
+
+	if name, ok := service["name"]; ok {
+		nameStr, ok := name.(string)
+		if !ok {
+			return pcf.Service{}, fmt.Errorf("service name must be a string")
+		}
+		detail.Name = nameStr
+	}
+
+	if protocol, ok := service["protocol"]; ok {
+		protocolStr, ok := protocol.(string)
+		if !ok || (protocolStr != "tcp" && protocolStr != "udp" && protocolStr != "sctp") {
+			return pcf.Service{}, fmt.Errorf("service protocol must be one of tcp, udp, sctp")
+		}
+		detail.Protocol = protocolStr
+	}
+
+	return detail, nil
+}
+
+// addServices adds a host's service names and structured services to a
+// tool response
+func addServices(hostMap map[string]interface{}, host *pcf.Host) {
+	if len(host.Services) > 0 {
+		hostMap["services"] = host.Services
+	}
+
+	if len(host.ServiceDetails) > 0 {
+		hostMap["service_details"] = host.ServiceDetails
+	}
+}
+
+// addHostTags adds a host's tags to a tool response
+func addHostTags(hostMap map[string]interface{}, host *pcf.Host) {
+	if len(host.Tags) > 0 {
+		hostMap["tags"] = host.Tags
+	}
+}
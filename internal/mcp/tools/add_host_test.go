@@ -2,9 +2,14 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
 	"github.com/aRustyDev/pcf-mcp/internal/pcf"
 )
 
@@ -155,6 +160,47 @@ func TestAddHostHandler(t *testing.T) {
 			mockError:    nil,
 			expectError:  true,
 		},
+		{
+			name: "CIDR range instead of an address",
+			params: map[string]interface{}{
+				"project_id": "proj-123",
+				"ip":         "10.0.0.0/24",
+			},
+			expectedReq:  pcf.CreateHostRequest{},
+			mockResponse: nil,
+			mockError:    nil,
+			expectError:  true,
+		},
+		{
+			name: "Invalid hostname",
+			params: map[string]interface{}{
+				"project_id": "proj-123",
+				"ip":         "192.168.1.100",
+				"hostname":   "web_01..corp",
+			},
+			expectedReq:  pcf.CreateHostRequest{},
+			mockResponse: nil,
+			mockError:    nil,
+			expectError:  true,
+		},
+		{
+			name: "IPv6 address is normalized",
+			params: map[string]interface{}{
+				"project_id": "proj-123",
+				"ip":         "2001:0DB8:0:0:0:0:0:1",
+			},
+			expectedReq: pcf.CreateHostRequest{
+				IP: "2001:db8::1",
+			},
+			mockResponse: &pcf.Host{
+				ID:        "host-v6",
+				ProjectID: "proj-123",
+				IP:        "2001:db8::1",
+				Status:    "active",
+			},
+			mockError:   nil,
+			expectError: false,
+		},
 		{
 			name: "Invalid project_id type",
 			params: map[string]interface{}{
@@ -305,3 +351,151 @@ func TestAddHostHandler(t *testing.T) {
 		})
 	}
 }
+
+// TestAddHostServiceDetails tests services given as names, structured objects, or both
+func TestAddHostServiceDetails(t *testing.T) {
+	tests := []struct {
+		name        string
+		services    interface{}
+		wantNames   []string
+		wantDetails []pcf.Service
+		wantErr     string
+	}{
+		{
+			name:      "Names only",
+			services:  []interface{}{"ssh", "http"},
+			wantNames: []string{"ssh", "http"},
+		},
+		{
+			name:        "Structured",
+			services:    []interface{}{map[string]interface{}{"name": "ssh", "port": float64(2222)}, map[string]interface{}{"port": float64(53), "protocol": "udp"}},
+			wantNames:   []string{"ssh"},
+			wantDetails: []pcf.Service{{Name: "ssh", Port: 2222, Protocol: "tcp"}, {Port: 53, Protocol: "udp"}},
+		},
+		{
+			name:        "Mixed",
+			services:    []interface{}{"http", map[string]interface{}{"name": "ssh", "port": 2222}, "ssh"},
+			wantNames:   []string{"http", "ssh"},
+			wantDetails: []pcf.Service{{Name: "ssh", Port: 2222, Protocol: "tcp"}},
+		},
+		{name: "Port out of range", services: []interface{}{map[string]interface{}{"port": float64(0)}}, wantErr: "between 1 and 65535"},
+		{name: "Fractional port", services: []interface{}{map[string]interface{}{"port": 22.5}}, wantErr: "port must be an integer"},
+		{name: "Missing port", services: []interface{}{map[string]interface{}{"name": "ssh"}}, wantErr: "port must be an integer"},
+		{name: "Unknown protocol", services: []interface{}{map[string]interface{}{"port": float64(22), "protocol": "icmp"}}, wantErr: "protocol must be one of"},
+		{name: "Invalid item", services: []interface{}{float64(22)}, wantErr: "services must be strings or service objects"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got pcf.CreateHostRequest
+			client := &MockAddHostClient{
+				AddHostFunc: func(ctx context.Context, projectID string, req pcf.CreateHostRequest) (*pcf.Host, error) {
+					got = req
+					return &pcf.Host{ID: "host-1", ProjectID: projectID, IP: req.IP, Services: req.Services, ServiceDetails: req.ServiceDetails}, nil
+				},
+			}
+
+			result, err := NewAddHostTool(client).Handler(context.Background(), map[string]interface{}{
+				"project_id": "proj-1",
+				"ip":         "10.0.0.5",
+				"services":   tt.services,
+			})
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got.Services, tt.wantNames) || !reflect.DeepEqual(got.ServiceDetails, tt.wantDetails) {
+				t.Errorf("Expected services %v and details %v, got %v and %v", tt.wantNames, tt.wantDetails, got.Services, got.ServiceDetails)
+			}
+
+			// The structured form round-trips through the JSON response
+			encoded, err := json.Marshal(result.(map[string]interface{})["host"])
+			if err != nil {
+				t.Fatalf("Failed to encode host: %v", err)
+			}
+			var host pcf.Host
+			if err := json.Unmarshal(encoded, &host); err != nil {
+				t.Fatalf("Failed to decode host: %v", err)
+			}
+			if !reflect.DeepEqual(host.ServiceDetails, tt.wantDetails) {
+				t.Errorf("Expected round-tripped details %v, got %v", tt.wantDetails, host.ServiceDetails)
+			}
+		})
+	}
+}
+
+// TestAddHostServicesSchema tests that both service shapes pass input validation
+func TestAddHostServicesSchema(t *testing.T) {
+	server, err := mcp.NewServer(config.ServerConfig{Transport: "stdio", ValidateToolInput: true})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	client := &MockAddHostClient{
+		AddHostFunc: func(ctx context.Context, projectID string, req pcf.CreateHostRequest) (*pcf.Host, error) {
+			return &pcf.Host{ID: "host-1", ProjectID: projectID, IP: req.IP}, nil
+		},
+	}
+	if err := server.RegisterTool(NewAddHostTool(client)); err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	params := map[string]interface{}{
+		"project_id": "proj-1",
+		"ip":         "10.0.0.5",
+		"services":   []interface{}{"http", map[string]interface{}{"name": "ssh", "port": float64(2222), "protocol": "tcp"}},
+	}
+	if _, err := server.ExecuteTool(context.Background(), "add_host", params); err != nil {
+		t.Errorf("Expected mixed services to validate, got %v", err)
+	}
+
+	params["services"] = []interface{}{map[string]interface{}{"port": float64(70000)}}
+	var inputErr *mcp.InputValidationError
+	if _, err := server.ExecuteTool(context.Background(), "add_host", params); !errors.As(err, &inputErr) {
+		t.Errorf("Expected input validation error for out-of-range port, got %v", err)
+	}
+}
+
+// TestAddHostTags tests that tags are trimmed, deduplicated, and returned
+func TestAddHostTags(t *testing.T) {
+	var got pcf.CreateHostRequest
+	client := &MockAddHostClient{
+		AddHostFunc: func(ctx context.Context, projectID string, req pcf.CreateHostRequest) (*pcf.Host, error) {
+			got = req
+			return &pcf.Host{ID: "host-1", ProjectID: projectID, IP: req.IP, Tags: req.Tags}, nil
+		},
+	}
+	tool := NewAddHostTool(client)
+
+	result, err := tool.Handler(context.Background(), map[string]interface{}{
+		"project_id": "proj-1",
+		"ip":         "10.0.0.5",
+		"tags":       []interface{}{"dmz", " domain-controller ", "DMZ"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"dmz", "domain-controller"}
+	if !reflect.DeepEqual(got.Tags, expected) {
+		t.Errorf("Expected tags %v sent to PCF, got %v", expected, got.Tags)
+	}
+	if tags := result.(map[string]interface{})["host"].(map[string]interface{})["tags"]; !reflect.DeepEqual(tags, expected) {
+		t.Errorf("Expected tags %v in the response, got %v", expected, tags)
+	}
+
+	for _, tags := range []interface{}{"dmz", []interface{}{"dmz", ""}, []interface{}{float64(1)}} {
+		_, err := tool.Handler(context.Background(), map[string]interface{}{"project_id": "proj-1", "ip": "10.0.0.5", "tags": tags})
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != "tags" {
+			t.Errorf("Expected a tags validation error for %v, got %v", tags, err)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// maxBulkHosts caps the number of hosts accepted in a single add_hosts call
+const maxBulkHosts = 500
+
+// AddHostsClient defines the interface for adding hosts in bulk
+type AddHostsClient interface {
+	AddHosts(ctx context.Context, projectID string, reqs []pcf.CreateHostRequest) ([]pcf.Host, error)
+}
+
+// NewAddHostsTool creates an MCP tool for adding many hosts to a PCF project in one call
+func NewAddHostsTool(client AddHostsClient) mcp.Tool {
+	return mcp.Tool{
+		Name:          "add_hosts",
+		Description:   "Add multiple hosts to a PCF project in one call, reporting success or failure per host",
+		Category:      categoryHosts,
+		Tags:          []string{categoryHosts, tagWrite},
+		RequiredScope: mcp.ScopeWrite,
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"project_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the project to add the hosts to",
+				},
+				"hosts": map[string]interface{}{
+					"type":        "array",
+					"description": "The hosts to add",
+					"minItems":    1,
+					"maxItems":    maxBulkHosts,
+					"items": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"ip": map[string]interface{}{
+								"type":        "string",
+								"description": "The IP address of the host",
+							},
+							"hostname": map[string]interface{}{
+								"type":        "string",
+								"description": "The hostname (optional)",
+							},
+							"os": map[string]interface{}{
+								"type":        "string",
+								"description": "The operating system of the host (optional)",
+							},
+							"services": servicesSchema(),
+							"tags":     hostTagsSchema(),
+						},
+						"required":             []string{"ip"},
+						"additionalProperties": false,
+					},
+				},
+			},
+			"required":             []string{"project_id", "hosts"},
+			"additionalProperties": false,
+		},
+		Handler: createAddHostsHandler(client),
+	}
+}
+
+// createAddHostsHandler creates the handler function for adding hosts in bulk
+func createAddHostsHandler(client AddHostsClient) mcp.ToolHandler {
+	return func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+		// Extract and validate project_id
+		projectID, ok := params["project_id"].(string)
+		if !ok {
+			return nil, fmt.Errorf("project_id parameter must be a string")
+		}
+
+		if projectID == "" {
+			return nil, fmt.Errorf("project_id cannot be empty")
+		}
+
+		// Extract and validate hosts
+		var items []map[string]interface{}
+		switch hosts := params["hosts"].(type) {
+		case []map[string]interface{}:
+			items = hosts
+		case []interface{}:
+			items = make([]map[string]interface{}, 0, len(hosts))
+			for i, host := range hosts {
+				hostMap, ok := host.(map[string]interface{})
+				if !ok {
+					return nil, fmt.Errorf("hosts[%d] must be an object", i)
+				}
+				items = append(items, hostMap)
+			}
+		default:
+			return nil, fmt.Errorf("hosts parameter must be an array of objects")
+		}
+
+		if len(items) == 0 {
+			return nil, fmt.Errorf("hosts cannot be empty")
+		}
+
+		if len(items) > maxBulkHosts {
+			return nil, fmt.Errorf("too many hosts: %d (maximum %d)", len(items), maxBulkHosts)
+		}
+
+		// Invalid items are reported individually instead of failing the batch
+		results := make([]map[string]interface{}, len(items))
+		reqs := make([]pcf.CreateHostRequest, 0, len(items))
+		reqIndex := make([]int, 0, len(items))
+
+		for i, item := range items {
+			req, err := parseCreateHostRequest(item)
+			if err != nil {
+				results[i] = bulkFailure(i, item["ip"], err)
+				continue
+			}
+			reqs = append(reqs, req)
+			reqIndex = append(reqIndex, i)
+		}
+
+		if len(reqs) > 0 {
+			hosts, err := client.AddHosts(ctx, projectID, reqs)
+
+			var bulkErr *pcf.BulkError
+			if err != nil && !errors.As(err, &bulkErr) {
+				return nil, fmt.Errorf("failed to add hosts: %w", err)
+			}
+
+			if len(hosts) != len(reqs) {
+				return nil, fmt.Errorf("failed to add hosts: expected %d results, got %d", len(reqs), len(hosts))
+			}
+
+			failed := make(map[int]error)
+			if bulkErr != nil {
+				for _, f := range bulkErr.Failures {
+					failed[f.Index] = f.Err
+				}
+			}
+
+			for j, req := range reqs {
+				i := reqIndex[j]
+				if err, ok := failed[j]; ok {
+					results[i] = bulkFailure(i, req.IP, err)
+					continue
+				}
+
+				host := hosts[j]
+				hostMap := map[string]interface{}{
+					"id":         host.ID,
+					"project_id": host.ProjectID,
+					"ip":         host.IP,
+				}
+
+				if host.Hostname != "" {
+					hostMap["hostname"] = host.Hostname
+				}
+
+				addServices(hostMap, &host)
+				addHostTags(hostMap, &host)
+
+				results[i] = map[string]interface{}{
+					"index":   i,
+					"ip":      req.IP,
+					"success": true,
+					"host":    hostMap,
+				}
+			}
+		}
+
+		createdCount := 0
+		for _, result := range results {
+			if result["success"] == true {
+				createdCount++
+			}
+		}
+		failedCount := len(results) - createdCount
+
+		// Build response
+		response := map[string]interface{}{
+			"project_id":    projectID,
+			"results":       results,
+			"total_count":   len(results),
+			"created_count": createdCount,
+			"failed_count":  failedCount,
+			"message":       fmt.Sprintf("Added %d of %d hosts to project %s", createdCount, len(results), projectID),
+		}
+
+		return response, nil
+	}
+}
+
+// bulkFailure builds the result entry for a host that could not be added
+func bulkFailure(index int, ip interface{}, err error) map[string]interface{} {
+	result := map[string]interface{}{
+		"index":   index,
+		"success": false,
+		"error":   err.Error(),
+	}
+
+	if ip != nil {
+		result["ip"] = ip
+	}
+
+	return result
+}
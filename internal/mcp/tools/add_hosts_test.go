@@ -0,0 +1,170 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// MockAddHostsClient implements AddHostsClient for testing
+type MockAddHostsClient struct {
+	AddHostsFunc func(ctx context.Context, projectID string, reqs []pcf.CreateHostRequest) ([]pcf.Host, error)
+}
+
+func (m *MockAddHostsClient) AddHosts(ctx context.Context, projectID string, reqs []pcf.CreateHostRequest) ([]pcf.Host, error) {
+	if m.AddHostsFunc != nil {
+		return m.AddHostsFunc(ctx, projectID, reqs)
+	}
+	return nil, errors.New("AddHostsFunc not implemented")
+}
+
+// TestNewAddHostsTool tests creating a new bulk add hosts tool
+func TestNewAddHostsTool(t *testing.T) {
+	tool := NewAddHostsTool(&MockAddHostsClient{})
+
+	if tool.Name != "add_hosts" {
+		t.Errorf("Expected tool name 'add_hosts', got '%s'", tool.Name)
+	}
+
+	if tool.Description == "" {
+		t.Error("Tool description should not be empty")
+	}
+
+	if tool.Handler == nil {
+		t.Error("Tool handler should not be nil")
+	}
+
+	props, ok := tool.InputSchema["properties"].(map[string]interface{})
+	if !ok {
+		t.Fatal("Input schema should have properties")
+	}
+
+	if _, ok := props["hosts"]; !ok {
+		t.Error("Input schema missing 'hosts' property")
+	}
+}
+
+// TestAddHostsHandler tests per-item results from the bulk add hosts handler
+func TestAddHostsHandler(t *testing.T) {
+	var received []pcf.CreateHostRequest
+
+	client := &MockAddHostsClient{
+		AddHostsFunc: func(ctx context.Context, projectID string, reqs []pcf.CreateHostRequest) ([]pcf.Host, error) {
+			received = reqs
+			hosts := make([]pcf.Host, len(reqs))
+			var bulkErr pcf.BulkError
+			for i, req := range reqs {
+				if req.IP == "10.0.0.2" {
+					bulkErr.Failures = append(bulkErr.Failures, pcf.BulkItemError{Index: i, Err: errors.New("host already exists")})
+					continue
+				}
+				hosts[i] = pcf.Host{ID: "host-" + req.IP, ProjectID: projectID, IP: req.IP, Hostname: req.Hostname}
+			}
+			bulkErr.Total = len(reqs)
+			return hosts, &bulkErr
+		},
+	}
+
+	tool := NewAddHostsTool(client)
+
+	result, err := tool.Handler(context.Background(), map[string]interface{}{
+		"project_id": "proj-123",
+		"hosts": []interface{}{
+			map[string]interface{}{"ip": "10.0.0.1", "hostname": "web01"},
+			map[string]interface{}{"ip": "not-an-ip"},
+			map[string]interface{}{"ip": "10.0.0.2"},
+			map[string]interface{}{"ip": "10.0.0.3", "services": []interface{}{"ssh"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The invalid item never reaches PCF
+	if len(received) != 3 {
+		t.Fatalf("Expected 3 hosts sent to PCF, got %d", len(received))
+	}
+
+	res := result.(map[string]interface{})
+
+	if res["created_count"] != 2 {
+		t.Errorf("Expected created_count 2, got %v", res["created_count"])
+	}
+
+	if res["failed_count"] != 2 {
+		t.Errorf("Expected failed_count 2, got %v", res["failed_count"])
+	}
+
+	results := res["results"].([]map[string]interface{})
+	if len(results) != 4 {
+		t.Fatalf("Expected 4 results, got %d", len(results))
+	}
+
+	expectSuccess := []bool{true, false, false, true}
+	for i, want := range expectSuccess {
+		if results[i]["index"] != i {
+			t.Errorf("Result %d has index %v", i, results[i]["index"])
+		}
+		if results[i]["success"] != want {
+			t.Errorf("Result %d: expected success=%v, got %v", i, want, results[i]["success"])
+		}
+	}
+
+	host := results[0]["host"].(map[string]interface{})
+	if host["id"] != "host-10.0.0.1" || host["hostname"] != "web01" {
+		t.Errorf("Unexpected host for result 0: %v", host)
+	}
+
+	if results[2]["error"] != "host already exists" {
+		t.Errorf("Expected duplicate error for result 2, got %v", results[2]["error"])
+	}
+}
+
+// TestAddHostsHandlerErrors tests validation and client errors for the bulk add hosts handler
+func TestAddHostsHandlerErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		params    map[string]interface{}
+		clientErr error
+	}{
+		{
+			name:   "Missing project_id",
+			params: map[string]interface{}{"hosts": []interface{}{map[string]interface{}{"ip": "10.0.0.1"}}},
+		},
+		{
+			name:   "Missing hosts",
+			params: map[string]interface{}{"project_id": "proj-123"},
+		},
+		{
+			name:   "Empty hosts",
+			params: map[string]interface{}{"project_id": "proj-123", "hosts": []interface{}{}},
+		},
+		{
+			name:   "Host is not an object",
+			params: map[string]interface{}{"project_id": "proj-123", "hosts": []interface{}{"10.0.0.1"}},
+		},
+		{
+			name:      "Whole request fails",
+			params:    map[string]interface{}{"project_id": "proj-123", "hosts": []interface{}{map[string]interface{}{"ip": "10.0.0.1"}}},
+			clientErr: errors.New("connection refused"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &MockAddHostsClient{
+				AddHostsFunc: func(ctx context.Context, projectID string, reqs []pcf.CreateHostRequest) ([]pcf.Host, error) {
+					return nil, tt.clientErr
+				},
+			}
+
+			tool := NewAddHostsTool(client)
+
+			if _, err := tool.Handler(context.Background(), tt.params); err == nil {
+				t.Error("Expected error but got none")
+			}
+		})
+	}
+}
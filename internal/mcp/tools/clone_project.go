@@ -0,0 +1,243 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// CloneProjectClient defines the interface for cloning projects
+type CloneProjectClient interface {
+	CreateProjectClient
+	ListHostsClient
+	AddHostsClient
+	ListIssuesClient
+	CreateIssueClient
+	GetProject(ctx context.Context, projectID string) (*pcf.Project, error)
+}
+
+// NewCloneProjectTool creates an MCP tool that starts a new PCF project from
+// the hosts, and optionally the issues, of an existing one
+func NewCloneProjectTool(client CloneProjectClient) mcp.Tool {
+	return mcp.Tool{
+		Name:          "clone_project",
+		Description:   "Create a new PCF project copying the hosts, and optionally the issues, of an existing project. Credentials are never copied.",
+		Category:      categoryProjects,
+		Tags:          []string{categoryProjects, tagWrite},
+		RequiredScope: mcp.ScopeWrite,
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"project_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the project to copy from",
+				},
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "The name of the new project",
+					"minLength":   1,
+					"maxLength":   100,
+				},
+				"description": map[string]interface{}{
+					"type":        "string",
+					"description": "A description of the new project (default: the source project's)",
+					"maxLength":   500,
+				},
+				"include_issues": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Also copy the source project's issues, linked to the copied hosts (default false)",
+				},
+			},
+			"required":             []string{"project_id", "name"},
+			"additionalProperties": false,
+		},
+		Handler: createCloneProjectHandler(client),
+	}
+}
+
+// createCloneProjectHandler creates the handler function for cloning projects
+func createCloneProjectHandler(client CloneProjectClient) mcp.ToolHandler {
+	return func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+		// Extract and validate project_id
+		sourceID, ok := params["project_id"].(string)
+		if !ok {
+			return nil, invalidParam("project_id", "must be a string")
+		}
+
+		if sourceID == "" {
+			return nil, invalidParam("project_id", "cannot be empty")
+		}
+
+		// Extract and validate name
+		name, ok := params["name"].(string)
+		if !ok {
+			return nil, invalidParam("name", "must be a string")
+		}
+
+		if name == "" {
+			return nil, invalidParam("name", "cannot be empty")
+		}
+
+		includeIssues := false
+		if include, ok := params["include_issues"].(bool); ok {
+			includeIssues = include
+		}
+
+		source, err := client.GetProject(ctx, sourceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get source project: %w", err)
+		}
+
+		// Read everything to copy before creating the project, so a source
+		// that cannot be read leaves nothing behind
+		hosts, err := client.ListHosts(ctx, sourceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list source hosts: %w", err)
+		}
+
+		var issues []pcf.Issue
+		if includeIssues {
+			issues, err = client.ListIssues(ctx, sourceID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list source issues: %w", err)
+			}
+		}
+
+		req := pcf.CreateProjectRequest{
+			Name:        name,
+			Description: source.Description,
+			Team:        source.Team,
+		}
+		if desc, ok := params["description"].(string); ok {
+			req.Description = desc
+		}
+
+		project, err := client.CreateProject(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create project: %w", err)
+		}
+
+		// Hosts and issues that fail to copy are reported individually; the
+		// new project is kept with everything that was copied
+		var failures []map[string]interface{}
+
+		newHostIDs, hostFailures, err := cloneHosts(ctx, client, project.ID, hosts)
+		if err != nil {
+			return nil, fmt.Errorf("created project %s but failed to copy hosts: %w", project.ID, err)
+		}
+		failures = append(failures, hostFailures...)
+
+		issuesCopied := 0
+		for _, issue := range issues {
+			issueReq := pcf.CreateIssueRequest{
+				Title:       issue.Title,
+				Description: issue.Description,
+				Severity:    issue.Severity,
+				CVE:         issue.CVE,
+				CVSS:        issue.CVSS,
+			}
+
+			if issue.HostID != "" {
+				hostID, ok := newHostIDs[issue.HostID]
+				if !ok {
+					failures = append(failures, cloneFailure("issue", issue.ID, fmt.Errorf("its host %s was not copied", issue.HostID)))
+					continue
+				}
+				issueReq.HostID = hostID
+			}
+
+			if _, err := client.CreateIssue(ctx, project.ID, issueReq); err != nil {
+				failures = append(failures, cloneFailure("issue", issue.ID, fmt.Errorf("failed to create issue: %w", err)))
+				continue
+			}
+			issuesCopied++
+		}
+
+		if failures == nil {
+			failures = []map[string]interface{}{}
+		}
+
+		response := map[string]interface{}{
+			"project": map[string]interface{}{
+				"id":          project.ID,
+				"name":        project.Name,
+				"description": project.Description,
+				"status":      project.Status,
+			},
+			"source_project_id": sourceID,
+			"hosts_copied":      len(newHostIDs),
+			"hosts_failed":      len(hosts) - len(newHostIDs),
+			"issues_copied":     issuesCopied,
+			"issues_failed":     len(issues) - issuesCopied,
+			"failures":          failures,
+			"message": fmt.Sprintf("Project '%s' created from %s with %d of %d hosts and %d of %d issues",
+				project.Name, sourceID, len(newHostIDs), len(hosts), issuesCopied, len(issues)),
+		}
+
+		return response, nil
+	}
+}
+
+// cloneHosts adds copies of hosts to the project, returning the new host ID
+// for each copied source host ID and a failure entry for each host that was
+// not copied. An error means no result was available for any host.
+func cloneHosts(ctx context.Context, client AddHostsClient, projectID string, hosts []pcf.Host) (map[string]string, []map[string]interface{}, error) {
+	newIDs := make(map[string]string, len(hosts))
+	if len(hosts) == 0 {
+		return newIDs, nil, nil
+	}
+
+	reqs := make([]pcf.CreateHostRequest, len(hosts))
+	for i, host := range hosts {
+		reqs[i] = pcf.CreateHostRequest{
+			IP:             host.IP,
+			Hostname:       host.Hostname,
+			OS:             host.OS,
+			Services:       host.Services,
+			ServiceDetails: host.ServiceDetails,
+			Tags:           host.Tags,
+		}
+	}
+
+	added, err := client.AddHosts(ctx, projectID, reqs)
+
+	var bulkErr *pcf.BulkError
+	if err != nil && !errors.As(err, &bulkErr) {
+		return nil, nil, err
+	}
+
+	if len(added) != len(hosts) {
+		return nil, nil, fmt.Errorf("expected %d results, got %d", len(hosts), len(added))
+	}
+
+	failed := make(map[int]error)
+	if bulkErr != nil {
+		for _, f := range bulkErr.Failures {
+			failed[f.Index] = f.Err
+		}
+	}
+
+	var failures []map[string]interface{}
+	for i, host := range hosts {
+		if err, ok := failed[i]; ok {
+			failures = append(failures, cloneFailure("host", host.ID, err))
+			continue
+		}
+		newIDs[host.ID] = added[i].ID
+	}
+
+	return newIDs, failures, nil
+}
+
+// cloneFailure builds the failure entry for a host or issue that could not
+// be copied
+func cloneFailure(kind, sourceID string, err error) map[string]interface{} {
+	return map[string]interface{}{
+		"type":      kind,
+		"source_id": sourceID,
+		"error":     err.Error(),
+	}
+}
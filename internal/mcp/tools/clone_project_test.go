@@ -0,0 +1,285 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// newCloneMockClient returns a mock holding a source project with two hosts,
+// two issues, and a credential, recording what is created in the clone
+func newCloneMockClient(t *testing.T) (*MockFullPCFClient, *[]pcf.CreateHostRequest, *[]pcf.CreateIssueRequest) {
+	t.Helper()
+
+	var hostReqs []pcf.CreateHostRequest
+	var issueReqs []pcf.CreateIssueRequest
+
+	client := &MockFullPCFClient{
+		GetProjectFunc: func(ctx context.Context, projectID string) (*pcf.Project, error) {
+			if projectID != "proj-src" {
+				return nil, pcf.ErrNotFound
+			}
+			return &pcf.Project{ID: projectID, Name: "Source", Description: "Source engagement", Team: []string{"alice"}}, nil
+		},
+		CreateProjectFunc: func(ctx context.Context, req pcf.CreateProjectRequest) (*pcf.Project, error) {
+			return &pcf.Project{ID: "proj-new", Name: req.Name, Description: req.Description, Team: req.Team, Status: "active"}, nil
+		},
+		ListHostsFunc: func(ctx context.Context, projectID string) ([]pcf.Host, error) {
+			return []pcf.Host{
+				{ID: "host-1", ProjectID: projectID, IP: "10.0.0.1", Hostname: "web", OS: "Linux", Services: []string{"http"}, Tags: []string{"dmz"}},
+				{ID: "host-2", ProjectID: projectID, IP: "10.0.0.2", Hostname: "db"},
+			}, nil
+		},
+		AddHostsFunc: func(ctx context.Context, projectID string, reqs []pcf.CreateHostRequest) ([]pcf.Host, error) {
+			if projectID != "proj-new" {
+				t.Errorf("Expected hosts to be added to proj-new, got %s", projectID)
+			}
+			hostReqs = append(hostReqs, reqs...)
+			hosts := make([]pcf.Host, len(reqs))
+			for i, req := range reqs {
+				hosts[i] = pcf.Host{ID: fmt.Sprintf("new-host-%d", i+1), ProjectID: projectID, IP: req.IP}
+			}
+			return hosts, nil
+		},
+		ListIssuesFunc: func(ctx context.Context, projectID string) ([]pcf.Issue, error) {
+			return []pcf.Issue{
+				{ID: "issue-1", ProjectID: projectID, HostID: "host-2", Title: "Weak password", Severity: "High"},
+				{ID: "issue-2", ProjectID: projectID, Title: "Missing policy", Severity: "Low"},
+			}, nil
+		},
+		CreateIssueFunc: func(ctx context.Context, projectID string, req pcf.CreateIssueRequest) (*pcf.Issue, error) {
+			issueReqs = append(issueReqs, req)
+			return &pcf.Issue{ID: fmt.Sprintf("new-issue-%d", len(issueReqs)), ProjectID: projectID, HostID: req.HostID, Title: req.Title}, nil
+		},
+		ListCredentialsFunc: func(ctx context.Context, projectID string) ([]pcf.Credential, error) {
+			t.Error("Credentials should never be read when cloning")
+			return nil, nil
+		},
+		AddCredentialFunc: func(ctx context.Context, projectID string, req pcf.AddCredentialRequest) (*pcf.Credential, error) {
+			t.Error("Credentials should never be copied when cloning")
+			return nil, nil
+		},
+	}
+
+	return client, &hostReqs, &issueReqs
+}
+
+// TestNewCloneProjectTool tests creating a new clone project tool
+func TestNewCloneProjectTool(t *testing.T) {
+	tool := NewCloneProjectTool(&MockFullPCFClient{})
+
+	if tool.Name != "clone_project" {
+		t.Errorf("Expected tool name 'clone_project', got '%s'", tool.Name)
+	}
+
+	if tool.Handler == nil {
+		t.Error("Tool handler should not be nil")
+	}
+
+	required, ok := tool.InputSchema["required"].([]string)
+	if !ok || !reflect.DeepEqual(required, []string{"project_id", "name"}) {
+		t.Errorf("Expected project_id and name to be required, got %v", tool.InputSchema["required"])
+	}
+}
+
+// TestCloneProjectHandler tests that hosts are copied into a new project and
+// issues and credentials are left behind by default
+func TestCloneProjectHandler(t *testing.T) {
+	client, hostReqs, issueReqs := newCloneMockClient(t)
+	client.ListIssuesFunc = func(ctx context.Context, projectID string) ([]pcf.Issue, error) {
+		t.Error("Issues should not be read unless include_issues is set")
+		return nil, nil
+	}
+
+	tool := NewCloneProjectTool(client)
+
+	result, err := tool.Handler(context.Background(), map[string]interface{}{
+		"project_id": "proj-src",
+		"name":       "Retest",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	resultMap := result.(map[string]interface{})
+
+	project := resultMap["project"].(map[string]interface{})
+	if project["id"] != "proj-new" || project["name"] != "Retest" || project["description"] != "Source engagement" {
+		t.Errorf("Expected the new project to take the source description, got %v", project)
+	}
+
+	if resultMap["hosts_copied"] != 2 || resultMap["hosts_failed"] != 0 || resultMap["issues_copied"] != 0 {
+		t.Errorf("Expected two hosts and no issues copied, got %v", resultMap)
+	}
+
+	if len(*hostReqs) != 2 || (*hostReqs)[0].IP != "10.0.0.1" || (*hostReqs)[0].Hostname != "web" ||
+		!reflect.DeepEqual((*hostReqs)[0].Tags, []string{"dmz"}) {
+		t.Errorf("Expected the source hosts to be copied, got %+v", *hostReqs)
+	}
+
+	if len(*issueReqs) != 0 {
+		t.Errorf("Expected no issues to be created, got %+v", *issueReqs)
+	}
+
+	if failures := resultMap["failures"].([]map[string]interface{}); len(failures) != 0 {
+		t.Errorf("Expected no failures, got %v", failures)
+	}
+}
+
+// TestCloneProjectHandlerIssues tests that copied issues are linked to the
+// copies of their hosts
+func TestCloneProjectHandlerIssues(t *testing.T) {
+	client, _, issueReqs := newCloneMockClient(t)
+	tool := NewCloneProjectTool(client)
+
+	result, err := tool.Handler(context.Background(), map[string]interface{}{
+		"project_id":     "proj-src",
+		"name":           "Retest",
+		"description":    "Second round",
+		"include_issues": true,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	resultMap := result.(map[string]interface{})
+	if resultMap["issues_copied"] != 2 || resultMap["issues_failed"] != 0 {
+		t.Errorf("Expected two issues copied, got %v", resultMap)
+	}
+
+	if project := resultMap["project"].(map[string]interface{}); project["description"] != "Second round" {
+		t.Errorf("Expected the given description, got %v", project["description"])
+	}
+
+	if len(*issueReqs) != 2 || (*issueReqs)[0].HostID != "new-host-2" || (*issueReqs)[1].HostID != "" {
+		t.Errorf("Expected issues linked to the copied hosts, got %+v", *issueReqs)
+	}
+}
+
+// TestCloneProjectHandlerPartialFailure tests that hosts and issues that fail
+// to copy are reported without failing the clone
+func TestCloneProjectHandlerPartialFailure(t *testing.T) {
+	client, _, issueReqs := newCloneMockClient(t)
+	client.AddHostsFunc = func(ctx context.Context, projectID string, reqs []pcf.CreateHostRequest) ([]pcf.Host, error) {
+		return []pcf.Host{{ID: "new-host-1"}, {}}, &pcf.BulkError{
+			Total:    len(reqs),
+			Failures: []pcf.BulkItemError{{Index: 1, Err: errors.New("duplicate host")}},
+		}
+	}
+
+	tool := NewCloneProjectTool(client)
+
+	result, err := tool.Handler(context.Background(), map[string]interface{}{
+		"project_id":     "proj-src",
+		"name":           "Retest",
+		"include_issues": true,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	resultMap := result.(map[string]interface{})
+	if resultMap["hosts_copied"] != 1 || resultMap["hosts_failed"] != 1 ||
+		resultMap["issues_copied"] != 1 || resultMap["issues_failed"] != 1 {
+		t.Errorf("Expected one host and one issue copied, got %v", resultMap)
+	}
+
+	failures := resultMap["failures"].([]map[string]interface{})
+	if len(failures) != 2 {
+		t.Fatalf("Expected two failures, got %v", failures)
+	}
+	if failures[0]["type"] != "host" || failures[0]["source_id"] != "host-2" || failures[0]["error"] != "duplicate host" {
+		t.Errorf("Expected the host failure, got %v", failures[0])
+	}
+	if failures[1]["type"] != "issue" || failures[1]["source_id"] != "issue-1" {
+		t.Errorf("Expected the issue on the missing host to fail, got %v", failures[1])
+	}
+
+	// Only the issue without a host was created
+	if len(*issueReqs) != 1 || (*issueReqs)[0].Title != "Missing policy" {
+		t.Errorf("Expected only the unlinked issue to be created, got %+v", *issueReqs)
+	}
+}
+
+// TestCloneProjectHandlerErrors tests that failures before any copying are
+// returned as errors, and later ones name the created project. Parameter
+// errors are ValidationErrors naming the field.
+func TestCloneProjectHandlerErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   map[string]interface{}
+		setup    func(client *MockFullPCFClient)
+		wantErr  string
+		errField string
+	}{
+		{
+			name:     "missing name",
+			params:   map[string]interface{}{"project_id": "proj-src"},
+			wantErr:  "must be a string",
+			errField: "name",
+		},
+		{
+			name:     "empty project_id",
+			params:   map[string]interface{}{"project_id": "", "name": "Retest"},
+			wantErr:  "cannot be empty",
+			errField: "project_id",
+		},
+		{
+			name:    "unknown source",
+			params:  map[string]interface{}{"project_id": "proj-missing", "name": "Retest"},
+			wantErr: "failed to get source project",
+		},
+		{
+			name:   "hosts not readable",
+			params: map[string]interface{}{"project_id": "proj-src", "name": "Retest"},
+			setup: func(client *MockFullPCFClient) {
+				client.ListHostsFunc = func(ctx context.Context, projectID string) ([]pcf.Host, error) {
+					return nil, errors.New("connection refused")
+				}
+				client.CreateProjectFunc = func(ctx context.Context, req pcf.CreateProjectRequest) (*pcf.Project, error) {
+					t.Error("No project should be created when the source cannot be read")
+					return nil, nil
+				}
+			},
+			wantErr: "failed to list source hosts",
+		},
+		{
+			name:   "hosts not added",
+			params: map[string]interface{}{"project_id": "proj-src", "name": "Retest"},
+			setup: func(client *MockFullPCFClient) {
+				client.AddHostsFunc = func(ctx context.Context, projectID string, reqs []pcf.CreateHostRequest) ([]pcf.Host, error) {
+					return nil, errors.New("connection refused")
+				}
+			},
+			wantErr: "created project proj-new but failed to copy hosts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _, _ := newCloneMockClient(t)
+			if tt.setup != nil {
+				tt.setup(client)
+			}
+
+			_, err := NewCloneProjectTool(client).Handler(context.Background(), tt.params)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+
+			var validationErr *ValidationError
+			isValidation := errors.As(err, &validationErr)
+			if tt.errField == "" && isValidation {
+				t.Errorf("Expected a PCF error, got ValidationError %v", err)
+			}
+			if tt.errField != "" && (!isValidation || validationErr.Field != tt.errField) {
+				t.Errorf("Expected a ValidationError for %q, got %v", tt.errField, err)
+			}
+		})
+	}
+}
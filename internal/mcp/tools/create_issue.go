@@ -8,6 +8,15 @@ import (
 	"github.com/aRustyDev/pcf-mcp/internal/pcf"
 )
 
+// validIssueSeverities lists the severity levels PCF accepts for issues
+var validIssueSeverities = map[string]bool{
+	"Critical": true,
+	"High":     true,
+	"Medium":   true,
+	"Low":      true,
+	"Info":     true,
+}
+
 // CreateIssueClient defines the interface for creating issues
 type CreateIssueClient interface {
 	CreateIssue(ctx context.Context, projectID string, req pcf.CreateIssueRequest) (*pcf.Issue, error)
@@ -16,8 +25,11 @@ type CreateIssueClient interface {
 // NewCreateIssueTool creates an MCP tool for creating security issues in a PCF project
 func NewCreateIssueTool(client CreateIssueClient) mcp.Tool {
 	return mcp.Tool{
-		Name:        "create_issue",
-		Description: "Create a new security issue/finding in a PCF project",
+		Name:          "create_issue",
+		Description:   "Create a new security issue/finding in a PCF project",
+		Category:      categoryIssues,
+		Tags:          []string{categoryIssues, tagWrite},
+		RequiredScope: mcp.ScopeWrite,
 		InputSchema: map[string]interface{}{
 			"type": "object",
 			"properties": map[string]interface{}{
@@ -70,50 +82,42 @@ func createCreateIssueHandler(client CreateIssueClient) mcp.ToolHandler {
 		// Extract and validate project_id
 		projectID, ok := params["project_id"].(string)
 		if !ok {
-			return nil, fmt.Errorf("project_id parameter must be a string")
+			return nil, invalidParam("project_id", "must be a string")
 		}
 
 		if projectID == "" {
-			return nil, fmt.Errorf("project_id cannot be empty")
+			return nil, invalidParam("project_id", "cannot be empty")
 		}
 
 		// Extract and validate title
 		title, ok := params["title"].(string)
 		if !ok {
-			return nil, fmt.Errorf("title parameter must be a string")
+			return nil, invalidParam("title", "must be a string")
 		}
 
 		if title == "" {
-			return nil, fmt.Errorf("title cannot be empty")
+			return nil, invalidParam("title", "cannot be empty")
 		}
 
 		// Extract and validate description
 		description, ok := params["description"].(string)
 		if !ok {
-			return nil, fmt.Errorf("description parameter must be a string")
+			return nil, invalidParam("description", "must be a string")
 		}
 
 		if description == "" {
-			return nil, fmt.Errorf("description cannot be empty")
+			return nil, invalidParam("description", "cannot be empty")
 		}
 
 		// Extract and validate severity
 		severity, ok := params["severity"].(string)
 		if !ok {
-			return nil, fmt.Errorf("severity parameter must be a string")
+			return nil, invalidParam("severity", "must be a string")
 		}
 
 		// Validate severity value
-		validSeverities := map[string]bool{
-			"Critical": true,
-			"High":     true,
-			"Medium":   true,
-			"Low":      true,
-			"Info":     true,
-		}
-
-		if !validSeverities[severity] {
-			return nil, fmt.Errorf("invalid severity value: %s. Must be one of: Critical, High, Medium, Low, Info", severity)
+		if !validIssueSeverities[severity] {
+			return nil, invalidParam("severity", "must be one of Critical, High, Medium, Low, Info, got %q", severity)
 		}
 
 		// Create request
@@ -143,12 +147,12 @@ func createCreateIssueHandler(client CreateIssueClient) mcp.ToolHandler {
 			case int:
 				cvss = float64(v)
 			default:
-				return nil, fmt.Errorf("cvss parameter must be a number")
+				return nil, invalidParam("cvss", "must be a number")
 			}
 
 			// Validate CVSS range
 			if cvss < 0 || cvss > 10 {
-				return nil, fmt.Errorf("cvss score must be between 0 and 10, got %f", cvss)
+				return nil, invalidParam("cvss", "must be between 0 and 10, got %g", cvss)
 			}
 
 			req.CVSS = cvss
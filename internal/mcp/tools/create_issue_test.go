@@ -2,9 +2,15 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
 	"github.com/aRustyDev/pcf-mcp/internal/pcf"
 )
 
@@ -345,3 +351,90 @@ func TestCreateIssueHandler(t *testing.T) {
 		})
 	}
 }
+
+// TestCreateIssueValidationErrors tests that rejected parameters are
+// reported as ValidationErrors naming the field
+func TestCreateIssueValidationErrors(t *testing.T) {
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"project_id":  "proj-123",
+			"title":       "Test Issue",
+			"description": "Test description",
+			"severity":    "Low",
+		}
+	}
+
+	tests := []struct {
+		name   string
+		field  string
+		value  interface{}
+		reason string
+	}{
+		{name: "Missing project_id", field: "project_id", reason: "must be a string"},
+		{name: "Empty title", field: "title", value: "", reason: "cannot be empty"},
+		{name: "Unknown severity", field: "severity", value: "SuperCritical", reason: "must be one of"},
+		{name: "Non-numeric cvss", field: "cvss", value: "high", reason: "must be a number"},
+		{name: "Out of range cvss", field: "cvss", value: 11.0, reason: "must be between 0 and 10"},
+	}
+
+	tool := NewCreateIssueTool(&MockCreateIssueClient{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := valid()
+			if tt.value == nil {
+				delete(params, tt.field)
+			} else {
+				params[tt.field] = tt.value
+			}
+
+			_, err := tool.Handler(context.Background(), params)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected a ValidationError, got %v", err)
+			}
+			if validationErr.Field != tt.field {
+				t.Errorf("Expected field %q, got %q", tt.field, validationErr.Field)
+			}
+			if !strings.Contains(validationErr.Reason, tt.reason) {
+				t.Errorf("Expected reason containing %q, got %q", tt.reason, validationErr.Reason)
+			}
+		})
+	}
+}
+
+// TestCreateIssueInvalidParamsResponse tests that the HTTP transport reports
+// a ValidationError with the invalid_params code and field-level details
+func TestCreateIssueInvalidParamsResponse(t *testing.T) {
+	server, err := mcp.NewServer(config.ServerConfig{Transport: "http"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	if err := server.RegisterTool(NewCreateIssueTool(&MockCreateIssueClient{})); err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	body := `{"project_id":"proj-123","title":"Test","description":"Test","severity":"Low","cvss":11}`
+	req := httptest.NewRequest(http.MethodPost, "/tools/create_issue", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	server.HTTPHandler().ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response mcp.ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode error response: %v", err)
+	}
+	if response.Error.Code != mcp.CodeInvalidParams {
+		t.Errorf("Expected code %q, got %q", mcp.CodeInvalidParams, response.Error.Code)
+	}
+	if response.Error.Details["field"] != "cvss" {
+		t.Errorf("Expected field cvss in details, got %v", response.Error.Details)
+	}
+	if reason, _ := response.Error.Details["reason"].(string); !strings.Contains(reason, "between 0 and 10") {
+		t.Errorf("Expected range reason in details, got %v", response.Error.Details)
+	}
+}
@@ -16,8 +16,11 @@ type CreateProjectClient interface {
 // NewCreateProjectTool creates an MCP tool for creating PCF projects
 func NewCreateProjectTool(client CreateProjectClient) mcp.Tool {
 	return mcp.Tool{
-		Name:        "create_project",
-		Description: "Create a new project in the Pentest Collaboration Framework",
+		Name:          "create_project",
+		Description:   "Create a new project in the Pentest Collaboration Framework",
+		Category:      categoryProjects,
+		Tags:          []string{categoryProjects, tagWrite},
+		RequiredScope: mcp.ScopeWrite,
 		InputSchema: map[string]interface{}{
 			"type": "object",
 			"properties": map[string]interface{}{
@@ -0,0 +1,157 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf/redact"
+)
+
+// NewCredentialSummaryTool creates an MCP tool that groups a project's
+// credential usernames by service and by type, e.g. to plan password sprays.
+// When redactor masks usernames the groups only count credentials; nil masks
+// the default fields.
+func NewCredentialSummaryTool(client ListCredentialsClient, redactor *redact.Redactor) mcp.Tool {
+	return mcp.Tool{
+		Name:          "credential_summary",
+		Description:   "Summarize the distinct usernames of a PCF project's credentials per service and per type, for planning password sprays. Credential values are never returned",
+		Category:      categoryCredentials,
+		Tags:          []string{categoryCredentials, tagRead},
+		RequiredScope: mcp.ScopeRead,
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"project_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the project to summarize credentials from",
+				},
+				"service": map[string]interface{}{
+					"type":        "string",
+					"description": "Only summarize credentials for this service",
+				},
+			},
+			"required":             []string{"project_id"},
+			"additionalProperties": false,
+		},
+		Handler: createCredentialSummaryHandler(client, redactor),
+	}
+}
+
+// usernameGroup collects the distinct usernames of the credentials in a group
+type usernameGroup struct {
+	usernames   map[string]bool
+	credentials int
+}
+
+// addToGroup records one credential's username in the group for key
+func addToGroup(groups map[string]*usernameGroup, key, username string) {
+	group, ok := groups[key]
+	if !ok {
+		group = &usernameGroup{usernames: make(map[string]bool)}
+		groups[key] = group
+	}
+	group.usernames[username] = true
+	group.credentials++
+}
+
+// summarizeGroups converts groups to response entries sorted by key, with
+// each group's usernames sorted. Without withUsernames the entries only
+// count credentials.
+func summarizeGroups(keyName string, groups map[string]*usernameGroup, withUsernames bool) []map[string]interface{} {
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	summaries := make([]map[string]interface{}, 0, len(keys))
+	for _, key := range keys {
+		if !withUsernames {
+			summaries = append(summaries, map[string]interface{}{
+				keyName:            key,
+				"credential_count": groups[key].credentials,
+			})
+			continue
+		}
+
+		usernames := make([]string, 0, len(groups[key].usernames))
+		for username := range groups[key].usernames {
+			usernames = append(usernames, username)
+		}
+		sort.Strings(usernames)
+
+		summaries = append(summaries, map[string]interface{}{
+			keyName:            key,
+			"usernames":        usernames,
+			"username_count":   len(usernames),
+			"credential_count": groups[key].credentials,
+		})
+	}
+
+	return summaries
+}
+
+// createCredentialSummaryHandler creates the handler function for summarizing credentials
+func createCredentialSummaryHandler(client ListCredentialsClient, redactor *redact.Redactor) mcp.ToolHandler {
+	return func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+		// Extract and validate project_id
+		projectID, ok := params["project_id"].(string)
+		if !ok {
+			return nil, invalidParam("project_id", "must be a string")
+		}
+
+		if projectID == "" {
+			return nil, invalidParam("project_id", "cannot be empty")
+		}
+
+		// Only the service filter of list_credentials applies here
+		serviceFilter, _ := params["service"].(string)
+		filter := credentialFilter{service: serviceFilter}
+
+		// Call PCF client to list credentials
+		credentials, err := client.ListCredentials(ctx, projectID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list credentials: %w", err)
+		}
+
+		// Group the redacted form of each credential, so fields configured
+		// in pcf.redact_fields stay masked; the value is never read. Masked
+		// usernames would all group under the placeholder, so they are
+		// left out and only credentials are counted.
+		withUsernames := !redactor.Redacts("username")
+		byService := make(map[string]*usernameGroup)
+		byType := make(map[string]*usernameGroup)
+		allUsernames := make(map[string]bool)
+		matched := 0
+
+		for _, cred := range credentials {
+			if !filter.matches(cred) {
+				continue
+			}
+			matched++
+
+			redacted := formatCredential(cred, redactor)
+			username, _ := redacted["username"].(string)
+			service, _ := redacted["service"].(string)
+			credType, _ := redacted["type"].(string)
+
+			addToGroup(byService, service, username)
+			addToGroup(byType, credType, username)
+			allUsernames[username] = true
+		}
+
+		// Build response
+		response := map[string]interface{}{
+			"project_id": projectID,
+			"services":   summarizeGroups("service", byService, withUsernames),
+			"types":      summarizeGroups("type", byType, withUsernames),
+		}
+		if withUsernames {
+			response["distinct_users"] = len(allUsernames)
+		}
+
+		return withListMetadata(response, matched, len(credentials), filter.applied()), nil
+	}
+}
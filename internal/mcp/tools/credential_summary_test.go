@@ -0,0 +1,202 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf/redact"
+)
+
+// summaryCredentials repeats usernames across services and types, and
+// includes a credential without a service
+var summaryCredentials = []pcf.Credential{
+	{ID: "cred-1", Type: "password", Username: "admin", Value: "Winter2024!", Service: "ssh"},
+	{ID: "cred-2", Type: "hash", Username: "admin", Value: "aad3b435b51404ee", Service: "ssh"},
+	{ID: "cred-3", Type: "password", Username: "root", Value: "toor", Service: "ssh"},
+	{ID: "cred-4", Type: "password", Username: "admin", Value: "Summer2024!", Service: "smb"},
+	{ID: "cred-5", Type: "token", Username: "svc-deploy", Value: "ghp_abcdef123456"},
+}
+
+// TestNewCredentialSummaryTool tests creating a new credential summary tool
+func TestNewCredentialSummaryTool(t *testing.T) {
+	tool := NewCredentialSummaryTool(&MockListCredentialsClient{}, nil)
+
+	if tool.Name != "credential_summary" {
+		t.Errorf("Expected tool name 'credential_summary', got '%s'", tool.Name)
+	}
+
+	if tool.Handler == nil {
+		t.Error("Tool handler should not be nil")
+	}
+
+	required, ok := tool.InputSchema["required"].([]string)
+	if !ok || len(required) != 1 || required[0] != "project_id" {
+		t.Errorf("Expected required fields [project_id], got %v", tool.InputSchema["required"])
+	}
+}
+
+// TestCredentialSummaryHandler tests grouping and deduplicating usernames
+// per service and per type
+func TestCredentialSummaryHandler(t *testing.T) {
+	client := &MockListCredentialsClient{
+		ListCredentialsFunc: func(ctx context.Context, projectID string) ([]pcf.Credential, error) {
+			return summaryCredentials, nil
+		},
+	}
+
+	result, err := NewCredentialSummaryTool(client, nil).Handler(context.Background(), map[string]interface{}{
+		"project_id": "proj-123",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	res := result.(map[string]interface{})
+
+	expectedServices := []map[string]interface{}{
+		{"service": "", "usernames": []string{"svc-deploy"}, "username_count": 1, "credential_count": 1},
+		{"service": "smb", "usernames": []string{"admin"}, "username_count": 1, "credential_count": 1},
+		{"service": "ssh", "usernames": []string{"admin", "root"}, "username_count": 2, "credential_count": 3},
+	}
+	if !reflect.DeepEqual(res["services"], expectedServices) {
+		t.Errorf("Expected services %v, got %v", expectedServices, res["services"])
+	}
+
+	expectedTypes := []map[string]interface{}{
+		{"type": "hash", "usernames": []string{"admin"}, "username_count": 1, "credential_count": 1},
+		{"type": "password", "usernames": []string{"admin", "root"}, "username_count": 2, "credential_count": 3},
+		{"type": "token", "usernames": []string{"svc-deploy"}, "username_count": 1, "credential_count": 1},
+	}
+	if !reflect.DeepEqual(res["types"], expectedTypes) {
+		t.Errorf("Expected types %v, got %v", expectedTypes, res["types"])
+	}
+
+	if res["distinct_users"] != 3 || res["total_count"] != 5 {
+		t.Errorf("Expected 3 distinct users across 5 credentials, got %v and %v", res["distinct_users"], res["total_count"])
+	}
+
+	// The service filter narrows the summary
+	result, err = NewCredentialSummaryTool(client, nil).Handler(context.Background(), map[string]interface{}{
+		"project_id": "proj-123",
+		"service":    "ssh",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	res = result.(map[string]interface{})
+	if services := res["services"].([]map[string]interface{}); len(services) != 1 || services[0]["service"] != "ssh" {
+		t.Errorf("Expected only the ssh service, got %v", services)
+	}
+	if res["total_count"] != 3 || res["unfiltered_count"] != 5 {
+		t.Errorf("Expected 3 of 5 credentials, got %v of %v", res["total_count"], res["unfiltered_count"])
+	}
+}
+
+// TestCredentialSummaryNeverLeaksValues tests that no credential value
+// appears in the output, and that configured redactions still apply
+func TestCredentialSummaryNeverLeaksValues(t *testing.T) {
+	redactor, err := redact.New([]string{"username"}, "[hidden]")
+	if err != nil {
+		t.Fatalf("Failed to create redactor: %v", err)
+	}
+
+	client := &MockListCredentialsClient{
+		ListCredentialsFunc: func(ctx context.Context, projectID string) ([]pcf.Credential, error) {
+			return summaryCredentials, nil
+		},
+	}
+
+	for name, configured := range map[string]*redact.Redactor{
+		"default redaction":  nil,
+		"username redaction": redactor,
+	} {
+		t.Run(name, func(t *testing.T) {
+			result, err := NewCredentialSummaryTool(client, configured).Handler(context.Background(), map[string]interface{}{
+				"project_id": "proj-123",
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			data, err := json.Marshal(result)
+			if err != nil {
+				t.Fatalf("Failed to marshal result: %v", err)
+			}
+
+			for _, cred := range summaryCredentials {
+				if strings.Contains(string(data), cred.Value) {
+					t.Errorf("Credential value %q leaked: %s", cred.Value, data)
+				}
+			}
+
+			if name == "username redaction" && (strings.Contains(string(data), "admin") || strings.Contains(string(data), "[hidden]")) {
+				t.Errorf("Expected usernames to be left out: %s", data)
+			}
+		})
+	}
+}
+
+// TestCredentialSummaryMaskedUsernames tests that masked usernames are left
+// out rather than grouped under the placeholder
+func TestCredentialSummaryMaskedUsernames(t *testing.T) {
+	redactor, err := redact.New([]string{"username"}, "[hidden]")
+	if err != nil {
+		t.Fatalf("Failed to create redactor: %v", err)
+	}
+
+	client := &MockListCredentialsClient{
+		ListCredentialsFunc: func(ctx context.Context, projectID string) ([]pcf.Credential, error) {
+			return summaryCredentials, nil
+		},
+	}
+
+	result, err := NewCredentialSummaryTool(client, redactor).Handler(context.Background(), map[string]interface{}{
+		"project_id": "proj-123",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	res := result.(map[string]interface{})
+	expectedServices := []map[string]interface{}{
+		{"service": "", "credential_count": 1},
+		{"service": "smb", "credential_count": 1},
+		{"service": "ssh", "credential_count": 3},
+	}
+	if !reflect.DeepEqual(res["services"], expectedServices) {
+		t.Errorf("Expected services %v, got %v", expectedServices, res["services"])
+	}
+
+	if _, ok := res["distinct_users"]; ok {
+		t.Errorf("Expected no distinct_users, got %v", res["distinct_users"])
+	}
+	if res["total_count"] != 5 {
+		t.Errorf("Expected 5 credentials, got %v", res["total_count"])
+	}
+}
+
+// TestCredentialSummaryHandlerErrors tests error handling
+func TestCredentialSummaryHandlerErrors(t *testing.T) {
+	client := &MockListCredentialsClient{
+		ListCredentialsFunc: func(ctx context.Context, projectID string) ([]pcf.Credential, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+	tool := NewCredentialSummaryTool(client, nil)
+
+	_, err := tool.Handler(context.Background(), map[string]interface{}{})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "project_id" {
+		t.Errorf("Expected a ValidationError for project_id, got %v", err)
+	}
+
+	if _, err := tool.Handler(context.Background(), map[string]interface{}{"project_id": "proj-123"}); err == nil || !strings.Contains(err.Error(), "failed to list credentials") {
+		t.Errorf("Expected a list error, got %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf/redact"
+)
+
+// credentialFilter holds the optional filters shared by the credential
+// listing tools; empty fields match every credential
+type credentialFilter struct {
+	credType string
+	hostID   string
+	service  string
+}
+
+// credentialFilterFromParams reads the type, host_id, and service filters
+// from tool params, ignoring any that are absent
+func credentialFilterFromParams(params map[string]interface{}) credentialFilter {
+	var filter credentialFilter
+	filter.credType, _ = params["type"].(string)
+	filter.hostID, _ = params["host_id"].(string)
+	filter.service, _ = params["service"].(string)
+	return filter
+}
+
+// matches reports whether cred passes every set filter
+func (f credentialFilter) matches(cred pcf.Credential) bool {
+	return (f.credType == "" || cred.Type == f.credType) &&
+		(f.hostID == "" || cred.HostID == f.hostID) &&
+		(f.service == "" || cred.Service == f.service)
+}
+
+// applied returns the filters by parameter name, for withListMetadata
+func (f credentialFilter) applied() map[string]string {
+	return map[string]string{
+		"type":    f.credType,
+		"host_id": f.hostID,
+		"service": f.service,
+	}
+}
+
+// formatCredential converts a credential to its response form with sensitive
+// fields masked. Every tool that returns credentials must use this.
+func formatCredential(cred pcf.Credential, redactor *redact.Redactor) map[string]interface{} {
+	credMap := map[string]interface{}{
+		"id":         cred.ID,
+		"project_id": cred.ProjectID,
+		"type":       cred.Type,
+		"username":   cred.Username,
+		"value":      cred.Value,
+	}
+
+	// Add optional fields if present
+	if cred.HostID != "" {
+		credMap["host_id"] = cred.HostID
+	}
+
+	if cred.Service != "" {
+		credMap["service"] = cred.Service
+	}
+
+	if cred.Notes != "" {
+		credMap["notes"] = cred.Notes
+	}
+
+	return redactor.Apply(credMap)
+}
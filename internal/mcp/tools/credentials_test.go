@@ -0,0 +1,64 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf/redact"
+)
+
+// TestFormatCredential tests that configured fields are masked and others pass through
+func TestFormatCredential(t *testing.T) {
+	redactor, err := redact.New([]string{"username", "notes"}, "[hidden]")
+	if err != nil {
+		t.Fatalf("Failed to create redactor: %v", err)
+	}
+
+	cred := pcf.Credential{
+		ID:        "cred-1",
+		ProjectID: "proj-1",
+		HostID:    "host-1",
+		Type:      "password",
+		Username:  "admin",
+		Value:     "s3cret",
+		Service:   "ssh",
+		Notes:     "reused on db01",
+	}
+
+	credMap := formatCredential(cred, redactor)
+
+	for _, field := range []string{"value", "username", "notes"} {
+		if credMap[field] != "[hidden]" {
+			t.Errorf("Field %q should be masked, got %v", field, credMap[field])
+		}
+	}
+
+	if credMap["service"] != "ssh" || credMap["host_id"] != "host-1" || credMap["type"] != "password" {
+		t.Errorf("Unredacted fields should pass through, got %v", credMap)
+	}
+}
+
+// TestListCredentialsUsesRedactor tests that list_credentials applies the
+// configured redactor
+func TestListCredentialsUsesRedactor(t *testing.T) {
+	redactor, _ := redact.New([]string{"username"}, "XXX")
+
+	client := &MockListCredentialsClient{
+		ListCredentialsFunc: func(ctx context.Context, projectID string) ([]pcf.Credential, error) {
+			return []pcf.Credential{{ID: "cred-1", Type: "password", Username: "admin", Value: "s3cret"}}, nil
+		},
+	}
+
+	tool := NewListCredentialsTool(client, redactor)
+
+	result, err := tool.Handler(context.Background(), map[string]interface{}{"project_id": "proj-1"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	creds := result.(map[string]interface{})["credentials"].([]map[string]interface{})
+	if creds[0]["username"] != "XXX" || creds[0]["value"] != "XXX" {
+		t.Errorf("Expected username and value to be masked, got %v", creds[0])
+	}
+}
@@ -0,0 +1,64 @@
+package tools
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+)
+
+// defaultProjectCategories lists the tool categories that fall back to the
+// default project. Project tools are excluded so that, for example,
+// delete_project never acts on a project the caller did not name.
+var defaultProjectCategories = map[string]bool{
+	categoryHosts:       true,
+	categoryIssues:      true,
+	categoryCredentials: true,
+	categoryReports:     true,
+}
+
+// withDefaultProject makes project_id optional for a project-scoped tool,
+// filling in projectID through ParamDefaults when a call omits it or leaves
+// it empty, so the server reports the project a call actually used. Tools
+// outside defaultProjectCategories, or without a project_id parameter, are
+// returned unchanged.
+func withDefaultProject(tool mcp.Tool, projectID string) mcp.Tool {
+	if projectID == "" || !defaultProjectCategories[tool.Category] {
+		return tool
+	}
+
+	properties, ok := tool.InputSchema["properties"].(map[string]interface{})
+	if !ok {
+		return tool
+	}
+	property, ok := properties["project_id"].(map[string]interface{})
+	if !ok {
+		return tool
+	}
+
+	// Copy the schema rather than modify the one the constructor built
+	property = maps.Clone(property)
+	if description, ok := property["description"].(string); ok {
+		property["description"] = description + " (defaults to " + projectID + ")"
+	}
+	properties = maps.Clone(properties)
+	properties["project_id"] = property
+
+	schema := maps.Clone(tool.InputSchema)
+	schema["properties"] = properties
+	if required, ok := schema["required"].([]string); ok {
+		schema["required"] = slices.DeleteFunc(slices.Clone(required), func(name string) bool {
+			return name == "project_id"
+		})
+	}
+	tool.InputSchema = schema
+
+	defaults := maps.Clone(tool.ParamDefaults)
+	if defaults == nil {
+		defaults = make(map[string]interface{}, 1)
+	}
+	defaults["project_id"] = projectID
+	tool.ParamDefaults = defaults
+
+	return tool
+}
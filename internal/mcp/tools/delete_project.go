@@ -0,0 +1,74 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+)
+
+// DeleteProjectClient defines the interface for deleting projects
+type DeleteProjectClient interface {
+	DeleteProject(ctx context.Context, projectID string) error
+}
+
+// NewDeleteProjectTool creates an MCP tool for deleting PCF projects
+func NewDeleteProjectTool(client DeleteProjectClient) mcp.Tool {
+	return mcp.Tool{
+		Name:          "delete_project",
+		Description:   "Permanently delete a project from the Pentest Collaboration Framework. Requires confirm: true",
+		Category:      categoryProjects,
+		Tags:          []string{categoryProjects, tagWrite, tagDestructive},
+		RequiredScope: mcp.ScopeWrite,
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"project_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the project to delete",
+				},
+				"confirm": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Must be true to confirm the deletion",
+				},
+			},
+			"required":             []string{"project_id", "confirm"},
+			"additionalProperties": false,
+		},
+		Handler: createDeleteProjectHandler(client),
+	}
+}
+
+// createDeleteProjectHandler creates the handler function for deleting projects
+func createDeleteProjectHandler(client DeleteProjectClient) mcp.ToolHandler {
+	return func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+		// Extract and validate project_id
+		projectID, ok := params["project_id"].(string)
+		if !ok {
+			return nil, fmt.Errorf("project_id parameter must be a string")
+		}
+
+		if projectID == "" {
+			return nil, fmt.Errorf("project_id cannot be empty")
+		}
+
+		// Require explicit confirmation so a project is never deleted by accident
+		confirm, ok := params["confirm"].(bool)
+		if !ok || !confirm {
+			return nil, fmt.Errorf("deleting a project requires confirm: true")
+		}
+
+		// Call PCF client to delete project
+		if err := client.DeleteProject(ctx, projectID); err != nil {
+			return nil, fmt.Errorf("failed to delete project: %w", err)
+		}
+
+		response := map[string]interface{}{
+			"project_id": projectID,
+			"deleted":    true,
+			"message":    fmt.Sprintf("Project %s deleted successfully", projectID),
+		}
+
+		return response, nil
+	}
+}
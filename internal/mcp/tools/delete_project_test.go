@@ -0,0 +1,119 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// MockDeleteProjectClient is a mock implementation for DeleteProject
+type MockDeleteProjectClient struct {
+	DeleteProjectFunc func(ctx context.Context, projectID string) error
+}
+
+func (m *MockDeleteProjectClient) DeleteProject(ctx context.Context, projectID string) error {
+	if m.DeleteProjectFunc != nil {
+		return m.DeleteProjectFunc(ctx, projectID)
+	}
+	return errors.New("DeleteProjectFunc not implemented")
+}
+
+// TestDeleteProjectHandler tests the delete project handler functionality
+func TestDeleteProjectHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		params       map[string]interface{}
+		mockError    error
+		expectError  bool
+		expectDelete bool
+	}{
+		{
+			name: "Confirmed deletion",
+			params: map[string]interface{}{
+				"project_id": "proj-123",
+				"confirm":    true,
+			},
+			expectDelete: true,
+		},
+		{
+			name: "Missing confirmation",
+			params: map[string]interface{}{
+				"project_id": "proj-123",
+			},
+			expectError: true,
+		},
+		{
+			name: "Confirmation false",
+			params: map[string]interface{}{
+				"project_id": "proj-123",
+				"confirm":    false,
+			},
+			expectError: true,
+		},
+		{
+			name: "Confirmation wrong type",
+			params: map[string]interface{}{
+				"project_id": "proj-123",
+				"confirm":    "true",
+			},
+			expectError: true,
+		},
+		{
+			name: "Empty project_id",
+			params: map[string]interface{}{
+				"project_id": "",
+				"confirm":    true,
+			},
+			expectError: true,
+		},
+		{
+			name: "PCF API error",
+			params: map[string]interface{}{
+				"project_id": "proj-123",
+				"confirm":    true,
+			},
+			mockError:    errors.New("PCF API error"),
+			expectError:  true,
+			expectDelete: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleted := false
+			mockClient := &MockDeleteProjectClient{
+				DeleteProjectFunc: func(ctx context.Context, projectID string) error {
+					deleted = true
+					return tt.mockError
+				},
+			}
+
+			tool := NewDeleteProjectTool(mockClient)
+			result, err := tool.Handler(context.Background(), tt.params)
+
+			if deleted != tt.expectDelete {
+				t.Errorf("Expected delete called=%v, got %v", tt.expectDelete, deleted)
+			}
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			res, ok := result.(map[string]interface{})
+			if !ok {
+				t.Fatal("Result should be a map")
+			}
+
+			if res["deleted"] != true {
+				t.Errorf("Expected deleted true, got %v", res["deleted"])
+			}
+		})
+	}
+}
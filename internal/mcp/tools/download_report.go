@@ -0,0 +1,148 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+)
+
+// defaultReportDirName is the directory under os.TempDir used when the client
+// does not provide a report directory
+const defaultReportDirName = "pcf-mcp-reports"
+
+// DownloadReportClient defines the interface for downloading reports
+type DownloadReportClient interface {
+	DownloadReport(ctx context.Context, reportURL string, w io.Writer) error
+}
+
+// reportDirOrDefault returns dir, or a directory under os.TempDir when dir
+// is empty
+func reportDirOrDefault(dir string) string {
+	if dir != "" {
+		return dir
+	}
+	return filepath.Join(os.TempDir(), defaultReportDirName)
+}
+
+// NewDownloadReportTool creates an MCP tool that downloads a generated report
+// into outputDir. It requires the write scope because it writes files on the
+// server.
+func NewDownloadReportTool(client DownloadReportClient, outputDir string) mcp.Tool {
+	return mcp.Tool{
+		Name:          "download_report",
+		Description:   "Download a generated report to the server's report directory",
+		Category:      categoryReports,
+		Tags:          []string{categoryReports, tagWrite},
+		RequiredScope: mcp.ScopeWrite,
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"report_url": map[string]interface{}{
+					"type":        "string",
+					"description": "The report URL returned by generate_report",
+				},
+				"filename": map[string]interface{}{
+					"type":        "string",
+					"description": "File name to save the report as (defaults to the last segment of the URL)",
+				},
+			},
+			"required":             []string{"report_url"},
+			"additionalProperties": false,
+		},
+		Handler: createDownloadReportHandler(client, outputDir),
+	}
+}
+
+// createDownloadReportHandler creates the handler function for downloading reports
+func createDownloadReportHandler(client DownloadReportClient, outputDir string) mcp.ToolHandler {
+	return func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+		// Extract and validate report_url
+		reportURL, ok := params["report_url"].(string)
+		if !ok {
+			return nil, fmt.Errorf("report_url parameter must be a string")
+		}
+
+		if reportURL == "" {
+			return nil, fmt.Errorf("report_url cannot be empty")
+		}
+
+		parsed, err := url.Parse(reportURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid report_url: %w", err)
+		}
+
+		// Extract optional filename, defaulting to the URL's last segment
+		filename := path.Base(parsed.Path)
+		if filenameRaw, ok := params["filename"]; ok {
+			filename, ok = filenameRaw.(string)
+			if !ok {
+				return nil, fmt.Errorf("filename parameter must be a string")
+			}
+		}
+
+		// Keep downloads inside outputDir
+		if filename == "" || filename == "." || filename == ".." || filename == "/" || filepath.Base(filename) != filename {
+			return nil, fmt.Errorf("invalid filename: %q. Must be a plain file name", filename)
+		}
+
+		if err := os.MkdirAll(outputDir, 0o750); err != nil {
+			return nil, fmt.Errorf("failed to create report directory: %w", err)
+		}
+
+		// Download to a temporary file so a failed download never leaves a
+		// partial report under the final name
+		tmp, err := os.CreateTemp(outputDir, ".download-*")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create report file: %w", err)
+		}
+		defer os.Remove(tmp.Name())
+
+		counter := &countingWriter{w: tmp}
+		if err := client.DownloadReport(ctx, reportURL, counter); err != nil {
+			tmp.Close()
+			return nil, fmt.Errorf("failed to download report: %w", err)
+		}
+
+		if err := tmp.Close(); err != nil {
+			return nil, fmt.Errorf("failed to write report file: %w", err)
+		}
+
+		// Linking fails rather than replacing an existing report
+		reportPath := filepath.Join(outputDir, filename)
+		if err := os.Link(tmp.Name(), reportPath); err != nil {
+			if errors.Is(err, fs.ErrExist) {
+				return nil, invalidParam("filename", "%q already exists in the report directory", filename)
+			}
+			return nil, fmt.Errorf("failed to save report: %w", err)
+		}
+
+		response := map[string]interface{}{
+			"path":       reportPath,
+			"bytes":      counter.n,
+			"size_human": formatBytes(counter.n),
+			"message":    fmt.Sprintf("Report downloaded to %s (%s)", reportPath, formatBytes(counter.n)),
+		}
+
+		return response, nil
+	}
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,191 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// MockDownloadReportClient implements DownloadReportClient for testing
+type MockDownloadReportClient struct {
+	DownloadReportFunc func(ctx context.Context, reportURL string, w io.Writer) error
+}
+
+func (m *MockDownloadReportClient) DownloadReport(ctx context.Context, reportURL string, w io.Writer) error {
+	if m.DownloadReportFunc != nil {
+		return m.DownloadReportFunc(ctx, reportURL, w)
+	}
+	return errors.New("DownloadReportFunc not implemented")
+}
+
+// TestNewDownloadReportTool tests creating a new download report tool
+func TestNewDownloadReportTool(t *testing.T) {
+	tool := NewDownloadReportTool(&MockDownloadReportClient{}, t.TempDir())
+
+	if tool.Name != "download_report" {
+		t.Errorf("Expected tool name 'download_report', got '%s'", tool.Name)
+	}
+
+	if tool.Description == "" {
+		t.Error("Tool description should not be empty")
+	}
+
+	if tool.Handler == nil {
+		t.Error("Tool handler should not be nil")
+	}
+
+	required, ok := tool.InputSchema["required"].([]string)
+	if !ok || len(required) != 1 || required[0] != "report_url" {
+		t.Errorf("Expected report_url to be required, got %v", tool.InputSchema["required"])
+	}
+
+	// Saving files on the server needs the write scope
+	if tool.RequiredScope != mcp.ScopeWrite {
+		t.Errorf("Expected write scope, got %q", tool.RequiredScope)
+	}
+}
+
+// TestDownloadReportHandler tests the download report handler
+func TestDownloadReportHandler(t *testing.T) {
+	content := bytes.Repeat([]byte("report "), 3<<10)
+
+	client := &MockDownloadReportClient{
+		DownloadReportFunc: func(ctx context.Context, reportURL string, w io.Writer) error {
+			switch reportURL {
+			case "/reports/rpt-1.pdf":
+				_, err := w.Write(content)
+				return err
+			case "/reports/partial.pdf":
+				w.Write(content[:10])
+				return errors.New("connection reset")
+			default:
+				return fmt.Errorf("PCF API error: %w", pcf.ErrNotFound)
+			}
+		},
+	}
+
+	outputDir := filepath.Join(t.TempDir(), "reports")
+	tool := NewDownloadReportTool(client, outputDir)
+
+	tests := []struct {
+		name        string
+		params      map[string]interface{}
+		wantFile    string
+		expectError bool
+		errContains string
+	}{
+		{
+			name:     "Default filename from URL",
+			params:   map[string]interface{}{"report_url": "/reports/rpt-1.pdf"},
+			wantFile: "rpt-1.pdf",
+		},
+		{
+			name:     "Custom filename",
+			params:   map[string]interface{}{"report_url": "/reports/rpt-1.pdf", "filename": "acme-pentest.pdf"},
+			wantFile: "acme-pentest.pdf",
+		},
+		{
+			name:        "Existing report is not replaced",
+			params:      map[string]interface{}{"report_url": "/reports/rpt-1.pdf"},
+			expectError: true,
+			errContains: "already exists",
+		},
+		{
+			name:        "Filename escaping the directory",
+			params:      map[string]interface{}{"report_url": "/reports/rpt-1.pdf", "filename": "../escape.pdf"},
+			expectError: true,
+			errContains: "invalid filename",
+		},
+		{
+			name:        "Missing report_url",
+			params:      map[string]interface{}{},
+			expectError: true,
+			errContains: "report_url parameter must be a string",
+		},
+		{
+			name:        "Empty report_url",
+			params:      map[string]interface{}{"report_url": ""},
+			expectError: true,
+			errContains: "report_url cannot be empty",
+		},
+		{
+			name:        "Report not found",
+			params:      map[string]interface{}{"report_url": "/reports/missing.pdf"},
+			expectError: true,
+			errContains: "failed to download report",
+		},
+		{
+			name:        "Interrupted download",
+			params:      map[string]interface{}{"report_url": "/reports/partial.pdf"},
+			expectError: true,
+			errContains: "connection reset",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tool.Handler(context.Background(), tt.params)
+
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("Expected error but got none")
+				}
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("Expected error containing '%s', got '%s'", tt.errContains, err.Error())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			resultMap := result.(map[string]interface{})
+			wantPath := filepath.Join(outputDir, tt.wantFile)
+			if resultMap["path"] != wantPath {
+				t.Errorf("Expected path '%s', got '%v'", wantPath, resultMap["path"])
+			}
+			if resultMap["bytes"] != int64(len(content)) {
+				t.Errorf("Expected %d bytes, got %v", len(content), resultMap["bytes"])
+			}
+
+			saved, err := os.ReadFile(wantPath)
+			if err != nil {
+				t.Fatalf("Failed to read saved report: %v", err)
+			}
+			if !bytes.Equal(saved, content) {
+				t.Error("Saved report does not match downloaded content")
+			}
+		})
+	}
+
+	// Failed downloads leave no files behind
+	entries, err := os.ReadDir(outputDir)
+	if err != nil {
+		t.Fatalf("Failed to read report directory: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("Expected only the 2 completed reports, got %d entries", len(entries))
+	}
+}
+
+// TestReportDirOrDefault tests falling back to a directory under os.TempDir
+func TestReportDirOrDefault(t *testing.T) {
+	if dir := reportDirOrDefault("/srv/reports"); dir != "/srv/reports" {
+		t.Errorf("Expected configured report directory, got '%s'", dir)
+	}
+
+	want := filepath.Join(os.TempDir(), defaultReportDirName)
+	if dir := reportDirOrDefault(""); dir != want {
+		t.Errorf("Expected default report directory '%s', got '%s'", want, dir)
+	}
+}
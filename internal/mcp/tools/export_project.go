@@ -0,0 +1,186 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aRustyDev/pcf-mcp/internal/auth"
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf/redact"
+)
+
+// ExportProjectClient defines the interface for exporting projects
+type ExportProjectClient interface {
+	ListHostsClient
+	ListIssuesClient
+	ListCredentialsClient
+	GetProject(ctx context.Context, projectID string) (*pcf.Project, error)
+}
+
+// NewExportProjectTool creates an MCP tool that exports a PCF project with
+// its hosts, issues, and credentials as a single JSON document. Credentials
+// are masked by redactor, where nil masks the default fields, and raw values
+// are only exported when allowValues is set and the caller has write scope.
+func NewExportProjectTool(client ExportProjectClient, redactor *redact.Redactor, allowValues bool) mcp.Tool {
+	return mcp.Tool{
+		Name:          "export_project",
+		Description:   "Export a PCF project with its hosts, issues, and redacted credentials as one JSON document for archival",
+		Category:      categoryProjects,
+		Tags:          []string{categoryProjects, tagRead},
+		RequiredScope: mcp.ScopeRead,
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"project_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the project to export",
+				},
+				"include_credentials": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Include the project's credentials, with sensitive fields redacted (default true)",
+				},
+				"include_credential_values": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Include raw credential values; requires the write scope and fails unless the server sets pcf.allow_credential_export (default false)",
+				},
+			},
+			"required":             []string{"project_id"},
+			"additionalProperties": false,
+		},
+		Handler: createExportProjectHandler(client, redactor, allowValues),
+	}
+}
+
+// createExportProjectHandler creates the handler function for exporting projects
+func createExportProjectHandler(client ExportProjectClient, redactor *redact.Redactor, allowValues bool) mcp.ToolHandler {
+	return func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+		// Extract and validate project_id
+		projectID, ok := params["project_id"].(string)
+		if !ok {
+			return nil, invalidParam("project_id", "must be a string")
+		}
+
+		if projectID == "" {
+			return nil, invalidParam("project_id", "cannot be empty")
+		}
+
+		includeCredentials := true
+		if include, ok := params["include_credentials"].(bool); ok {
+			includeCredentials = include
+		}
+
+		includeValues, _ := params["include_credential_values"].(bool)
+		if includeValues && !includeCredentials {
+			return nil, invalidParam("include_credential_values", "requires include_credentials")
+		}
+		if includeValues && !allowValues {
+			return nil, invalidParam("include_credential_values", "credential export is disabled on this server (pcf.allow_credential_export)")
+		}
+		// Raw secrets need more than the read scope the tool itself requires
+		if includeValues && !auth.HasScope(ctx, mcp.ScopeWrite) {
+			return nil, fmt.Errorf("%w: include_credential_values requires scope '%s'", mcp.ErrInsufficientScope, mcp.ScopeWrite)
+		}
+
+		// Fetch each resource type concurrently. A snapshot missing a section
+		// would be a misleading archive, so any failure fails the export.
+		var (
+			wg          sync.WaitGroup
+			mu          sync.Mutex
+			firstErr    error
+			project     *pcf.Project
+			hosts       []pcf.Host
+			issues      []pcf.Issue
+			credentials []pcf.Credential
+		)
+
+		fetch := func(f func() error) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := f(); err != nil {
+					mu.Lock()
+					defer mu.Unlock()
+					if firstErr == nil {
+						firstErr = err
+					}
+				}
+			}()
+		}
+
+		fetch(func() (err error) {
+			if project, err = client.GetProject(ctx, projectID); err != nil {
+				return fmt.Errorf("failed to get project: %w", err)
+			}
+			return nil
+		})
+		fetch(func() (err error) {
+			if hosts, err = client.ListHosts(ctx, projectID); err != nil {
+				return fmt.Errorf("failed to list hosts: %w", err)
+			}
+			return nil
+		})
+		fetch(func() (err error) {
+			if issues, err = client.ListIssues(ctx, projectID); err != nil {
+				return fmt.Errorf("failed to list issues: %w", err)
+			}
+			return nil
+		})
+		if includeCredentials {
+			fetch(func() (err error) {
+				if credentials, err = client.ListCredentials(ctx, projectID); err != nil {
+					return fmt.Errorf("failed to list credentials: %w", err)
+				}
+				return nil
+			})
+		}
+
+		wg.Wait()
+
+		if firstErr != nil {
+			return nil, fmt.Errorf("failed to export project '%s': %w", projectID, firstErr)
+		}
+
+		if hosts == nil {
+			hosts = []pcf.Host{}
+		}
+		if issues == nil {
+			issues = []pcf.Issue{}
+		}
+
+		counts := map[string]int{
+			searchTypeHosts:  len(hosts),
+			searchTypeIssues: len(issues),
+		}
+
+		response := map[string]interface{}{
+			"exported_at": time.Now().UTC().Format(time.RFC3339),
+			"project":     project,
+			"hosts":       hosts,
+			"issues":      issues,
+			"counts":      counts,
+		}
+
+		if includeCredentials {
+			counts[searchTypeCredentials] = len(credentials)
+
+			if includeValues {
+				if credentials == nil {
+					credentials = []pcf.Credential{}
+				}
+				response["credentials"] = credentials
+			} else {
+				credentialList := make([]map[string]interface{}, 0, len(credentials))
+				for _, cred := range credentials {
+					credentialList = append(credentialList, formatCredential(cred, redactor))
+				}
+				response["credentials"] = credentialList
+			}
+			response["credential_values_included"] = includeValues
+		}
+
+		return response, nil
+	}
+}
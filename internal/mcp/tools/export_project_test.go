@@ -0,0 +1,214 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/auth"
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf/redact"
+)
+
+// newExportMockClient returns the search mock with a project to export
+func newExportMockClient() *MockFullPCFClient {
+	client := newSearchMockClient()
+	client.GetProjectFunc = func(ctx context.Context, projectID string) (*pcf.Project, error) {
+		return &pcf.Project{ID: projectID, Name: "Acme External", Status: "active", Team: []string{"alice"}}, nil
+	}
+	return client
+}
+
+// exportedJSON runs the export tool and returns its result as decoded JSON,
+// the form clients receive
+func exportedJSON(t *testing.T, client ExportProjectClient, allowValues bool, params map[string]interface{}) map[string]interface{} {
+	t.Helper()
+
+	result, err := NewExportProjectTool(client, nil, allowValues).Handler(context.Background(), params)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Failed to encode export: %v", err)
+	}
+
+	var exported map[string]interface{}
+	if err := json.Unmarshal(data, &exported); err != nil {
+		t.Fatalf("Failed to decode export: %v", err)
+	}
+	return exported
+}
+
+// TestNewExportProjectTool tests creating a new export project tool
+func TestNewExportProjectTool(t *testing.T) {
+	tool := NewExportProjectTool(&MockFullPCFClient{}, nil, false)
+
+	if tool.Name != "export_project" {
+		t.Errorf("Expected tool name 'export_project', got '%s'", tool.Name)
+	}
+
+	if tool.Handler == nil {
+		t.Error("Tool handler should not be nil")
+	}
+
+	required, ok := tool.InputSchema["required"].([]string)
+	if !ok || len(required) != 1 || required[0] != "project_id" {
+		t.Errorf("Expected project_id to be required, got %v", tool.InputSchema["required"])
+	}
+}
+
+// TestExportProjectHandler tests the exported document's shape and that
+// credential values are redacted by default
+func TestExportProjectHandler(t *testing.T) {
+	exported := exportedJSON(t, newExportMockClient(), false, map[string]interface{}{"project_id": "proj-1"})
+
+	for _, key := range []string{"exported_at", "project", "hosts", "issues", "credentials", "counts"} {
+		if _, ok := exported[key]; !ok {
+			t.Errorf("Expected %q in the export, got %v", key, exported)
+		}
+	}
+
+	project := exported["project"].(map[string]interface{})
+	if project["id"] != "proj-1" || project["name"] != "Acme External" {
+		t.Errorf("Expected the project, got %v", project)
+	}
+
+	hosts := exported["hosts"].([]interface{})
+	if len(hosts) != 3 || hosts[0].(map[string]interface{})["ip"] != "10.0.1.30" {
+		t.Errorf("Expected the project's hosts, got %v", hosts)
+	}
+
+	issues := exported["issues"].([]interface{})
+	if len(issues) != 2 || issues[1].(map[string]interface{})["host_id"] != "host-2" {
+		t.Errorf("Expected the project's issues, got %v", issues)
+	}
+
+	wantCounts := map[string]interface{}{"hosts": 3.0, "issues": 2.0, "credentials": 2.0}
+	if !reflect.DeepEqual(exported["counts"], wantCounts) {
+		t.Errorf("Expected counts %v, got %v", wantCounts, exported["counts"])
+	}
+
+	credentials := exported["credentials"].([]interface{})
+	if len(credentials) != 2 {
+		t.Fatalf("Expected two credentials, got %v", credentials)
+	}
+	for _, c := range credentials {
+		cred := c.(map[string]interface{})
+		if cred["value"] != redact.DefaultPlaceholder {
+			t.Errorf("Expected the credential value redacted, got %v", cred)
+		}
+	}
+	if exported["credential_values_included"] != false {
+		t.Errorf("Expected credential_values_included false, got %v", exported["credential_values_included"])
+	}
+
+	data, _ := json.Marshal(exported)
+	if strings.Contains(string(data), "s3cret") || strings.Contains(string(data), "hunter2") {
+		t.Errorf("Credential values leaked into the export: %s", data)
+	}
+}
+
+// TestExportProjectHandlerCredentials tests leaving credentials out and
+// exporting their values only when the server allows it and the caller has
+// write scope
+func TestExportProjectHandlerCredentials(t *testing.T) {
+	client := newExportMockClient()
+
+	// Without credentials, they are neither fetched nor counted
+	client.ListCredentialsFunc = func(ctx context.Context, projectID string) ([]pcf.Credential, error) {
+		t.Error("Credentials should not be fetched when excluded")
+		return nil, nil
+	}
+	exported := exportedJSON(t, client, false, map[string]interface{}{"project_id": "proj-1", "include_credentials": false})
+	if _, ok := exported["credentials"]; ok {
+		t.Errorf("Expected no credentials, got %v", exported["credentials"])
+	}
+	if _, ok := exported["counts"].(map[string]interface{})["credentials"]; ok {
+		t.Errorf("Expected no credential count, got %v", exported["counts"])
+	}
+
+	// Values are refused unless the server allows them
+	_, err := NewExportProjectTool(newExportMockClient(), nil, false).Handler(context.Background(), map[string]interface{}{
+		"project_id":                "proj-1",
+		"include_credential_values": true,
+	})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "include_credential_values" {
+		t.Errorf("Expected a validation error for include_credential_values, got %v", err)
+	}
+
+	// Read-only callers are refused even when the server allows values
+	readOnly := auth.WithScopes(context.Background(), []string{mcp.ScopeRead})
+	_, err = NewExportProjectTool(newExportMockClient(), nil, true).Handler(readOnly, map[string]interface{}{
+		"project_id":                "proj-1",
+		"include_credential_values": true,
+	})
+	if !errors.Is(err, mcp.ErrInsufficientScope) {
+		t.Errorf("Expected ErrInsufficientScope for a read-only caller, got %v", err)
+	}
+
+	// With the server's permission the values are exported
+	allowed := newExportMockClient()
+	exported = exportedJSON(t, allowed, true, map[string]interface{}{"project_id": "proj-1"})
+	if cred := exported["credentials"].([]interface{})[0].(map[string]interface{}); cred["value"] != redact.DefaultPlaceholder {
+		t.Errorf("Expected values redacted unless requested, got %v", cred)
+	}
+
+	exported = exportedJSON(t, allowed, true, map[string]interface{}{"project_id": "proj-1", "include_credential_values": true})
+	if cred := exported["credentials"].([]interface{})[0].(map[string]interface{}); cred["value"] != "s3cret" {
+		t.Errorf("Expected the raw credential value, got %v", cred)
+	}
+	if exported["credential_values_included"] != true {
+		t.Errorf("Expected credential_values_included true, got %v", exported["credential_values_included"])
+	}
+}
+
+// TestExportProjectHandlerError tests that a failed fetch fails the export
+func TestExportProjectHandlerError(t *testing.T) {
+	client := newExportMockClient()
+	client.ListIssuesFunc = func(ctx context.Context, projectID string) ([]pcf.Issue, error) {
+		return nil, errors.New("connection refused")
+	}
+
+	_, err := NewExportProjectTool(client, nil, false).Handler(context.Background(), map[string]interface{}{"project_id": "proj-1"})
+	if err == nil || !strings.Contains(err.Error(), "failed to list issues: connection refused") {
+		t.Errorf("Expected the issue listing error, got %v", err)
+	}
+}
+
+// TestExportProjectInvalidParamsResponse tests that the HTTP transport
+// reports a bad project_id with the invalid_params code
+func TestExportProjectInvalidParamsResponse(t *testing.T) {
+	server, err := mcp.NewServer(config.ServerConfig{Transport: "http"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	if err := server.RegisterTool(NewExportProjectTool(newExportMockClient(), nil, false)); err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/tools/export_project", strings.NewReader(`{"project_id":""}`))
+	w := httptest.NewRecorder()
+	server.HTTPHandler().ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response mcp.ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode error response: %v", err)
+	}
+	if response.Error.Code != mcp.CodeInvalidParams || response.Error.Details["field"] != "project_id" {
+		t.Errorf("Expected code %q for project_id, got %+v", mcp.CodeInvalidParams, response.Error)
+	}
+}
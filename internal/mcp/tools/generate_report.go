@@ -13,11 +13,17 @@ type GenerateReportClient interface {
 	GenerateReport(ctx context.Context, projectID string, req pcf.GenerateReportRequest) (*pcf.Report, error)
 }
 
+// maxIdempotencyKeyLength caps the length of a caller-supplied idempotency key
+const maxIdempotencyKeyLength = 255
+
 // NewGenerateReportTool creates an MCP tool for generating reports from a PCF project
 func NewGenerateReportTool(client GenerateReportClient) mcp.Tool {
 	return mcp.Tool{
-		Name:        "generate_report",
-		Description: "Generate a security assessment report for a PCF project",
+		Name:          "generate_report",
+		Description:   "Generate a security assessment report for a PCF project",
+		Category:      categoryReports,
+		Tags:          []string{categoryReports, tagWrite},
+		RequiredScope: mcp.ScopeWrite,
 		InputSchema: map[string]interface{}{
 			"type": "object",
 			"properties": map[string]interface{}{
@@ -52,6 +58,11 @@ func NewGenerateReportTool(client GenerateReportClient) mcp.Tool {
 						"type": "string",
 					},
 				},
+				"idempotency_key": map[string]interface{}{
+					"type":        "string",
+					"description": "Key PCF uses to deduplicate retries of this request; reuse the returned key to retry without starting a second report job. Generated when omitted",
+					"maxLength":   maxIdempotencyKeyLength,
+				},
 			},
 			"required":             []string{"project_id", "format"},
 			"additionalProperties": false,
@@ -132,6 +143,13 @@ func createGenerateReportHandler(client GenerateReportClient) mcp.ToolHandler {
 			}
 		}
 
+		// Send an idempotency key so retries do not start duplicate report jobs
+		idempotencyKey, err := reportIdempotencyKey(params)
+		if err != nil {
+			return nil, err
+		}
+		req.IdempotencyKey = idempotencyKey
+
 		// Call PCF client to generate report
 		report, err := client.GenerateReport(ctx, projectID, req)
 		if err != nil {
@@ -174,14 +192,40 @@ func createGenerateReportHandler(client GenerateReportClient) mcp.ToolHandler {
 		}
 
 		response := map[string]interface{}{
-			"report":  reportMap,
-			"message": message,
+			"report":          reportMap,
+			"message":         message,
+			"idempotency_key": idempotencyKey,
 		}
 
 		return response, nil
 	}
 }
 
+// reportIdempotencyKey returns the idempotency_key param, or a new key when
+// it is omitted. Keys are sent as a header, so only printable ASCII without
+// spaces is accepted.
+func reportIdempotencyKey(params map[string]interface{}) (string, error) {
+	raw, ok := params["idempotency_key"]
+	if !ok {
+		return pcf.NewIdempotencyKey()
+	}
+
+	key, ok := raw.(string)
+	if !ok {
+		return "", fmt.Errorf("idempotency_key parameter must be a string")
+	}
+	if key == "" || len(key) > maxIdempotencyKeyLength {
+		return "", fmt.Errorf("idempotency_key must be 1 to %d characters", maxIdempotencyKeyLength)
+	}
+	for _, c := range key {
+		if c < '!' || c > '~' {
+			return "", fmt.Errorf("idempotency_key must contain only printable ASCII characters without spaces")
+		}
+	}
+
+	return key, nil
+}
+
 // formatBytes converts bytes to human-readable format
 func formatBytes(bytes int64) string {
 	const unit = 1024
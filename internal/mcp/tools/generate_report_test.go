@@ -363,3 +363,43 @@ func TestGenerateReportHandler(t *testing.T) {
 		})
 	}
 }
+
+// TestGenerateReportIdempotencyKey tests that the idempotency key is passed
+// to PCF and returned, whether given or generated
+func TestGenerateReportIdempotencyKey(t *testing.T) {
+	var sent []string
+	tool := NewGenerateReportTool(&MockGenerateReportClient{
+		GenerateReportFunc: func(ctx context.Context, projectID string, req pcf.GenerateReportRequest) (*pcf.Report, error) {
+			sent = append(sent, req.IdempotencyKey)
+			return &pcf.Report{ID: "report-1", Format: req.Format, Status: "in_progress"}, nil
+		},
+	})
+
+	// A generated key is returned so the caller can retry with it
+	result, err := tool.Handler(context.Background(), map[string]interface{}{"project_id": "proj-123", "format": "pdf"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	generated, _ := result.(map[string]interface{})["idempotency_key"].(string)
+	if generated == "" || sent[0] != generated {
+		t.Fatalf("Expected the generated key to be sent and returned, sent %q and returned %q", sent[0], generated)
+	}
+
+	// Retrying with the returned key reuses it
+	result, err = tool.Handler(context.Background(), map[string]interface{}{"project_id": "proj-123", "format": "pdf", "idempotency_key": generated})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sent[1] != generated || result.(map[string]interface{})["idempotency_key"] != generated {
+		t.Errorf("Expected the given key %q to be reused, sent %q", generated, sent[1])
+	}
+
+	for _, key := range []interface{}{"", "has space", "line\nbreak", 42} {
+		if _, err := tool.Handler(context.Background(), map[string]interface{}{"project_id": "proj-123", "format": "pdf", "idempotency_key": key}); err == nil {
+			t.Errorf("Expected an error for idempotency key %q", key)
+		}
+	}
+	if len(sent) != 2 {
+		t.Errorf("Expected invalid keys to be rejected before calling PCF, got %d calls", len(sent))
+	}
+}
@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// GetHostClient defines the interface for fetching a single host
+type GetHostClient interface {
+	GetHost(ctx context.Context, projectID, hostID string) (*pcf.Host, error)
+}
+
+// NewGetHostTool creates an MCP tool for fetching a single host from a PCF project
+func NewGetHostTool(client GetHostClient) mcp.Tool {
+	return mcp.Tool{
+		Name:          "get_host",
+		Description:   "Get full details of a specific host in a PCF project",
+		Category:      categoryHosts,
+		Tags:          []string{categoryHosts, tagRead},
+		RequiredScope: mcp.ScopeRead,
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"project_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the project the host belongs to",
+				},
+				"host_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the host to fetch",
+				},
+			},
+			"required":             []string{"project_id", "host_id"},
+			"additionalProperties": false,
+		},
+		Handler: createGetHostHandler(client),
+	}
+}
+
+// createGetHostHandler creates the handler function for fetching a host
+func createGetHostHandler(client GetHostClient) mcp.ToolHandler {
+	return func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+		// Extract and validate project_id
+		projectID, ok := params["project_id"].(string)
+		if !ok {
+			return nil, fmt.Errorf("project_id parameter must be a string")
+		}
+
+		if projectID == "" {
+			return nil, fmt.Errorf("project_id cannot be empty")
+		}
+
+		// Extract and validate host_id
+		hostID, ok := params["host_id"].(string)
+		if !ok {
+			return nil, fmt.Errorf("host_id parameter must be a string")
+		}
+
+		if hostID == "" {
+			return nil, fmt.Errorf("host_id cannot be empty")
+		}
+
+		// Call PCF client to fetch the host
+		host, err := client.GetHost(ctx, projectID, hostID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get host: %w", err)
+		}
+
+		hostMap := map[string]interface{}{
+			"id":         host.ID,
+			"project_id": host.ProjectID,
+			"ip":         host.IP,
+		}
+
+		// Add optional fields if present
+		if host.Hostname != "" {
+			hostMap["hostname"] = host.Hostname
+		}
+
+		if host.OS != "" {
+			hostMap["os"] = host.OS
+		}
+
+		addServices(hostMap, host)
+		addHostTags(hostMap, host)
+
+		if host.Status != "" {
+			hostMap["status"] = host.Status
+		}
+
+		// Build response
+		response := map[string]interface{}{
+			"host": hostMap,
+		}
+
+		return response, nil
+	}
+}
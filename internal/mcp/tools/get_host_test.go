@@ -0,0 +1,116 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// MockGetHostClient implements GetHostClient for testing
+type MockGetHostClient struct {
+	GetHostFunc func(ctx context.Context, projectID, hostID string) (*pcf.Host, error)
+}
+
+func (m *MockGetHostClient) GetHost(ctx context.Context, projectID, hostID string) (*pcf.Host, error) {
+	if m.GetHostFunc != nil {
+		return m.GetHostFunc(ctx, projectID, hostID)
+	}
+	return nil, errors.New("GetHostFunc not implemented")
+}
+
+// TestNewGetHostTool tests creating a new get host tool
+func TestNewGetHostTool(t *testing.T) {
+	tool := NewGetHostTool(&MockGetHostClient{})
+
+	if tool.Name != "get_host" {
+		t.Errorf("Expected tool name 'get_host', got '%s'", tool.Name)
+	}
+
+	if tool.Description == "" {
+		t.Error("Tool description should not be empty")
+	}
+
+	if tool.Handler == nil {
+		t.Error("Tool handler should not be nil")
+	}
+
+	required, ok := tool.InputSchema["required"].([]string)
+	if !ok || len(required) != 2 {
+		t.Errorf("Expected project_id and host_id to be required, got %v", tool.InputSchema["required"])
+	}
+}
+
+// TestGetHostHandler tests the get host handler
+func TestGetHostHandler(t *testing.T) {
+	client := &MockGetHostClient{
+		GetHostFunc: func(ctx context.Context, projectID, hostID string) (*pcf.Host, error) {
+			if hostID != "host-1" {
+				return nil, fmt.Errorf("PCF API error: %w", pcf.ErrNotFound)
+			}
+			return &pcf.Host{
+				ID:        hostID,
+				ProjectID: projectID,
+				IP:        "10.0.0.1",
+				Hostname:  "web01",
+				OS:        "Linux",
+				Services:  []string{"ssh", "http"},
+				Status:    "active",
+			}, nil
+		},
+	}
+
+	tool := NewGetHostTool(client)
+
+	result, err := tool.Handler(context.Background(), map[string]interface{}{
+		"project_id": "proj-123",
+		"host_id":    "host-1",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	host := result.(map[string]interface{})["host"].(map[string]interface{})
+	if host["id"] != "host-1" || host["ip"] != "10.0.0.1" {
+		t.Errorf("Unexpected host: %v", host)
+	}
+
+	if host["hostname"] != "web01" || host["os"] != "Linux" || host["status"] != "active" {
+		t.Errorf("Expected optional fields in host, got %v", host)
+	}
+
+	// Missing hosts keep ErrNotFound in the error chain
+	_, err = tool.Handler(context.Background(), map[string]interface{}{
+		"project_id": "proj-123",
+		"host_id":    "missing",
+	})
+	if !errors.Is(err, pcf.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+// TestGetHostHandlerErrors tests parameter validation for the get host handler
+func TestGetHostHandlerErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+	}{
+		{name: "Missing project_id", params: map[string]interface{}{"host_id": "host-1"}},
+		{name: "Empty project_id", params: map[string]interface{}{"project_id": "", "host_id": "host-1"}},
+		{name: "Missing host_id", params: map[string]interface{}{"project_id": "proj-123"}},
+		{name: "Empty host_id", params: map[string]interface{}{"project_id": "proj-123", "host_id": ""}},
+		{name: "Invalid host_id type", params: map[string]interface{}{"project_id": "proj-123", "host_id": 1}},
+	}
+
+	tool := NewGetHostTool(&MockGetHostClient{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tool.Handler(context.Background(), tt.params); err == nil {
+				t.Error("Expected error but got none")
+			}
+		})
+	}
+}
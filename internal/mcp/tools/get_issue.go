@@ -0,0 +1,106 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// GetIssueClient defines the interface for fetching a single issue
+type GetIssueClient interface {
+	GetIssue(ctx context.Context, projectID, issueID string) (*pcf.Issue, error)
+}
+
+// NewGetIssueTool creates an MCP tool for fetching a single issue from a PCF project
+func NewGetIssueTool(client GetIssueClient) mcp.Tool {
+	return mcp.Tool{
+		Name:          "get_issue",
+		Description:   "Get full details of a specific security issue or finding in a PCF project",
+		Category:      categoryIssues,
+		Tags:          []string{categoryIssues, tagRead},
+		RequiredScope: mcp.ScopeRead,
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"project_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the project the issue belongs to",
+				},
+				"issue_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the issue to fetch",
+				},
+			},
+			"required":             []string{"project_id", "issue_id"},
+			"additionalProperties": false,
+		},
+		Handler: createGetIssueHandler(client),
+	}
+}
+
+// createGetIssueHandler creates the handler function for fetching an issue
+func createGetIssueHandler(client GetIssueClient) mcp.ToolHandler {
+	return func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+		// Extract and validate project_id
+		projectID, ok := params["project_id"].(string)
+		if !ok {
+			return nil, fmt.Errorf("project_id parameter must be a string")
+		}
+
+		if projectID == "" {
+			return nil, fmt.Errorf("project_id cannot be empty")
+		}
+
+		// Extract and validate issue_id
+		issueID, ok := params["issue_id"].(string)
+		if !ok {
+			return nil, fmt.Errorf("issue_id parameter must be a string")
+		}
+
+		if issueID == "" {
+			return nil, fmt.Errorf("issue_id cannot be empty")
+		}
+
+		// Call PCF client to fetch the issue
+		issue, err := client.GetIssue(ctx, projectID, issueID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issue: %w", err)
+		}
+
+		issueMap := map[string]interface{}{
+			"id":          issue.ID,
+			"project_id":  issue.ProjectID,
+			"title":       issue.Title,
+			"description": issue.Description,
+			"severity":    issue.Severity,
+			"status":      issue.Status,
+		}
+
+		// Add optional fields if present
+		if issue.HostID != "" {
+			issueMap["host_id"] = issue.HostID
+		}
+
+		if issue.CVE != "" {
+			issueMap["cve"] = issue.CVE
+		}
+
+		if issue.CVSS > 0 {
+			issueMap["cvss"] = issue.CVSS
+		}
+
+		if !issue.UpdatedAt.IsZero() {
+			issueMap["updated_at"] = issue.UpdatedAt.UTC().Format(time.RFC3339)
+		}
+
+		// Build response
+		response := map[string]interface{}{
+			"issue": issueMap,
+		}
+
+		return response, nil
+	}
+}
@@ -0,0 +1,118 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// MockGetIssueClient implements GetIssueClient for testing
+type MockGetIssueClient struct {
+	GetIssueFunc func(ctx context.Context, projectID, issueID string) (*pcf.Issue, error)
+}
+
+func (m *MockGetIssueClient) GetIssue(ctx context.Context, projectID, issueID string) (*pcf.Issue, error) {
+	if m.GetIssueFunc != nil {
+		return m.GetIssueFunc(ctx, projectID, issueID)
+	}
+	return nil, errors.New("GetIssueFunc not implemented")
+}
+
+// TestNewGetIssueTool tests creating a new get issue tool
+func TestNewGetIssueTool(t *testing.T) {
+	tool := NewGetIssueTool(&MockGetIssueClient{})
+
+	if tool.Name != "get_issue" {
+		t.Errorf("Expected tool name 'get_issue', got '%s'", tool.Name)
+	}
+
+	if tool.Description == "" {
+		t.Error("Tool description should not be empty")
+	}
+
+	if tool.Handler == nil {
+		t.Error("Tool handler should not be nil")
+	}
+
+	required, ok := tool.InputSchema["required"].([]string)
+	if !ok || len(required) != 2 {
+		t.Errorf("Expected project_id and issue_id to be required, got %v", tool.InputSchema["required"])
+	}
+}
+
+// TestGetIssueHandler tests the get issue handler
+func TestGetIssueHandler(t *testing.T) {
+	client := &MockGetIssueClient{
+		GetIssueFunc: func(ctx context.Context, projectID, issueID string) (*pcf.Issue, error) {
+			if issueID != "issue-1" {
+				return nil, fmt.Errorf("PCF API error: %w", pcf.ErrNotFound)
+			}
+			return &pcf.Issue{
+				ID:          issueID,
+				ProjectID:   projectID,
+				HostID:      "host-1",
+				Title:       "SQL Injection",
+				Description: "Login form is injectable",
+				Severity:    "High",
+				Status:      "Open",
+				CVE:         "CVE-2024-0001",
+				CVSS:        8.1,
+			}, nil
+		},
+	}
+
+	tool := NewGetIssueTool(client)
+
+	result, err := tool.Handler(context.Background(), map[string]interface{}{
+		"project_id": "proj-123",
+		"issue_id":   "issue-1",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	issue := result.(map[string]interface{})["issue"].(map[string]interface{})
+	if issue["id"] != "issue-1" || issue["title"] != "SQL Injection" || issue["severity"] != "High" {
+		t.Errorf("Unexpected issue: %v", issue)
+	}
+
+	if issue["host_id"] != "host-1" || issue["cve"] != "CVE-2024-0001" || issue["cvss"] != 8.1 {
+		t.Errorf("Expected optional fields in issue, got %v", issue)
+	}
+
+	// Missing issues keep ErrNotFound in the error chain
+	_, err = tool.Handler(context.Background(), map[string]interface{}{
+		"project_id": "proj-123",
+		"issue_id":   "missing",
+	})
+	if !errors.Is(err, pcf.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+// TestGetIssueHandlerErrors tests parameter validation for the get issue handler
+func TestGetIssueHandlerErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+	}{
+		{name: "Missing project_id", params: map[string]interface{}{"issue_id": "issue-1"}},
+		{name: "Empty project_id", params: map[string]interface{}{"project_id": "", "issue_id": "issue-1"}},
+		{name: "Missing issue_id", params: map[string]interface{}{"project_id": "proj-123"}},
+		{name: "Empty issue_id", params: map[string]interface{}{"project_id": "proj-123", "issue_id": ""}},
+		{name: "Invalid issue_id type", params: map[string]interface{}{"project_id": "proj-123", "issue_id": 1}},
+	}
+
+	tool := NewGetIssueTool(&MockGetIssueClient{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tool.Handler(context.Background(), tt.params); err == nil {
+				t.Error("Expected error but got none")
+			}
+		})
+	}
+}
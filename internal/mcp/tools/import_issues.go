@@ -0,0 +1,182 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf/issueimport"
+)
+
+// maxImportIssues caps the number of rows accepted in a single import_issues call
+const maxImportIssues = 1000
+
+// ImportIssuesClient defines the interface for importing issues from CSV
+type ImportIssuesClient interface {
+	ListHostsClient
+	CreateIssueClient
+}
+
+// NewImportIssuesTool creates an MCP tool for importing issues from CSV into a PCF project
+func NewImportIssuesTool(client ImportIssuesClient) mcp.Tool {
+	return mcp.Tool{
+		Name:          "import_issues",
+		Description:   "Import security issues into a PCF project from CSV with the header title,description,severity,cve,cvss,host_ip",
+		Category:      categoryIssues,
+		Tags:          []string{categoryIssues, tagWrite},
+		RequiredScope: mcp.ScopeWrite,
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"project_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the project to import issues into",
+				},
+				"csv": map[string]interface{}{
+					"type":        "string",
+					"description": "CSV with a header row naming title, description, and severity, and optionally cve, cvss, and host_ip",
+					"minLength":   1,
+				},
+			},
+			"required":             []string{"project_id", "csv"},
+			"additionalProperties": false,
+		},
+		Handler: createImportIssuesHandler(client),
+	}
+}
+
+// createImportIssuesHandler creates the handler function for importing issues
+func createImportIssuesHandler(client ImportIssuesClient) mcp.ToolHandler {
+	return func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+		// Extract and validate project_id
+		projectID, ok := params["project_id"].(string)
+		if !ok {
+			return nil, invalidParam("project_id", "must be a string")
+		}
+
+		if projectID == "" {
+			return nil, invalidParam("project_id", "cannot be empty")
+		}
+
+		// Extract and validate csv
+		content, ok := params["csv"].(string)
+		if !ok {
+			return nil, invalidParam("csv", "must be a string")
+		}
+
+		if strings.TrimSpace(content) == "" {
+			return nil, invalidParam("csv", "cannot be empty")
+		}
+
+		rows, err := issueimport.ParseCSV(strings.NewReader(content))
+		if err != nil {
+			return nil, invalidParam("csv", "%v", err)
+		}
+
+		if len(rows) == 0 {
+			return nil, invalidParam("csv", "contains no issues")
+		}
+
+		if len(rows) > maxImportIssues {
+			return nil, invalidParam("csv", "has %d issues, more than the maximum of %d", len(rows), maxImportIssues)
+		}
+
+		// Resolve host IPs only when some row names one
+		var hostIDs map[string]string
+		for _, row := range rows {
+			if row.Err == nil && row.HostIP != "" {
+				hostIDs, err = hostIDsByIP(ctx, client, projectID)
+				if err != nil {
+					return nil, err
+				}
+				break
+			}
+		}
+
+		// Invalid rows are reported individually instead of failing the import
+		results := make([]map[string]interface{}, 0, len(rows))
+		createdCount := 0
+		for _, row := range rows {
+			if row.Err != nil {
+				results = append(results, importFailure(row, row.Err))
+				continue
+			}
+
+			req := row.Issue
+			if row.HostIP != "" {
+				hostID, ok := hostIDs[row.HostIP]
+				if !ok {
+					results = append(results, importFailure(row, fmt.Errorf("no host with IP %s in project %s", row.HostIP, projectID)))
+					continue
+				}
+				req.HostID = hostID
+			}
+
+			issue, err := client.CreateIssue(ctx, projectID, req)
+			if err != nil {
+				results = append(results, importFailure(row, fmt.Errorf("failed to create issue: %w", err)))
+				continue
+			}
+
+			issueMap := map[string]interface{}{
+				"id":       issue.ID,
+				"title":    issue.Title,
+				"severity": issue.Severity,
+			}
+
+			if issue.HostID != "" {
+				issueMap["host_id"] = issue.HostID
+			}
+
+			results = append(results, map[string]interface{}{
+				"line":    row.Line,
+				"success": true,
+				"issue":   issueMap,
+			})
+			createdCount++
+		}
+
+		// Build response
+		response := map[string]interface{}{
+			"project_id":    projectID,
+			"results":       results,
+			"total_count":   len(results),
+			"created_count": createdCount,
+			"failed_count":  len(results) - createdCount,
+			"message":       fmt.Sprintf("Imported %d of %d issues into project %s", createdCount, len(results), projectID),
+		}
+
+		return response, nil
+	}
+}
+
+// hostIDsByIP maps the normalized IP of every host in the project to its ID
+func hostIDsByIP(ctx context.Context, client ListHostsClient, projectID string) (map[string]string, error) {
+	hosts, err := client.ListHosts(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list hosts: %w", err)
+	}
+
+	ids := make(map[string]string, len(hosts))
+	for _, host := range hosts {
+		ids[canonicalIP(host.IP)] = host.ID
+	}
+
+	return ids, nil
+}
+
+// importFailure builds the result entry for a CSV row that could not be imported
+func importFailure(row issueimport.Row, err error) map[string]interface{} {
+	result := map[string]interface{}{
+		"line":    row.Line,
+		"success": false,
+		"error":   err.Error(),
+	}
+
+	if row.Issue.Title != "" {
+		result["title"] = row.Issue.Title
+	}
+
+	return result
+}
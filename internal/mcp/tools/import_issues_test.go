@@ -0,0 +1,183 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// importIssuesCSV mixes valid rows with rows that fail validation, host
+// resolution, and creation in PCF
+const importIssuesCSV = `title,description,severity,cve,cvss,host_ip
+Outdated Apache,Apache 2.4.49 is vulnerable,High,CVE-2021-41773,7.5,10.0.1.30
+Weak TLS,TLS 1.0 is enabled,Severe,,,
+Default credentials,Admin uses the default password,critical,,,
+Unknown host,Host is not in the project,Medium,,,10.0.9.9
+Bad CVSS,CVSS is not a number,Low,,n/a,
+Rejected by PCF,PCF refuses this one,Info,,,
+`
+
+// TestNewImportIssuesTool tests creating a new import issues tool
+func TestNewImportIssuesTool(t *testing.T) {
+	tool := NewImportIssuesTool(&MockFullPCFClient{})
+
+	if tool.Name != "import_issues" {
+		t.Errorf("Expected tool name 'import_issues', got '%s'", tool.Name)
+	}
+
+	if tool.Description == "" {
+		t.Error("Tool description should not be empty")
+	}
+
+	if tool.Handler == nil {
+		t.Error("Tool handler should not be nil")
+	}
+
+	required, ok := tool.InputSchema["required"].([]string)
+	if !ok {
+		t.Fatal("Input schema should have required fields")
+	}
+
+	if len(required) != 2 || required[0] != "project_id" || required[1] != "csv" {
+		t.Errorf("Expected required fields [project_id csv], got %v", required)
+	}
+}
+
+// TestImportIssuesHandler tests that valid rows are created and every other
+// row is reported without aborting the import
+func TestImportIssuesHandler(t *testing.T) {
+	var created []pcf.CreateIssueRequest
+
+	client := &MockFullPCFClient{
+		ListHostsFunc: func(ctx context.Context, projectID string) ([]pcf.Host, error) {
+			return []pcf.Host{
+				{ID: "host-1", ProjectID: projectID, IP: "10.0.1.30"},
+			}, nil
+		},
+		CreateIssueFunc: func(ctx context.Context, projectID string, req pcf.CreateIssueRequest) (*pcf.Issue, error) {
+			if req.Title == "Rejected by PCF" {
+				return nil, errors.New("issue already exists")
+			}
+			created = append(created, req)
+			return &pcf.Issue{ID: "issue-new", ProjectID: projectID, HostID: req.HostID, Title: req.Title, Severity: req.Severity}, nil
+		},
+	}
+
+	tool := NewImportIssuesTool(client)
+
+	result, err := tool.Handler(context.Background(), map[string]interface{}{
+		"project_id": "proj-123",
+		"csv":        importIssuesCSV,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(created) != 2 {
+		t.Fatalf("Expected 2 issues to be created, got %d", len(created))
+	}
+
+	if created[0].HostID != "host-1" || created[0].CVE != "CVE-2021-41773" || created[0].CVSS != 7.5 {
+		t.Errorf("Unexpected issue request: %+v", created[0])
+	}
+
+	if created[1].Severity != "Critical" || created[1].HostID != "" {
+		t.Errorf("Expected a host-less Critical issue, got %+v", created[1])
+	}
+
+	res := result.(map[string]interface{})
+
+	if res["total_count"] != 6 || res["created_count"] != 2 || res["failed_count"] != 4 {
+		t.Errorf("Expected 6 rows with 2 created and 4 failed, got %v/%v/%v", res["total_count"], res["created_count"], res["failed_count"])
+	}
+
+	wantErrors := map[int]string{
+		3: "severity must be one of",
+		5: "no host with IP 10.0.9.9",
+		6: "cvss must be a number",
+		7: "failed to create issue",
+	}
+	for _, entry := range res["results"].([]map[string]interface{}) {
+		line := entry["line"].(int)
+		wantErr, shouldFail := wantErrors[line]
+		if entry["success"] != !shouldFail {
+			t.Errorf("Line %d: expected success %v, got %v", line, !shouldFail, entry["success"])
+			continue
+		}
+		if shouldFail {
+			if msg, _ := entry["error"].(string); !strings.Contains(msg, wantErr) {
+				t.Errorf("Line %d: expected error containing %q, got %v", line, wantErr, entry["error"])
+			}
+		}
+	}
+}
+
+// TestImportIssuesHandlerErrors tests errors that fail the whole import.
+// Parameter errors are ValidationErrors naming the field.
+func TestImportIssuesHandlerErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		params    map[string]interface{}
+		listErr   error
+		errSubstr string
+		errField  string
+	}{
+		{
+			name:      "Missing project_id",
+			params:    map[string]interface{}{"csv": importIssuesCSV},
+			errSubstr: "must be a string",
+			errField:  "project_id",
+		},
+		{
+			name:      "Empty CSV",
+			params:    map[string]interface{}{"project_id": "proj-123", "csv": "  "},
+			errSubstr: "cannot be empty",
+			errField:  "csv",
+		},
+		{
+			name:      "Header only",
+			params:    map[string]interface{}{"project_id": "proj-123", "csv": "title,description,severity\n"},
+			errSubstr: "contains no issues",
+			errField:  "csv",
+		},
+		{
+			name:      "Invalid header",
+			params:    map[string]interface{}{"project_id": "proj-123", "csv": "name,severity\nSQLi,High\n"},
+			errSubstr: "unknown CSV column",
+			errField:  "csv",
+		},
+		{
+			name:      "Host lookup failure",
+			params:    map[string]interface{}{"project_id": "proj-123", "csv": importIssuesCSV},
+			listErr:   errors.New("connection refused"),
+			errSubstr: "failed to list hosts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &MockFullPCFClient{
+				ListHostsFunc: func(ctx context.Context, projectID string) ([]pcf.Host, error) {
+					return nil, tt.listErr
+				},
+			}
+
+			_, err := NewImportIssuesTool(client).Handler(context.Background(), tt.params)
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Errorf("Expected error containing %q, got %v", tt.errSubstr, err)
+			}
+
+			var validationErr *ValidationError
+			isValidation := errors.As(err, &validationErr)
+			if tt.errField == "" && isValidation {
+				t.Errorf("Expected a PCF error, got ValidationError %v", err)
+			}
+			if tt.errField != "" && (!isValidation || validationErr.Field != tt.errField) {
+				t.Errorf("Expected a ValidationError for %q, got %v", tt.errField, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf/scanimport"
+)
+
+// ImportScanClient defines the interface for importing scan results as hosts
+type ImportScanClient interface {
+	ListHostsClient
+	AddHostClient
+}
+
+// NewImportScanTool creates an MCP tool for importing nmap XML results into a PCF project
+func NewImportScanTool(client ImportScanClient) mcp.Tool {
+	return mcp.Tool{
+		Name:          "import_scan",
+		Description:   "Import hosts and open services from nmap XML output (nmap -oX) into a PCF project",
+		Category:      categoryHosts,
+		Tags:          []string{categoryHosts, tagWrite},
+		RequiredScope: mcp.ScopeWrite,
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"project_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the project to import hosts into",
+				},
+				"nmap_xml": map[string]interface{}{
+					"type":        "string",
+					"description": "The nmap XML document to import",
+					"minLength":   1,
+				},
+			},
+			"required":             []string{"project_id", "nmap_xml"},
+			"additionalProperties": false,
+		},
+		Handler: createImportScanHandler(client),
+	}
+}
+
+// createImportScanHandler creates the handler function for importing scans
+func createImportScanHandler(client ImportScanClient) mcp.ToolHandler {
+	return func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+		// Extract and validate project_id
+		projectID, ok := params["project_id"].(string)
+		if !ok {
+			return nil, fmt.Errorf("project_id parameter must be a string")
+		}
+
+		if projectID == "" {
+			return nil, fmt.Errorf("project_id cannot be empty")
+		}
+
+		// Extract and validate nmap_xml
+		nmapXML, ok := params["nmap_xml"].(string)
+		if !ok {
+			return nil, fmt.Errorf("nmap_xml parameter must be a string")
+		}
+
+		if strings.TrimSpace(nmapXML) == "" {
+			return nil, fmt.Errorf("nmap_xml cannot be empty")
+		}
+
+		scanned, err := scanimport.ParseNmapXML(strings.NewReader(nmapXML))
+		if err != nil {
+			return nil, err
+		}
+
+		// Load existing hosts so duplicates are skipped rather than re-created
+		existing, err := client.ListHosts(ctx, projectID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list hosts: %w", err)
+		}
+
+		existingByIP := make(map[string]map[string]bool, len(existing))
+		existingIDs := make(map[string]string, len(existing))
+		for _, host := range existing {
+			services := make(map[string]bool, len(host.Services))
+			for _, svc := range host.Services {
+				services[svc] = true
+			}
+			ip := canonicalIP(host.IP)
+			existingByIP[ip] = services
+			existingIDs[ip] = host.ID
+		}
+
+		created := make([]map[string]interface{}, 0)
+		skipped := make([]map[string]interface{}, 0)
+		failed := make([]map[string]interface{}, 0)
+
+		for _, host := range scanned {
+			req := host.CreateHostRequest()
+
+			// Skip hosts already in PCF, reporting services the scan found that PCF lacks
+			if services, ok := existingByIP[req.IP]; ok {
+				entry := map[string]interface{}{
+					"ip":     req.IP,
+					"reason": "host already exists",
+				}
+				if id := existingIDs[req.IP]; id != "" {
+					entry["host_id"] = id
+				}
+
+				var newServices []string
+				for _, svc := range req.Services {
+					if !services[svc] {
+						newServices = append(newServices, svc)
+					}
+				}
+				if len(newServices) > 0 {
+					entry["new_services"] = newServices
+				}
+
+				skipped = append(skipped, entry)
+				continue
+			}
+
+			added, err := client.AddHost(ctx, projectID, req)
+			if err != nil {
+				failed = append(failed, map[string]interface{}{
+					"ip":    req.IP,
+					"error": err.Error(),
+				})
+				continue
+			}
+
+			// Guard against the same IP appearing twice in one scan
+			existingByIP[req.IP] = make(map[string]bool, len(req.Services))
+			for _, svc := range req.Services {
+				existingByIP[req.IP][svc] = true
+			}
+			existingIDs[req.IP] = added.ID
+
+			hostMap := map[string]interface{}{
+				"id": added.ID,
+				"ip": added.IP,
+			}
+
+			if added.Hostname != "" {
+				hostMap["hostname"] = added.Hostname
+			}
+
+			addServices(hostMap, added)
+			addHostTags(hostMap, added)
+
+			created = append(created, hostMap)
+		}
+
+		// Build response
+		response := map[string]interface{}{
+			"project_id":    projectID,
+			"scanned_count": len(scanned),
+			"created":       created,
+			"created_count": len(created),
+			"skipped":       skipped,
+			"skipped_count": len(skipped),
+			"message":       fmt.Sprintf("Imported %d of %d hosts (%d skipped, %d failed)", len(created), len(scanned), len(skipped), len(failed)),
+		}
+
+		if len(failed) > 0 {
+			response["failed"] = failed
+			response["failed_count"] = len(failed)
+		}
+
+		return response, nil
+	}
+}
@@ -0,0 +1,200 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// importScanXML contains two live hosts, one of which already exists in PCF
+const importScanXML = `<?xml version="1.0"?>
+<nmaprun>
+  <host>
+    <status state="up"/>
+    <address addr="10.0.1.30" addrtype="ipv4"/>
+    <ports>
+      <port protocol="tcp" portid="22"><state state="open"/><service name="ssh"/></port>
+      <port protocol="tcp" portid="3389"><state state="open"/><service name="ms-wbt-server"/></port>
+    </ports>
+  </host>
+  <host>
+    <status state="up"/>
+    <address addr="10.0.1.40" addrtype="ipv4"/>
+    <hostnames><hostname name="files01"/></hostnames>
+    <ports>
+      <port protocol="tcp" portid="445"><state state="open"/><service name="microsoft-ds"/></port>
+    </ports>
+  </host>
+</nmaprun>`
+
+// TestNewImportScanTool tests creating a new import scan tool
+func TestNewImportScanTool(t *testing.T) {
+	tool := NewImportScanTool(&MockFullPCFClient{})
+
+	if tool.Name != "import_scan" {
+		t.Errorf("Expected tool name 'import_scan', got '%s'", tool.Name)
+	}
+
+	if tool.Description == "" {
+		t.Error("Tool description should not be empty")
+	}
+
+	if tool.Handler == nil {
+		t.Error("Tool handler should not be nil")
+	}
+
+	required, ok := tool.InputSchema["required"].([]string)
+	if !ok {
+		t.Fatal("Input schema should have required fields")
+	}
+
+	if len(required) != 2 || required[0] != "project_id" || required[1] != "nmap_xml" {
+		t.Errorf("Expected required fields [project_id nmap_xml], got %v", required)
+	}
+}
+
+// TestImportScanHandler tests importing nmap results with deduplication
+func TestImportScanHandler(t *testing.T) {
+	var added []pcf.CreateHostRequest
+
+	client := &MockFullPCFClient{
+		ListHostsFunc: func(ctx context.Context, projectID string) ([]pcf.Host, error) {
+			return []pcf.Host{
+				{ID: "host-1", ProjectID: projectID, IP: "10.0.1.30", Services: []string{"ssh"}},
+			}, nil
+		},
+		AddHostFunc: func(ctx context.Context, projectID string, req pcf.CreateHostRequest) (*pcf.Host, error) {
+			added = append(added, req)
+			return &pcf.Host{
+				ID:        "host-new",
+				ProjectID: projectID,
+				IP:        req.IP,
+				Hostname:  req.Hostname,
+				Services:  req.Services,
+			}, nil
+		},
+	}
+
+	tool := NewImportScanTool(client)
+
+	result, err := tool.Handler(context.Background(), map[string]interface{}{
+		"project_id": "proj-123",
+		"nmap_xml":   importScanXML,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(added) != 1 {
+		t.Fatalf("Expected 1 host to be added, got %d", len(added))
+	}
+
+	if added[0].IP != "10.0.1.40" || added[0].Hostname != "files01" {
+		t.Errorf("Unexpected host request: %+v", added[0])
+	}
+
+	if len(added[0].Services) != 1 || added[0].Services[0] != "microsoft-ds" {
+		t.Errorf("Expected services [microsoft-ds], got %v", added[0].Services)
+	}
+
+	res := result.(map[string]interface{})
+
+	if res["created_count"] != 1 {
+		t.Errorf("Expected created_count 1, got %v", res["created_count"])
+	}
+
+	if res["skipped_count"] != 1 {
+		t.Errorf("Expected skipped_count 1, got %v", res["skipped_count"])
+	}
+
+	skipped := res["skipped"].([]map[string]interface{})
+	if skipped[0]["host_id"] != "host-1" {
+		t.Errorf("Expected skipped host_id host-1, got %v", skipped[0]["host_id"])
+	}
+
+	newServices, ok := skipped[0]["new_services"].([]string)
+	if !ok || len(newServices) != 1 || newServices[0] != "ms-wbt-server" {
+		t.Errorf("Expected new_services [ms-wbt-server], got %v", skipped[0]["new_services"])
+	}
+
+	if _, ok := res["failed"]; ok {
+		t.Error("Response should not include failures")
+	}
+}
+
+// TestImportScanHandlerErrors tests error handling for the import scan tool
+func TestImportScanHandlerErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		params    map[string]interface{}
+		listError error
+	}{
+		{
+			name:   "Missing project_id",
+			params: map[string]interface{}{"nmap_xml": importScanXML},
+		},
+		{
+			name:   "Missing nmap_xml",
+			params: map[string]interface{}{"project_id": "proj-123"},
+		},
+		{
+			name:   "Malformed XML",
+			params: map[string]interface{}{"project_id": "proj-123", "nmap_xml": "<nmaprun><host>"},
+		},
+		{
+			name:      "PCF API error",
+			params:    map[string]interface{}{"project_id": "proj-123", "nmap_xml": importScanXML},
+			listError: errors.New("PCF API error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &MockFullPCFClient{
+				ListHostsFunc: func(ctx context.Context, projectID string) ([]pcf.Host, error) {
+					return nil, tt.listError
+				},
+			}
+
+			tool := NewImportScanTool(client)
+
+			if _, err := tool.Handler(context.Background(), tt.params); err == nil {
+				t.Error("Expected error but got none")
+			}
+		})
+	}
+}
+
+// TestImportScanHandlerPartialFailure tests that one failed host does not abort the import
+func TestImportScanHandlerPartialFailure(t *testing.T) {
+	client := &MockFullPCFClient{
+		AddHostFunc: func(ctx context.Context, projectID string, req pcf.CreateHostRequest) (*pcf.Host, error) {
+			if req.IP == "10.0.1.30" {
+				return nil, errors.New("PCF API error")
+			}
+			return &pcf.Host{ID: "host-new", IP: req.IP}, nil
+		},
+	}
+
+	tool := NewImportScanTool(client)
+
+	result, err := tool.Handler(context.Background(), map[string]interface{}{
+		"project_id": "proj-123",
+		"nmap_xml":   importScanXML,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	res := result.(map[string]interface{})
+
+	if res["created_count"] != 1 {
+		t.Errorf("Expected created_count 1, got %v", res["created_count"])
+	}
+
+	if res["failed_count"] != 1 {
+		t.Errorf("Expected failed_count 1, got %v", res["failed_count"])
+	}
+}
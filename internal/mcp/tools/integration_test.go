@@ -2,24 +2,100 @@ package tools
 
 import (
 	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/aRustyDev/pcf-mcp/internal/auth"
 	"github.com/aRustyDev/pcf-mcp/internal/config"
 	"github.com/aRustyDev/pcf-mcp/internal/mcp"
 	"github.com/aRustyDev/pcf-mcp/internal/pcf"
 )
 
-// MockFullPCFClient implements all PCF client interfaces for testing
+// MockFullPCFClient implements pcf.API for testing. Methods without a func
+// set return zero values.
 type MockFullPCFClient struct {
-	ListProjectsFunc    func(ctx context.Context) ([]pcf.Project, error)
-	CreateProjectFunc   func(ctx context.Context, req pcf.CreateProjectRequest) (*pcf.Project, error)
-	ListHostsFunc       func(ctx context.Context, projectID string) ([]pcf.Host, error)
-	AddHostFunc         func(ctx context.Context, projectID string, req pcf.CreateHostRequest) (*pcf.Host, error)
-	ListIssuesFunc      func(ctx context.Context, projectID string) ([]pcf.Issue, error)
-	CreateIssueFunc     func(ctx context.Context, projectID string, req pcf.CreateIssueRequest) (*pcf.Issue, error)
-	ListCredentialsFunc func(ctx context.Context, projectID string) ([]pcf.Credential, error)
-	AddCredentialFunc   func(ctx context.Context, projectID string, req pcf.AddCredentialRequest) (*pcf.Credential, error)
-	GenerateReportFunc  func(ctx context.Context, projectID string, req pcf.GenerateReportRequest) (*pcf.Report, error)
+	PingFunc                func(ctx context.Context) error
+	ListProjectsFunc        func(ctx context.Context) ([]pcf.Project, error)
+	ListProjectsPageFunc    func(ctx context.Context, opts pcf.ListOptions) ([]pcf.Project, *pcf.PageInfo, error)
+	GetProjectFunc          func(ctx context.Context, projectID string) (*pcf.Project, error)
+	ListHostsPageFunc       func(ctx context.Context, projectID string, opts pcf.ListOptions) ([]pcf.Host, *pcf.PageInfo, error)
+	ListIssuesPageFunc      func(ctx context.Context, projectID string, opts pcf.ListOptions) ([]pcf.Issue, *pcf.PageInfo, error)
+	ListCredentialsPageFunc func(ctx context.Context, projectID string, opts pcf.ListOptions) ([]pcf.Credential, *pcf.PageInfo, error)
+	GetCredentialFunc       func(ctx context.Context, projectID, credID string) (*pcf.Credential, error)
+	CreateProjectFunc       func(ctx context.Context, req pcf.CreateProjectRequest) (*pcf.Project, error)
+	UpdateProjectFunc       func(ctx context.Context, projectID string, req pcf.UpdateProjectRequest) (*pcf.Project, error)
+	DeleteProjectFunc       func(ctx context.Context, projectID string) error
+	ListHostsFunc           func(ctx context.Context, projectID string) ([]pcf.Host, error)
+	GetHostFunc             func(ctx context.Context, projectID, hostID string) (*pcf.Host, error)
+	AddHostFunc             func(ctx context.Context, projectID string, req pcf.CreateHostRequest) (*pcf.Host, error)
+	AddHostsFunc            func(ctx context.Context, projectID string, reqs []pcf.CreateHostRequest) ([]pcf.Host, error)
+	ListIssuesFunc          func(ctx context.Context, projectID string) ([]pcf.Issue, error)
+	GetIssueFunc            func(ctx context.Context, projectID, issueID string) (*pcf.Issue, error)
+	CreateIssueFunc         func(ctx context.Context, projectID string, req pcf.CreateIssueRequest) (*pcf.Issue, error)
+	UpdateIssueFunc         func(ctx context.Context, projectID, issueID string, req pcf.UpdateIssueRequest) (*pcf.Issue, error)
+	LinkIssueToHostFunc     func(ctx context.Context, projectID, issueID, hostID string) (*pcf.Issue, error)
+	ListCredentialsFunc     func(ctx context.Context, projectID string) ([]pcf.Credential, error)
+	AddCredentialFunc       func(ctx context.Context, projectID string, req pcf.AddCredentialRequest) (*pcf.Credential, error)
+	GenerateReportFunc      func(ctx context.Context, projectID string, req pcf.GenerateReportRequest) (*pcf.Report, error)
+	DownloadReportFunc      func(ctx context.Context, reportURL string, w io.Writer) error
+}
+
+// Ensure MockFullPCFClient can stand in for the real client
+var _ pcf.API = (*MockFullPCFClient)(nil)
+
+func (m *MockFullPCFClient) Ping(ctx context.Context) error {
+	if m.PingFunc != nil {
+		return m.PingFunc(ctx)
+	}
+	return nil
+}
+
+func (m *MockFullPCFClient) ListProjectsPage(ctx context.Context, opts pcf.ListOptions) ([]pcf.Project, *pcf.PageInfo, error) {
+	if m.ListProjectsPageFunc != nil {
+		return m.ListProjectsPageFunc(ctx, opts)
+	}
+	return nil, nil, nil
+}
+
+func (m *MockFullPCFClient) GetProject(ctx context.Context, projectID string) (*pcf.Project, error) {
+	if m.GetProjectFunc != nil {
+		return m.GetProjectFunc(ctx, projectID)
+	}
+	return nil, nil
+}
+
+func (m *MockFullPCFClient) ListHostsPage(ctx context.Context, projectID string, opts pcf.ListOptions) ([]pcf.Host, *pcf.PageInfo, error) {
+	if m.ListHostsPageFunc != nil {
+		return m.ListHostsPageFunc(ctx, projectID, opts)
+	}
+	return nil, nil, nil
+}
+
+func (m *MockFullPCFClient) ListIssuesPage(ctx context.Context, projectID string, opts pcf.ListOptions) ([]pcf.Issue, *pcf.PageInfo, error) {
+	if m.ListIssuesPageFunc != nil {
+		return m.ListIssuesPageFunc(ctx, projectID, opts)
+	}
+	return nil, nil, nil
+}
+
+func (m *MockFullPCFClient) ListCredentialsPage(ctx context.Context, projectID string, opts pcf.ListOptions) ([]pcf.Credential, *pcf.PageInfo, error) {
+	if m.ListCredentialsPageFunc != nil {
+		return m.ListCredentialsPageFunc(ctx, projectID, opts)
+	}
+	return nil, nil, nil
+}
+
+func (m *MockFullPCFClient) GetCredential(ctx context.Context, projectID, credID string) (*pcf.Credential, error) {
+	if m.GetCredentialFunc != nil {
+		return m.GetCredentialFunc(ctx, projectID, credID)
+	}
+	return nil, nil
 }
 
 func (m *MockFullPCFClient) ListProjects(ctx context.Context) ([]pcf.Project, error) {
@@ -36,6 +112,20 @@ func (m *MockFullPCFClient) CreateProject(ctx context.Context, req pcf.CreatePro
 	return nil, nil
 }
 
+func (m *MockFullPCFClient) UpdateProject(ctx context.Context, projectID string, req pcf.UpdateProjectRequest) (*pcf.Project, error) {
+	if m.UpdateProjectFunc != nil {
+		return m.UpdateProjectFunc(ctx, projectID, req)
+	}
+	return nil, nil
+}
+
+func (m *MockFullPCFClient) DeleteProject(ctx context.Context, projectID string) error {
+	if m.DeleteProjectFunc != nil {
+		return m.DeleteProjectFunc(ctx, projectID)
+	}
+	return nil
+}
+
 func (m *MockFullPCFClient) ListHosts(ctx context.Context, projectID string) ([]pcf.Host, error) {
 	if m.ListHostsFunc != nil {
 		return m.ListHostsFunc(ctx, projectID)
@@ -43,6 +133,13 @@ func (m *MockFullPCFClient) ListHosts(ctx context.Context, projectID string) ([]
 	return nil, nil
 }
 
+func (m *MockFullPCFClient) GetHost(ctx context.Context, projectID, hostID string) (*pcf.Host, error) {
+	if m.GetHostFunc != nil {
+		return m.GetHostFunc(ctx, projectID, hostID)
+	}
+	return nil, nil
+}
+
 func (m *MockFullPCFClient) AddHost(ctx context.Context, projectID string, req pcf.CreateHostRequest) (*pcf.Host, error) {
 	if m.AddHostFunc != nil {
 		return m.AddHostFunc(ctx, projectID, req)
@@ -50,6 +147,13 @@ func (m *MockFullPCFClient) AddHost(ctx context.Context, projectID string, req p
 	return nil, nil
 }
 
+func (m *MockFullPCFClient) AddHosts(ctx context.Context, projectID string, reqs []pcf.CreateHostRequest) ([]pcf.Host, error) {
+	if m.AddHostsFunc != nil {
+		return m.AddHostsFunc(ctx, projectID, reqs)
+	}
+	return nil, nil
+}
+
 func (m *MockFullPCFClient) ListIssues(ctx context.Context, projectID string) ([]pcf.Issue, error) {
 	if m.ListIssuesFunc != nil {
 		return m.ListIssuesFunc(ctx, projectID)
@@ -57,6 +161,13 @@ func (m *MockFullPCFClient) ListIssues(ctx context.Context, projectID string) ([
 	return nil, nil
 }
 
+func (m *MockFullPCFClient) GetIssue(ctx context.Context, projectID, issueID string) (*pcf.Issue, error) {
+	if m.GetIssueFunc != nil {
+		return m.GetIssueFunc(ctx, projectID, issueID)
+	}
+	return nil, nil
+}
+
 func (m *MockFullPCFClient) CreateIssue(ctx context.Context, projectID string, req pcf.CreateIssueRequest) (*pcf.Issue, error) {
 	if m.CreateIssueFunc != nil {
 		return m.CreateIssueFunc(ctx, projectID, req)
@@ -64,6 +175,20 @@ func (m *MockFullPCFClient) CreateIssue(ctx context.Context, projectID string, r
 	return nil, nil
 }
 
+func (m *MockFullPCFClient) UpdateIssue(ctx context.Context, projectID, issueID string, req pcf.UpdateIssueRequest) (*pcf.Issue, error) {
+	if m.UpdateIssueFunc != nil {
+		return m.UpdateIssueFunc(ctx, projectID, issueID, req)
+	}
+	return nil, nil
+}
+
+func (m *MockFullPCFClient) LinkIssueToHost(ctx context.Context, projectID, issueID, hostID string) (*pcf.Issue, error) {
+	if m.LinkIssueToHostFunc != nil {
+		return m.LinkIssueToHostFunc(ctx, projectID, issueID, hostID)
+	}
+	return nil, nil
+}
+
 func (m *MockFullPCFClient) ListCredentials(ctx context.Context, projectID string) ([]pcf.Credential, error) {
 	if m.ListCredentialsFunc != nil {
 		return m.ListCredentialsFunc(ctx, projectID)
@@ -85,6 +210,13 @@ func (m *MockFullPCFClient) GenerateReport(ctx context.Context, projectID string
 	return nil, nil
 }
 
+func (m *MockFullPCFClient) DownloadReport(ctx context.Context, reportURL string, w io.Writer) error {
+	if m.DownloadReportFunc != nil {
+		return m.DownloadReportFunc(ctx, reportURL, w)
+	}
+	return nil
+}
+
 // TestRegisterAllTools tests registering all PCF tools with the MCP server
 func TestRegisterAllTools(t *testing.T) {
 	// Create MCP server
@@ -118,7 +250,7 @@ func TestRegisterAllTools(t *testing.T) {
 	}
 
 	// Register all tools
-	err = RegisterAllTools(server, mockClient)
+	err = RegisterAllTools(server, mockClient, Options{})
 	if err != nil {
 		t.Fatalf("Failed to register tools: %v", err)
 	}
@@ -144,6 +276,45 @@ func TestRegisterAllTools(t *testing.T) {
 		t.Error("list_projects tool was not registered")
 	}
 
+	// Every tool is categorized and tagged as reading or writing PCF data
+	for _, tool := range tools {
+		if tool.Category == "" {
+			t.Errorf("Tool %s has no category", tool.Name)
+		}
+		if !slices.Contains(tool.Tags, tool.Category) {
+			t.Errorf("Tool %s should be tagged with its category %q, got %v", tool.Name, tool.Category, tool.Tags)
+		}
+		if slices.Contains(tool.Tags, tagRead) == slices.Contains(tool.Tags, tagWrite) {
+			t.Errorf("Tool %s should be tagged either %q or %q, got %v", tool.Name, tagRead, tagWrite, tool.Tags)
+		}
+
+		// Read tools need the read scope and mutating tools the write scope
+		wantScope := mcp.ScopeRead
+		if slices.Contains(tool.Tags, tagWrite) {
+			wantScope = mcp.ScopeWrite
+		}
+		if tool.RequiredScope != wantScope {
+			t.Errorf("Tool %s should require scope %q, got %q", tool.Name, wantScope, tool.RequiredScope)
+		}
+		for _, prefix := range []string{"create_", "add_", "delete_"} {
+			if strings.HasPrefix(tool.Name, prefix) && tool.RequiredScope != mcp.ScopeWrite {
+				t.Errorf("Tool %s should require the write scope", tool.Name)
+			}
+		}
+		if strings.HasPrefix(tool.Name, "list_") && tool.RequiredScope != mcp.ScopeRead {
+			t.Errorf("Tool %s should require the read scope", tool.Name)
+		}
+	}
+
+	// A read-only caller may list but not create
+	readOnly := auth.WithScopes(context.Background(), []string{mcp.ScopeRead})
+	if _, err := server.ExecuteTool(readOnly, "list_projects", map[string]interface{}{}); err != nil {
+		t.Errorf("Read-only caller should be able to list projects: %v", err)
+	}
+	if _, err := server.ExecuteTool(readOnly, "create_project", map[string]interface{}{"name": "New"}); !errors.Is(err, mcp.ErrInsufficientScope) {
+		t.Errorf("Expected ErrInsufficientScope for read-only create, got %v", err)
+	}
+
 	// Test executing the tool
 	ctx := context.Background()
 	result, err := server.ExecuteTool(ctx, "list_projects", map[string]interface{}{})
@@ -161,3 +332,183 @@ func TestRegisterAllTools(t *testing.T) {
 		t.Error("Result should contain 'projects' key")
 	}
 }
+
+// TestRegisterAllToolsWithAPI tests that tools work against any pcf.API,
+// including a mock wrapped by the caching client
+func TestRegisterAllToolsWithAPI(t *testing.T) {
+	server, err := mcp.NewServer(config.ServerConfig{Transport: "stdio"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	calls := 0
+	mockClient := &MockFullPCFClient{
+		ListProjectsFunc: func(ctx context.Context) ([]pcf.Project, error) {
+			calls++
+			return []pcf.Project{{ID: "proj-1", Name: "Alt Backend"}}, nil
+		},
+	}
+
+	var client pcf.API = pcf.NewCachingClient(mockClient, time.Minute)
+	if err := RegisterAllTools(server, client, Options{}); err != nil {
+		t.Fatalf("Failed to register tools: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		result, err := server.ExecuteTool(context.Background(), "list_projects", map[string]interface{}{})
+		if err != nil {
+			t.Fatalf("Failed to execute list_projects: %v", err)
+		}
+
+		projects, ok := result.(map[string]interface{})["projects"].([]map[string]interface{})
+		if !ok || len(projects) != 1 || projects[0]["name"] != "Alt Backend" {
+			t.Errorf("Unexpected result: %v", result)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected the cached backend to be called once, got %d", calls)
+	}
+}
+
+// TestRegisterAllToolsSelection tests that disabled tools are never
+// registered and that enabled_tools restricts registration to its entries
+func TestRegisterAllToolsSelection(t *testing.T) {
+	server, err := mcp.NewServer(config.ServerConfig{
+		Transport:     "http",
+		DisabledTools: []string{"add_credential"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	if err := RegisterAllTools(server, &MockFullPCFClient{}, Options{}); err != nil {
+		t.Fatalf("Failed to register tools: %v", err)
+	}
+
+	handler := server.HTTPHandler()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tools", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 listing tools, got %d", w.Code)
+	}
+	if strings.Contains(w.Body.String(), `"add_credential"`) {
+		t.Error("Disabled tool add_credential should not be listed")
+	}
+	if !strings.Contains(w.Body.String(), `"list_credentials"`) {
+		t.Error("list_credentials should still be listed")
+	}
+
+	w = httptest.NewRecorder()
+	body := `{"project_id":"proj-1","name":"admin","type":"password","value":"secret"}`
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/tools/add_credential", strings.NewReader(body)))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 executing a disabled tool, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// An allowlist registers only its entries
+	server, err = mcp.NewServer(config.ServerConfig{
+		Transport:    "stdio",
+		EnabledTools: []string{"list_projects", "list_hosts"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	if err := RegisterAllTools(server, &MockFullPCFClient{}, Options{}); err != nil {
+		t.Fatalf("Failed to register tools: %v", err)
+	}
+
+	var names []string
+	for _, tool := range server.ListTools() {
+		names = append(names, tool.Name)
+	}
+	slices.Sort(names)
+	if !slices.Equal(names, []string{"list_hosts", "list_projects"}) {
+		t.Errorf("Expected only the enabled tools, got %v", names)
+	}
+
+	// Unknown names are rejected
+	server, err = mcp.NewServer(config.ServerConfig{
+		Transport:     "stdio",
+		DisabledTools: []string{"add_credentials"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	if err := RegisterAllTools(server, &MockFullPCFClient{}, Options{}); !errors.Is(err, mcp.ErrToolNotFound) {
+		t.Errorf("Expected ErrToolNotFound for an unknown disabled tool, got %v", err)
+	}
+}
+
+// TestRegisterAllToolsDefaultProject tests that project-scoped tools fall
+// back to the default project and that an explicit project_id wins
+func TestRegisterAllToolsDefaultProject(t *testing.T) {
+	server, err := mcp.NewServer(config.ServerConfig{
+		Transport:         "http",
+		ValidateToolInput: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	var listedProject string
+	client := &MockFullPCFClient{
+		ListHostsFunc: func(ctx context.Context, projectID string) ([]pcf.Host, error) {
+			listedProject = projectID
+			return []pcf.Host{}, nil
+		},
+	}
+
+	if err := RegisterAllTools(server, client, Options{DefaultProjectID: "proj-default"}); err != nil {
+		t.Fatalf("Failed to register tools: %v", err)
+	}
+
+	tools := make(map[string]mcp.Tool)
+	for _, tool := range server.ListTools() {
+		tools[tool.Name] = tool
+	}
+
+	if slices.Contains(tools["list_hosts"].InputSchema["required"].([]string), "project_id") {
+		t.Error("project_id should be optional for list_hosts with a default project")
+	}
+	if !slices.Contains(tools["delete_project"].InputSchema["required"].([]string), "project_id") {
+		t.Error("project_id should stay required for delete_project")
+	}
+
+	tests := []struct {
+		name        string
+		params      map[string]interface{}
+		wantProject string
+	}{
+		{name: "Omitted", params: map[string]interface{}{}, wantProject: "proj-default"},
+		{name: "Empty", params: map[string]interface{}{"project_id": ""}, wantProject: "proj-default"},
+		{name: "Explicit", params: map[string]interface{}{"project_id": "proj-other"}, wantProject: "proj-other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listedProject = ""
+			if _, err := server.ExecuteTool(context.Background(), "list_hosts", tt.params); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if listedProject != tt.wantProject {
+				t.Errorf("Expected project %q, got %q", tt.wantProject, listedProject)
+			}
+		})
+	}
+
+	// Without a default, project_id is still required
+	server, err = mcp.NewServer(config.ServerConfig{Transport: "stdio"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	if err := RegisterAllTools(server, &MockFullPCFClient{}, Options{}); err != nil {
+		t.Fatalf("Failed to register tools: %v", err)
+	}
+	for _, tool := range server.ListTools() {
+		if tool.Name == "list_hosts" && !slices.Contains(tool.InputSchema["required"].([]string), "project_id") {
+			t.Error("project_id should be required for list_hosts without a default project")
+		}
+	}
+}
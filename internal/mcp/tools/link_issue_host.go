@@ -0,0 +1,120 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// LinkIssueHostClient defines the interface for linking issues to hosts
+type LinkIssueHostClient interface {
+	GetIssueClient
+	GetHostClient
+	LinkIssueToHost(ctx context.Context, projectID, issueID, hostID string) (*pcf.Issue, error)
+}
+
+// NewLinkIssueHostTool creates an MCP tool for associating an existing issue with a host
+func NewLinkIssueHostTool(client LinkIssueHostClient) mcp.Tool {
+	return mcp.Tool{
+		Name:          "link_issue_host",
+		Description:   "Associate an existing security issue with a host in the same PCF project, replacing any previous host",
+		Category:      categoryIssues,
+		Tags:          []string{categoryIssues, categoryHosts, tagWrite},
+		RequiredScope: mcp.ScopeWrite,
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"project_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the project the issue and host belong to",
+				},
+				"issue_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the issue to link",
+				},
+				"host_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the host the issue affects",
+				},
+			},
+			"required":             []string{"project_id", "issue_id", "host_id"},
+			"additionalProperties": false,
+		},
+		Handler: createLinkIssueHostHandler(client),
+	}
+}
+
+// createLinkIssueHostHandler creates the handler function for linking issues to hosts
+func createLinkIssueHostHandler(client LinkIssueHostClient) mcp.ToolHandler {
+	return func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+		// Extract and validate project_id
+		projectID, ok := params["project_id"].(string)
+		if !ok {
+			return nil, fmt.Errorf("project_id parameter must be a string")
+		}
+
+		if projectID == "" {
+			return nil, fmt.Errorf("project_id cannot be empty")
+		}
+
+		// Extract and validate issue_id
+		issueID, ok := params["issue_id"].(string)
+		if !ok {
+			return nil, fmt.Errorf("issue_id parameter must be a string")
+		}
+
+		if issueID == "" {
+			return nil, fmt.Errorf("issue_id cannot be empty")
+		}
+
+		// Extract and validate host_id
+		hostID, ok := params["host_id"].(string)
+		if !ok {
+			return nil, fmt.Errorf("host_id parameter must be a string")
+		}
+
+		if hostID == "" {
+			return nil, fmt.Errorf("host_id cannot be empty")
+		}
+
+		// Check both exist so a typo gets a clear error instead of a dangling link
+		previous, err := client.GetIssue(ctx, projectID, issueID)
+		if errors.Is(err, pcf.ErrNotFound) {
+			return nil, fmt.Errorf("issue '%s' not found in project '%s': %w", issueID, projectID, err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issue: %w", err)
+		}
+
+		if _, err := client.GetHost(ctx, projectID, hostID); errors.Is(err, pcf.ErrNotFound) {
+			return nil, fmt.Errorf("host '%s' not found in project '%s': %w", hostID, projectID, err)
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to get host: %w", err)
+		}
+
+		// Call PCF client to link the issue
+		issue, err := client.LinkIssueToHost(ctx, projectID, issueID, hostID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to link issue to host: %w", err)
+		}
+
+		response := map[string]interface{}{
+			"issue": map[string]interface{}{
+				"id":         issue.ID,
+				"project_id": issue.ProjectID,
+				"title":      issue.Title,
+				"host_id":    issue.HostID,
+			},
+			"message": fmt.Sprintf("Issue '%s' linked to host '%s'", issueID, hostID),
+		}
+
+		if previous.HostID != "" && previous.HostID != hostID {
+			response["previous_host_id"] = previous.HostID
+		}
+
+		return response, nil
+	}
+}
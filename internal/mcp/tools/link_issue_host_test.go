@@ -0,0 +1,167 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// MockLinkIssueHostClient implements LinkIssueHostClient for testing
+type MockLinkIssueHostClient struct {
+	issues map[string]*pcf.Issue
+	hosts  map[string]*pcf.Host
+	linked string
+}
+
+func (m *MockLinkIssueHostClient) GetIssue(ctx context.Context, projectID, issueID string) (*pcf.Issue, error) {
+	if issue, ok := m.issues[issueID]; ok {
+		return issue, nil
+	}
+	return nil, fmt.Errorf("PCF API error: %w", pcf.ErrNotFound)
+}
+
+func (m *MockLinkIssueHostClient) GetHost(ctx context.Context, projectID, hostID string) (*pcf.Host, error) {
+	if host, ok := m.hosts[hostID]; ok {
+		return host, nil
+	}
+	if hostID == "host-broken" {
+		return nil, errors.New("PCF connection failed")
+	}
+	return nil, fmt.Errorf("PCF API error: %w", pcf.ErrNotFound)
+}
+
+func (m *MockLinkIssueHostClient) LinkIssueToHost(ctx context.Context, projectID, issueID, hostID string) (*pcf.Issue, error) {
+	m.linked = issueID + "->" + hostID
+	issue := *m.issues[issueID]
+	issue.HostID = hostID
+	return &issue, nil
+}
+
+// TestNewLinkIssueHostTool tests creating a new link issue host tool
+func TestNewLinkIssueHostTool(t *testing.T) {
+	tool := NewLinkIssueHostTool(&MockLinkIssueHostClient{})
+
+	if tool.Name != "link_issue_host" {
+		t.Errorf("Expected tool name 'link_issue_host', got '%s'", tool.Name)
+	}
+
+	if tool.Handler == nil {
+		t.Error("Tool handler should not be nil")
+	}
+
+	required, ok := tool.InputSchema["required"].([]string)
+	if !ok || len(required) != 3 {
+		t.Errorf("Expected project_id, issue_id and host_id to be required, got %v", tool.InputSchema["required"])
+	}
+}
+
+// TestLinkIssueHostHandler tests the link issue host handler functionality
+func TestLinkIssueHostHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		params       map[string]interface{}
+		expectError  bool
+		errContains  string
+		expectLinked string
+		previousHost string
+	}{
+		{
+			name:         "Successful link",
+			params:       map[string]interface{}{"project_id": "proj-1", "issue_id": "issue-1", "host_id": "host-2"},
+			expectLinked: "issue-1->host-2",
+		},
+		{
+			name:         "Re-link to a different host",
+			params:       map[string]interface{}{"project_id": "proj-1", "issue_id": "issue-2", "host_id": "host-2"},
+			expectLinked: "issue-2->host-2",
+			previousHost: "host-1",
+		},
+		{
+			name:        "Missing host",
+			params:      map[string]interface{}{"project_id": "proj-1", "issue_id": "issue-1", "host_id": "host-404"},
+			expectError: true,
+			errContains: "host 'host-404' not found in project 'proj-1'",
+		},
+		{
+			name:        "Missing issue",
+			params:      map[string]interface{}{"project_id": "proj-1", "issue_id": "issue-404", "host_id": "host-1"},
+			expectError: true,
+			errContains: "issue 'issue-404' not found in project 'proj-1'",
+		},
+		{
+			name:        "Host lookup failure",
+			params:      map[string]interface{}{"project_id": "proj-1", "issue_id": "issue-1", "host_id": "host-broken"},
+			expectError: true,
+			errContains: "failed to get host",
+		},
+		{
+			name:        "Empty host_id",
+			params:      map[string]interface{}{"project_id": "proj-1", "issue_id": "issue-1", "host_id": ""},
+			expectError: true,
+			errContains: "host_id cannot be empty",
+		},
+		{
+			name:        "Invalid issue_id type",
+			params:      map[string]interface{}{"project_id": "proj-1", "issue_id": 1, "host_id": "host-1"},
+			expectError: true,
+			errContains: "issue_id parameter must be a string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &MockLinkIssueHostClient{
+				issues: map[string]*pcf.Issue{
+					"issue-1": {ID: "issue-1", ProjectID: "proj-1", Title: "SQL Injection"},
+					"issue-2": {ID: "issue-2", ProjectID: "proj-1", Title: "Weak TLS", HostID: "host-1"},
+				},
+				hosts: map[string]*pcf.Host{
+					"host-1": {ID: "host-1", ProjectID: "proj-1"},
+					"host-2": {ID: "host-2", ProjectID: "proj-1"},
+				},
+			}
+			tool := NewLinkIssueHostTool(client)
+
+			result, err := tool.Handler(context.Background(), tt.params)
+
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("Expected error but got none")
+				}
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("Expected error containing '%s', got '%s'", tt.errContains, err.Error())
+				}
+				// Missing objects keep pcf.ErrNotFound so they are reported as 404
+				if strings.Contains(tt.errContains, "not found") && !errors.Is(err, pcf.ErrNotFound) {
+					t.Errorf("Expected the error to wrap pcf.ErrNotFound, got %v", err)
+				}
+				if client.linked != "" {
+					t.Errorf("Expected no link to be sent, got %s", client.linked)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if client.linked != tt.expectLinked {
+				t.Errorf("Expected link %s, got %s", tt.expectLinked, client.linked)
+			}
+
+			resultMap := result.(map[string]interface{})
+			issue := resultMap["issue"].(map[string]interface{})
+			if issue["host_id"] != tt.params["host_id"] {
+				t.Errorf("Expected host_id %v, got %v", tt.params["host_id"], issue["host_id"])
+			}
+
+			if tt.previousHost != "" && resultMap["previous_host_id"] != tt.previousHost {
+				t.Errorf("Expected previous_host_id '%s', got %v", tt.previousHost, resultMap["previous_host_id"])
+			}
+		})
+	}
+}
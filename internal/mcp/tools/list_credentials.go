@@ -6,6 +6,7 @@ import (
 
 	"github.com/aRustyDev/pcf-mcp/internal/mcp"
 	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf/redact"
 )
 
 // ListCredentialsClient defines the interface for listing credentials
@@ -13,11 +14,15 @@ type ListCredentialsClient interface {
 	ListCredentials(ctx context.Context, projectID string) ([]pcf.Credential, error)
 }
 
-// NewListCredentialsTool creates an MCP tool for listing credentials in a PCF project
-func NewListCredentialsTool(client ListCredentialsClient) mcp.Tool {
+// NewListCredentialsTool creates an MCP tool for listing credentials in a PCF
+// project, masked by redactor; nil masks the default fields
+func NewListCredentialsTool(client ListCredentialsClient, redactor *redact.Redactor) mcp.Tool {
 	return mcp.Tool{
-		Name:        "list_credentials",
-		Description: "List all stored credentials in a specific PCF project",
+		Name:          "list_credentials",
+		Description:   "List all stored credentials in a specific PCF project",
+		Category:      categoryCredentials,
+		Tags:          []string{categoryCredentials, tagRead},
+		RequiredScope: mcp.ScopeRead,
 		InputSchema: map[string]interface{}{
 			"type": "object",
 			"properties": map[string]interface{}{
@@ -42,12 +47,13 @@ func NewListCredentialsTool(client ListCredentialsClient) mcp.Tool {
 			"required":             []string{"project_id"},
 			"additionalProperties": false,
 		},
-		Handler: createListCredentialsHandler(client),
+		Handler: createListCredentialsHandler(client, redactor),
 	}
 }
 
 // createListCredentialsHandler creates the handler function for listing credentials
-func createListCredentialsHandler(client ListCredentialsClient) mcp.ToolHandler {
+func createListCredentialsHandler(client ListCredentialsClient, redactor *redact.Redactor) mcp.ToolHandler {
+
 	return func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
 		// Extract and validate project_id
 		projectID, ok := params["project_id"].(string)
@@ -59,21 +65,7 @@ func createListCredentialsHandler(client ListCredentialsClient) mcp.ToolHandler
 			return nil, fmt.Errorf("project_id cannot be empty")
 		}
 
-		// Extract optional filters
-		typeFilter := ""
-		if credType, ok := params["type"].(string); ok {
-			typeFilter = credType
-		}
-
-		hostIDFilter := ""
-		if hostID, ok := params["host_id"].(string); ok {
-			hostIDFilter = hostID
-		}
-
-		serviceFilter := ""
-		if service, ok := params["service"].(string); ok {
-			serviceFilter = service
-		}
+		filter := credentialFilterFromParams(params)
 
 		// Call PCF client to list credentials
 		credentials, err := client.ListCredentials(ctx, projectID)
@@ -89,68 +81,20 @@ func createListCredentialsHandler(client ListCredentialsClient) mcp.ToolHandler
 			// Count by type (before filtering)
 			typeCount[cred.Type]++
 
-			// Apply type filter if provided
-			if typeFilter != "" && cred.Type != typeFilter {
+			if !filter.matches(cred) {
 				continue
 			}
 
-			// Apply host ID filter if provided
-			if hostIDFilter != "" && cred.HostID != hostIDFilter {
-				continue
-			}
-
-			// Apply service filter if provided
-			if serviceFilter != "" && cred.Service != serviceFilter {
-				continue
-			}
-
-			credMap := map[string]interface{}{
-				"id":         cred.ID,
-				"project_id": cred.ProjectID,
-				"type":       cred.Type,
-				"username":   cred.Username,
-				"value":      "***REDACTED***", // Always redact credential values
-			}
-
-			// Add optional fields if present
-			if cred.HostID != "" {
-				credMap["host_id"] = cred.HostID
-			}
-
-			if cred.Service != "" {
-				credMap["service"] = cred.Service
-			}
-
-			if cred.Notes != "" {
-				credMap["notes"] = cred.Notes
-			}
-
-			credentialList = append(credentialList, credMap)
+			credentialList = append(credentialList, formatCredential(cred, redactor))
 		}
 
 		// Build response
 		response := map[string]interface{}{
 			"credentials":    credentialList,
-			"total_count":    len(credentialList),
 			"project_id":     projectID,
 			"type_breakdown": typeCount,
 		}
 
-		// Add filter information if filters were applied
-		if typeFilter != "" || hostIDFilter != "" || serviceFilter != "" {
-			filters := make(map[string]interface{})
-			if typeFilter != "" {
-				filters["type"] = typeFilter
-			}
-			if hostIDFilter != "" {
-				filters["host_id"] = hostIDFilter
-			}
-			if serviceFilter != "" {
-				filters["service"] = serviceFilter
-			}
-			response["filters"] = filters
-		}
-
-		return response, nil
+		return withListMetadata(response, len(credentialList), len(credentials), filter.applied()), nil
 	}
 }
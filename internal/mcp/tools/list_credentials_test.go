@@ -25,7 +25,7 @@ func (m *MockListCredentialsClient) ListCredentials(ctx context.Context, project
 func TestNewListCredentialsTool(t *testing.T) {
 	mockClient := &MockListCredentialsClient{}
 
-	tool := NewListCredentialsTool(mockClient)
+	tool := NewListCredentialsTool(mockClient, nil)
 
 	if tool.Name != "list_credentials" {
 		t.Errorf("Expected tool name 'list_credentials', got '%s'", tool.Name)
@@ -241,7 +241,7 @@ func TestListCredentialsHandler(t *testing.T) {
 			}
 
 			// Create tool
-			tool := NewListCredentialsTool(mockClient)
+			tool := NewListCredentialsTool(mockClient, nil)
 
 			// Execute handler
 			ctx := context.Background()
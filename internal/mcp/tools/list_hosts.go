@@ -3,11 +3,22 @@ package tools
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/aRustyDev/pcf-mcp/internal/mcp"
 	"github.com/aRustyDev/pcf-mcp/internal/pcf"
 )
 
+// Modes of the list_hosts tags filter
+const (
+	// tagModeAny matches hosts with at least one of the tags
+	tagModeAny = "any"
+
+	// tagModeAll matches hosts with every one of the tags
+	tagModeAll = "all"
+)
+
 // ListHostsClient defines the interface for listing hosts
 type ListHostsClient interface {
 	ListHosts(ctx context.Context, projectID string) ([]pcf.Host, error)
@@ -16,8 +27,11 @@ type ListHostsClient interface {
 // NewListHostsTool creates an MCP tool for listing hosts in a PCF project
 func NewListHostsTool(client ListHostsClient) mcp.Tool {
 	return mcp.Tool{
-		Name:        "list_hosts",
-		Description: "List all hosts in a specific PCF project",
+		Name:          "list_hosts",
+		Description:   "List all hosts in a specific PCF project",
+		Category:      categoryHosts,
+		Tags:          []string{categoryHosts, tagRead},
+		RequiredScope: mcp.ScopeRead,
 		InputSchema: map[string]interface{}{
 			"type": "object",
 			"properties": map[string]interface{}{
@@ -34,6 +48,19 @@ func NewListHostsTool(client ListHostsClient) mcp.Tool {
 					"type":        "string",
 					"description": "Filter hosts by operating system",
 				},
+				"tags": map[string]interface{}{
+					"type":        "array",
+					"description": "Filter hosts by tags, ignoring case (see tag_mode)",
+					"items": map[string]interface{}{
+						"type":      "string",
+						"minLength": 1,
+					},
+				},
+				"tag_mode": map[string]interface{}{
+					"type":        "string",
+					"description": "Whether hosts must have any (default) or all of the tags",
+					"enum":        []string{tagModeAny, tagModeAll},
+				},
 			},
 			"required":             []string{"project_id"},
 			"additionalProperties": false,
@@ -66,6 +93,23 @@ func createListHostsHandler(client ListHostsClient) mcp.ToolHandler {
 			osFilter = osParam
 		}
 
+		var tagsFilter []string
+		if tagsRaw, ok := params["tags"]; ok {
+			tags, err := parseHostTags(tagsRaw)
+			if err != nil {
+				return nil, err
+			}
+			tagsFilter = tags
+		}
+
+		tagMode := tagModeAny
+		if mode, ok := params["tag_mode"].(string); ok && mode != "" {
+			if mode != tagModeAny && mode != tagModeAll {
+				return nil, invalidParam("tag_mode", "must be %s or %s", tagModeAny, tagModeAll)
+			}
+			tagMode = mode
+		}
+
 		// Call PCF client to list hosts
 		hosts, err := client.ListHosts(ctx, projectID)
 		if err != nil {
@@ -74,6 +118,8 @@ func createListHostsHandler(client ListHostsClient) mcp.ToolHandler {
 
 		// Convert hosts to response format and apply filters
 		var hostList []map[string]interface{}
+		osCount := map[string]int{}
+		serviceCount := map[string]int{}
 
 		for _, host := range hosts {
 			// Apply status filter if provided
@@ -86,6 +132,26 @@ func createListHostsHandler(client ListHostsClient) mcp.ToolHandler {
 				continue
 			}
 
+			// Apply tags filter if provided
+			if len(tagsFilter) > 0 && !hasTags(host.Tags, tagsFilter, tagMode == tagModeAll) {
+				continue
+			}
+
+			// Count matching hosts by OS and by each service they expose
+			osName := host.OS
+			if osName == "" {
+				osName = "unknown"
+			}
+			osCount[osName]++
+
+			seen := make(map[string]bool, len(host.Services))
+			for _, service := range host.Services {
+				if !seen[service] {
+					seen[service] = true
+					serviceCount[service]++
+				}
+			}
+
 			hostMap := map[string]interface{}{
 				"id":         host.ID,
 				"project_id": host.ProjectID,
@@ -101,9 +167,8 @@ func createListHostsHandler(client ListHostsClient) mcp.ToolHandler {
 				hostMap["os"] = host.OS
 			}
 
-			if len(host.Services) > 0 {
-				hostMap["services"] = host.Services
-			}
+			addServices(hostMap, &host)
+			addHostTags(hostMap, &host)
 
 			if host.Status != "" {
 				hostMap["status"] = host.Status
@@ -114,23 +179,35 @@ func createListHostsHandler(client ListHostsClient) mcp.ToolHandler {
 
 		// Build response
 		response := map[string]interface{}{
-			"hosts":       hostList,
-			"total_count": len(hostList),
-			"project_id":  projectID,
+			"hosts":              hostList,
+			"project_id":         projectID,
+			"os_breakdown":       osCount,
+			"services_breakdown": serviceCount,
 		}
 
-		// Add filter information if filters were applied
-		if statusFilter != "" || osFilter != "" {
-			filters := make(map[string]interface{})
-			if statusFilter != "" {
-				filters["status"] = statusFilter
-			}
-			if osFilter != "" {
-				filters["os"] = osFilter
-			}
-			response["filters"] = filters
+		applied := map[string]string{
+			"status": statusFilter,
+			"os":     osFilter,
+		}
+		if len(tagsFilter) > 0 {
+			applied["tags"] = strings.Join(tagsFilter, ",")
+			applied["tag_mode"] = tagMode
 		}
 
-		return response, nil
+		return withListMetadata(response, len(hostList), len(hosts), applied), nil
+	}
+}
+
+// hasTags reports whether hostTags, compared ignoring case, contain any of
+// tags, or every one of them when all is set
+func hasTags(hostTags, tags []string, all bool) bool {
+	for _, tag := range tags {
+		found := slices.ContainsFunc(hostTags, func(hostTag string) bool {
+			return strings.EqualFold(hostTag, tag)
+		})
+		if found != all {
+			return found
+		}
 	}
+	return all
 }
@@ -3,6 +3,8 @@ package tools
 import (
 	"context"
 	"errors"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/aRustyDev/pcf-mcp/internal/pcf"
@@ -286,3 +288,151 @@ func TestListHostsHandler(t *testing.T) {
 		})
 	}
 }
+
+// TestListHostsBreakdown tests the OS and service breakdowns of the filtered hosts
+func TestListHostsBreakdown(t *testing.T) {
+	mockClient := &MockListHostsClient{
+		ListHostsFunc: func(ctx context.Context, projectID string) ([]pcf.Host, error) {
+			return []pcf.Host{
+				{ID: "host-1", IP: "10.0.0.1", OS: "Linux", Services: []string{"ssh", "http", "https"}, Status: "active"},
+				{ID: "host-2", IP: "10.0.0.2", OS: "Linux", Services: []string{"ssh", "http", "http"}, Status: "active"},
+				{ID: "host-3", IP: "10.0.0.3", OS: "Windows", Services: []string{"rdp", "smb", "http"}, Status: "active"},
+				{ID: "host-4", IP: "10.0.0.4", Services: []string{"ssh"}, Status: "active"},
+				{ID: "host-5", IP: "10.0.0.5", OS: "Windows", Services: []string{"smb"}, Status: "inactive"},
+			}, nil
+		},
+	}
+
+	tool := NewListHostsTool(mockClient)
+
+	tests := []struct {
+		name             string
+		params           map[string]interface{}
+		expectedOS       map[string]int
+		expectedServices map[string]int
+	}{
+		{
+			name:             "All hosts",
+			params:           map[string]interface{}{"project_id": "proj-123"},
+			expectedOS:       map[string]int{"Linux": 2, "Windows": 2, "unknown": 1},
+			expectedServices: map[string]int{"ssh": 3, "http": 3, "https": 1, "rdp": 1, "smb": 2},
+		},
+		{
+			name:             "Filtered by status",
+			params:           map[string]interface{}{"project_id": "proj-123", "status": "active"},
+			expectedOS:       map[string]int{"Linux": 2, "Windows": 1, "unknown": 1},
+			expectedServices: map[string]int{"ssh": 3, "http": 3, "https": 1, "rdp": 1, "smb": 1},
+		},
+		{
+			name:             "Filtered by OS",
+			params:           map[string]interface{}{"project_id": "proj-123", "os": "Windows"},
+			expectedOS:       map[string]int{"Windows": 2},
+			expectedServices: map[string]int{"rdp": 1, "smb": 2, "http": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tool.Handler(context.Background(), tt.params)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			resultMap := result.(map[string]interface{})
+
+			osBreakdown, ok := resultMap["os_breakdown"].(map[string]int)
+			if !ok {
+				t.Fatal("Result should contain 'os_breakdown'")
+			}
+			if !reflect.DeepEqual(osBreakdown, tt.expectedOS) {
+				t.Errorf("Expected os_breakdown %v, got %v", tt.expectedOS, osBreakdown)
+			}
+
+			servicesBreakdown, ok := resultMap["services_breakdown"].(map[string]int)
+			if !ok {
+				t.Fatal("Result should contain 'services_breakdown'")
+			}
+			if !reflect.DeepEqual(servicesBreakdown, tt.expectedServices) {
+				t.Errorf("Expected services_breakdown %v, got %v", tt.expectedServices, servicesBreakdown)
+			}
+		})
+	}
+}
+
+// TestListHostsTagsFilter tests filtering hosts by any or all of several tags
+func TestListHostsTagsFilter(t *testing.T) {
+	client := &MockListHostsClient{
+		ListHostsFunc: func(ctx context.Context, projectID string) ([]pcf.Host, error) {
+			return []pcf.Host{
+				{ID: "host-1", IP: "10.0.0.1", Tags: []string{"dmz", "web"}},
+				{ID: "host-2", IP: "10.0.0.2", Tags: []string{"DMZ", "domain-controller"}},
+				{ID: "host-3", IP: "10.0.0.3", Tags: []string{"domain-controller"}},
+				{ID: "host-4", IP: "10.0.0.4"},
+			}, nil
+		},
+	}
+	tool := NewListHostsTool(client)
+
+	tests := []struct {
+		name     string
+		params   map[string]interface{}
+		expected []string
+		applied  map[string]string
+		wantErr  string
+	}{
+		{
+			name:     "No tags",
+			params:   map[string]interface{}{},
+			expected: []string{"host-1", "host-2", "host-3", "host-4"},
+			applied:  map[string]string{},
+		},
+		{
+			name:     "Any by default",
+			params:   map[string]interface{}{"tags": []interface{}{"web", "domain-controller"}},
+			expected: []string{"host-1", "host-2", "host-3"},
+			applied:  map[string]string{"tags": "web,domain-controller", "tag_mode": "any"},
+		},
+		{
+			name:     "All, ignoring case",
+			params:   map[string]interface{}{"tags": []interface{}{"dmz", "Domain-Controller"}, "tag_mode": "all"},
+			expected: []string{"host-2"},
+			applied:  map[string]string{"tags": "dmz,Domain-Controller", "tag_mode": "all"},
+		},
+		{
+			name:     "No match",
+			params:   map[string]interface{}{"tags": []string{"out-of-scope"}},
+			expected: nil,
+			applied:  map[string]string{"tags": "out-of-scope", "tag_mode": "any"},
+		},
+		{name: "Invalid mode", params: map[string]interface{}{"tags": []interface{}{"dmz"}, "tag_mode": "some"}, wantErr: "tag_mode"},
+		{name: "Invalid tag", params: map[string]interface{}{"tags": []interface{}{"dmz", 1.0}}, wantErr: "must be an array of strings"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.params["project_id"] = "proj-1"
+			result, err := tool.Handler(context.Background(), tt.params)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			resultMap := result.(map[string]interface{})
+			var ids []string
+			for _, host := range resultMap["hosts"].([]map[string]interface{}) {
+				ids = append(ids, host["id"].(string))
+			}
+			if !reflect.DeepEqual(ids, tt.expected) {
+				t.Errorf("Expected hosts %v, got %v", tt.expected, ids)
+			}
+			if !reflect.DeepEqual(resultMap["applied_filters"], tt.applied) {
+				t.Errorf("Expected applied filters %v, got %v", tt.applied, resultMap["applied_filters"])
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/aRustyDev/pcf-mcp/internal/mcp"
 	"github.com/aRustyDev/pcf-mcp/internal/pcf"
@@ -16,8 +17,11 @@ type ListIssuesClient interface {
 // NewListIssuesTool creates an MCP tool for listing issues in a PCF project
 func NewListIssuesTool(client ListIssuesClient) mcp.Tool {
 	return mcp.Tool{
-		Name:        "list_issues",
-		Description: "List all security issues/findings in a specific PCF project",
+		Name:          "list_issues",
+		Description:   "List all security issues/findings in a specific PCF project",
+		Category:      categoryIssues,
+		Tags:          []string{categoryIssues, tagRead},
+		RequiredScope: mcp.ScopeRead,
 		InputSchema: map[string]interface{}{
 			"type": "object",
 			"properties": map[string]interface{}{
@@ -39,6 +43,11 @@ func NewListIssuesTool(client ListIssuesClient) mcp.Tool {
 					"type":        "string",
 					"description": "Filter issues by host ID",
 				},
+				"updated_since": map[string]interface{}{
+					"type":        "string",
+					"description": "Only list issues updated at or after this RFC 3339 timestamp (e.g. 2024-01-15T00:00:00Z)",
+					"format":      "date-time",
+				},
 			},
 			"required":             []string{"project_id"},
 			"additionalProperties": false,
@@ -76,6 +85,17 @@ func createListIssuesHandler(client ListIssuesClient) mcp.ToolHandler {
 			hostIDFilter = hostID
 		}
 
+		updatedSinceFilter := ""
+		var updatedSince time.Time
+		if raw, ok := params["updated_since"].(string); ok && raw != "" {
+			since, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				return nil, fmt.Errorf("updated_since must be an RFC 3339 timestamp, got %q", raw)
+			}
+			updatedSinceFilter = raw
+			updatedSince = since
+		}
+
 		// Call PCF client to list issues
 		issues, err := client.ListIssues(ctx, projectID)
 		if err != nil {
@@ -113,6 +133,13 @@ func createListIssuesHandler(client ListIssuesClient) mcp.ToolHandler {
 				continue
 			}
 
+			// Apply updated_since filter if provided. The PCF issue listing
+			// takes no time filter, so it is applied here; issues without a
+			// timestamp are kept since they may have changed.
+			if !updatedSince.IsZero() && !issue.UpdatedAt.IsZero() && issue.UpdatedAt.Before(updatedSince) {
+				continue
+			}
+
 			issueMap := map[string]interface{}{
 				"id":          issue.ID,
 				"project_id":  issue.ProjectID,
@@ -135,32 +162,25 @@ func createListIssuesHandler(client ListIssuesClient) mcp.ToolHandler {
 				issueMap["cvss"] = issue.CVSS
 			}
 
+			if !issue.UpdatedAt.IsZero() {
+				issueMap["updated_at"] = issue.UpdatedAt.UTC().Format(time.RFC3339)
+			}
+
 			issueList = append(issueList, issueMap)
 		}
 
 		// Build response
 		response := map[string]interface{}{
 			"issues":             issueList,
-			"total_count":        len(issueList),
 			"project_id":         projectID,
 			"severity_breakdown": severityCount,
 		}
 
-		// Add filter information if filters were applied
-		if severityFilter != "" || statusFilter != "" || hostIDFilter != "" {
-			filters := make(map[string]interface{})
-			if severityFilter != "" {
-				filters["severity"] = severityFilter
-			}
-			if statusFilter != "" {
-				filters["status"] = statusFilter
-			}
-			if hostIDFilter != "" {
-				filters["host_id"] = hostIDFilter
-			}
-			response["filters"] = filters
-		}
-
-		return response, nil
+		return withListMetadata(response, len(issueList), len(issues), map[string]string{
+			"severity":      severityFilter,
+			"status":        statusFilter,
+			"host_id":       hostIDFilter,
+			"updated_since": updatedSinceFilter,
+		}), nil
 	}
 }
@@ -3,7 +3,10 @@ package tools
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aRustyDev/pcf-mcp/internal/pcf"
 )
@@ -321,3 +324,71 @@ func TestListIssuesHandler(t *testing.T) {
 		})
 	}
 }
+
+// TestListIssuesUpdatedSince tests that updated_since keeps only issues
+// updated at or after the given time
+func TestListIssuesUpdatedSince(t *testing.T) {
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	client := &MockListIssuesClient{
+		ListIssuesFunc: func(ctx context.Context, projectID string) ([]pcf.Issue, error) {
+			return []pcf.Issue{
+				{ID: "old", Title: "Old", Severity: "Low", UpdatedAt: since.Add(-24 * time.Hour)},
+				{ID: "boundary", Title: "Boundary", Severity: "High", UpdatedAt: since},
+				{ID: "new", Title: "New", Severity: "Critical", UpdatedAt: since.Add(2 * time.Hour)},
+				{ID: "unknown", Title: "No timestamp", Severity: "Info"},
+			}, nil
+		},
+	}
+
+	tool := NewListIssuesTool(client)
+
+	result, err := tool.Handler(context.Background(), map[string]interface{}{
+		"project_id":    "proj-123",
+		"updated_since": "2024-03-01T00:00:00Z",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	res := result.(map[string]interface{})
+	var ids []string
+	for _, issue := range res["issues"].([]map[string]interface{}) {
+		ids = append(ids, issue["id"].(string))
+	}
+	if want := []string{"boundary", "new", "unknown"}; !slices.Equal(ids, want) {
+		t.Errorf("Expected issues %v, got %v", want, ids)
+	}
+
+	issues := res["issues"].([]map[string]interface{})
+	if issues[1]["updated_at"] != "2024-03-01T02:00:00Z" {
+		t.Errorf("Expected updated_at in the response, got %v", issues[1]["updated_at"])
+	}
+	if _, ok := issues[2]["updated_at"]; ok {
+		t.Error("Issues without a timestamp should omit updated_at")
+	}
+
+	filters := res["applied_filters"].(map[string]string)
+	if filters["updated_since"] != "2024-03-01T00:00:00Z" {
+		t.Errorf("Expected updated_since in applied_filters, got %v", filters)
+	}
+
+	// A timestamp in another offset is compared by instant
+	result, err = tool.Handler(context.Background(), map[string]interface{}{
+		"project_id":    "proj-123",
+		"updated_since": "2024-03-01T03:00:00+02:00",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count := result.(map[string]interface{})["total_count"]; count != 2 {
+		t.Errorf("Expected 2 issues updated since 01:00 UTC, got %v", count)
+	}
+
+	if _, err := tool.Handler(context.Background(), map[string]interface{}{
+		"project_id":    "proj-123",
+		"updated_since": "last week",
+	}); err == nil || !strings.Contains(err.Error(), "RFC 3339") {
+		t.Errorf("Expected an RFC 3339 error, got %v", err)
+	}
+}
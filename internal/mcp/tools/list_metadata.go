@@ -0,0 +1,20 @@
+package tools
+
+// withListMetadata adds the counts and filters shared by every list tool to
+// response: total_count is the number of items returned, unfiltered_count the
+// number PCF returned before filtering, and applied_filters the non-empty
+// entries of filters keyed by parameter name
+func withListMetadata(response map[string]interface{}, returned, unfiltered int, filters map[string]string) map[string]interface{} {
+	applied := make(map[string]string, len(filters))
+	for name, value := range filters {
+		if value != "" {
+			applied[name] = value
+		}
+	}
+
+	response["total_count"] = returned
+	response["unfiltered_count"] = unfiltered
+	response["applied_filters"] = applied
+
+	return response
+}
@@ -0,0 +1,83 @@
+package tools
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// TestListMetadata tests the counts and filters reported by every list tool
+func TestListMetadata(t *testing.T) {
+	client := newSearchMockClient()
+	client.ListProjectsFunc = func(ctx context.Context) ([]pcf.Project, error) {
+		return []pcf.Project{
+			{ID: "proj-1", Status: "active"},
+			{ID: "proj-2", Status: "completed"},
+		}, nil
+	}
+
+	tests := []struct {
+		name           string
+		handler        func(ctx context.Context, params map[string]interface{}) (interface{}, error)
+		params         map[string]interface{}
+		wantTotal      int
+		wantUnfiltered int
+		wantFilters    map[string]string
+	}{
+		{
+			name:           "Projects by status",
+			handler:        NewListProjectsTool(client).Handler,
+			params:         map[string]interface{}{"status": "active"},
+			wantTotal:      1,
+			wantUnfiltered: 2,
+			wantFilters:    map[string]string{"status": "active"},
+		},
+		{
+			name:           "Hosts without filters",
+			handler:        NewListHostsTool(client).Handler,
+			params:         map[string]interface{}{"project_id": "proj-1"},
+			wantTotal:      3,
+			wantUnfiltered: 3,
+			wantFilters:    map[string]string{},
+		},
+		{
+			name:           "Issues by severity and status",
+			handler:        NewListIssuesTool(client).Handler,
+			params:         map[string]interface{}{"project_id": "proj-1", "severity": "Critical", "status": "Open"},
+			wantTotal:      1,
+			wantUnfiltered: 2,
+			wantFilters:    map[string]string{"severity": "Critical", "status": "Open"},
+		},
+		{
+			name:           "Credentials by host",
+			handler:        NewListCredentialsTool(client, nil).Handler,
+			params:         map[string]interface{}{"project_id": "proj-1", "host_id": "host-9"},
+			wantTotal:      0,
+			wantUnfiltered: 2,
+			wantFilters:    map[string]string{"host_id": "host-9"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.handler(context.Background(), tt.params)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			resultMap := result.(map[string]interface{})
+
+			if resultMap["total_count"] != tt.wantTotal {
+				t.Errorf("Expected total_count %d, got %v", tt.wantTotal, resultMap["total_count"])
+			}
+			if resultMap["unfiltered_count"] != tt.wantUnfiltered {
+				t.Errorf("Expected unfiltered_count %d, got %v", tt.wantUnfiltered, resultMap["unfiltered_count"])
+			}
+			if !reflect.DeepEqual(resultMap["applied_filters"], tt.wantFilters) {
+				t.Errorf("Expected applied_filters %v, got %v", tt.wantFilters, resultMap["applied_filters"])
+			}
+		})
+	}
+}
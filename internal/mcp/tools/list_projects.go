@@ -4,6 +4,8 @@ package tools
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/aRustyDev/pcf-mcp/internal/mcp"
 	"github.com/aRustyDev/pcf-mcp/internal/pcf"
@@ -18,8 +20,11 @@ type PCFClient interface {
 // NewListProjectsTool creates an MCP tool for listing PCF projects
 func NewListProjectsTool(client PCFClient) mcp.Tool {
 	return mcp.Tool{
-		Name:        "list_projects",
-		Description: "List all projects in the Pentest Collaboration Framework",
+		Name:          "list_projects",
+		Description:   "List all projects in the Pentest Collaboration Framework",
+		Category:      categoryProjects,
+		Tags:          []string{categoryProjects, tagRead},
+		RequiredScope: mcp.ScopeRead,
 		InputSchema: map[string]interface{}{
 			"type": "object",
 			"properties": map[string]interface{}{
@@ -28,6 +33,14 @@ func NewListProjectsTool(client PCFClient) mcp.Tool {
 					"description": "Filter projects by status (active, completed, on-hold)",
 					"enum":        []string{"active", "completed", "on-hold"},
 				},
+				"team": map[string]interface{}{
+					"type":        "string",
+					"description": "Only projects with this team member, ignoring case",
+				},
+				"name_contains": map[string]interface{}{
+					"type":        "string",
+					"description": "Only projects whose name contains this text, ignoring case",
+				},
 			},
 			"additionalProperties": false,
 		},
@@ -48,6 +61,25 @@ func createListProjectsHandler(client PCFClient) mcp.ToolHandler {
 			statusFilter = statusStr
 		}
 
+		teamFilter := ""
+		if team, ok := params["team"]; ok {
+			teamStr, ok := team.(string)
+			if !ok {
+				return nil, fmt.Errorf("team parameter must be a string")
+			}
+			teamFilter = teamStr
+		}
+
+		nameFilter := ""
+		if name, ok := params["name_contains"]; ok {
+			nameStr, ok := name.(string)
+			if !ok {
+				return nil, fmt.Errorf("name_contains parameter must be a string")
+			}
+			nameFilter = nameStr
+		}
+		nameNeedle := strings.ToLower(nameFilter)
+
 		// Call PCF client to list projects
 		projects, err := client.ListProjects(ctx)
 		if err != nil {
@@ -58,11 +90,21 @@ func createListProjectsHandler(client PCFClient) mcp.ToolHandler {
 		var projectList []map[string]interface{}
 
 		for _, project := range projects {
-			// Apply status filter if provided
+			// Apply filters if provided; a project must match all of them
 			if statusFilter != "" && project.Status != statusFilter {
 				continue
 			}
 
+			if teamFilter != "" && !slices.ContainsFunc(project.Team, func(member string) bool {
+				return strings.EqualFold(member, teamFilter)
+			}) {
+				continue
+			}
+
+			if nameFilter != "" && !strings.Contains(strings.ToLower(project.Name), nameNeedle) {
+				continue
+			}
+
 			projectMap := map[string]interface{}{
 				"id":          project.ID,
 				"name":        project.Name,
@@ -82,10 +124,13 @@ func createListProjectsHandler(client PCFClient) mcp.ToolHandler {
 
 		// Build response
 		response := map[string]interface{}{
-			"projects":    projectList,
-			"total_count": len(projectList),
+			"projects": projectList,
 		}
 
-		return response, nil
+		return withListMetadata(response, len(projectList), len(projects), map[string]string{
+			"status":        statusFilter,
+			"team":          teamFilter,
+			"name_contains": nameFilter,
+		}), nil
 	}
 }
@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/aRustyDev/pcf-mcp/internal/pcf"
@@ -197,6 +198,87 @@ func TestListProjectsHandler(t *testing.T) {
 	}
 }
 
+// TestListProjectsFilters tests the team and name filters alone and ANDed
+// with each other and with status
+func TestListProjectsFilters(t *testing.T) {
+	projects := []pcf.Project{
+		{ID: "proj1", Name: "Acme External", Status: "active", Team: []string{"alice", "bob"}},
+		{ID: "proj2", Name: "Acme Internal", Status: "completed", Team: []string{"Alice"}},
+		{ID: "proj3", Name: "Globex Web App", Status: "active", Team: []string{"carol"}},
+		{ID: "proj4", Name: "Initech Review", Status: "active"},
+	}
+
+	tests := []struct {
+		name            string
+		params          map[string]interface{}
+		expectedIDs     []string
+		expectedFilters map[string]string
+	}{
+		{
+			name:            "Team only",
+			params:          map[string]interface{}{"team": "alice"},
+			expectedIDs:     []string{"proj1", "proj2"},
+			expectedFilters: map[string]string{"team": "alice"},
+		},
+		{
+			name:            "Name only",
+			params:          map[string]interface{}{"name_contains": "ACME"},
+			expectedIDs:     []string{"proj1", "proj2"},
+			expectedFilters: map[string]string{"name_contains": "ACME"},
+		},
+		{
+			name:            "Team and name",
+			params:          map[string]interface{}{"team": "bob", "name_contains": "acme"},
+			expectedIDs:     []string{"proj1"},
+			expectedFilters: map[string]string{"team": "bob", "name_contains": "acme"},
+		},
+		{
+			name:            "Team, name, and status",
+			params:          map[string]interface{}{"team": "alice", "name_contains": "acme", "status": "completed"},
+			expectedIDs:     []string{"proj2"},
+			expectedFilters: map[string]string{"team": "alice", "name_contains": "acme", "status": "completed"},
+		},
+		{
+			name:            "No match",
+			params:          map[string]interface{}{"team": "carol", "name_contains": "acme"},
+			expectedIDs:     nil,
+			expectedFilters: map[string]string{"team": "carol", "name_contains": "acme"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := NewListProjectsTool(&MockPCFClient{
+				ListProjectsFunc: func(ctx context.Context) ([]pcf.Project, error) {
+					return projects, nil
+				},
+			})
+
+			result, err := tool.Handler(context.Background(), tt.params)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			resultMap := result.(map[string]interface{})
+
+			var ids []string
+			for _, project := range resultMap["projects"].([]map[string]interface{}) {
+				ids = append(ids, project["id"].(string))
+			}
+			if !reflect.DeepEqual(ids, tt.expectedIDs) {
+				t.Errorf("Expected projects %v, got %v", tt.expectedIDs, ids)
+			}
+
+			if resultMap["unfiltered_count"] != len(projects) {
+				t.Errorf("Expected unfiltered_count %d, got %v", len(projects), resultMap["unfiltered_count"])
+			}
+			if !reflect.DeepEqual(resultMap["applied_filters"], tt.expectedFilters) {
+				t.Errorf("Expected applied filters %v, got %v", tt.expectedFilters, resultMap["applied_filters"])
+			}
+		})
+	}
+}
+
 // TestListProjectsInputValidation tests input parameter validation
 func TestListProjectsInputValidation(t *testing.T) {
 	mockClient := &MockPCFClient{
@@ -0,0 +1,145 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+)
+
+// ProjectSummaryClient defines the interface for summarizing a project
+type ProjectSummaryClient interface {
+	ListHostsClient
+	ListIssuesClient
+	ListCredentialsClient
+}
+
+// NewProjectSummaryTool creates an MCP tool that summarizes the hosts, issues,
+// and credentials of a PCF project in a single call
+func NewProjectSummaryTool(client ProjectSummaryClient) mcp.Tool {
+	return mcp.Tool{
+		Name:          "project_summary",
+		Description:   "Summarize a PCF project: host, issue, and credential counts with OS, severity, and credential type breakdowns",
+		Category:      categoryProjects,
+		Tags:          []string{categoryProjects, tagRead},
+		RequiredScope: mcp.ScopeRead,
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"project_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the project to summarize",
+				},
+			},
+			"required":             []string{"project_id"},
+			"additionalProperties": false,
+		},
+		Handler: createProjectSummaryHandler(client),
+	}
+}
+
+// createProjectSummaryHandler creates the handler function for summarizing projects
+func createProjectSummaryHandler(client ProjectSummaryClient) mcp.ToolHandler {
+	return func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+		// Extract and validate project_id
+		projectID, ok := params["project_id"].(string)
+		if !ok {
+			return nil, fmt.Errorf("project_id parameter must be a string")
+		}
+
+		if projectID == "" {
+			return nil, fmt.Errorf("project_id cannot be empty")
+		}
+
+		// Fetch each resource type concurrently; one failing does not cancel
+		// the others so the summary can still report what was fetched
+		var (
+			wg     sync.WaitGroup
+			mu     sync.Mutex
+			counts = make(map[string]int)
+			errs   = make(map[string]string)
+		)
+
+		response := map[string]interface{}{
+			"project_id": projectID,
+		}
+
+		record := func(section string, count int, breakdownKey string, breakdown map[string]int, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				errs[section] = err.Error()
+				return
+			}
+			counts[section] = count
+			response[breakdownKey] = breakdown
+		}
+
+		wg.Add(3)
+
+		go func() {
+			defer wg.Done()
+			hosts, err := client.ListHosts(ctx, projectID)
+			if err != nil {
+				record(searchTypeHosts, 0, "", nil, fmt.Errorf("failed to list hosts: %w", err))
+				return
+			}
+
+			osCount := make(map[string]int)
+			for _, host := range hosts {
+				osName := host.OS
+				if osName == "" {
+					osName = "unknown"
+				}
+				osCount[osName]++
+			}
+			record(searchTypeHosts, len(hosts), "os_breakdown", osCount, nil)
+		}()
+
+		go func() {
+			defer wg.Done()
+			issues, err := client.ListIssues(ctx, projectID)
+			if err != nil {
+				record(searchTypeIssues, 0, "", nil, fmt.Errorf("failed to list issues: %w", err))
+				return
+			}
+
+			severityCount := make(map[string]int)
+			for _, issue := range issues {
+				severityCount[issue.Severity]++
+			}
+			record(searchTypeIssues, len(issues), "severity_breakdown", severityCount, nil)
+		}()
+
+		go func() {
+			defer wg.Done()
+			credentials, err := client.ListCredentials(ctx, projectID)
+			if err != nil {
+				record(searchTypeCredentials, 0, "", nil, fmt.Errorf("failed to list credentials: %w", err))
+				return
+			}
+
+			// Only credential types are summarized; values never leave this function
+			typeCount := make(map[string]int)
+			for _, cred := range credentials {
+				typeCount[cred.Type]++
+			}
+			record(searchTypeCredentials, len(credentials), "credential_type_breakdown", typeCount, nil)
+		}()
+
+		wg.Wait()
+
+		if len(errs) == 3 {
+			return nil, fmt.Errorf("failed to summarize project '%s': %s", projectID, errs[searchTypeHosts])
+		}
+
+		response["counts"] = counts
+		if len(errs) > 0 {
+			response["errors"] = errs
+		}
+
+		return response, nil
+	}
+}
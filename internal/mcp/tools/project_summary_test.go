@@ -0,0 +1,142 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// TestNewProjectSummaryTool tests creating a new project summary tool
+func TestNewProjectSummaryTool(t *testing.T) {
+	tool := NewProjectSummaryTool(&MockFullPCFClient{})
+
+	if tool.Name != "project_summary" {
+		t.Errorf("Expected tool name 'project_summary', got '%s'", tool.Name)
+	}
+
+	if tool.Description == "" {
+		t.Error("Tool description should not be empty")
+	}
+
+	if tool.Handler == nil {
+		t.Error("Tool handler should not be nil")
+	}
+
+	required, ok := tool.InputSchema["required"].([]string)
+	if !ok || len(required) != 1 || required[0] != "project_id" {
+		t.Errorf("Expected project_id to be required, got %v", tool.InputSchema["required"])
+	}
+}
+
+// TestProjectSummaryHandler tests summarizing a project
+func TestProjectSummaryHandler(t *testing.T) {
+	client := newSearchMockClient()
+	client.ListHostsFunc = func(ctx context.Context, projectID string) ([]pcf.Host, error) {
+		return []pcf.Host{
+			{ID: "host-1", ProjectID: projectID, IP: "10.0.1.30", OS: "Linux"},
+			{ID: "host-2", ProjectID: projectID, IP: "10.0.1.31", OS: "Linux"},
+			{ID: "host-3", ProjectID: projectID, IP: "10.0.1.32"},
+		}, nil
+	}
+
+	tool := NewProjectSummaryTool(client)
+
+	result, err := tool.Handler(context.Background(), map[string]interface{}{"project_id": "proj-1"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	resultMap := result.(map[string]interface{})
+
+	wantCounts := map[string]int{"hosts": 3, "issues": 2, "credentials": 2}
+	if !reflect.DeepEqual(resultMap["counts"], wantCounts) {
+		t.Errorf("Expected counts %v, got %v", wantCounts, resultMap["counts"])
+	}
+
+	wantOS := map[string]int{"Linux": 2, "unknown": 1}
+	if !reflect.DeepEqual(resultMap["os_breakdown"], wantOS) {
+		t.Errorf("Expected OS breakdown %v, got %v", wantOS, resultMap["os_breakdown"])
+	}
+
+	wantSeverity := map[string]int{"High": 1, "Critical": 1}
+	if !reflect.DeepEqual(resultMap["severity_breakdown"], wantSeverity) {
+		t.Errorf("Expected severity breakdown %v, got %v", wantSeverity, resultMap["severity_breakdown"])
+	}
+
+	wantTypes := map[string]int{"password": 2}
+	if !reflect.DeepEqual(resultMap["credential_type_breakdown"], wantTypes) {
+		t.Errorf("Expected credential type breakdown %v, got %v", wantTypes, resultMap["credential_type_breakdown"])
+	}
+
+	if _, ok := resultMap["errors"]; ok {
+		t.Errorf("Expected no errors, got %v", resultMap["errors"])
+	}
+
+	// Credential values never appear in the summary
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Failed to encode result: %v", err)
+	}
+	for _, secret := range []string{"s3cret", "hunter2"} {
+		if strings.Contains(string(encoded), secret) {
+			t.Errorf("Summary leaked credential value %q", secret)
+		}
+	}
+}
+
+// TestProjectSummaryPartialFailure tests that one failing fetch does not fail the summary
+func TestProjectSummaryPartialFailure(t *testing.T) {
+	client := newSearchMockClient()
+	client.ListCredentialsFunc = func(ctx context.Context, projectID string) ([]pcf.Credential, error) {
+		return nil, errors.New("PCF API error: internal server error")
+	}
+
+	tool := NewProjectSummaryTool(client)
+
+	result, err := tool.Handler(context.Background(), map[string]interface{}{"project_id": "proj-1"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	resultMap := result.(map[string]interface{})
+
+	wantCounts := map[string]int{"hosts": 3, "issues": 2}
+	if !reflect.DeepEqual(resultMap["counts"], wantCounts) {
+		t.Errorf("Expected counts %v, got %v", wantCounts, resultMap["counts"])
+	}
+
+	if _, ok := resultMap["credential_type_breakdown"]; ok {
+		t.Error("Failed credentials fetch should not report a type breakdown")
+	}
+
+	errs, ok := resultMap["errors"].(map[string]string)
+	if !ok || !strings.Contains(errs["credentials"], "failed to list credentials") {
+		t.Errorf("Expected credentials error, got %v", resultMap["errors"])
+	}
+
+	// Every fetch failing fails the whole call
+	failing := &MockFullPCFClient{
+		ListHostsFunc: func(ctx context.Context, projectID string) ([]pcf.Host, error) {
+			return nil, pcf.ErrNotFound
+		},
+		ListIssuesFunc: func(ctx context.Context, projectID string) ([]pcf.Issue, error) {
+			return nil, pcf.ErrNotFound
+		},
+		ListCredentialsFunc: func(ctx context.Context, projectID string) ([]pcf.Credential, error) {
+			return nil, pcf.ErrNotFound
+		},
+	}
+	if _, err := NewProjectSummaryTool(failing).Handler(context.Background(), map[string]interface{}{"project_id": "proj-1"}); err == nil {
+		t.Error("Expected error when every fetch fails")
+	}
+
+	// Parameter validation
+	if _, err := tool.Handler(context.Background(), map[string]interface{}{}); err == nil || !strings.Contains(err.Error(), "project_id parameter must be a string") {
+		t.Errorf("Expected project_id error, got %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package tools
+
+import (
+	"fmt"
+
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+)
+
+// projectIDArgument is the project argument shared by the pentest prompts
+var projectIDArgument = mcp.PromptArgument{
+	Name:        "project_id",
+	Description: "The ID of the PCF project",
+	Required:    true,
+}
+
+// pentestPrompts are the prompt templates registered by RegisterAllPrompts.
+// They steer the model towards the tools that gather the data they need.
+var pentestPrompts = []mcp.Prompt{
+	{
+		Name:        "summarize_critical_findings",
+		Description: "Summarize the critical and high severity findings of a project",
+		Arguments:   []mcp.PromptArgument{projectIDArgument},
+		Template: `Summarize the critical and high severity findings in PCF project {{.project_id}}.
+
+Use list_issues with project_id "{{.project_id}}" and severity "Critical", then "High". For each finding give the title, affected host, CVE and CVSS score if known, and a one-sentence remediation. Order findings by CVSS score, highest first, and end with the three most urgent actions.`,
+	},
+	{
+		Name:        "triage_host",
+		Description: "Review everything known about one host and suggest next testing steps",
+		Arguments: []mcp.PromptArgument{
+			projectIDArgument,
+			{Name: "host_id", Description: "The ID of the host to triage", Required: true},
+		},
+		Template: `Triage host {{.host_id}} in PCF project {{.project_id}}.
+
+Use get_host for its services, list_issues filtered by host_id "{{.host_id}}" for known findings, and list_credentials filtered by host_id "{{.host_id}}" for access we already have. Summarize the attack surface, note which findings are unconfirmed, and propose the next three tests to run.`,
+	},
+	{
+		Name:        "executive_summary",
+		Description: "Draft a non-technical executive summary of a project's results",
+		Arguments: []mcp.PromptArgument{
+			projectIDArgument,
+			{Name: "audience", Description: "Who the summary is for, e.g. \"board\" or \"IT leadership\""},
+		},
+		Template: `Draft an executive summary of PCF project {{.project_id}}{{if .audience}} for {{.audience}}{{end}}.
+
+Use project_summary with project_id "{{.project_id}}" for the overall numbers and list_issues for the most severe findings. Avoid jargon, explain business impact rather than technique, and keep it under 300 words.`,
+	},
+}
+
+// RegisterAllPrompts registers the pentest prompt templates with the MCP server
+func RegisterAllPrompts(server *mcp.Server) error {
+	for _, prompt := range pentestPrompts {
+		if err := server.RegisterPrompt(prompt); err != nil {
+			return fmt.Errorf("failed to register prompt '%s': %w", prompt.Name, err)
+		}
+	}
+
+	return nil
+}
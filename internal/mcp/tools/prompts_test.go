@@ -0,0 +1,45 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+)
+
+// TestRegisterAllPrompts tests registering and rendering the pentest prompts
+func TestRegisterAllPrompts(t *testing.T) {
+	server, err := mcp.NewServer(config.ServerConfig{Transport: "stdio"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	if err := RegisterAllPrompts(server); err != nil {
+		t.Fatalf("Failed to register prompts: %v", err)
+	}
+
+	if prompts := server.ListPrompts(); len(prompts) != len(pentestPrompts) {
+		t.Errorf("Expected %d prompts, got %d", len(pentestPrompts), len(prompts))
+	}
+
+	_, text, err := server.RenderPrompt("summarize_critical_findings", map[string]string{"project_id": "proj-42"})
+	if err != nil {
+		t.Fatalf("Failed to render prompt: %v", err)
+	}
+	if !strings.Contains(text, `project_id "proj-42"`) {
+		t.Errorf("Expected project ID to be substituted, got: %s", text)
+	}
+
+	_, text, err = server.RenderPrompt("executive_summary", map[string]string{"project_id": "proj-42", "audience": "the board"})
+	if err != nil {
+		t.Fatalf("Failed to render prompt: %v", err)
+	}
+	if !strings.Contains(text, "proj-42 for the board.") {
+		t.Errorf("Expected audience to be substituted, got: %s", text)
+	}
+
+	if _, _, err := server.RenderPrompt("triage_host", map[string]string{"project_id": "proj-42"}); err == nil {
+		t.Error("Expected error when host_id is missing")
+	}
+}
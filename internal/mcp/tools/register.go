@@ -4,38 +4,87 @@ import (
 	"fmt"
 
 	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf/redact"
 )
 
-// FullPCFClient defines the complete interface for all PCF operations
-type FullPCFClient interface {
-	PCFClient
-	CreateProjectClient
-	ListHostsClient
-	AddHostClient
-	ListIssuesClient
-	CreateIssueClient
-	ListCredentialsClient
-	AddCredentialClient
-	GenerateReportClient
+// Tool categories, also used as tags so clients can filter by either
+const (
+	categoryProjects    = "projects"
+	categoryHosts       = "hosts"
+	categoryIssues      = "issues"
+	categoryCredentials = "credentials"
+	categoryReports     = "reports"
+	categorySearch      = "search"
+)
+
+// Tool tags describing what a tool does to PCF data
+const (
+	tagRead        = "read"
+	tagWrite       = "write"
+	tagDestructive = "destructive"
+)
+
+// Options configures the tools registered by RegisterAllTools
+type Options struct {
+	// DefaultProjectID, when set, is used by project-scoped tools whose
+	// calls omit project_id
+	DefaultProjectID string
+
+	// ReportDir is where download_report saves reports; empty means a
+	// directory under os.TempDir
+	ReportDir string
+
+	// Redactor masks credential fields in tool results; nil masks the
+	// default fields
+	Redactor *redact.Redactor
+
+	// AllowCredentialExport lets export_project include raw credential values
+	AllowCredentialExport bool
 }
 
-// RegisterAllTools registers all available PCF tools with the MCP server
-func RegisterAllTools(server *mcp.Server, pcfClient FullPCFClient) error {
+// RegisterAllTools registers all available PCF tools with the MCP server,
+// backed by any implementation of pcf.API and configured by opts. Tools
+// excluded by the server's enabled_tools and disabled_tools configuration
+// are not registered.
+func RegisterAllTools(server *mcp.Server, pcfClient pcf.API, opts Options) error {
 	// List of all tools to register
 	tools := []mcp.Tool{
 		NewListProjectsTool(pcfClient),
 		NewCreateProjectTool(pcfClient),
+		NewUpdateProjectTool(pcfClient),
+		NewDeleteProjectTool(pcfClient),
+		NewProjectSummaryTool(pcfClient),
+		NewCloneProjectTool(pcfClient),
+		NewExportProjectTool(pcfClient, opts.Redactor, opts.AllowCredentialExport),
 		NewListHostsTool(pcfClient),
+		NewGetHostTool(pcfClient),
 		NewAddHostTool(pcfClient),
+		NewAddHostsTool(pcfClient),
+		NewImportScanTool(pcfClient),
 		NewListIssuesTool(pcfClient),
+		NewGetIssueTool(pcfClient),
 		NewCreateIssueTool(pcfClient),
-		NewListCredentialsTool(pcfClient),
-		NewAddCredentialTool(pcfClient),
+		NewImportIssuesTool(pcfClient),
+		NewUpdateIssueTool(pcfClient),
+		NewResolveHostIssuesTool(pcfClient),
+		NewLinkIssueHostTool(pcfClient),
+		NewListCredentialsTool(pcfClient, opts.Redactor),
+		NewCredentialSummaryTool(pcfClient, opts.Redactor),
+		NewAddCredentialTool(pcfClient, opts.Redactor),
+		NewSearchTool(pcfClient, opts.Redactor),
 		NewGenerateReportTool(pcfClient),
+		NewDownloadReportTool(pcfClient, reportDirOrDefault(opts.ReportDir)),
+	}
+
+	tools, err := server.SelectTools(tools)
+	if err != nil {
+		return fmt.Errorf("invalid tool selection: %w", err)
 	}
 
 	// Register each tool
 	for _, tool := range tools {
+		tool = withDefaultProject(tool, opts.DefaultProjectID)
 		if err := server.RegisterTool(tool); err != nil {
 			return fmt.Errorf("failed to register tool '%s': %w", tool.Name, err)
 		}
@@ -0,0 +1,149 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// closedIssueStatuses lists the issue statuses that need no further work
+var closedIssueStatuses = map[string]bool{
+	"Resolved": true,
+	"Closed":   true,
+}
+
+// ResolveHostIssuesClient defines the interface for resolving a host's issues
+type ResolveHostIssuesClient interface {
+	ListIssuesClient
+	UpdateIssueClient
+}
+
+// NewResolveHostIssuesTool creates an MCP tool for resolving every open issue on a host
+func NewResolveHostIssuesTool(client ResolveHostIssuesClient) mcp.Tool {
+	return mcp.Tool{
+		Name:          "resolve_host_issues",
+		Description:   "Resolve or close every open security issue on a host, e.g. once it is decommissioned or patched. Issues that are already Resolved or Closed are left unchanged",
+		Category:      categoryIssues,
+		Tags:          []string{categoryIssues, tagWrite},
+		RequiredScope: mcp.ScopeWrite,
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"project_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the project the host belongs to",
+				},
+				"host_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the host whose issues to resolve",
+				},
+				"status": map[string]interface{}{
+					"type":        "string",
+					"description": "The status to set on each open issue (defaults to Resolved)",
+					"enum":        []string{"Resolved", "Closed"},
+				},
+			},
+			"required":             []string{"project_id", "host_id"},
+			"additionalProperties": false,
+		},
+		Handler: createResolveHostIssuesHandler(client),
+	}
+}
+
+// createResolveHostIssuesHandler creates the handler function for resolving a host's issues
+func createResolveHostIssuesHandler(client ResolveHostIssuesClient) mcp.ToolHandler {
+	return func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+		// Extract and validate project_id
+		projectID, ok := params["project_id"].(string)
+		if !ok {
+			return nil, invalidParam("project_id", "must be a string")
+		}
+
+		if projectID == "" {
+			return nil, invalidParam("project_id", "cannot be empty")
+		}
+
+		// Extract and validate host_id
+		hostID, ok := params["host_id"].(string)
+		if !ok {
+			return nil, invalidParam("host_id", "must be a string")
+		}
+
+		if hostID == "" {
+			return nil, invalidParam("host_id", "cannot be empty")
+		}
+
+		status := "Resolved"
+		if statusRaw, ok := params["status"]; ok {
+			status, ok = statusRaw.(string)
+			if !ok {
+				return nil, invalidParam("status", "must be a string")
+			}
+
+			if !closedIssueStatuses[status] {
+				return nil, invalidParam("status", "must be one of Resolved, Closed, got %q", status)
+			}
+		}
+
+		issues, err := client.ListIssues(ctx, projectID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list issues: %w", err)
+		}
+
+		// Issues that are already closed are skipped, so repeated calls only
+		// retry the updates that failed
+		results := make([]map[string]interface{}, 0)
+		resolvedCount, alreadyClosedCount, failedCount := 0, 0, 0
+		for _, issue := range issues {
+			if issue.HostID != hostID {
+				continue
+			}
+
+			result := map[string]interface{}{
+				"issue_id":        issue.ID,
+				"title":           issue.Title,
+				"previous_status": issue.Status,
+			}
+
+			if closedIssueStatuses[issue.Status] {
+				result["success"] = true
+				result["status"] = issue.Status
+				result["skipped"] = true
+				results = append(results, result)
+				alreadyClosedCount++
+				continue
+			}
+
+			updated, err := client.UpdateIssue(ctx, projectID, issue.ID, pcf.UpdateIssueRequest{Status: &status})
+			if err != nil {
+				result["success"] = false
+				result["error"] = fmt.Sprintf("failed to update issue: %v", err)
+				results = append(results, result)
+				failedCount++
+				continue
+			}
+
+			result["success"] = true
+			result["status"] = updated.Status
+			results = append(results, result)
+			resolvedCount++
+		}
+
+		// Build response
+		response := map[string]interface{}{
+			"project_id":           projectID,
+			"host_id":              hostID,
+			"status":               status,
+			"results":              results,
+			"total_count":          len(results),
+			"resolved_count":       resolvedCount,
+			"already_closed_count": alreadyClosedCount,
+			"failed_count":         failedCount,
+			"message":              fmt.Sprintf("Set %d issues on host %s to %s (%d already closed, %d failed)", resolvedCount, hostID, status, alreadyClosedCount, failedCount),
+		}
+
+		return response, nil
+	}
+}
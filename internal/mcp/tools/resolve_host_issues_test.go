@@ -0,0 +1,198 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// TestNewResolveHostIssuesTool tests creating a new resolve host issues tool
+func TestNewResolveHostIssuesTool(t *testing.T) {
+	tool := NewResolveHostIssuesTool(&MockFullPCFClient{})
+
+	if tool.Name != "resolve_host_issues" {
+		t.Errorf("Expected tool name 'resolve_host_issues', got '%s'", tool.Name)
+	}
+
+	if tool.Handler == nil {
+		t.Error("Tool handler should not be nil")
+	}
+
+	required, ok := tool.InputSchema["required"].([]string)
+	if !ok {
+		t.Fatal("Input schema should have required fields")
+	}
+
+	if len(required) != 2 || required[0] != "project_id" || required[1] != "host_id" {
+		t.Errorf("Expected required fields [project_id host_id], got %v", required)
+	}
+}
+
+// TestResolveHostIssuesHandler tests that only the host's open issues are
+// updated and that a failing update is reported without aborting the rest
+func TestResolveHostIssuesHandler(t *testing.T) {
+	issues := []pcf.Issue{
+		{ID: "issue-1", HostID: "host-1", Title: "Outdated Apache", Status: "Open"},
+		{ID: "issue-2", HostID: "host-1", Title: "Weak TLS", Status: "In Progress"},
+		{ID: "issue-3", HostID: "host-1", Title: "Default credentials", Status: "Resolved"},
+		{ID: "issue-4", HostID: "host-1", Title: "Locked by PCF", Status: "Open"},
+		{ID: "issue-5", HostID: "host-1", Title: "Open SMB", Status: "Closed"},
+		{ID: "issue-6", HostID: "host-2", Title: "Other host", Status: "Open"},
+	}
+
+	var updatedIDs []string
+	client := &MockFullPCFClient{
+		ListIssuesFunc: func(ctx context.Context, projectID string) ([]pcf.Issue, error) {
+			return issues, nil
+		},
+		UpdateIssueFunc: func(ctx context.Context, projectID, issueID string, req pcf.UpdateIssueRequest) (*pcf.Issue, error) {
+			if req.Status == nil || *req.Status != "Closed" {
+				t.Errorf("Expected status 'Closed', got %v", req.Status)
+			}
+			if issueID == "issue-4" {
+				return nil, errors.New("issue is locked")
+			}
+			updatedIDs = append(updatedIDs, issueID)
+			for i := range issues {
+				if issues[i].ID == issueID {
+					issues[i].Status = *req.Status
+					return &issues[i], nil
+				}
+			}
+			return nil, pcf.ErrNotFound
+		},
+	}
+
+	tool := NewResolveHostIssuesTool(client)
+	params := map[string]interface{}{
+		"project_id": "proj-123",
+		"host_id":    "host-1",
+		"status":     "Closed",
+	}
+
+	result, err := tool.Handler(context.Background(), params)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if strings.Join(updatedIDs, ",") != "issue-1,issue-2" {
+		t.Errorf("Expected issue-1 and issue-2 to be updated, got %v", updatedIDs)
+	}
+
+	res := result.(map[string]interface{})
+	if res["total_count"] != 5 || res["resolved_count"] != 2 || res["already_closed_count"] != 2 || res["failed_count"] != 1 {
+		t.Errorf("Expected 5 issues with 2 resolved, 2 already closed, and 1 failed, got %v/%v/%v/%v",
+			res["total_count"], res["resolved_count"], res["already_closed_count"], res["failed_count"])
+	}
+
+	for _, entry := range res["results"].([]map[string]interface{}) {
+		if entry["issue_id"] == "issue-4" {
+			if entry["success"] != false || !strings.Contains(entry["error"].(string), "issue is locked") {
+				t.Errorf("Expected issue-4 to report the failed update, got %v", entry)
+			}
+		}
+	}
+
+	// A second call only retries the failed update
+	updatedIDs = nil
+	result, err = tool.Handler(context.Background(), params)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	res = result.(map[string]interface{})
+	if len(updatedIDs) != 0 || res["resolved_count"] != 0 || res["already_closed_count"] != 4 || res["failed_count"] != 1 {
+		t.Errorf("Expected a repeated call to change nothing, got updates %v and counts %v/%v/%v",
+			updatedIDs, res["resolved_count"], res["already_closed_count"], res["failed_count"])
+	}
+}
+
+// TestResolveHostIssuesHandlerDefaultStatus tests that issues are resolved
+// when no status is given
+func TestResolveHostIssuesHandlerDefaultStatus(t *testing.T) {
+	client := &MockFullPCFClient{
+		ListIssuesFunc: func(ctx context.Context, projectID string) ([]pcf.Issue, error) {
+			return []pcf.Issue{{ID: "issue-1", HostID: "host-1", Status: "Open"}}, nil
+		},
+		UpdateIssueFunc: func(ctx context.Context, projectID, issueID string, req pcf.UpdateIssueRequest) (*pcf.Issue, error) {
+			return &pcf.Issue{ID: issueID, HostID: "host-1", Status: *req.Status}, nil
+		},
+	}
+
+	result, err := NewResolveHostIssuesTool(client).Handler(context.Background(), map[string]interface{}{
+		"project_id": "proj-123",
+		"host_id":    "host-1",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	res := result.(map[string]interface{})
+	if res["status"] != "Resolved" || res["resolved_count"] != 1 {
+		t.Errorf("Expected one issue set to Resolved, got %v", res)
+	}
+}
+
+// TestResolveHostIssuesHandlerErrors tests errors that fail the whole call.
+// Parameter errors are ValidationErrors naming the field.
+func TestResolveHostIssuesHandlerErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		params    map[string]interface{}
+		listErr   error
+		errSubstr string
+		errField  string
+	}{
+		{
+			name:      "Missing project_id",
+			params:    map[string]interface{}{"host_id": "host-1"},
+			errSubstr: "must be a string",
+			errField:  "project_id",
+		},
+		{
+			name:      "Empty host_id",
+			params:    map[string]interface{}{"project_id": "proj-123", "host_id": ""},
+			errSubstr: "cannot be empty",
+			errField:  "host_id",
+		},
+		{
+			name:      "Reopening status",
+			params:    map[string]interface{}{"project_id": "proj-123", "host_id": "host-1", "status": "Open"},
+			errSubstr: `must be one of Resolved, Closed, got "Open"`,
+			errField:  "status",
+		},
+		{
+			name:      "List failure",
+			params:    map[string]interface{}{"project_id": "proj-123", "host_id": "host-1"},
+			listErr:   errors.New("connection refused"),
+			errSubstr: "failed to list issues",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &MockFullPCFClient{
+				ListIssuesFunc: func(ctx context.Context, projectID string) ([]pcf.Issue, error) {
+					return nil, tt.listErr
+				},
+			}
+
+			_, err := NewResolveHostIssuesTool(client).Handler(context.Background(), tt.params)
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Errorf("Expected error containing %q, got %v", tt.errSubstr, err)
+			}
+
+			var validationErr *ValidationError
+			isValidation := errors.As(err, &validationErr)
+			if tt.errField == "" && isValidation {
+				t.Errorf("Expected a PCF error, got ValidationError %v", err)
+			}
+			if tt.errField != "" && (!isValidation || validationErr.Field != tt.errField) {
+				t.Errorf("Expected a ValidationError for %q, got %v", tt.errField, err)
+			}
+		})
+	}
+}
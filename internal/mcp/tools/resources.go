@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf/redact"
+)
+
+// ResourcesClient defines the interface for reading PCF data as MCP resources
+type ResourcesClient interface {
+	PCFClient
+	ListHostsClient
+	ListIssuesClient
+	ListCredentialsClient
+}
+
+// NewPCFResources returns the read-only MCP resources backed by PCF data,
+// with credentials masked by redactor; nil masks the default fields
+func NewPCFResources(client ResourcesClient, redactor *redact.Redactor) []mcp.Resource {
+
+	return []mcp.Resource{
+		{
+			URITemplate: "pcf://projects",
+			Name:        "Projects",
+			Description: "All projects in PCF",
+			Handler: func(ctx context.Context, params map[string]string) (interface{}, error) {
+				projects, err := client.ListProjects(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list projects: %w", err)
+				}
+				return projects, nil
+			},
+		},
+		{
+			URITemplate: "pcf://projects/{project_id}/hosts",
+			Name:        "Project hosts",
+			Description: "Hosts in a PCF project",
+			Handler: func(ctx context.Context, params map[string]string) (interface{}, error) {
+				hosts, err := client.ListHosts(ctx, params["project_id"])
+				if err != nil {
+					return nil, fmt.Errorf("failed to list hosts: %w", err)
+				}
+				return hosts, nil
+			},
+		},
+		{
+			URITemplate: "pcf://projects/{project_id}/issues",
+			Name:        "Project issues",
+			Description: "Security issues in a PCF project",
+			Handler: func(ctx context.Context, params map[string]string) (interface{}, error) {
+				issues, err := client.ListIssues(ctx, params["project_id"])
+				if err != nil {
+					return nil, fmt.Errorf("failed to list issues: %w", err)
+				}
+				return issues, nil
+			},
+		},
+		{
+			URITemplate: "pcf://projects/{project_id}/credentials",
+			Name:        "Project credentials",
+			Description: "Credentials in a PCF project, with sensitive fields redacted",
+			Handler: func(ctx context.Context, params map[string]string) (interface{}, error) {
+				credentials, err := client.ListCredentials(ctx, params["project_id"])
+				if err != nil {
+					return nil, fmt.Errorf("failed to list credentials: %w", err)
+				}
+
+				credentialList := make([]map[string]interface{}, 0, len(credentials))
+				for _, cred := range credentials {
+					credentialList = append(credentialList, formatCredential(cred, redactor))
+				}
+				return credentialList, nil
+			},
+		},
+	}
+}
+
+// RegisterAllResources registers the PCF resources with the MCP server
+func RegisterAllResources(server *mcp.Server, client ResourcesClient, redactor *redact.Redactor) error {
+	for _, resource := range NewPCFResources(client, redactor) {
+		if err := server.RegisterResource(resource); err != nil {
+			return fmt.Errorf("failed to register resource '%s': %w", resource.URITemplate, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// TestRegisterAllResources tests reading PCF data through the registered resources
+func TestRegisterAllResources(t *testing.T) {
+	server, err := mcp.NewServer(config.ServerConfig{Transport: "stdio"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	if err := RegisterAllResources(server, newSearchMockClient(), nil); err != nil {
+		t.Fatalf("Failed to register resources: %v", err)
+	}
+
+	if resources := server.ListResources(); len(resources) != 4 {
+		t.Errorf("Expected 4 resources, got %d", len(resources))
+	}
+
+	// Hosts
+	contents, err := server.ReadResource(context.Background(), "pcf://projects/proj-1/hosts")
+	if err != nil {
+		t.Fatalf("Failed to read hosts resource: %v", err)
+	}
+
+	var hosts []pcf.Host
+	if err := json.Unmarshal([]byte(contents.Text), &hosts); err != nil {
+		t.Fatalf("Hosts resource should be JSON: %v", err)
+	}
+	if len(hosts) != 3 || hosts[0].ProjectID != "proj-1" {
+		t.Errorf("Unexpected hosts: %+v", hosts)
+	}
+
+	// Credential values are redacted
+	contents, err = server.ReadResource(context.Background(), "pcf://projects/proj-1/credentials")
+	if err != nil {
+		t.Fatalf("Failed to read credentials resource: %v", err)
+	}
+	for _, secret := range []string{"s3cret", "hunter2"} {
+		if strings.Contains(contents.Text, secret) {
+			t.Errorf("Credentials resource leaked value %q", secret)
+		}
+	}
+	if !strings.Contains(contents.Text, `"username":"admin"`) {
+		t.Errorf("Expected usernames in credentials resource, got %s", contents.Text)
+	}
+}
@@ -0,0 +1,454 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf/netutil"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf/redact"
+)
+
+// Search result types
+const (
+	searchTypeHosts       = "hosts"
+	searchTypeIssues      = "issues"
+	searchTypeCredentials = "credentials"
+)
+
+// Search result limits
+const (
+	defaultSearchLimit = 25
+	maxSearchLimit     = 100
+)
+
+// Match scores, highest first
+const (
+	scoreExact     = 3
+	scorePrefix    = 2
+	scoreSubstring = 1
+)
+
+// searchTypes lists every resource type the search tool can cover
+var searchTypes = []string{searchTypeHosts, searchTypeIssues, searchTypeCredentials}
+
+// SearchClient defines the interface for searching across project resources
+type SearchClient interface {
+	ListHostsClient
+	ListIssuesClient
+	ListCredentialsClient
+}
+
+// NewSearchTool creates an MCP tool for searching hosts, issues, and
+// credentials in a PCF project. Matching credentials are masked by redactor;
+// nil masks the default fields.
+func NewSearchTool(client SearchClient, redactor *redact.Redactor) mcp.Tool {
+	return mcp.Tool{
+		Name:          "search",
+		Description:   "Search hosts, issues, and credentials in a PCF project by IP, hostname, title, username, or service",
+		Category:      categorySearch,
+		Tags:          []string{categorySearch, tagRead},
+		RequiredScope: mcp.ScopeRead,
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"project_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the project to search",
+				},
+				"query": map[string]interface{}{
+					"type":        "string",
+					"description": "Case-insensitive text to match (e.g. an IP address or hostname)",
+					"minLength":   1,
+				},
+				"types": map[string]interface{}{
+					"type":        "array",
+					"description": "Resource types to search (defaults to all)",
+					"items": map[string]interface{}{
+						"type": "string",
+						"enum": searchTypes,
+					},
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of results per type",
+					"minimum":     1,
+					"maximum":     maxSearchLimit,
+					"default":     defaultSearchLimit,
+				},
+			},
+			"required":             []string{"project_id", "query"},
+			"additionalProperties": false,
+		},
+		Handler: createSearchHandler(client, redactor),
+	}
+}
+
+// searchMatch is a scored search hit
+type searchMatch struct {
+	score         int
+	matchedFields []string
+	record        map[string]interface{}
+}
+
+// createSearchHandler creates the handler function for searching project resources
+func createSearchHandler(client SearchClient, redactor *redact.Redactor) mcp.ToolHandler {
+
+	return func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+		// Extract and validate project_id
+		projectID, ok := params["project_id"].(string)
+		if !ok {
+			return nil, invalidParam("project_id", "must be a string")
+		}
+
+		if projectID == "" {
+			return nil, invalidParam("project_id", "cannot be empty")
+		}
+
+		// Extract and validate query
+		query, ok := params["query"].(string)
+		if !ok {
+			return nil, invalidParam("query", "must be a string")
+		}
+
+		query = strings.TrimSpace(query)
+		if query == "" {
+			return nil, invalidParam("query", "cannot be empty")
+		}
+
+		// Extract optional types filter
+		types, err := parseSearchTypes(params["types"])
+		if err != nil {
+			return nil, err
+		}
+
+		// Extract optional limit
+		limit := defaultSearchLimit
+		if limitRaw, ok := params["limit"]; ok {
+			switch v := limitRaw.(type) {
+			case float64:
+				limit = int(v)
+			case int:
+				limit = v
+			default:
+				return nil, invalidParam("limit", "must be a number")
+			}
+
+			if limit < 1 || limit > maxSearchLimit {
+				return nil, invalidParam("limit", "must be between 1 and %d, got %d", maxSearchLimit, limit)
+			}
+		}
+
+		// An address query matches however PCF happens to spell the IP
+		needle := strings.ToLower(query)
+		if ip, err := netutil.NormalizeIP(query); err == nil {
+			needle = ip
+		}
+		results := make(map[string]interface{})
+		totalCount := 0
+		truncated := false
+
+		// Hosts are always fetched when issues or credentials are searched so
+		// records attached to a matching host are found too
+		matchedHostIDs := make(map[string]bool)
+		if types[searchTypeHosts] || types[searchTypeIssues] || types[searchTypeCredentials] {
+			hosts, err := client.ListHosts(ctx, projectID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list hosts: %w", err)
+			}
+
+			var matches []searchMatch
+			for _, host := range hosts {
+				match, ok := matchHost(host, needle)
+				if !ok {
+					continue
+				}
+				matchedHostIDs[host.ID] = true
+				matches = append(matches, match)
+			}
+
+			if types[searchTypeHosts] {
+				list, more := rankMatches(matches, limit)
+				results[searchTypeHosts] = list
+				totalCount += len(list)
+				truncated = truncated || more
+			}
+		}
+
+		if types[searchTypeIssues] {
+			issues, err := client.ListIssues(ctx, projectID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list issues: %w", err)
+			}
+
+			var matches []searchMatch
+			for _, issue := range issues {
+				if match, ok := matchIssue(issue, needle, matchedHostIDs); ok {
+					matches = append(matches, match)
+				}
+			}
+
+			list, more := rankMatches(matches, limit)
+			results[searchTypeIssues] = list
+			totalCount += len(list)
+			truncated = truncated || more
+		}
+
+		if types[searchTypeCredentials] {
+			credentials, err := client.ListCredentials(ctx, projectID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list credentials: %w", err)
+			}
+
+			var matches []searchMatch
+			for _, cred := range credentials {
+				if match, ok := matchCredential(cred, needle, matchedHostIDs, redactor); ok {
+					matches = append(matches, match)
+				}
+			}
+
+			list, more := rankMatches(matches, limit)
+			results[searchTypeCredentials] = list
+			totalCount += len(list)
+			truncated = truncated || more
+		}
+
+		// Build response
+		response := map[string]interface{}{
+			"project_id":  projectID,
+			"query":       query,
+			"results":     results,
+			"total_count": totalCount,
+			"limit":       limit,
+			"truncated":   truncated,
+		}
+
+		return response, nil
+	}
+}
+
+// canonicalIP returns the normalized form of ip, or ip unchanged when it is
+// not a single address
+func canonicalIP(ip string) string {
+	if normalized, err := netutil.NormalizeIP(ip); err == nil {
+		return normalized
+	}
+	return ip
+}
+
+// parseSearchTypes converts the types parameter into a set, defaulting to all types
+func parseSearchTypes(raw interface{}) (map[string]bool, error) {
+	selected := make(map[string]bool)
+
+	if raw == nil {
+		for _, t := range searchTypes {
+			selected[t] = true
+		}
+		return selected, nil
+	}
+
+	var names []string
+	switch v := raw.(type) {
+	case []string:
+		names = v
+	case []interface{}:
+		names = make([]string, 0, len(v))
+		for _, item := range v {
+			name, ok := item.(string)
+			if !ok {
+				return nil, invalidParam("types", "must be an array of strings")
+			}
+			names = append(names, name)
+		}
+	default:
+		return nil, invalidParam("types", "must be an array of strings")
+	}
+
+	for _, name := range names {
+		valid := false
+		for _, t := range searchTypes {
+			if name == t {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, invalidParam("types", "must be one of %s, got %q", strings.Join(searchTypes, ", "), name)
+		}
+		selected[name] = true
+	}
+
+	if len(selected) == 0 {
+		return nil, invalidParam("types", "cannot be empty")
+	}
+
+	return selected, nil
+}
+
+// scoreField returns how well value matches the lowercased needle
+func scoreField(value, needle string) int {
+	if value == "" {
+		return 0
+	}
+
+	value = strings.ToLower(value)
+	switch {
+	case value == needle:
+		return scoreExact
+	case strings.HasPrefix(value, needle):
+		return scorePrefix
+	case strings.Contains(value, needle):
+		return scoreSubstring
+	default:
+		return 0
+	}
+}
+
+// fieldMatcher accumulates the best score and the names of matching fields
+type fieldMatcher struct {
+	needle string
+	score  int
+	fields []string
+}
+
+// check scores a single named field
+func (m *fieldMatcher) check(name, value string) {
+	if s := scoreField(value, m.needle); s > 0 {
+		if s > m.score {
+			m.score = s
+		}
+		m.fields = append(m.fields, name)
+	}
+}
+
+// matchHost scores a host against the query
+func matchHost(host pcf.Host, needle string) (searchMatch, bool) {
+	m := &fieldMatcher{needle: needle}
+	m.check("ip", canonicalIP(host.IP))
+	m.check("hostname", host.Hostname)
+	m.check("os", host.OS)
+	for _, service := range host.Services {
+		if scoreField(service, needle) > 0 {
+			m.check("services", service)
+			break
+		}
+	}
+
+	if m.score == 0 {
+		return searchMatch{}, false
+	}
+
+	record := map[string]interface{}{
+		"id":         host.ID,
+		"project_id": host.ProjectID,
+		"ip":         host.IP,
+	}
+
+	if host.Hostname != "" {
+		record["hostname"] = host.Hostname
+	}
+
+	if host.OS != "" {
+		record["os"] = host.OS
+	}
+
+	if len(host.Services) > 0 {
+		record["services"] = host.Services
+	}
+
+	if host.Status != "" {
+		record["status"] = host.Status
+	}
+
+	return searchMatch{score: m.score, matchedFields: m.fields, record: record}, true
+}
+
+// matchIssue scores an issue against the query, including issues on matching hosts
+func matchIssue(issue pcf.Issue, needle string, matchedHostIDs map[string]bool) (searchMatch, bool) {
+	m := &fieldMatcher{needle: needle}
+	m.check("title", issue.Title)
+	m.check("cve", issue.CVE)
+	m.check("description", issue.Description)
+
+	if issue.HostID != "" && matchedHostIDs[issue.HostID] {
+		if m.score < scoreSubstring {
+			m.score = scoreSubstring
+		}
+		m.fields = append(m.fields, "host")
+	}
+
+	if m.score == 0 {
+		return searchMatch{}, false
+	}
+
+	record := map[string]interface{}{
+		"id":         issue.ID,
+		"project_id": issue.ProjectID,
+		"title":      issue.Title,
+		"severity":   issue.Severity,
+		"status":     issue.Status,
+	}
+
+	if issue.HostID != "" {
+		record["host_id"] = issue.HostID
+	}
+
+	if issue.CVE != "" {
+		record["cve"] = issue.CVE
+	}
+
+	return searchMatch{score: m.score, matchedFields: m.fields, record: record}, true
+}
+
+// matchCredential scores a credential against the query. Redacted fields are
+// never searched, so a match cannot reveal their contents.
+func matchCredential(cred pcf.Credential, needle string, matchedHostIDs map[string]bool, redactor *redact.Redactor) (searchMatch, bool) {
+	m := &fieldMatcher{needle: needle}
+	for _, field := range []struct{ name, value string }{
+		{"username", cred.Username},
+		{"service", cred.Service},
+		{"notes", cred.Notes},
+	} {
+		if !redactor.Redacts(field.name) {
+			m.check(field.name, field.value)
+		}
+	}
+
+	if cred.HostID != "" && matchedHostIDs[cred.HostID] && !redactor.Redacts("host_id") {
+		if m.score < scoreSubstring {
+			m.score = scoreSubstring
+		}
+		m.fields = append(m.fields, "host")
+	}
+
+	if m.score == 0 {
+		return searchMatch{}, false
+	}
+
+	return searchMatch{score: m.score, matchedFields: m.fields, record: formatCredential(cred, redactor)}, true
+}
+
+// rankMatches orders matches by score and returns at most limit records,
+// reporting whether any were dropped
+func rankMatches(matches []searchMatch, limit int) ([]map[string]interface{}, bool) {
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	truncated := len(matches) > limit
+	if truncated {
+		matches = matches[:limit]
+	}
+
+	list := make([]map[string]interface{}, 0, len(matches))
+	for _, match := range matches {
+		match.record["score"] = match.score
+		match.record["matched_fields"] = match.matchedFields
+		list = append(list, match.record)
+	}
+
+	return list, truncated
+}
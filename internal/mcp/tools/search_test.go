@@ -0,0 +1,272 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// newSearchMockClient returns a client with a small project containing related records
+func newSearchMockClient() *MockFullPCFClient {
+	return &MockFullPCFClient{
+		ListHostsFunc: func(ctx context.Context, projectID string) ([]pcf.Host, error) {
+			return []pcf.Host{
+				{ID: "host-1", ProjectID: projectID, IP: "10.0.1.30", Hostname: "web01.corp.local", Services: []string{"http", "ssh"}},
+				{ID: "host-2", ProjectID: projectID, IP: "10.0.1.31", Hostname: "db01.corp.local", Services: []string{"postgresql"}},
+				{ID: "host-3", ProjectID: projectID, IP: "10.0.1.300", Hostname: "legacy.corp.local"},
+			}, nil
+		},
+		ListIssuesFunc: func(ctx context.Context, projectID string) ([]pcf.Issue, error) {
+			return []pcf.Issue{
+				{ID: "issue-1", ProjectID: projectID, HostID: "host-1", Title: "Outdated Apache", Severity: "High", Status: "Open"},
+				{ID: "issue-2", ProjectID: projectID, HostID: "host-2", Title: "Weak postgres password", Severity: "Critical", Status: "Open"},
+			}, nil
+		},
+		ListCredentialsFunc: func(ctx context.Context, projectID string) ([]pcf.Credential, error) {
+			return []pcf.Credential{
+				{ID: "cred-1", ProjectID: projectID, HostID: "host-1", Type: "password", Username: "admin", Value: "s3cret", Service: "ssh"},
+				{ID: "cred-2", ProjectID: projectID, HostID: "host-2", Type: "password", Username: "postgres", Value: "hunter2", Service: "postgresql"},
+			}, nil
+		},
+	}
+}
+
+// TestNewSearchTool tests creating a new search tool
+func TestNewSearchTool(t *testing.T) {
+	tool := NewSearchTool(&MockFullPCFClient{}, nil)
+
+	if tool.Name != "search" {
+		t.Errorf("Expected tool name 'search', got '%s'", tool.Name)
+	}
+
+	if tool.Description == "" {
+		t.Error("Tool description should not be empty")
+	}
+
+	if tool.Handler == nil {
+		t.Error("Tool handler should not be nil")
+	}
+
+	props, ok := tool.InputSchema["properties"].(map[string]interface{})
+	if !ok {
+		t.Fatal("Input schema should have properties")
+	}
+
+	for _, name := range []string{"project_id", "query", "types", "limit"} {
+		if _, ok := props[name]; !ok {
+			t.Errorf("Input schema missing '%s' property", name)
+		}
+	}
+
+	required, ok := tool.InputSchema["required"].([]string)
+	if !ok {
+		t.Fatal("Input schema should have required fields")
+	}
+
+	if len(required) != 2 || required[0] != "project_id" || required[1] != "query" {
+		t.Errorf("Expected required fields [project_id query], got %v", required)
+	}
+}
+
+// TestSearchHandler tests the search handler functionality
+func TestSearchHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		params         map[string]interface{}
+		expectError    bool
+		errorField     string
+		validateResult func(t *testing.T, result map[string]interface{})
+	}{
+		{
+			name: "Search by IP finds related records",
+			params: map[string]interface{}{
+				"project_id": "proj-123",
+				"query":      "10.0.1.30",
+			},
+			validateResult: func(t *testing.T, result map[string]interface{}) {
+				results := result["results"].(map[string]interface{})
+
+				hosts := results["hosts"].([]map[string]interface{})
+				if len(hosts) != 2 {
+					t.Fatalf("Expected 2 matching hosts, got %d", len(hosts))
+				}
+				// Exact IP match ranks ahead of the prefix match on 10.0.1.300
+				if hosts[0]["id"] != "host-1" {
+					t.Errorf("Expected exact match first, got %v", hosts[0]["id"])
+				}
+
+				issues := results["issues"].([]map[string]interface{})
+				if len(issues) != 1 || issues[0]["id"] != "issue-1" {
+					t.Errorf("Expected issue-1 via its host, got %v", issues)
+				}
+
+				creds := results["credentials"].([]map[string]interface{})
+				if len(creds) != 1 || creds[0]["id"] != "cred-1" {
+					t.Fatalf("Expected cred-1 via its host, got %v", creds)
+				}
+				if creds[0]["value"] != "***REDACTED***" {
+					t.Errorf("Credential value should be redacted, got %v", creds[0]["value"])
+				}
+
+				if result["total_count"] != 4 {
+					t.Errorf("Expected total_count 4, got %v", result["total_count"])
+				}
+			},
+		},
+		{
+			name: "Search by differently spelled IP",
+			params: map[string]interface{}{
+				"project_id": "proj-123",
+				"query":      "::ffff:10.0.1.31",
+				"types":      []interface{}{"hosts"},
+			},
+			validateResult: func(t *testing.T, result map[string]interface{}) {
+				hosts := result["results"].(map[string]interface{})["hosts"].([]map[string]interface{})
+				if len(hosts) != 1 || hosts[0]["id"] != "host-2" {
+					t.Errorf("Expected host-2 for the IPv4-mapped address, got %v", hosts)
+				}
+			},
+		},
+		{
+			name: "Case-insensitive match on services and usernames",
+			params: map[string]interface{}{
+				"project_id": "proj-123",
+				"query":      "POSTGRES",
+			},
+			validateResult: func(t *testing.T, result map[string]interface{}) {
+				results := result["results"].(map[string]interface{})
+
+				hosts := results["hosts"].([]map[string]interface{})
+				if len(hosts) != 1 || hosts[0]["id"] != "host-2" {
+					t.Errorf("Expected host-2, got %v", hosts)
+				}
+
+				creds := results["credentials"].([]map[string]interface{})
+				if len(creds) != 1 || creds[0]["id"] != "cred-2" {
+					t.Errorf("Expected cred-2, got %v", creds)
+				}
+			},
+		},
+		{
+			name: "Types filter restricts results",
+			params: map[string]interface{}{
+				"project_id": "proj-123",
+				"query":      "10.0.1.30",
+				"types":      []interface{}{"credentials"},
+			},
+			validateResult: func(t *testing.T, result map[string]interface{}) {
+				results := result["results"].(map[string]interface{})
+
+				if _, ok := results["hosts"]; ok {
+					t.Error("Hosts should not be returned when filtered out")
+				}
+				if _, ok := results["issues"]; ok {
+					t.Error("Issues should not be returned when filtered out")
+				}
+				if creds := results["credentials"].([]map[string]interface{}); len(creds) != 1 {
+					t.Errorf("Expected 1 credential, got %d", len(creds))
+				}
+			},
+		},
+		{
+			name: "Limit truncates results",
+			params: map[string]interface{}{
+				"project_id": "proj-123",
+				"query":      "corp.local",
+				"types":      []string{"hosts"},
+				"limit":      float64(2),
+			},
+			validateResult: func(t *testing.T, result map[string]interface{}) {
+				hosts := result["results"].(map[string]interface{})["hosts"].([]map[string]interface{})
+				if len(hosts) != 2 {
+					t.Errorf("Expected 2 hosts, got %d", len(hosts))
+				}
+				if result["truncated"] != true {
+					t.Error("Expected truncated to be true")
+				}
+			},
+		},
+		{
+			name:        "Missing query",
+			params:      map[string]interface{}{"project_id": "proj-123"},
+			expectError: true,
+			errorField:  "query",
+		},
+		{
+			name:        "Empty query",
+			params:      map[string]interface{}{"project_id": "proj-123", "query": "  "},
+			expectError: true,
+			errorField:  "query",
+		},
+		{
+			name:        "Missing project_id",
+			params:      map[string]interface{}{"query": "web"},
+			expectError: true,
+			errorField:  "project_id",
+		},
+		{
+			name:        "Invalid type",
+			params:      map[string]interface{}{"project_id": "proj-123", "query": "web", "types": []string{"projects"}},
+			expectError: true,
+			errorField:  "types",
+		},
+		{
+			name:        "Limit out of range",
+			params:      map[string]interface{}{"project_id": "proj-123", "query": "web", "limit": 0},
+			expectError: true,
+			errorField:  "limit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := NewSearchTool(newSearchMockClient(), nil)
+
+			result, err := tool.Handler(context.Background(), tt.params)
+
+			if tt.expectError {
+				var validationErr *ValidationError
+				if !errors.As(err, &validationErr) {
+					t.Fatalf("Expected a ValidationError, got %v", err)
+				}
+				if validationErr.Field != tt.errorField {
+					t.Errorf("Expected field %q, got %q", tt.errorField, validationErr.Field)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			res, ok := result.(map[string]interface{})
+			if !ok {
+				t.Fatal("Result should be a map")
+			}
+
+			if tt.validateResult != nil {
+				tt.validateResult(t, res)
+			}
+		})
+	}
+}
+
+// TestSearchHandlerClientError tests that PCF failures are surfaced
+func TestSearchHandlerClientError(t *testing.T) {
+	client := newSearchMockClient()
+	client.ListIssuesFunc = func(ctx context.Context, projectID string) ([]pcf.Issue, error) {
+		return nil, errors.New("PCF API error")
+	}
+
+	tool := NewSearchTool(client, nil)
+
+	_, err := tool.Handler(context.Background(), map[string]interface{}{
+		"project_id": "proj-123",
+		"query":      "web",
+	})
+	if err == nil {
+		t.Error("Expected error when PCF call fails")
+	}
+}
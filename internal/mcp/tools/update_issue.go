@@ -0,0 +1,200 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// validIssueStatuses lists the lifecycle states PCF accepts for issues
+var validIssueStatuses = map[string]bool{
+	"Open":        true,
+	"In Progress": true,
+	"Resolved":    true,
+	"Closed":      true,
+}
+
+// UpdateIssueClient defines the interface for updating issues
+type UpdateIssueClient interface {
+	UpdateIssue(ctx context.Context, projectID, issueID string, req pcf.UpdateIssueRequest) (*pcf.Issue, error)
+}
+
+// NewUpdateIssueTool creates an MCP tool for updating security issues in a PCF project
+func NewUpdateIssueTool(client UpdateIssueClient) mcp.Tool {
+	return mcp.Tool{
+		Name:          "update_issue",
+		Description:   "Update the status, severity, description, or CVSS score of an existing security issue in a PCF project",
+		Category:      categoryIssues,
+		Tags:          []string{categoryIssues, tagWrite},
+		RequiredScope: mcp.ScopeWrite,
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"project_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the project the issue belongs to",
+				},
+				"issue_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the issue to update",
+				},
+				"status": map[string]interface{}{
+					"type":        "string",
+					"description": "The new issue status",
+					"enum":        []string{"Open", "In Progress", "Resolved", "Closed"},
+				},
+				"severity": map[string]interface{}{
+					"type":        "string",
+					"description": "The new severity level",
+					"enum":        []string{"Critical", "High", "Medium", "Low", "Info"},
+				},
+				"description": map[string]interface{}{
+					"type":        "string",
+					"description": "The new description, e.g. with remediation notes",
+					"minLength":   1,
+				},
+				"cvss": map[string]interface{}{
+					"type":        "number",
+					"description": "The new CVSS score (0-10)",
+					"minimum":     0,
+					"maximum":     10,
+				},
+			},
+			"required":             []string{"project_id", "issue_id"},
+			"additionalProperties": false,
+		},
+		Handler: createUpdateIssueHandler(client),
+	}
+}
+
+// createUpdateIssueHandler creates the handler function for updating issues
+func createUpdateIssueHandler(client UpdateIssueClient) mcp.ToolHandler {
+	return func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+		// Extract and validate project_id
+		projectID, ok := params["project_id"].(string)
+		if !ok {
+			return nil, fmt.Errorf("project_id parameter must be a string")
+		}
+
+		if projectID == "" {
+			return nil, fmt.Errorf("project_id cannot be empty")
+		}
+
+		// Extract and validate issue_id
+		issueID, ok := params["issue_id"].(string)
+		if !ok {
+			return nil, fmt.Errorf("issue_id parameter must be a string")
+		}
+
+		if issueID == "" {
+			return nil, fmt.Errorf("issue_id cannot be empty")
+		}
+
+		// Only fields present in params are sent to PCF
+		req := pcf.UpdateIssueRequest{}
+		updated := make([]string, 0, 4)
+
+		if statusRaw, ok := params["status"]; ok {
+			status, ok := statusRaw.(string)
+			if !ok {
+				return nil, fmt.Errorf("status parameter must be a string")
+			}
+
+			// Validate status value
+			if !validIssueStatuses[status] {
+				return nil, fmt.Errorf("invalid status value: %s. Must be one of: Open, In Progress, Resolved, Closed", status)
+			}
+			req.Status = &status
+			updated = append(updated, "status")
+		}
+
+		if severityRaw, ok := params["severity"]; ok {
+			severity, ok := severityRaw.(string)
+			if !ok {
+				return nil, fmt.Errorf("severity parameter must be a string")
+			}
+
+			// Validate severity value
+			if !validIssueSeverities[severity] {
+				return nil, fmt.Errorf("invalid severity value: %s. Must be one of: Critical, High, Medium, Low, Info", severity)
+			}
+			req.Severity = &severity
+			updated = append(updated, "severity")
+		}
+
+		if descRaw, ok := params["description"]; ok {
+			desc, ok := descRaw.(string)
+			if !ok {
+				return nil, fmt.Errorf("description parameter must be a string")
+			}
+			if desc == "" {
+				return nil, fmt.Errorf("description cannot be empty")
+			}
+			req.Description = &desc
+			updated = append(updated, "description")
+		}
+
+		if cvssRaw, ok := params["cvss"]; ok {
+			// Handle both float64 and int types
+			var cvss float64
+			switch v := cvssRaw.(type) {
+			case float64:
+				cvss = v
+			case int:
+				cvss = float64(v)
+			default:
+				return nil, fmt.Errorf("cvss parameter must be a number")
+			}
+
+			// Validate CVSS range
+			if cvss < 0 || cvss > 10 {
+				return nil, fmt.Errorf("cvss score must be between 0 and 10, got %f", cvss)
+			}
+			req.CVSS = &cvss
+			updated = append(updated, "cvss")
+		}
+
+		if len(updated) == 0 {
+			return nil, fmt.Errorf("at least one of status, severity, description, or cvss must be provided")
+		}
+
+		// Call PCF client to update issue
+		issue, err := client.UpdateIssue(ctx, projectID, issueID, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update issue: %w", err)
+		}
+
+		// Build response
+		issueMap := map[string]interface{}{
+			"id":          issue.ID,
+			"project_id":  issue.ProjectID,
+			"title":       issue.Title,
+			"description": issue.Description,
+			"severity":    issue.Severity,
+			"status":      issue.Status,
+		}
+
+		// Add optional fields if present
+		if issue.HostID != "" {
+			issueMap["host_id"] = issue.HostID
+		}
+
+		if issue.CVE != "" {
+			issueMap["cve"] = issue.CVE
+		}
+
+		if issue.CVSS > 0 {
+			issueMap["cvss"] = issue.CVSS
+		}
+
+		response := map[string]interface{}{
+			"issue":          issueMap,
+			"updated_fields": updated,
+			"message":        fmt.Sprintf("Issue '%s' updated successfully", issue.Title),
+		}
+
+		return response, nil
+	}
+}
@@ -0,0 +1,175 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// UpdateProjectClient defines the interface for updating projects
+type UpdateProjectClient interface {
+	UpdateProject(ctx context.Context, projectID string, req pcf.UpdateProjectRequest) (*pcf.Project, error)
+}
+
+// NewUpdateProjectTool creates an MCP tool for updating PCF project metadata
+func NewUpdateProjectTool(client UpdateProjectClient) mcp.Tool {
+	return mcp.Tool{
+		Name:          "update_project",
+		Description:   "Update the metadata of an existing project in the Pentest Collaboration Framework",
+		Category:      categoryProjects,
+		Tags:          []string{categoryProjects, tagWrite},
+		RequiredScope: mcp.ScopeWrite,
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"project_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the project to update",
+				},
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "The new name of the project",
+					"minLength":   1,
+					"maxLength":   100,
+				},
+				"description": map[string]interface{}{
+					"type":        "string",
+					"description": "The new description of the project",
+					"maxLength":   500,
+				},
+				"status": map[string]interface{}{
+					"type":        "string",
+					"description": "The new project status",
+					"enum":        []string{"active", "completed", "on-hold"},
+				},
+				"team": map[string]interface{}{
+					"type":        "array",
+					"description": "Replacement list of team member usernames",
+					"items": map[string]interface{}{
+						"type": "string",
+					},
+				},
+			},
+			"required":             []string{"project_id"},
+			"additionalProperties": false,
+		},
+		Handler: createUpdateProjectHandler(client),
+	}
+}
+
+// createUpdateProjectHandler creates the handler function for updating projects
+func createUpdateProjectHandler(client UpdateProjectClient) mcp.ToolHandler {
+	return func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+		// Extract and validate project_id
+		projectID, ok := params["project_id"].(string)
+		if !ok {
+			return nil, fmt.Errorf("project_id parameter must be a string")
+		}
+
+		if projectID == "" {
+			return nil, fmt.Errorf("project_id cannot be empty")
+		}
+
+		// Only fields present in params are sent to PCF
+		req := pcf.UpdateProjectRequest{}
+		updated := make([]string, 0, 4)
+
+		if nameRaw, ok := params["name"]; ok {
+			name, ok := nameRaw.(string)
+			if !ok {
+				return nil, fmt.Errorf("name parameter must be a string")
+			}
+			if name == "" {
+				return nil, fmt.Errorf("project name cannot be empty")
+			}
+			req.Name = &name
+			updated = append(updated, "name")
+		}
+
+		if descRaw, ok := params["description"]; ok {
+			desc, ok := descRaw.(string)
+			if !ok {
+				return nil, fmt.Errorf("description parameter must be a string")
+			}
+			req.Description = &desc
+			updated = append(updated, "description")
+		}
+
+		if statusRaw, ok := params["status"]; ok {
+			status, ok := statusRaw.(string)
+			if !ok {
+				return nil, fmt.Errorf("status parameter must be a string")
+			}
+
+			// Validate status value
+			validStatuses := map[string]bool{
+				"active":    true,
+				"completed": true,
+				"on-hold":   true,
+			}
+
+			if !validStatuses[status] {
+				return nil, fmt.Errorf("invalid status: %s. Must be one of: active, completed, on-hold", status)
+			}
+			req.Status = &status
+			updated = append(updated, "status")
+		}
+
+		if teamRaw, ok := params["team"]; ok {
+			// Handle different types that might come from JSON
+			switch team := teamRaw.(type) {
+			case []string:
+				req.Team = &team
+			case []interface{}:
+				// Convert []interface{} to []string
+				teamMembers := make([]string, 0, len(team))
+				for _, member := range team {
+					if memberStr, ok := member.(string); ok {
+						teamMembers = append(teamMembers, memberStr)
+					} else {
+						return nil, fmt.Errorf("team members must be strings")
+					}
+				}
+				req.Team = &teamMembers
+			default:
+				return nil, fmt.Errorf("team parameter must be an array of strings")
+			}
+			updated = append(updated, "team")
+		}
+
+		if len(updated) == 0 {
+			return nil, fmt.Errorf("at least one of name, description, status, or team must be provided")
+		}
+
+		// Call PCF client to update project
+		project, err := client.UpdateProject(ctx, projectID, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update project: %w", err)
+		}
+
+		// Build response
+		projectMap := map[string]interface{}{
+			"id":          project.ID,
+			"name":        project.Name,
+			"description": project.Description,
+			"status":      project.Status,
+			"created_at":  project.CreatedAt.Format("2006-01-02T15:04:05Z"),
+			"updated_at":  project.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		}
+
+		// Add team if present
+		if len(project.Team) > 0 {
+			projectMap["team"] = project.Team
+		}
+
+		response := map[string]interface{}{
+			"project":        projectMap,
+			"updated_fields": updated,
+			"message":        fmt.Sprintf("Project '%s' updated successfully", project.Name),
+		}
+
+		return response, nil
+	}
+}
@@ -0,0 +1,189 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// MockUpdateProjectClient is a mock implementation for UpdateProject
+type MockUpdateProjectClient struct {
+	UpdateProjectFunc func(ctx context.Context, projectID string, req pcf.UpdateProjectRequest) (*pcf.Project, error)
+}
+
+func (m *MockUpdateProjectClient) UpdateProject(ctx context.Context, projectID string, req pcf.UpdateProjectRequest) (*pcf.Project, error) {
+	if m.UpdateProjectFunc != nil {
+		return m.UpdateProjectFunc(ctx, projectID, req)
+	}
+	return nil, errors.New("UpdateProjectFunc not implemented")
+}
+
+// TestNewUpdateProjectTool tests creating a new update project tool
+func TestNewUpdateProjectTool(t *testing.T) {
+	tool := NewUpdateProjectTool(&MockUpdateProjectClient{})
+
+	if tool.Name != "update_project" {
+		t.Errorf("Expected tool name 'update_project', got '%s'", tool.Name)
+	}
+
+	if tool.Handler == nil {
+		t.Error("Tool handler should not be nil")
+	}
+
+	required, ok := tool.InputSchema["required"].([]string)
+	if !ok {
+		t.Fatal("Input schema should have required fields")
+	}
+
+	if len(required) != 1 || required[0] != "project_id" {
+		t.Error("'project_id' should be the only required field")
+	}
+}
+
+// TestUpdateProjectHandler tests the update project handler functionality
+func TestUpdateProjectHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      map[string]interface{}
+		mockError   error
+		expectError bool
+		validateReq func(t *testing.T, req pcf.UpdateProjectRequest)
+	}{
+		{
+			name: "Update name only",
+			params: map[string]interface{}{
+				"project_id": "proj-123",
+				"name":       "Renamed",
+			},
+			validateReq: func(t *testing.T, req pcf.UpdateProjectRequest) {
+				if req.Name == nil || *req.Name != "Renamed" {
+					t.Errorf("Expected name 'Renamed', got %v", req.Name)
+				}
+				if req.Description != nil || req.Status != nil || req.Team != nil {
+					t.Error("Unprovided fields should not be set")
+				}
+			},
+		},
+		{
+			name: "Update status and team",
+			params: map[string]interface{}{
+				"project_id": "proj-123",
+				"status":     "completed",
+				"team":       []interface{}{"alice", "bob"},
+			},
+			validateReq: func(t *testing.T, req pcf.UpdateProjectRequest) {
+				if req.Status == nil || *req.Status != "completed" {
+					t.Errorf("Expected status 'completed', got %v", req.Status)
+				}
+				if req.Team == nil || len(*req.Team) != 2 {
+					t.Errorf("Expected 2 team members, got %v", req.Team)
+				}
+				if req.Name != nil {
+					t.Error("Name should not be set")
+				}
+			},
+		},
+		{
+			name: "Clear team",
+			params: map[string]interface{}{
+				"project_id": "proj-123",
+				"team":       []interface{}{},
+			},
+			validateReq: func(t *testing.T, req pcf.UpdateProjectRequest) {
+				if req.Team == nil || len(*req.Team) != 0 {
+					t.Errorf("Expected an empty team, got %v", req.Team)
+				}
+
+				// The empty list must reach PCF rather than being omitted
+				body, err := json.Marshal(req)
+				if err != nil {
+					t.Fatalf("Failed to encode request: %v", err)
+				}
+				if string(body) != `{"team":[]}` {
+					t.Errorf("Expected the empty team to be sent, got %s", body)
+				}
+			},
+		},
+		{
+			name: "No fields to update",
+			params: map[string]interface{}{
+				"project_id": "proj-123",
+			},
+			expectError: true,
+		},
+		{
+			name: "Missing project_id",
+			params: map[string]interface{}{
+				"name": "Renamed",
+			},
+			expectError: true,
+		},
+		{
+			name: "Invalid status",
+			params: map[string]interface{}{
+				"project_id": "proj-123",
+				"status":     "archived",
+			},
+			expectError: true,
+		},
+		{
+			name: "Empty name",
+			params: map[string]interface{}{
+				"project_id": "proj-123",
+				"name":       "",
+			},
+			expectError: true,
+		},
+		{
+			name: "PCF API error",
+			params: map[string]interface{}{
+				"project_id": "proj-123",
+				"name":       "Renamed",
+			},
+			mockError:   errors.New("PCF API error"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockUpdateProjectClient{
+				UpdateProjectFunc: func(ctx context.Context, projectID string, req pcf.UpdateProjectRequest) (*pcf.Project, error) {
+					if tt.validateReq != nil {
+						tt.validateReq(t, req)
+					}
+					if tt.mockError != nil {
+						return nil, tt.mockError
+					}
+					return &pcf.Project{ID: projectID, Name: "Renamed", Status: "active"}, nil
+				},
+			}
+
+			tool := NewUpdateProjectTool(mockClient)
+			result, err := tool.Handler(context.Background(), tt.params)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			res, ok := result.(map[string]interface{})
+			if !ok {
+				t.Fatal("Result should be a map")
+			}
+
+			if _, ok := res["project"]; !ok {
+				t.Error("Result should contain 'project' key")
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package tools
+
+import (
+	"fmt"
+
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+)
+
+// ValidationError is returned by a tool handler when a parameter is missing
+// or invalid. The HTTP transport reports it with the invalid_params code and
+// the field and reason as details.
+type ValidationError struct {
+	// Field is the name of the offending parameter
+	Field string
+
+	// Reason explains why the parameter was rejected
+	Reason string
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid parameter %s: %s", e.Field, e.Reason)
+}
+
+// ParamField implements mcp.ParamError
+func (e *ValidationError) ParamField() mcp.FieldError {
+	return mcp.FieldError{Field: e.Field, Reason: e.Reason}
+}
+
+// invalidParam returns a ValidationError for field with a formatted reason
+func invalidParam(field, format string, args ...interface{}) error {
+	return &ValidationError{Field: field, Reason: fmt.Sprintf(format, args...)}
+}
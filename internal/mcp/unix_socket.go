@@ -0,0 +1,42 @@
+package mcp
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"time"
+)
+
+// staleSocketDialTimeout bounds the check that an existing socket file has
+// no server behind it
+const staleSocketDialTimeout = time.Second
+
+// listenUnixSocket listens on the Unix domain socket at path. A socket file
+// left behind by a server that did not shut down cleanly is removed first;
+// a socket another server is still listening on, or any other kind of file,
+// is left alone and reported as an error.
+func listenUnixSocket(path string) (net.Listener, error) {
+	info, err := os.Lstat(path)
+	switch {
+	case err == nil && info.Mode()&fs.ModeSocket == 0:
+		return nil, fmt.Errorf("unix socket path %s exists and is not a socket", path)
+	case err == nil:
+		if conn, err := net.DialTimeout("unix", path, staleSocketDialTimeout); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("unix socket %s is already in use", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale unix socket: %w", err)
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		return nil, fmt.Errorf("failed to check unix socket path: %w", err)
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on unix socket: %w", err)
+	}
+	return listener, nil
+}
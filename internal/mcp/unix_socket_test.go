@@ -0,0 +1,105 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+)
+
+// TestStartHTTPUnixSocket tests that the HTTP transport serves on a Unix
+// socket, replacing a stale socket file and removing it on shutdown
+func TestStartHTTPUnixSocket(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "mcp.sock")
+
+	// Leave a socket file behind as a crashed server would
+	stale, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("Failed to create stale socket: %v", err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	server, err := NewServer(config.ServerConfig{
+		Transport:    "http",
+		UnixSocket:   socketPath,
+		ReadTimeout:  5 * time.Second,
+		WriteTimeout: 5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.StartHTTP(ctx)
+	}()
+
+	client := &http.Client{
+		Timeout: time.Second,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", socketPath)
+			},
+		},
+	}
+
+	// Poll until the server is listening
+	var resp *http.Response
+	for i := 0; i < 50; i++ {
+		resp, err = client.Get("http://unix/health")
+		if err == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("Request over the Unix socket failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	// A second server cannot take over the live socket
+	if _, err := listenUnixSocket(socketPath); err == nil || !strings.Contains(err.Error(), "already in use") {
+		t.Errorf("Expected the live socket to be reported in use, got %v", err)
+	}
+
+	cancel()
+	if err := <-errCh; err != nil {
+		t.Errorf("Server stopped with error: %v", err)
+	}
+
+	if _, err := os.Lstat(socketPath); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected the socket file to be removed on shutdown, got %v", err)
+	}
+}
+
+// TestListenUnixSocketRefusesOtherFiles tests that a path holding something
+// other than a socket is never removed
+func TestListenUnixSocketRefusesOtherFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mcp.sock")
+	if err := os.WriteFile(path, []byte("data"), 0o600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	if _, err := listenUnixSocket(path); err == nil || !strings.Contains(err.Error(), "is not a socket") {
+		t.Errorf("Expected a regular file to be refused, got %v", err)
+	}
+
+	if data, err := os.ReadFile(path); err != nil || string(data) != "data" {
+		t.Errorf("Expected the file to be left alone, got %q, %v", data, err)
+	}
+}
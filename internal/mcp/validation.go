@@ -0,0 +1,160 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// validationPrinter renders schema violations as English messages
+var validationPrinter = message.NewPrinter(language.English)
+
+// FieldError describes a single tool parameter that failed schema validation
+type FieldError struct {
+	// Field is the dotted path of the offending parameter
+	Field string `json:"field"`
+
+	// Reason explains why the parameter was rejected
+	Reason string `json:"reason"`
+}
+
+// InputValidationError is returned when tool parameters do not match the
+// tool's InputSchema. It is only produced when ValidateToolInput is enabled.
+type InputValidationError struct {
+	// Tool is the name of the tool whose parameters were rejected
+	Tool string
+
+	// Fields lists every parameter that failed validation
+	Fields []FieldError
+}
+
+// Error implements the error interface
+func (e *InputValidationError) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		parts = append(parts, fmt.Sprintf("%s: %s", f.Field, f.Reason))
+	}
+	return fmt.Sprintf("invalid parameters for tool '%s': %s", e.Tool, strings.Join(parts, "; "))
+}
+
+// compileInputSchema compiles a tool's InputSchema for parameter validation
+func compileInputSchema(toolName string, schema map[string]interface{}) (*jsonschema.Schema, error) {
+	doc, err := toJSONValue(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	url := "mem://tools/" + toolName + ".json"
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(url, doc); err != nil {
+		return nil, err
+	}
+
+	return compiler.Compile(url)
+}
+
+// validateInput checks params against the compiled schema of the named tool.
+// It is a no-op for tools without a compiled schema.
+func (s *Server) validateInput(name string, params map[string]interface{}) error {
+	s.toolsMutex.RLock()
+	schema := s.schemas[name]
+	s.toolsMutex.RUnlock()
+
+	if schema == nil {
+		return nil
+	}
+
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+
+	instance, err := toJSONValue(params)
+	if err != nil {
+		return &InputValidationError{
+			Tool:   name,
+			Fields: []FieldError{{Field: "(root)", Reason: err.Error()}},
+		}
+	}
+
+	err = schema.Validate(instance)
+	if err == nil {
+		return nil
+	}
+
+	var verr *jsonschema.ValidationError
+	if !errors.As(err, &verr) {
+		return fmt.Errorf("failed to validate parameters for tool '%s': %w", name, err)
+	}
+
+	return &InputValidationError{Tool: name, Fields: collectFieldErrors(verr)}
+}
+
+// toJSONValue round-trips v through JSON so that Go-specific types such as
+// []string and int become the generic values the schema library expects
+func toJSONValue(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return jsonschema.UnmarshalJSON(bytes.NewReader(data))
+}
+
+// collectFieldErrors flattens a validation error tree into per-field errors
+func collectFieldErrors(verr *jsonschema.ValidationError) []FieldError {
+	var fields []FieldError
+
+	var walk func(e *jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) > 0 {
+			for _, cause := range e.Causes {
+				walk(cause)
+			}
+			return
+		}
+
+		location := strings.Join(e.InstanceLocation, ".")
+
+		// Required and additionalProperties errors are reported on the parent
+		// object, so attribute them to the named properties instead
+		switch k := e.ErrorKind.(type) {
+		case *kind.Required:
+			for _, missing := range k.Missing {
+				fields = append(fields, FieldError{Field: joinField(location, missing), Reason: "is required"})
+			}
+			return
+		case *kind.AdditionalProperties:
+			for _, extra := range k.Properties {
+				fields = append(fields, FieldError{Field: joinField(location, extra), Reason: "is not allowed"})
+			}
+			return
+		}
+
+		if location == "" {
+			location = "(root)"
+		}
+		fields = append(fields, FieldError{Field: location, Reason: e.ErrorKind.LocalizedString(validationPrinter)})
+	}
+	walk(verr)
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i].Field < fields[j].Field
+	})
+
+	return fields
+}
+
+// joinField appends a property name to a dotted parameter path
+func joinField(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
@@ -0,0 +1,214 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+)
+
+// validatedTool returns a tool whose schema exercises types, required fields and enums
+func validatedTool(called *bool) Tool {
+	return Tool{
+		Name:        "validated_tool",
+		Description: "Tool with a strict schema",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"name": map[string]interface{}{
+					"type": "string",
+				},
+				"status": map[string]interface{}{
+					"type": "string",
+					"enum": []string{"active", "completed"},
+				},
+				"limit": map[string]interface{}{
+					"type":    "integer",
+					"minimum": 1,
+				},
+			},
+			"required": []string{"name"},
+		},
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			*called = true
+			return "ok", nil
+		},
+	}
+}
+
+// TestValidateToolInput tests schema validation of tool parameters
+func TestValidateToolInput(t *testing.T) {
+	tests := []struct {
+		name           string
+		params         map[string]interface{}
+		expectedFields []string
+	}{
+		{
+			name:   "Valid parameters",
+			params: map[string]interface{}{"name": "proj", "status": "active", "limit": 5},
+		},
+		{
+			name:           "Missing required field",
+			params:         map[string]interface{}{"status": "active"},
+			expectedFields: []string{"name"},
+		},
+		{
+			name:           "Wrong type",
+			params:         map[string]interface{}{"name": 42},
+			expectedFields: []string{"name"},
+		},
+		{
+			name:           "Value outside enum",
+			params:         map[string]interface{}{"name": "proj", "status": "archived"},
+			expectedFields: []string{"status"},
+		},
+		{
+			name:           "Multiple offending fields",
+			params:         map[string]interface{}{"status": "archived", "limit": 0},
+			expectedFields: []string{"limit", "name", "status"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, err := NewServer(config.ServerConfig{Transport: "stdio", ValidateToolInput: true})
+			if err != nil {
+				t.Fatalf("Failed to create server: %v", err)
+			}
+
+			called := false
+			if err := server.RegisterTool(validatedTool(&called)); err != nil {
+				t.Fatalf("Failed to register tool: %v", err)
+			}
+
+			_, err = server.ExecuteTool(context.Background(), "validated_tool", tt.params)
+
+			if len(tt.expectedFields) == 0 {
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if !called {
+					t.Error("Handler should have been called")
+				}
+				return
+			}
+
+			var inputErr *InputValidationError
+			if !errors.As(err, &inputErr) {
+				t.Fatalf("Expected InputValidationError, got %v", err)
+			}
+
+			if called {
+				t.Error("Handler should not run when validation fails")
+			}
+
+			if len(inputErr.Fields) != len(tt.expectedFields) {
+				t.Fatalf("Expected fields %v, got %+v", tt.expectedFields, inputErr.Fields)
+			}
+			for i, field := range tt.expectedFields {
+				if inputErr.Fields[i].Field != field {
+					t.Errorf("Expected field %q at index %d, got %q", field, i, inputErr.Fields[i].Field)
+				}
+				if inputErr.Fields[i].Reason == "" {
+					t.Errorf("Field %q should have a reason", field)
+				}
+			}
+		})
+	}
+}
+
+// TestValidateToolInputDisabled tests that validation is lenient by default
+func TestValidateToolInputDisabled(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{Transport: "stdio"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	called := false
+	if err := server.RegisterTool(validatedTool(&called)); err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	if _, err := server.ExecuteTool(context.Background(), "validated_tool", map[string]interface{}{"status": "archived"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !called {
+		t.Error("Handler should run when validation is disabled")
+	}
+}
+
+// TestRegisterToolInvalidSchema tests that malformed schemas are rejected at registration
+func TestRegisterToolInvalidSchema(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{Transport: "stdio", ValidateToolInput: true})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	err = server.RegisterTool(Tool{
+		Name: "bad_schema",
+		InputSchema: map[string]interface{}{
+			"type": 12,
+		},
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return nil, nil
+		},
+	})
+	if err == nil {
+		t.Error("Expected error registering tool with invalid schema")
+	}
+}
+
+// TestHTTPToolInputValidation tests that invalid parameters produce a 400 listing fields
+func TestHTTPToolInputValidation(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{Transport: "http", ValidateToolInput: true})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	called := false
+	if err := server.RegisterTool(validatedTool(&called)); err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{"status": "archived"})
+	req := httptest.NewRequest(http.MethodPost, "/tools/validated_tool", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	server.HTTPHandler().ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response struct {
+		Error struct {
+			Code    ErrorCode `json:"code"`
+			Message string    `json:"message"`
+			Details struct {
+				Fields []FieldError `json:"fields"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if response.Error.Code != CodeValidation || response.Error.Message == "" {
+		t.Errorf("Expected validation error with a message, got %+v", response.Error)
+	}
+
+	if len(response.Error.Details.Fields) != 2 {
+		t.Errorf("Expected 2 offending fields, got %+v", response.Error.Details.Fields)
+	}
+
+	if called {
+		t.Error("Handler should not run when validation fails")
+	}
+}
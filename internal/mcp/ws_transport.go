@@ -0,0 +1,101 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aRustyDev/pcf-mcp/internal/observability"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// wsPath is the endpoint that upgrades to a WebSocket MCP session
+	wsPath = "/ws"
+
+	// wsMaxMessageSize caps the size of a single incoming JSON-RPC message
+	wsMaxMessageSize = 10 << 20
+
+	// wsWriteTimeout bounds how long a single response write may block
+	wsWriteTimeout = 10 * time.Second
+)
+
+// wsUpgrader upgrades HTTP requests to WebSocket connections. The default
+// origin check is kept, so browsers may only connect from the server's own
+// origin; non-browser clients do not send an Origin header.
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  4096,
+	WriteBufferSize: 4096,
+}
+
+// wsSession holds the state of a single WebSocket connection
+type wsSession struct {
+	conn *websocket.Conn
+
+	// writeMu serializes writes, since responses to concurrent calls may
+	// finish at the same time
+	writeMu sync.Mutex
+}
+
+// handleWebSocket upgrades the connection and serves JSON-RPC 2.0 messages
+// using the same methods as the stdio transport. Each message is handled in
+// its own goroutine, so slow tool calls do not block others; responses are
+// matched to requests by ID. Authentication happens in authMiddleware before
+// the upgrade.
+func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already written an error response
+		observability.FromContext(r.Context()).DebugContext(r.Context(), "WebSocket upgrade failed", "error", err)
+		return
+	}
+	defer conn.Close()
+
+	// Clear deadlines inherited from the HTTP server's read/write timeouts
+	_ = conn.SetReadDeadline(time.Time{})
+	_ = conn.SetWriteDeadline(time.Time{})
+	conn.SetReadLimit(wsMaxMessageSize)
+
+	session := &wsSession{conn: conn}
+
+	// In-flight calls are canceled when the connection closes
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	var inflight sync.WaitGroup
+	defer inflight.Wait()
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if errors.Is(err, websocket.ErrReadLimit) {
+				observability.FromContext(ctx).WarnContext(ctx, "WebSocket message too large", "limit", wsMaxMessageSize)
+			} else if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				observability.FromContext(ctx).DebugContext(ctx, "WebSocket read ended", "error", err)
+			}
+			cancel()
+			return
+		}
+
+		inflight.Add(1)
+		go func() {
+			defer inflight.Done()
+			if resp := s.handleJSONRPC(ctx, data); resp != nil {
+				session.write(ctx, resp)
+			}
+		}()
+	}
+}
+
+// write sends a response as a single text message
+func (ws *wsSession) write(ctx context.Context, resp *jsonRPCResponse) {
+	ws.writeMu.Lock()
+	defer ws.writeMu.Unlock()
+
+	_ = ws.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	if err := ws.conn.WriteJSON(resp); err != nil {
+		observability.FromContext(ctx).DebugContext(ctx, "Failed to write WebSocket response", "error", err)
+	}
+}
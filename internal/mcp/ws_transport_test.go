@@ -0,0 +1,193 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+	"github.com/gorilla/websocket"
+)
+
+// TestWebSocketTransport tests JSON-RPC over the /ws endpoint
+func TestWebSocketTransport(t *testing.T) {
+	server, err := NewServer(config.ServerConfig{
+		Transport:    "http",
+		AuthRequired: true,
+		AuthToken:    "test-token",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	release := make(chan struct{})
+
+	tools := []Tool{
+		{
+			Name:        "list_projects",
+			Description: "List projects",
+			Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+				return map[string]interface{}{
+					"projects": []map[string]interface{}{{"id": "proj-1", "name": "Test Project"}},
+				}, nil
+			},
+		},
+		{
+			Name:        "slow_tool",
+			Description: "Blocks until released",
+			Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+				select {
+				case <-release:
+					return "done", nil
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			},
+		},
+	}
+	for _, tool := range tools {
+		if err := server.RegisterTool(tool); err != nil {
+			t.Fatalf("Failed to register tool: %v", err)
+		}
+	}
+
+	ts := httptest.NewServer(server.HTTPHandler())
+	defer ts.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(ts.URL, "http") + "/ws"
+
+	// The upgrade handshake requires a valid bearer token
+	_, resp, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err == nil {
+		t.Fatal("Expected unauthenticated dial to fail")
+	}
+	if resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 for unauthenticated dial, got %v", resp)
+	}
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer test-token")
+
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, header)
+	if err != nil {
+		t.Fatalf("Failed to dial WebSocket: %v", err)
+	}
+	defer conn.Close()
+
+	send := func(msg string) {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+			t.Fatalf("Failed to send message: %v", err)
+		}
+	}
+
+	receive := func() jsonRPCResponse {
+		_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		var resp jsonRPCResponse
+		if err := conn.ReadJSON(&resp); err != nil {
+			t.Fatalf("Failed to read response: %v", err)
+		}
+		return resp
+	}
+
+	// A slow call does not block a later one; responses are matched by ID
+	send(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"slow_tool"}}`)
+	send(`{"jsonrpc":"2.0","id":"list-1","method":"tools/call","params":{"name":"list_projects","arguments":{}}}`)
+
+	listResp := receive()
+	if string(listResp.ID) != `"list-1"` {
+		t.Fatalf("Expected response for id \"list-1\" first, got %s", listResp.ID)
+	}
+	if listResp.Error != nil {
+		t.Fatalf("Unexpected error: %+v", listResp.Error)
+	}
+
+	result := listResp.Result.(map[string]interface{})
+	content := result["content"].([]interface{})
+	text := content[0].(map[string]interface{})["text"].(string)
+
+	var projects map[string]interface{}
+	if err := json.Unmarshal([]byte(text), &projects); err != nil {
+		t.Fatalf("Failed to decode tool result: %v", err)
+	}
+	if list, ok := projects["projects"].([]interface{}); !ok || len(list) != 1 {
+		t.Errorf("Expected 1 project, got %v", projects["projects"])
+	}
+
+	close(release)
+	if slowResp := receive(); string(slowResp.ID) != "1" {
+		t.Errorf("Expected response for id 1, got %s", slowResp.ID)
+	}
+
+	// Other MCP methods use the stdio framing
+	send(`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`)
+	toolsResp := receive()
+	if string(toolsResp.ID) != "2" || toolsResp.Error != nil {
+		t.Fatalf("Unexpected tools/list response: %+v", toolsResp)
+	}
+	if list := toolsResp.Result.(map[string]interface{})["tools"].([]interface{}); len(list) != 2 {
+		t.Errorf("Expected 2 tools, got %d", len(list))
+	}
+
+	send(`not json`)
+	if parseResp := receive(); parseResp.Error == nil || parseResp.Error.Code != jsonRPCParseError {
+		t.Errorf("Expected parse error, got %+v", parseResp)
+	}
+}
+
+// TestWebSocketResourceReadScope tests that resources/read over /ws requires
+// the read scope like the HTTP endpoint
+func TestWebSocketResourceReadScope(t *testing.T) {
+	server := newResourceServer(t)
+	server.config.AuthRequired = true
+	server.config.AuthTokens = []string{"read-token", "write-token"}
+	server.tokenScopes = map[string][]string{
+		"read-token":  {ScopeRead},
+		"write-token": {ScopeWrite},
+	}
+
+	ts := httptest.NewServer(server.HTTPHandler())
+	defer ts.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(ts.URL, "http") + "/ws"
+
+	for token, wantForbidden := range map[string]bool{
+		"read-token":  false,
+		"write-token": true,
+	} {
+		t.Run(token, func(t *testing.T) {
+			header := http.Header{}
+			header.Set("Authorization", "Bearer "+token)
+
+			conn, _, err := websocket.DefaultDialer.Dial(wsURL, header)
+			if err != nil {
+				t.Fatalf("Failed to dial WebSocket: %v", err)
+			}
+			defer conn.Close()
+
+			msg := `{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":"pcf://projects"}}`
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+				t.Fatalf("Failed to send message: %v", err)
+			}
+
+			_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+			var resp jsonRPCResponse
+			if err := conn.ReadJSON(&resp); err != nil {
+				t.Fatalf("Failed to read response: %v", err)
+			}
+
+			if wantForbidden {
+				if resp.Error == nil || resp.Error.Code != jsonRPCForbidden {
+					t.Errorf("Expected a forbidden error, got %+v", resp)
+				}
+				return
+			}
+			if resp.Error != nil || resp.Result == nil {
+				t.Errorf("Expected the resource contents, got %+v", resp)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package observability
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// latencyWindowSize is the number of most recent executions per tool
+	// the latency summary is computed over
+	latencyWindowSize = 1000
+
+	// latencyEWMAAlpha is the weight given to each new sample in the
+	// exponentially weighted moving average
+	latencyEWMAAlpha = 0.1
+)
+
+// LatencySummary summarizes recent tool execution latencies in milliseconds
+type LatencySummary struct {
+	// Count is the number of samples in the window
+	Count int `json:"count"`
+
+	// Total is the number of executions recorded since startup
+	Total int64 `json:"total"`
+
+	Min  float64 `json:"min_ms"`
+	Avg  float64 `json:"avg_ms"`
+	P50  float64 `json:"p50_ms"`
+	P95  float64 `json:"p95_ms"`
+	P99  float64 `json:"p99_ms"`
+	Max  float64 `json:"max_ms"`
+	EWMA float64 `json:"ewma_ms"`
+}
+
+// latencyWindow keeps the most recent samples in a ring buffer
+type latencyWindow struct {
+	samples []time.Duration
+	next    int
+	total   int64
+	ewma    float64
+}
+
+// observe adds a sample, evicting the oldest once the window is full
+func (w *latencyWindow) observe(d time.Duration) {
+	if len(w.samples) < latencyWindowSize {
+		w.samples = append(w.samples, d)
+	} else {
+		w.samples[w.next] = d
+		w.next = (w.next + 1) % latencyWindowSize
+	}
+
+	ms := durationMillis(d)
+	if w.total == 0 {
+		w.ewma = ms
+	} else {
+		w.ewma = latencyEWMAAlpha*ms + (1-latencyEWMAAlpha)*w.ewma
+	}
+	w.total++
+}
+
+// summary computes the latency summary over the current window
+func (w *latencyWindow) summary() LatencySummary {
+	summary := LatencySummary{Count: len(w.samples), Total: w.total, EWMA: w.ewma}
+	if len(w.samples) == 0 {
+		return summary
+	}
+
+	sorted := make([]time.Duration, len(w.samples))
+	copy(sorted, w.samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var sum time.Duration
+	for _, d := range sorted {
+		sum += d
+	}
+
+	summary.Min = durationMillis(sorted[0])
+	summary.Max = durationMillis(sorted[len(sorted)-1])
+	summary.Avg = durationMillis(sum / time.Duration(len(sorted)))
+	summary.P50 = durationMillis(percentile(sorted, 50))
+	summary.P95 = durationMillis(percentile(sorted, 95))
+	summary.P99 = durationMillis(percentile(sorted, 99))
+
+	return summary
+}
+
+// percentile returns the p-th percentile of sorted samples using the
+// nearest-rank index len*p/100, as in the stress tests
+func percentile(sorted []time.Duration, p int) time.Duration {
+	index := len(sorted) * p / 100
+	if index >= len(sorted) {
+		index = len(sorted) - 1
+	}
+	return sorted[index]
+}
+
+// durationMillis converts a duration to fractional milliseconds
+func durationMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// toolLatency tracks a latency window per tool
+type toolLatency struct {
+	mu      sync.Mutex
+	windows map[string]*latencyWindow
+}
+
+// newToolLatency creates an empty per-tool latency tracker
+func newToolLatency() *toolLatency {
+	return &toolLatency{windows: make(map[string]*latencyWindow)}
+}
+
+// observe records a tool execution duration
+func (t *toolLatency) observe(toolName string, d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	window, ok := t.windows[toolName]
+	if !ok {
+		window = &latencyWindow{}
+		t.windows[toolName] = window
+	}
+	window.observe(d)
+}
+
+// summaries returns the latency summary of every tool executed so far
+func (t *toolLatency) summaries() map[string]LatencySummary {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	summaries := make(map[string]LatencySummary, len(t.windows))
+	for name, window := range t.windows {
+		summaries[name] = window.summary()
+	}
+	return summaries
+}
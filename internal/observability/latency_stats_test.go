@@ -0,0 +1,91 @@
+package observability
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+)
+
+// TestLatencySummary tests percentiles computed from known durations
+func TestLatencySummary(t *testing.T) {
+	window := &latencyWindow{}
+
+	// Record 1ms..100ms in reverse so the summary has to sort
+	for i := 100; i >= 1; i-- {
+		window.observe(time.Duration(i) * time.Millisecond)
+	}
+
+	summary := window.summary()
+
+	want := LatencySummary{Count: 100, Total: 100, Min: 1, Avg: 50.5, P50: 51, P95: 96, P99: 100, Max: 100}
+	summary.EWMA, want.EWMA = 0, 0
+	if summary != want {
+		t.Errorf("Expected %+v, got %+v", want, summary)
+	}
+
+	// An empty window reports only zeros
+	if empty := (&latencyWindow{}).summary(); empty != (LatencySummary{}) {
+		t.Errorf("Expected empty summary, got %+v", empty)
+	}
+}
+
+// TestLatencyWindowEviction tests that only the most recent samples are summarized
+func TestLatencyWindowEviction(t *testing.T) {
+	window := &latencyWindow{}
+
+	for i := 0; i < latencyWindowSize; i++ {
+		window.observe(time.Second)
+	}
+	for i := 0; i < latencyWindowSize; i++ {
+		window.observe(time.Millisecond)
+	}
+
+	summary := window.summary()
+	if summary.Count != latencyWindowSize || summary.Total != 2*latencyWindowSize {
+		t.Errorf("Expected %d samples of %d total, got %d of %d", latencyWindowSize, 2*latencyWindowSize, summary.Count, summary.Total)
+	}
+	if summary.Max != 1 {
+		t.Errorf("Expected evicted samples to be dropped, got max %vms", summary.Max)
+	}
+	if summary.EWMA < 1 || summary.EWMA > 1.001 {
+		t.Errorf("Expected EWMA to converge to 1ms, got %vms", summary.EWMA)
+	}
+}
+
+// TestToolLatencyStats tests per-tool latency tracking through Metrics
+func TestToolLatencyStats(t *testing.T) {
+	metrics, err := InitMetrics(config.MetricsConfig{Enabled: true, EnableStatsEndpoint: true})
+	if err != nil {
+		t.Fatalf("Failed to initialize metrics: %v", err)
+	}
+
+	metrics.RecordToolExecution("list_projects", true, 10*time.Millisecond)
+	metrics.RecordToolExecution("list_projects", false, 30*time.Millisecond)
+	metrics.RecordToolExecution("get_host", true, 5*time.Millisecond)
+
+	stats := metrics.ToolLatencyStats()
+	if len(stats) != 2 {
+		t.Fatalf("Expected stats for 2 tools, got %d", len(stats))
+	}
+
+	projects := stats["list_projects"]
+	if projects.Count != 2 || projects.Min != 10 || projects.Max != 30 || projects.Avg != 20 {
+		t.Errorf("Unexpected list_projects stats: %+v", projects)
+	}
+
+	// 10ms then 30ms with alpha 0.1
+	if projects.EWMA != 12 {
+		t.Errorf("Expected EWMA 12ms, got %vms", projects.EWMA)
+	}
+
+	// Stats are only tracked when the endpoint is enabled
+	disabled, err := InitMetrics(config.MetricsConfig{Enabled: true})
+	if err != nil {
+		t.Fatalf("Failed to initialize metrics: %v", err)
+	}
+	disabled.RecordToolExecution("list_projects", true, 10*time.Millisecond)
+	if stats := disabled.ToolLatencyStats(); stats != nil {
+		t.Errorf("Expected nil stats when disabled, got %v", stats)
+	}
+}
@@ -5,11 +5,17 @@
 package observability
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
 	"os"
+	"reflect"
 	"strings"
 
 	"github.com/aRustyDev/pcf-mcp/internal/config"
@@ -21,11 +27,46 @@ type contextKey string
 // loggerKey is the context key for storing the logger
 const loggerKey contextKey = "logger"
 
+// requestIDKey is the context key for storing the request ID
+const requestIDKey contextKey = "request_id"
+
+// HeaderRequestID is the HTTP header carrying the request ID between services
+const HeaderRequestID = "X-Request-ID"
+
+// RedactedLogValue replaces the values of log attributes whose keys are
+// listed in LoggingConfig.RedactKeys
+const RedactedLogValue = "***"
+
 // NewLogger creates a new structured logger based on the provided configuration.
 // It supports JSON and text output formats, configurable log levels, and
-// optional source code location tracking.
+// optional source code location tracking. Logs go to cfg.Output (see
+// OpenLogOutput).
 func NewLogger(cfg config.LoggingConfig) (*slog.Logger, error) {
-	return NewLoggerWithWriter(cfg, os.Stdout)
+	w, err := OpenLogOutput(cfg.Output)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewLoggerWithWriter(cfg, w)
+}
+
+// OpenLogOutput returns the writer for a log output: "stdout", "stderr", or
+// a file path, which is created if needed and appended to. An empty output
+// means stdout. The file stays open for the life of the process.
+func OpenLogOutput(output string) (io.Writer, error) {
+	switch output {
+	case "", "stdout":
+		return os.Stdout, nil
+	case "stderr":
+		return os.Stderr, nil
+	}
+
+	file, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	return file, nil
 }
 
 // NewLoggerWithWriter creates a new logger with a custom writer.
@@ -37,10 +78,44 @@ func NewLoggerWithWriter(cfg config.LoggingConfig, w io.Writer) (*slog.Logger, e
 		return nil, err
 	}
 
+	return newLogger(cfg, w, level)
+}
+
+// NewDynamicLogger creates a logger like NewLoggerWithWriter whose level can
+// be changed at runtime through the returned LevelVar (see SetLogLevel).
+func NewDynamicLogger(cfg config.LoggingConfig, w io.Writer) (*slog.Logger, *slog.LevelVar, error) {
+	levelVar := &slog.LevelVar{}
+	if err := SetLogLevel(levelVar, cfg.Level); err != nil {
+		return nil, nil, err
+	}
+
+	logger, err := newLogger(cfg, w, levelVar)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return logger, levelVar, nil
+}
+
+// SetLogLevel parses level and stores it in levelVar. It is safe to call
+// while the logger is in use.
+func SetLogLevel(levelVar *slog.LevelVar, level string) error {
+	parsed, err := parseLogLevel(level)
+	if err != nil {
+		return err
+	}
+
+	levelVar.Set(parsed)
+	return nil
+}
+
+// newLogger creates a logger writing to w at the given level
+func newLogger(cfg config.LoggingConfig, w io.Writer, level slog.Leveler) (*slog.Logger, error) {
 	// Configure handler options
 	opts := &slog.HandlerOptions{
-		Level:     level,
-		AddSource: cfg.AddSource,
+		Level:       level,
+		AddSource:   cfg.AddSource,
+		ReplaceAttr: redactAttrs(cfg.RedactKeys),
 	}
 
 	// Create handler based on format
@@ -55,10 +130,170 @@ func NewLoggerWithWriter(cfg config.LoggingConfig, w io.Writer) (*slog.Logger, e
 	}
 
 	// Create and return logger
-	logger := slog.New(handler)
+	logger := slog.New(&requestIDHandler{Handler: handler})
 	return logger, nil
 }
 
+// redactAttrs returns a ReplaceAttr function masking every attribute whose
+// key matches one of keys, ignoring case, including keys of maps, structs,
+// and slices logged as values, e.g. tool params or a pcf.Credential. Structs
+// are matched by their JSON field names. It returns nil when there are no keys.
+func redactAttrs(keys []string) func(groups []string, a slog.Attr) slog.Attr {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	redacted := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		redacted[strings.ToLower(key)] = true
+	}
+
+	return func(_ []string, a slog.Attr) slog.Attr {
+		if redacted[strings.ToLower(a.Key)] {
+			return slog.String(a.Key, RedactedLogValue)
+		}
+		if a.Value.Kind() == slog.KindAny {
+			if masked, ok := redactAny(a.Value.Any(), redacted); ok {
+				return slog.Any(a.Key, masked)
+			}
+		}
+		return a
+	}
+}
+
+// redactAny masks redacted keys in a logged map, struct, or slice. Other
+// structured values are converted to their JSON form first so that their
+// fields can be matched by key. ok is false for values left as they are:
+// scalars, byte slices, and values that log as a single string, such as
+// errors and fmt.Stringers.
+func redactAny(value interface{}, redacted map[string]bool) (masked interface{}, ok bool) {
+	if m, ok := value.(map[string]interface{}); ok {
+		return redactMap(m, redacted), true
+	}
+
+	switch value.(type) {
+	case error, fmt.Stringer, encoding.TextMarshaler:
+		return nil, false
+	}
+
+	t := reflect.TypeOf(value)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil {
+		return nil, false
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map:
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, false
+	}
+	// UseNumber keeps large integers such as IDs exact
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, false
+	}
+	return redactValue(generic, redacted), true
+}
+
+// redactMap returns a copy of m with the values of redacted keys masked at
+// every level; m itself is left untouched as the caller may still use it
+func redactMap(m map[string]interface{}, redacted map[string]bool) map[string]interface{} {
+	masked := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		if redacted[strings.ToLower(key)] {
+			masked[key] = RedactedLogValue
+			continue
+		}
+		masked[key] = redactValue(value, redacted)
+	}
+	return masked
+}
+
+// redactValue masks redacted keys in the maps nested in value
+func redactValue(value interface{}, redacted map[string]bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return redactMap(v, redacted)
+	case []interface{}:
+		masked := make([]interface{}, len(v))
+		for i, item := range v {
+			masked[i] = redactValue(item, redacted)
+		}
+		return masked
+	}
+	return value
+}
+
+// requestIDHandler adds the request ID stored in the context to every record
+// logged with a context, e.g. via slog.InfoContext
+type requestIDHandler struct {
+	slog.Handler
+}
+
+// Handle adds the request_id attribute before passing the record on
+func (h *requestIDHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		record = record.Clone()
+		record.AddAttrs(slog.String(FieldRequestID, requestID))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+// WithAttrs returns a handler that keeps adding request IDs
+func (h *requestIDHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &requestIDHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup returns a handler that keeps adding request IDs
+func (h *requestIDHandler) WithGroup(name string) slog.Handler {
+	return &requestIDHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// WithLevel returns a logger that writes to the same handler as logger but
+// at the given level, e.g. to log one request at debug while the global
+// level stays at info
+func WithLevel(logger *slog.Logger, level string) (*slog.Logger, error) {
+	parsed, err := parseLogLevel(level)
+	if err != nil {
+		return nil, err
+	}
+
+	return slog.New(&levelHandler{Handler: logger.Handler(), level: parsed}), nil
+}
+
+// levelHandler overrides the minimum level of the handler it wraps
+type levelHandler struct {
+	slog.Handler
+	level slog.Level
+}
+
+// Enabled reports whether records at level are logged by this handler
+func (h *levelHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+// WithAttrs returns a handler that keeps the overridden level
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelHandler{Handler: h.Handler.WithAttrs(attrs), level: h.level}
+}
+
+// WithGroup returns a handler that keeps the overridden level
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{Handler: h.Handler.WithGroup(name), level: h.level}
+}
+
 // parseLogLevel converts a string log level to slog.Level
 func parseLogLevel(level string) (slog.Level, error) {
 	switch strings.ToLower(level) {
@@ -97,6 +332,32 @@ func FromContext(ctx context.Context) *slog.Logger {
 	return slog.Default()
 }
 
+// NewRequestID returns a random 128-bit request ID in hex
+func NewRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// WithRequestID stores a request ID in the context
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestIDFromContext retrieves the request ID from the context, or "" if
+// there is none
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
+
 // SetGlobalLogger sets the global default logger.
 // This affects all code that uses slog.Default().
 func SetGlobalLogger(logger *slog.Logger) {
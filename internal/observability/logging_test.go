@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -49,6 +52,43 @@ func TestNewLogger(t *testing.T) {
 	}
 }
 
+// TestOpenLogOutput tests each log destination
+func TestOpenLogOutput(t *testing.T) {
+	for output, expected := range map[string]*os.File{"": os.Stdout, "stdout": os.Stdout, "stderr": os.Stderr} {
+		w, err := OpenLogOutput(output)
+		if err != nil {
+			t.Fatalf("Failed to open log output %q: %v", output, err)
+		}
+		if w != expected {
+			t.Errorf("Expected log output %q to be %s", output, expected.Name())
+		}
+	}
+
+	// A file path is appended to, keeping earlier logs
+	path := filepath.Join(t.TempDir(), "pcf-mcp.log")
+	if err := os.WriteFile(path, []byte("earlier\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write log file: %v", err)
+	}
+
+	logger, err := NewLogger(config.LoggingConfig{Level: "info", Format: "json", Output: path})
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.Info("to file")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "earlier\n") || !strings.Contains(string(data), `"msg":"to file"`) {
+		t.Errorf("Expected the log to be appended to the file, got %q", data)
+	}
+
+	if _, err := OpenLogOutput(filepath.Join(t.TempDir(), "missing", "pcf-mcp.log")); err == nil {
+		t.Error("Expected an error for a log file in a missing directory")
+	}
+}
+
 // TestLoggerLevels tests that the logger respects configured log levels
 func TestLoggerLevels(t *testing.T) {
 	tests := []struct {
@@ -402,3 +442,205 @@ func TestInvalidLogFormat(t *testing.T) {
 		t.Error("Expected error for invalid log format, got nil")
 	}
 }
+
+// TestDynamicLogger tests changing a logger's level at runtime
+func TestDynamicLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger, levelVar, err := NewDynamicLogger(config.LoggingConfig{Level: "info", Format: "json"}, &buf)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+
+	logger.Debug("hidden")
+	if buf.Len() != 0 {
+		t.Errorf("Expected debug message to be filtered at info level, got %q", buf.String())
+	}
+
+	if err := SetLogLevel(levelVar, "debug"); err != nil {
+		t.Fatalf("Failed to set log level: %v", err)
+	}
+
+	logger.Debug("visible")
+	if !strings.Contains(buf.String(), "visible") {
+		t.Errorf("Expected debug message after level change, got %q", buf.String())
+	}
+
+	// An invalid level leaves the current level in place
+	if err := SetLogLevel(levelVar, "verbose"); err == nil {
+		t.Error("Expected error for invalid log level, got nil")
+	}
+	if levelVar.Level() != slog.LevelDebug {
+		t.Errorf("Expected level to remain debug, got %s", levelVar.Level())
+	}
+}
+
+// TestWithLevel tests overriding a logger's level without affecting the original
+func TestWithLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := NewLoggerWithWriter(config.LoggingConfig{Level: "info", Format: "json"}, &buf)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+
+	verbose, err := WithLevel(logger, "debug")
+	if err != nil {
+		t.Fatalf("Failed to override level: %v", err)
+	}
+
+	verbose.Debug("visible")
+	logger.Debug("hidden")
+
+	if !strings.Contains(buf.String(), "visible") {
+		t.Errorf("Expected debug message from overridden logger, got %q", buf.String())
+	}
+	if strings.Contains(buf.String(), "hidden") {
+		t.Errorf("Expected original logger to stay at info, got %q", buf.String())
+	}
+
+	if _, err := WithLevel(logger, "verbose"); err == nil {
+		t.Error("Expected error for invalid log level, got nil")
+	}
+}
+
+// TestRequestIDLogging tests that request IDs on the context are added to log records
+func TestRequestIDLogging(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := NewLoggerWithWriter(config.LoggingConfig{Level: "info", Format: "json"}, &buf)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+
+	ctx := WithRequestID(context.Background(), "req-123")
+	if got := RequestIDFromContext(ctx); got != "req-123" {
+		t.Fatalf("Expected request ID 'req-123', got '%s'", got)
+	}
+
+	logger.InfoContext(ctx, "with context")
+	logger.With(FieldComponent, "test").InfoContext(ctx, "derived logger")
+	logger.Info("without context")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 log lines, got %d", len(lines))
+	}
+
+	for i, want := range []string{"req-123", "req-123", ""} {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(lines[i]), &entry); err != nil {
+			t.Fatalf("Failed to parse log line: %v", err)
+		}
+		got, _ := entry[FieldRequestID].(string)
+		if got != want {
+			t.Errorf("Line %d: expected request_id '%s', got '%s'", i, want, got)
+		}
+	}
+
+	if id := NewRequestID(); len(id) != 32 || id == NewRequestID() {
+		t.Errorf("Expected unique 32-character request IDs, got '%s'", id)
+	}
+}
+
+// logCredential mirrors pcf.Credential, which this package cannot import
+type logCredential struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	Value    string `json:"value"`
+	Password string `json:"password,omitempty"`
+}
+
+// TestLoggerRedactStructs tests that fields of logged structs, pointers to
+// structs, and slices of structs are masked by their JSON names, for both
+// formats, while errors still log their message
+func TestLoggerRedactStructs(t *testing.T) {
+	const secret = "s3cr3t-token"
+	cred := logCredential{ID: "cred-1", Username: "admin", Value: secret, Password: secret}
+
+	for _, format := range []string{"json", "text"} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger, err := NewLoggerWithWriter(config.LoggingConfig{
+				Level:      "info",
+				Format:     format,
+				RedactKeys: []string{"value", "password"},
+			}, &buf)
+			if err != nil {
+				t.Fatalf("Failed to create logger: %v", err)
+			}
+
+			logger.Info("credential added",
+				"cred", cred,
+				"cred_ptr", &cred,
+				"creds", []logCredential{cred},
+				"error", errors.New("lookup failed"),
+			)
+
+			out := buf.String()
+			if strings.Contains(out, secret) {
+				t.Fatalf("Sensitive value leaked into the %s logs:\n%s", format, out)
+			}
+			for _, kept := range []string{"cred-1", "admin", "lookup failed", RedactedLogValue} {
+				if !strings.Contains(out, kept) {
+					t.Errorf("Expected %q in the %s logs:\n%s", kept, format, out)
+				}
+			}
+		})
+	}
+}
+
+// TestLoggerRedactKeys tests that attributes with redacted keys are masked,
+// ignoring case, in groups, derived loggers, and logged maps, for both formats
+func TestLoggerRedactKeys(t *testing.T) {
+	const secret = "s3cr3t-token"
+	params := map[string]interface{}{
+		"project_id": "proj-1",
+		"API_KEY":    secret,
+		"auth":       map[string]interface{}{"token": secret},
+		"hosts":      []interface{}{map[string]interface{}{"ip": "10.0.0.1", "password": secret}},
+	}
+
+	for _, format := range []string{"json", "text"} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger, err := NewLoggerWithWriter(config.LoggingConfig{
+				Level:      "info",
+				Format:     format,
+				RedactKeys: []string{"value", "token", "password", "api_key"},
+			}, &buf)
+			if err != nil {
+				t.Fatalf("Failed to create logger: %v", err)
+			}
+
+			logger.With("Token", secret).Info("tool called",
+				FieldTool, "add_credential",
+				slog.Group("credential", slog.String("username", "admin"), slog.String("value", secret)),
+				"params", params,
+			)
+
+			out := buf.String()
+			if strings.Contains(out, secret) {
+				t.Fatalf("Sensitive value leaked into the %s logs:\n%s", format, out)
+			}
+			for _, kept := range []string{"add_credential", "admin", "proj-1", "10.0.0.1", RedactedLogValue} {
+				if !strings.Contains(out, kept) {
+					t.Errorf("Expected %q in the %s logs:\n%s", kept, format, out)
+				}
+			}
+		})
+	}
+
+	// The logged map is copied, not masked in place
+	if params["API_KEY"] != secret || params["auth"].(map[string]interface{})["token"] != secret {
+		t.Error("Expected the logged params to be left unchanged")
+	}
+
+	// Without redact keys nothing is masked
+	var buf bytes.Buffer
+	logger, err := NewLoggerWithWriter(config.LoggingConfig{Level: "info", Format: "json"}, &buf)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.Info("tool called", "token", secret)
+	if !strings.Contains(buf.String(), secret) {
+		t.Errorf("Expected no masking without redact keys, got:\n%s", buf.String())
+	}
+}
@@ -32,6 +32,25 @@ type Metrics struct {
 	// ToolDuration tracks tool execution duration
 	ToolDuration *prometheus.HistogramVec
 
+	// PCFRequestsTotal counts outbound PCF API requests
+	PCFRequestsTotal *prometheus.CounterVec
+
+	// PCFRequestDuration tracks outbound PCF API request duration
+	PCFRequestDuration *prometheus.HistogramVec
+
+	// PCFRetriesTotal counts retried PCF API requests
+	PCFRetriesTotal *prometheus.CounterVec
+
+	// PCFRetryExhaustedTotal counts PCF API requests that failed on every attempt
+	PCFRetryExhaustedTotal prometheus.Counter
+
+	// PCFCircuitState reports the PCF circuit breaker state
+	PCFCircuitState prometheus.Gauge
+
+	// toolLatency keeps recent tool latencies for the /stats endpoint; nil
+	// unless the stats endpoint is enabled
+	toolLatency *toolLatency
+
 	// registry is the Prometheus registry
 	registry *prometheus.Registry
 
@@ -50,11 +69,25 @@ func InitMetrics(cfg config.MetricsConfig) (*Metrics, error) {
 		registry: registry,
 	}
 
+	if cfg.EnableStatsEndpoint {
+		m.toolLatency = newToolLatency()
+	}
+
 	if !cfg.Enabled {
 		// Return no-op implementation
 		return m, nil
 	}
 
+	requestBuckets, err := histogramBuckets(cfg.RequestDurationBuckets)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request duration buckets: %w", err)
+	}
+
+	toolBuckets, err := histogramBuckets(cfg.ToolDurationBuckets)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tool duration buckets: %w", err)
+	}
+
 	// HTTP request metrics
 	m.RequestsTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -68,7 +101,7 @@ func InitMetrics(cfg config.MetricsConfig) (*Metrics, error) {
 		prometheus.HistogramOpts{
 			Name:    "pcf_mcp_request_duration_seconds",
 			Help:    "HTTP request duration in seconds",
-			Buckets: prometheus.DefBuckets,
+			Buckets: requestBuckets,
 		},
 		[]string{"method", "path", "status"},
 	)
@@ -102,11 +135,51 @@ func InitMetrics(cfg config.MetricsConfig) (*Metrics, error) {
 		prometheus.HistogramOpts{
 			Name:    "pcf_mcp_tool_duration_seconds",
 			Help:    "Tool execution duration in seconds",
-			Buckets: prometheus.DefBuckets,
+			Buckets: toolBuckets,
 		},
 		[]string{"tool"},
 	)
 
+	// PCF client metrics
+	m.PCFRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "pcf_mcp_pcf_requests_total",
+			Help: "Total number of requests made to the PCF API",
+		},
+		[]string{"method", "path", "status"},
+	)
+
+	m.PCFRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "pcf_mcp_pcf_request_duration_seconds",
+			Help:    "PCF API request duration in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"method", "path"},
+	)
+
+	m.PCFRetriesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "pcf_mcp_pcf_retries_total",
+			Help: "Total number of retried PCF API requests",
+		},
+		[]string{"path"},
+	)
+
+	m.PCFRetryExhaustedTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "pcf_mcp_pcf_retry_exhausted_total",
+			Help: "Total number of PCF API requests that failed after all retries",
+		},
+	)
+
+	m.PCFCircuitState = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "pcf_mcp_pcf_circuit_state",
+			Help: "PCF circuit breaker state (0 closed, 1 open, 2 half-open)",
+		},
+	)
+
 	// Register all metrics
 	registry.MustRegister(
 		m.RequestsTotal,
@@ -115,6 +188,11 @@ func InitMetrics(cfg config.MetricsConfig) (*Metrics, error) {
 		m.ToolExecutions,
 		m.ToolErrors,
 		m.ToolDuration,
+		m.PCFRequestsTotal,
+		m.PCFRequestDuration,
+		m.PCFRetriesTotal,
+		m.PCFRetryExhaustedTotal,
+		m.PCFCircuitState,
 		// Also register standard Go metrics
 		collectors.NewGoCollector(),
 		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
@@ -123,6 +201,22 @@ func InitMetrics(cfg config.MetricsConfig) (*Metrics, error) {
 	return m, nil
 }
 
+// histogramBuckets returns custom, or the Prometheus default buckets when
+// custom is empty. Buckets must be strictly increasing.
+func histogramBuckets(custom []float64) ([]float64, error) {
+	if len(custom) == 0 {
+		return prometheus.DefBuckets, nil
+	}
+
+	for i := 1; i < len(custom); i++ {
+		if custom[i] <= custom[i-1] {
+			return nil, fmt.Errorf("buckets must be strictly increasing: %v", custom)
+		}
+	}
+
+	return custom, nil
+}
+
 // RecordRequest records an HTTP request metric
 func (m *Metrics) RecordRequest(method, path string, status int, duration time.Duration) {
 	if !m.enabled || m.RequestsTotal == nil {
@@ -137,6 +231,10 @@ func (m *Metrics) RecordRequest(method, path string, status int, duration time.D
 
 // RecordToolExecution records a tool execution metric
 func (m *Metrics) RecordToolExecution(toolName string, success bool, duration time.Duration) {
+	if m.toolLatency != nil {
+		m.toolLatency.observe(toolName, duration)
+	}
+
 	if !m.enabled || m.ToolExecutions == nil {
 		return
 	}
@@ -151,6 +249,59 @@ func (m *Metrics) RecordToolExecution(toolName string, success bool, duration ti
 	m.ToolDuration.WithLabelValues(toolName).Observe(duration.Seconds())
 }
 
+// ToolLatencyStats returns a summary of recent execution latencies per tool.
+// It returns nil unless the stats endpoint is enabled.
+func (m *Metrics) ToolLatencyStats() map[string]LatencySummary {
+	if m.toolLatency == nil {
+		return nil
+	}
+
+	return m.toolLatency.summaries()
+}
+
+// RecordPCFRequest records an outbound PCF API request. A status of 0
+// means no response was received and is recorded as "error".
+func (m *Metrics) RecordPCFRequest(method, path string, status int, duration time.Duration) {
+	if !m.enabled || m.PCFRequestsTotal == nil {
+		return
+	}
+
+	statusStr := "error"
+	if status > 0 {
+		statusStr = fmt.Sprintf("%d", status)
+	}
+
+	m.PCFRequestsTotal.WithLabelValues(method, path, statusStr).Inc()
+	m.PCFRequestDuration.WithLabelValues(method, path).Observe(duration.Seconds())
+}
+
+// RecordPCFRetry records a retry of a PCF API request
+func (m *Metrics) RecordPCFRetry(path string) {
+	if !m.enabled || m.PCFRetriesTotal == nil {
+		return
+	}
+
+	m.PCFRetriesTotal.WithLabelValues(path).Inc()
+}
+
+// RecordPCFRetriesExhausted records a PCF API request that failed on every attempt
+func (m *Metrics) RecordPCFRetriesExhausted() {
+	if !m.enabled || m.PCFRetryExhaustedTotal == nil {
+		return
+	}
+
+	m.PCFRetryExhaustedTotal.Inc()
+}
+
+// SetPCFCircuitState records the PCF circuit breaker state
+func (m *Metrics) SetPCFCircuitState(state int) {
+	if !m.enabled || m.PCFCircuitState == nil {
+		return
+	}
+
+	m.PCFCircuitState.Set(float64(state))
+}
+
 // ConnectionOpened increments the active connections gauge
 func (m *Metrics) ConnectionOpened() {
 	if !m.enabled || m.ActiveConnections == nil {
@@ -176,6 +327,12 @@ func (m *Metrics) Handler() http.Handler {
 	})
 }
 
+// Gatherer returns the registry holding the application metrics, e.g. to
+// serve them alongside other registries
+func (m *Metrics) Gatherer() prometheus.Gatherer {
+	return m.registry
+}
+
 // StartServer starts the metrics HTTP server
 func (m *Metrics) StartServer(cfg config.MetricsConfig) error {
 	if !cfg.Enabled {
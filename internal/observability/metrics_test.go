@@ -330,3 +330,58 @@ func TestHTTPMiddleware(t *testing.T) {
 		t.Error("Metrics output missing /test path label")
 	}
 }
+
+// TestCustomHistogramBuckets tests that configured buckets appear in the
+// scraped histograms
+func TestCustomHistogramBuckets(t *testing.T) {
+	metrics, err := InitMetrics(config.MetricsConfig{
+		Enabled:                true,
+		RequestDurationBuckets: []float64{0.05, 1},
+		ToolDurationBuckets:    []float64{0.001, 0.5, 30},
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize metrics: %v", err)
+	}
+
+	metrics.RecordRequest("GET", "/tools", 200, 10*time.Millisecond)
+	metrics.RecordToolExecution("generate_report", true, 2*time.Second)
+
+	w := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	body := w.Body.String()
+
+	expected := []string{
+		`pcf_mcp_tool_duration_seconds_bucket{tool="generate_report",le="0.001"} 0`,
+		`pcf_mcp_tool_duration_seconds_bucket{tool="generate_report",le="0.5"} 0`,
+		`pcf_mcp_tool_duration_seconds_bucket{tool="generate_report",le="30"} 1`,
+		`pcf_mcp_request_duration_seconds_bucket{method="GET",path="/tools",status="200",le="0.05"} 1`,
+		`pcf_mcp_request_duration_seconds_bucket{method="GET",path="/tools",status="200",le="1"} 1`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(body, line) {
+			t.Errorf("Expected scrape to contain %s", line)
+		}
+	}
+
+	// Default buckets are replaced, not extended
+	if strings.Contains(body, `pcf_mcp_tool_duration_seconds_bucket{tool="generate_report",le="0.005"}`) {
+		t.Error("Custom tool buckets should replace the default buckets")
+	}
+
+	// Unset buckets keep the defaults
+	defaults, err := InitMetrics(config.MetricsConfig{Enabled: true})
+	if err != nil {
+		t.Fatalf("Failed to initialize metrics: %v", err)
+	}
+	defaults.RecordToolExecution("generate_report", true, 2*time.Second)
+
+	w = httptest.NewRecorder()
+	defaults.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	if !strings.Contains(w.Body.String(), `pcf_mcp_tool_duration_seconds_bucket{tool="generate_report",le="0.005"} 0`) {
+		t.Error("Expected default buckets when none are configured")
+	}
+
+	if _, err := InitMetrics(config.MetricsConfig{Enabled: true, ToolDurationBuckets: []float64{1, 0.5}}); err == nil {
+		t.Error("Expected error for decreasing buckets")
+	}
+}
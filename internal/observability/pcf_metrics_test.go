@@ -0,0 +1,138 @@
+package observability_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+	"github.com/aRustyDev/pcf-mcp/internal/observability"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// TestPCFRequestMetrics tests that PCF client requests are exported as metrics
+func TestPCFRequestMetrics(t *testing.T) {
+	metrics, err := observability.InitMetrics(config.MetricsConfig{
+		Enabled: true,
+		Port:    9090,
+		Path:    "/metrics",
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize metrics: %v", err)
+	}
+
+	pcfServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/issues") {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(pcf.ErrorResponse{Error: "project not found"})
+			return
+		}
+		json.NewEncoder(w).Encode([]pcf.Host{{ID: "host-1", IP: "10.0.0.1"}})
+	}))
+	defer pcfServer.Close()
+
+	client, err := pcf.NewClient(config.PCFConfig{URL: pcfServer.URL, Timeout: 5 * time.Second}, pcf.WithMetrics(metrics))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := client.ListHosts(ctx, "proj-1"); err != nil {
+		t.Fatalf("ListHosts failed: %v", err)
+	}
+	if _, err := client.ListHosts(ctx, "proj-2"); err != nil {
+		t.Fatalf("ListHosts failed: %v", err)
+	}
+	if _, err := client.ListIssues(ctx, "proj-1"); err == nil {
+		t.Fatal("Expected ListIssues to fail")
+	}
+
+	server := httptest.NewServer(metrics.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to fetch metrics: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read metrics: %v", err)
+	}
+
+	metricsOutput := string(body)
+
+	// Project IDs are templated so both host listings share one series
+	expected := []string{
+		`pcf_mcp_pcf_requests_total{method="GET",path="/api/projects/:id/hosts",status="200"} 2`,
+		`pcf_mcp_pcf_requests_total{method="GET",path="/api/projects/:id/issues",status="404"} 1`,
+		`pcf_mcp_pcf_request_duration_seconds_count{method="GET",path="/api/projects/:id/hosts"} 2`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(metricsOutput, line) {
+			t.Errorf("Metrics output missing %q", line)
+		}
+	}
+
+	if strings.Contains(metricsOutput, "proj-1") {
+		t.Error("Metric labels should not contain project IDs")
+	}
+}
+
+// TestPCFRetryMetrics tests that PCF client retries are exported as metrics
+func TestPCFRetryMetrics(t *testing.T) {
+	metrics, err := observability.InitMetrics(config.MetricsConfig{
+		Enabled: true,
+		Port:    9090,
+		Path:    "/metrics",
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize metrics: %v", err)
+	}
+
+	pcfServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer pcfServer.Close()
+
+	client, err := pcf.NewClient(config.PCFConfig{URL: pcfServer.URL, Timeout: 5 * time.Second, MaxRetries: 3}, pcf.WithMetrics(metrics))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if _, err := client.ListHosts(context.Background(), "proj-1"); err == nil {
+		t.Fatal("Expected ListHosts to fail")
+	}
+
+	server := httptest.NewServer(metrics.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to fetch metrics: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read metrics: %v", err)
+	}
+
+	expected := []string{
+		`pcf_mcp_pcf_retries_total{path="/api/projects/:id/hosts"} 2`,
+		`pcf_mcp_pcf_retry_exhausted_total 1`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(string(body), line) {
+			t.Errorf("Metrics output missing %q", line)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package observability_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+	"github.com/aRustyDev/pcf-mcp/internal/mcp"
+	"github.com/aRustyDev/pcf-mcp/internal/observability"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// TestToolExecutionSpans tests that a tool call emits a tool span with a nested PCF request span
+func TestToolExecutionSpans(t *testing.T) {
+	mockExporter := &observability.MockExporter{}
+
+	shutdown, err := observability.InitTracingWithExporter(config.TracingConfig{
+		Enabled:      true,
+		SamplingRate: 1.0,
+		ServiceName:  "test-tool-spans",
+	}, mockExporter)
+	if err != nil {
+		t.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	defer shutdown(context.Background())
+
+	pcfServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]pcf.Host{{ID: "host-1", IP: "10.0.0.1"}})
+	}))
+	defer pcfServer.Close()
+
+	client, err := pcf.NewClient(config.PCFConfig{URL: pcfServer.URL, Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	server, err := mcp.NewServer(config.ServerConfig{Transport: "stdio"})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	err = server.RegisterTool(mcp.Tool{
+		Name: "list_hosts",
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return client.ListHosts(ctx, params["project_id"].(string))
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register tool: %v", err)
+	}
+
+	if _, err := server.ExecuteTool(context.Background(), "list_hosts", map[string]interface{}{"project_id": "proj-1"}); err != nil {
+		t.Fatalf("Tool execution failed: %v", err)
+	}
+
+	if tp, ok := otel.GetTracerProvider().(interface{ ForceFlush(context.Context) error }); ok {
+		tp.ForceFlush(context.Background())
+	}
+
+	spans := mockExporter.Spans()
+
+	var toolSpan, pcfSpan sdktrace.ReadOnlySpan
+	for _, span := range spans {
+		switch span.Name() {
+		case "tool.list_hosts":
+			toolSpan = span
+		case "pcf GET":
+			pcfSpan = span
+		}
+	}
+
+	if toolSpan == nil || pcfSpan == nil {
+		t.Fatalf("Expected tool and PCF spans, got %d spans", len(spans))
+	}
+
+	if pcfSpan.Parent().SpanID() != toolSpan.SpanContext().SpanID() {
+		t.Error("PCF request span should be a child of the tool span")
+	}
+
+	attrs := make(map[string]string)
+	for _, kv := range toolSpan.Attributes() {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	if attrs[observability.AttributeToolName] != "list_hosts" {
+		t.Errorf("Expected tool name attribute, got %v", attrs)
+	}
+	if attrs[observability.AttributeProjectID] != "proj-1" {
+		t.Errorf("Expected project ID attribute, got %v", attrs)
+	}
+
+	attrs = make(map[string]string)
+	for _, kv := range pcfSpan.Attributes() {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	if attrs[observability.AttributeHTTPStatus] != "200" {
+		t.Errorf("Expected HTTP status attribute 200, got %v", attrs)
+	}
+	if attrs[observability.AttributeHTTPPath] != "/api/projects/proj-1/hosts" {
+		t.Errorf("Expected HTTP path attribute, got %v", attrs)
+	}
+}
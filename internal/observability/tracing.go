@@ -3,14 +3,19 @@ package observability
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"net"
 	"net/url"
+	"os"
 
 	"github.com/aRustyDev/pcf-mcp/internal/config"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/exporters/zipkin"
 	"go.opentelemetry.io/otel/propagation"
@@ -18,6 +23,13 @@ import (
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/credentials"
+)
+
+// OTLP transport protocols
+const (
+	otlpProtocolHTTP = "http"
+	otlpProtocolGRPC = "grpc"
 )
 
 // InitTracing initializes OpenTelemetry tracing with the configured exporter
@@ -38,10 +50,10 @@ func InitTracing(cfg config.TracingConfig) (func(context.Context) error, error)
 
 	switch cfg.Exporter {
 	case "otlp":
-		exporter, err = createOTLPExporter(cfg.Endpoint)
+		exporter, err = createOTLPExporter(cfg)
 	case "jaeger":
 		// Jaeger now uses OTLP, redirect to OTLP exporter
-		exporter, err = createOTLPExporter(cfg.Endpoint)
+		exporter, err = createOTLPExporter(cfg)
 	case "zipkin":
 		exporter, err = createZipkinExporter(cfg.Endpoint)
 	default:
@@ -84,7 +96,7 @@ func initTracingWithExporter(cfg config.TracingConfig, exporter sdktrace.SpanExp
 
 	// Create tracer provider with sampling
 	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exporter),
+		sdktrace.WithBatcher(exporter, batchOptions(cfg)...),
 		sdktrace.WithResource(res),
 		sdktrace.WithSampler(sdktrace.TraceIDRatioBased(cfg.SamplingRate)),
 	)
@@ -102,27 +114,154 @@ func initTracingWithExporter(cfg config.TracingConfig, exporter sdktrace.SpanExp
 	return tp.Shutdown, nil
 }
 
-// createOTLPExporter creates an OTLP exporter
-func createOTLPExporter(endpoint string) (sdktrace.SpanExporter, error) {
-	// Parse endpoint to extract host:port
-	// otlptracehttp expects just host:port, not full URL
-	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
-		endpoint = u.Host
+// batchOptions returns the span batcher options for cfg. Unset values keep
+// the SDK defaults: a queue of 2048 spans exported in batches of up to 512
+// at least every 5 seconds.
+func batchOptions(cfg config.TracingConfig) []sdktrace.BatchSpanProcessorOption {
+	var opts []sdktrace.BatchSpanProcessorOption
+	if cfg.MaxQueueSize > 0 {
+		opts = append(opts, sdktrace.WithMaxQueueSize(cfg.MaxQueueSize))
 	}
+	if cfg.BatchTimeout > 0 {
+		opts = append(opts, sdktrace.WithBatchTimeout(cfg.BatchTimeout))
+	}
+	if cfg.MaxExportBatchSize > 0 {
+		opts = append(opts, sdktrace.WithMaxExportBatchSize(cfg.MaxExportBatchSize))
+	}
+	return opts
+}
 
-	client := otlptracehttp.NewClient(
-		otlptracehttp.WithEndpoint(endpoint),
-		otlptracehttp.WithInsecure(), // TODO: Configure TLS properly for production
-	)
+// createOTLPExporter creates an OTLP exporter using the configured protocol
+// (http or grpc). An empty protocol selects http.
+func createOTLPExporter(cfg config.TracingConfig) (sdktrace.SpanExporter, error) {
+	var client otlptrace.Client
+	switch cfg.Protocol {
+	case "", otlpProtocolHTTP:
+		opts, err := otlpHTTPOptions(cfg)
+		if err != nil {
+			return nil, err
+		}
+		client = otlptracehttp.NewClient(opts...)
+	case otlpProtocolGRPC:
+		opts, err := otlpGRPCOptions(cfg)
+		if err != nil {
+			return nil, err
+		}
+		client = otlptracegrpc.NewClient(opts...)
+	default:
+		return nil, fmt.Errorf("unsupported OTLP protocol: %s", cfg.Protocol)
+	}
 
 	return otlptrace.New(context.Background(), client)
 }
 
+// otlpEndpoint returns the host:port of the collector endpoint. Both OTLP
+// clients expect just host:port, not a full URL.
+func otlpEndpoint(endpoint string) string {
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return endpoint
+}
+
+// TracingExporterAddress returns the host:port traces are exported to, for
+// checking that the collector is reachable. A URL endpoint without a port
+// gets its scheme's default port.
+func TracingExporterAddress(cfg config.TracingConfig) string {
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil || u.Host == "" {
+		return cfg.Endpoint
+	}
+
+	if u.Port() != "" {
+		return u.Host
+	}
+	if u.Scheme == "https" {
+		return net.JoinHostPort(u.Hostname(), "443")
+	}
+	return net.JoinHostPort(u.Hostname(), "80")
+}
+
+// otlpHTTPOptions builds the otlptracehttp client options for cfg
+func otlpHTTPOptions(cfg config.TracingConfig) ([]otlptracehttp.Option, error) {
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(otlpEndpoint(cfg.Endpoint))}
+
+	tlsConfig, err := otlpTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig == nil {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	} else {
+		opts = append(opts, otlptracehttp.WithTLSClientConfig(tlsConfig))
+	}
+
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(cfg.Headers))
+	}
+
+	return opts, nil
+}
+
+// otlpGRPCOptions builds the otlptracegrpc client options for cfg
+func otlpGRPCOptions(cfg config.TracingConfig) ([]otlptracegrpc.Option, error) {
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(otlpEndpoint(cfg.Endpoint))}
+
+	tlsConfig, err := otlpTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig == nil {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	} else {
+		opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
+	}
+
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlptracegrpc.WithHeaders(cfg.Headers))
+	}
+
+	return opts, nil
+}
+
+// otlpTLSConfig returns the TLS configuration used to verify the collector,
+// or nil if cfg.Insecure is set. The CA comes from cfg.CAFile when given and
+// from the system roots otherwise.
+func otlpTLSConfig(cfg config.TracingConfig) (*tls.Config, error) {
+	if cfg.Insecure {
+		return nil, nil
+	}
+
+	var pool *x509.CertPool
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tracing CA file: %w", err)
+		}
+
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in tracing CA file: %s", cfg.CAFile)
+		}
+	} else {
+		var err error
+		pool, err = x509.SystemCertPool()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load system CA certificates: %w", err)
+		}
+	}
+
+	return &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}, nil
+}
+
 // createJaegerExporter creates a Jaeger exporter (deprecated - use OTLP instead)
 // Jaeger now recommends using OTLP exporters
 func createJaegerExporter(endpoint string) (sdktrace.SpanExporter, error) {
 	// Redirect to OTLP exporter as Jaeger now supports OTLP natively
-	return createOTLPExporter(endpoint)
+	return createOTLPExporter(config.TracingConfig{Endpoint: endpoint, Protocol: otlpProtocolHTTP})
 }
 
 // createZipkinExporter creates a Zipkin exporter
@@ -218,6 +357,9 @@ const (
 	// AttributeHTTPStatus is the trace attribute for HTTP status code
 	AttributeHTTPStatus = "http.status"
 
+	// AttributeAttempt is the trace attribute for the 1-based attempt number of a retried request
+	AttributeAttempt = "attempt"
+
 	// AttributeErrorType is the trace attribute for error type
 	AttributeErrorType = "error.type"
 )
@@ -2,8 +2,18 @@ package observability
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/aRustyDev/pcf-mcp/internal/config"
 	"go.opentelemetry.io/otel"
@@ -35,6 +45,41 @@ func TestInitTracing(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			name: "OTLP exporter over HTTP",
+			config: config.TracingConfig{
+				Enabled:      true,
+				Exporter:     "otlp",
+				Endpoint:     "http://localhost:4318",
+				Protocol:     "http",
+				SamplingRate: 1.0,
+				ServiceName:  "test-service",
+			},
+			expectErr: false,
+		},
+		{
+			name: "OTLP exporter over gRPC",
+			config: config.TracingConfig{
+				Enabled:      true,
+				Exporter:     "otlp",
+				Endpoint:     "localhost:4317",
+				Protocol:     "grpc",
+				SamplingRate: 1.0,
+				ServiceName:  "test-service",
+			},
+			expectErr: false,
+		},
+		{
+			name: "Invalid OTLP protocol",
+			config: config.TracingConfig{
+				Enabled:      true,
+				Exporter:     "otlp",
+				Endpoint:     "localhost:4317",
+				Protocol:     "udp",
+				SamplingRate: 1.0,
+			},
+			expectErr: true,
+		},
 		{
 			name: "Jaeger exporter",
 			config: config.TracingConfig{
@@ -285,6 +330,11 @@ func (m *MockExporter) Shutdown(ctx context.Context) error {
 	return nil
 }
 
+// Spans returns the exported spans
+func (m *MockExporter) Spans() []sdktrace.ReadOnlySpan {
+	return m.spans
+}
+
 // TestCustomExporter tests using a custom exporter
 func TestCustomExporter(t *testing.T) {
 	mockExporter := &MockExporter{}
@@ -318,3 +368,182 @@ func TestCustomExporter(t *testing.T) {
 		t.Error("No spans were exported")
 	}
 }
+
+// writeTestCA writes a self-signed CA certificate and returns its PEM path
+func writeTestCA(t *testing.T) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "pcf-mcp-test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatalf("Failed to write CA: %v", err)
+	}
+
+	return caFile
+}
+
+// TestOTLPTLSConfig tests TLS option construction for the OTLP exporter
+func TestOTLPTLSConfig(t *testing.T) {
+	caFile := writeTestCA(t)
+
+	notPEM := filepath.Join(t.TempDir(), "not-a-ca.pem")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		config    config.TracingConfig
+		expectTLS bool
+		expectErr bool
+		httpOpts  int
+		grpcOpts  int
+	}{
+		{
+			name:      "Insecure",
+			config:    config.TracingConfig{Endpoint: "http://localhost:4318", Insecure: true},
+			expectTLS: false,
+			httpOpts:  2,
+			grpcOpts:  2,
+		},
+		{
+			name:      "Secure with system roots",
+			config:    config.TracingConfig{Endpoint: "https://collector:4318"},
+			expectTLS: true,
+			httpOpts:  2,
+			grpcOpts:  2,
+		},
+		{
+			name: "Secure with CA file and headers",
+			config: config.TracingConfig{
+				Endpoint: "https://api.honeycomb.io:443",
+				CAFile:   caFile,
+				Headers:  map[string]string{"x-honeycomb-team": "secret"},
+			},
+			expectTLS: true,
+			httpOpts:  3,
+			grpcOpts:  3,
+		},
+		{
+			name:      "Missing CA file",
+			config:    config.TracingConfig{CAFile: filepath.Join(t.TempDir(), "missing.pem")},
+			expectErr: true,
+		},
+		{
+			name:      "CA file without certificates",
+			config:    config.TracingConfig{CAFile: notPEM},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tlsConfig, err := otlpTLSConfig(tt.config)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if (tlsConfig != nil) != tt.expectTLS {
+				t.Fatalf("Expected TLS %v, got config %v", tt.expectTLS, tlsConfig)
+			}
+			if tlsConfig != nil && tlsConfig.RootCAs == nil {
+				t.Error("Expected RootCAs to be set")
+			}
+
+			httpOpts, err := otlpHTTPOptions(tt.config)
+			if err != nil {
+				t.Fatalf("Failed to build HTTP options: %v", err)
+			}
+			if len(httpOpts) != tt.httpOpts {
+				t.Errorf("Expected %d HTTP options, got %d", tt.httpOpts, len(httpOpts))
+			}
+
+			grpcOpts, err := otlpGRPCOptions(tt.config)
+			if err != nil {
+				t.Fatalf("Failed to build gRPC options: %v", err)
+			}
+			if len(grpcOpts) != tt.grpcOpts {
+				t.Errorf("Expected %d gRPC options, got %d", tt.grpcOpts, len(grpcOpts))
+			}
+
+			for _, protocol := range []string{otlpProtocolHTTP, otlpProtocolGRPC} {
+				cfg := tt.config
+				cfg.Protocol = protocol
+				exporter, err := createOTLPExporter(cfg)
+				if err != nil {
+					t.Fatalf("Failed to create %s exporter: %v", protocol, err)
+				}
+				_ = exporter.Shutdown(context.Background())
+			}
+		})
+	}
+}
+
+// TestTracingExporterAddress tests the collector address derived from the endpoint
+func TestTracingExporterAddress(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     string
+	}{
+		{"localhost:4317", "localhost:4317"},
+		{"http://collector:4318", "collector:4318"},
+		{"http://zipkin.internal/api/v2/spans", "zipkin.internal:80"},
+		{"https://collector.example.com", "collector.example.com:443"},
+	}
+
+	for _, tt := range tests {
+		if got := TracingExporterAddress(config.TracingConfig{Endpoint: tt.endpoint}); got != tt.want {
+			t.Errorf("TracingExporterAddress(%q) = %q, want %q", tt.endpoint, got, tt.want)
+		}
+	}
+}
+
+// TestBatchOptions tests that configured batcher settings reach the span
+// processor and that unset ones keep the SDK defaults
+func TestBatchOptions(t *testing.T) {
+	apply := func(cfg config.TracingConfig) sdktrace.BatchSpanProcessorOptions {
+		var opts sdktrace.BatchSpanProcessorOptions
+		for _, opt := range batchOptions(cfg) {
+			opt(&opts)
+		}
+		return opts
+	}
+
+	got := apply(config.TracingConfig{MaxQueueSize: 8192, BatchTimeout: 2 * time.Second, MaxExportBatchSize: 1024})
+	if got.MaxQueueSize != 8192 || got.BatchTimeout != 2*time.Second || got.MaxExportBatchSize != 1024 {
+		t.Errorf("Expected queue 8192, timeout 2s, and batch 1024, got %+v", got)
+	}
+
+	if opts := batchOptions(config.TracingConfig{}); len(opts) != 0 {
+		t.Errorf("Expected no options for an unset config, got %d", len(opts))
+	}
+
+	if got := apply(config.TracingConfig{BatchTimeout: time.Second}); got.BatchTimeout != time.Second || got.MaxQueueSize != 0 || got.MaxExportBatchSize != 0 {
+		t.Errorf("Expected only the batch timeout set, got %+v", got)
+	}
+}
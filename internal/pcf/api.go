@@ -0,0 +1,39 @@
+package pcf
+
+import (
+	"context"
+	"io"
+)
+
+// API is the set of PCF operations the MCP tools are built on. Client
+// implements it against the PCF REST API; other backends, test doubles, and
+// wrappers such as CachingClient can stand in for it.
+type API interface {
+	Ping(ctx context.Context) error
+	ListProjects(ctx context.Context) ([]Project, error)
+	ListProjectsPage(ctx context.Context, opts ListOptions) ([]Project, *PageInfo, error)
+	GetProject(ctx context.Context, projectID string) (*Project, error)
+	CreateProject(ctx context.Context, req CreateProjectRequest) (*Project, error)
+	UpdateProject(ctx context.Context, projectID string, req UpdateProjectRequest) (*Project, error)
+	DeleteProject(ctx context.Context, projectID string) error
+	ListHosts(ctx context.Context, projectID string) ([]Host, error)
+	ListHostsPage(ctx context.Context, projectID string, opts ListOptions) ([]Host, *PageInfo, error)
+	GetHost(ctx context.Context, projectID, hostID string) (*Host, error)
+	AddHost(ctx context.Context, projectID string, req CreateHostRequest) (*Host, error)
+	AddHosts(ctx context.Context, projectID string, reqs []CreateHostRequest) ([]Host, error)
+	ListIssues(ctx context.Context, projectID string) ([]Issue, error)
+	ListIssuesPage(ctx context.Context, projectID string, opts ListOptions) ([]Issue, *PageInfo, error)
+	GetIssue(ctx context.Context, projectID, issueID string) (*Issue, error)
+	CreateIssue(ctx context.Context, projectID string, req CreateIssueRequest) (*Issue, error)
+	UpdateIssue(ctx context.Context, projectID, issueID string, req UpdateIssueRequest) (*Issue, error)
+	LinkIssueToHost(ctx context.Context, projectID, issueID, hostID string) (*Issue, error)
+	ListCredentials(ctx context.Context, projectID string) ([]Credential, error)
+	ListCredentialsPage(ctx context.Context, projectID string, opts ListOptions) ([]Credential, *PageInfo, error)
+	GetCredential(ctx context.Context, projectID, credID string) (*Credential, error)
+	AddCredential(ctx context.Context, projectID string, req AddCredentialRequest) (*Credential, error)
+	GenerateReport(ctx context.Context, projectID string, req GenerateReportRequest) (*Report, error)
+	DownloadReport(ctx context.Context, reportURL string, w io.Writer) error
+}
+
+// Ensure Client implements API
+var _ API = (*Client)(nil)
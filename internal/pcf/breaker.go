@@ -0,0 +1,387 @@
+package pcf
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/aRustyDev/pcf-mcp/internal/observability"
+)
+
+// Default circuit breaker settings
+const (
+	// DefaultBreakerFailureThreshold is the number of consecutive failed
+	// calls that opens the circuit
+	DefaultBreakerFailureThreshold = 5
+
+	// DefaultBreakerCooldown is how long an open circuit rejects calls
+	// before letting a probe through
+	DefaultBreakerCooldown = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned without calling PCF while the circuit breaker
+// is open
+var ErrCircuitOpen = errors.New("PCF circuit breaker is open")
+
+// BreakerState is the state of a circuit breaker
+type BreakerState int
+
+// Circuit breaker states
+const (
+	// BreakerClosed passes every call through to PCF
+	BreakerClosed BreakerState = iota
+
+	// BreakerOpen rejects every call with ErrCircuitOpen
+	BreakerOpen
+
+	// BreakerHalfOpen lets a single probe call through; its outcome closes
+	// or reopens the circuit
+	BreakerHalfOpen
+)
+
+// String returns the state name
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// BreakerMetrics records circuit breaker state changes
+type BreakerMetrics interface {
+	// SetPCFCircuitState records the current state: 0 closed, 1 open, or
+	// 2 half-open
+	SetPCFCircuitState(state int)
+}
+
+// BreakerSettings configures a BreakerClient
+type BreakerSettings struct {
+	// FailureThreshold is the number of consecutive failed calls that opens
+	// the circuit; DefaultBreakerFailureThreshold when not positive
+	FailureThreshold int
+
+	// Cooldown is how long the circuit stays open before a probe call is
+	// let through; DefaultBreakerCooldown when not positive
+	Cooldown time.Duration
+
+	// Metrics records state changes, if set
+	Metrics BreakerMetrics
+}
+
+// BreakerClient wraps an API with a circuit breaker. Only failures that mean
+// PCF is unreachable or erroring (ErrUnavailable) count; PCF answering with
+// a client error such as 404 counts as a success, and calls abandoned by
+// their caller are ignored. It is safe for concurrent use.
+type BreakerClient struct {
+	API
+
+	// threshold is the consecutive failure count that opens the circuit
+	threshold int
+
+	// cooldown is how long the circuit stays open
+	cooldown time.Duration
+
+	// metrics records state changes, if set
+	metrics BreakerMetrics
+
+	// mu protects the fields below
+	mu sync.Mutex
+
+	// state is the current breaker state
+	state BreakerState
+
+	// failures counts consecutive failed calls while closed
+	failures int
+
+	// openedAt is when the circuit last opened
+	openedAt time.Time
+
+	// probing is set while the half-open probe call is in flight
+	probing bool
+
+	// now returns the current time; replaced in tests
+	now func() time.Time
+}
+
+// NewBreakerClient wraps inner with a circuit breaker configured by settings
+func NewBreakerClient(inner API, settings BreakerSettings) *BreakerClient {
+	threshold := settings.FailureThreshold
+	if threshold <= 0 {
+		threshold = DefaultBreakerFailureThreshold
+	}
+
+	cooldown := settings.Cooldown
+	if cooldown <= 0 {
+		cooldown = DefaultBreakerCooldown
+	}
+
+	b := &BreakerClient{
+		API:       inner,
+		threshold: threshold,
+		cooldown:  cooldown,
+		metrics:   settings.Metrics,
+		now:       time.Now,
+	}
+
+	if b.metrics != nil {
+		b.metrics.SetPCFCircuitState(int(BreakerClosed))
+	}
+
+	return b
+}
+
+// State returns the current breaker state
+func (b *BreakerClient) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.halfOpenAfterCooldown(context.Background())
+	return b.state
+}
+
+// halfOpenAfterCooldown moves an open circuit whose cooldown has elapsed to
+// half-open. b.mu must be held.
+func (b *BreakerClient) halfOpenAfterCooldown(ctx context.Context) {
+	if b.state == BreakerOpen && b.now().Sub(b.openedAt) >= b.cooldown {
+		b.setState(ctx, BreakerHalfOpen)
+	}
+}
+
+// setState changes the state and reports it. b.mu must be held.
+func (b *BreakerClient) setState(ctx context.Context, state BreakerState) {
+	if state == b.state {
+		return
+	}
+
+	b.state = state
+	b.failures = 0
+	if state == BreakerOpen {
+		b.openedAt = b.now()
+	}
+
+	if b.metrics != nil {
+		b.metrics.SetPCFCircuitState(int(state))
+	}
+	observability.FromContext(ctx).WarnContext(ctx, "PCF circuit breaker state changed", "state", state.String())
+}
+
+// allow reports whether a call may proceed and whether it is the half-open
+// probe
+func (b *BreakerClient) allow(ctx context.Context) (probe bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.halfOpenAfterCooldown(ctx)
+
+	switch b.state {
+	case BreakerOpen:
+		return false, ErrCircuitOpen
+	case BreakerHalfOpen:
+		if b.probing {
+			return false, ErrCircuitOpen
+		}
+		b.probing = true
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// record updates the breaker with the outcome of a call
+func (b *BreakerClient) record(ctx context.Context, probe bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if probe {
+		b.probing = false
+	}
+
+	switch {
+	case errors.Is(err, ErrUnavailable):
+		if probe {
+			b.setState(ctx, BreakerOpen)
+		} else if b.state == BreakerClosed {
+			b.failures++
+			if b.failures >= b.threshold {
+				b.setState(ctx, BreakerOpen)
+			}
+		}
+	case err != nil && ctx.Err() != nil:
+		// The caller gave up, which says nothing about PCF
+	default:
+		if probe {
+			b.setState(ctx, BreakerClosed)
+		} else if b.state == BreakerClosed {
+			b.failures = 0
+		}
+	}
+}
+
+// do runs call unless the circuit is open, recording its outcome
+func (b *BreakerClient) do(ctx context.Context, call func() error) error {
+	probe, err := b.allow(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = call()
+	b.record(ctx, probe, err)
+	return err
+}
+
+// breakerCall runs call through the breaker and returns its result
+func breakerCall[T any](b *BreakerClient, ctx context.Context, call func() (T, error)) (T, error) {
+	var result T
+	err := b.do(ctx, func() error {
+		var err error
+		result, err = call()
+		return err
+	})
+	return result, err
+}
+
+// Ping checks PCF through the breaker
+func (b *BreakerClient) Ping(ctx context.Context) error {
+	return b.do(ctx, func() error { return b.API.Ping(ctx) })
+}
+
+// ListProjects lists projects through the breaker
+func (b *BreakerClient) ListProjects(ctx context.Context) ([]Project, error) {
+	return breakerCall(b, ctx, func() ([]Project, error) { return b.API.ListProjects(ctx) })
+}
+
+// ListProjectsPage lists a page of projects through the breaker
+func (b *BreakerClient) ListProjectsPage(ctx context.Context, opts ListOptions) (projects []Project, info *PageInfo, err error) {
+	err = b.do(ctx, func() error {
+		projects, info, err = b.API.ListProjectsPage(ctx, opts)
+		return err
+	})
+	return projects, info, err
+}
+
+// GetProject gets a project through the breaker
+func (b *BreakerClient) GetProject(ctx context.Context, projectID string) (*Project, error) {
+	return breakerCall(b, ctx, func() (*Project, error) { return b.API.GetProject(ctx, projectID) })
+}
+
+// CreateProject creates a project through the breaker
+func (b *BreakerClient) CreateProject(ctx context.Context, req CreateProjectRequest) (*Project, error) {
+	return breakerCall(b, ctx, func() (*Project, error) { return b.API.CreateProject(ctx, req) })
+}
+
+// UpdateProject updates a project through the breaker
+func (b *BreakerClient) UpdateProject(ctx context.Context, projectID string, req UpdateProjectRequest) (*Project, error) {
+	return breakerCall(b, ctx, func() (*Project, error) { return b.API.UpdateProject(ctx, projectID, req) })
+}
+
+// DeleteProject deletes a project through the breaker
+func (b *BreakerClient) DeleteProject(ctx context.Context, projectID string) error {
+	return b.do(ctx, func() error { return b.API.DeleteProject(ctx, projectID) })
+}
+
+// ListHosts lists a project's hosts through the breaker
+func (b *BreakerClient) ListHosts(ctx context.Context, projectID string) ([]Host, error) {
+	return breakerCall(b, ctx, func() ([]Host, error) { return b.API.ListHosts(ctx, projectID) })
+}
+
+// ListHostsPage lists a page of a project's hosts through the breaker
+func (b *BreakerClient) ListHostsPage(ctx context.Context, projectID string, opts ListOptions) (hosts []Host, info *PageInfo, err error) {
+	err = b.do(ctx, func() error {
+		hosts, info, err = b.API.ListHostsPage(ctx, projectID, opts)
+		return err
+	})
+	return hosts, info, err
+}
+
+// GetHost gets a host through the breaker
+func (b *BreakerClient) GetHost(ctx context.Context, projectID, hostID string) (*Host, error) {
+	return breakerCall(b, ctx, func() (*Host, error) { return b.API.GetHost(ctx, projectID, hostID) })
+}
+
+// AddHost adds a host through the breaker
+func (b *BreakerClient) AddHost(ctx context.Context, projectID string, req CreateHostRequest) (*Host, error) {
+	return breakerCall(b, ctx, func() (*Host, error) { return b.API.AddHost(ctx, projectID, req) })
+}
+
+// AddHosts adds hosts in bulk through the breaker
+func (b *BreakerClient) AddHosts(ctx context.Context, projectID string, reqs []CreateHostRequest) ([]Host, error) {
+	return breakerCall(b, ctx, func() ([]Host, error) { return b.API.AddHosts(ctx, projectID, reqs) })
+}
+
+// ListIssues lists a project's issues through the breaker
+func (b *BreakerClient) ListIssues(ctx context.Context, projectID string) ([]Issue, error) {
+	return breakerCall(b, ctx, func() ([]Issue, error) { return b.API.ListIssues(ctx, projectID) })
+}
+
+// ListIssuesPage lists a page of a project's issues through the breaker
+func (b *BreakerClient) ListIssuesPage(ctx context.Context, projectID string, opts ListOptions) (issues []Issue, info *PageInfo, err error) {
+	err = b.do(ctx, func() error {
+		issues, info, err = b.API.ListIssuesPage(ctx, projectID, opts)
+		return err
+	})
+	return issues, info, err
+}
+
+// GetIssue gets an issue through the breaker
+func (b *BreakerClient) GetIssue(ctx context.Context, projectID, issueID string) (*Issue, error) {
+	return breakerCall(b, ctx, func() (*Issue, error) { return b.API.GetIssue(ctx, projectID, issueID) })
+}
+
+// CreateIssue creates an issue through the breaker
+func (b *BreakerClient) CreateIssue(ctx context.Context, projectID string, req CreateIssueRequest) (*Issue, error) {
+	return breakerCall(b, ctx, func() (*Issue, error) { return b.API.CreateIssue(ctx, projectID, req) })
+}
+
+// UpdateIssue updates an issue through the breaker
+func (b *BreakerClient) UpdateIssue(ctx context.Context, projectID, issueID string, req UpdateIssueRequest) (*Issue, error) {
+	return breakerCall(b, ctx, func() (*Issue, error) { return b.API.UpdateIssue(ctx, projectID, issueID, req) })
+}
+
+// LinkIssueToHost links an issue to a host through the breaker
+func (b *BreakerClient) LinkIssueToHost(ctx context.Context, projectID, issueID, hostID string) (*Issue, error) {
+	return breakerCall(b, ctx, func() (*Issue, error) { return b.API.LinkIssueToHost(ctx, projectID, issueID, hostID) })
+}
+
+// ListCredentials lists a project's credentials through the breaker
+func (b *BreakerClient) ListCredentials(ctx context.Context, projectID string) ([]Credential, error) {
+	return breakerCall(b, ctx, func() ([]Credential, error) { return b.API.ListCredentials(ctx, projectID) })
+}
+
+// ListCredentialsPage lists a page of a project's credentials through the breaker
+func (b *BreakerClient) ListCredentialsPage(ctx context.Context, projectID string, opts ListOptions) (creds []Credential, info *PageInfo, err error) {
+	err = b.do(ctx, func() error {
+		creds, info, err = b.API.ListCredentialsPage(ctx, projectID, opts)
+		return err
+	})
+	return creds, info, err
+}
+
+// GetCredential gets a credential through the breaker
+func (b *BreakerClient) GetCredential(ctx context.Context, projectID, credID string) (*Credential, error) {
+	return breakerCall(b, ctx, func() (*Credential, error) { return b.API.GetCredential(ctx, projectID, credID) })
+}
+
+// AddCredential adds a credential through the breaker
+func (b *BreakerClient) AddCredential(ctx context.Context, projectID string, req AddCredentialRequest) (*Credential, error) {
+	return breakerCall(b, ctx, func() (*Credential, error) { return b.API.AddCredential(ctx, projectID, req) })
+}
+
+// GenerateReport generates a report through the breaker
+func (b *BreakerClient) GenerateReport(ctx context.Context, projectID string, req GenerateReportRequest) (*Report, error) {
+	return breakerCall(b, ctx, func() (*Report, error) { return b.API.GenerateReport(ctx, projectID, req) })
+}
+
+// DownloadReport downloads a report through the breaker
+func (b *BreakerClient) DownloadReport(ctx context.Context, reportURL string, w io.Writer) error {
+	return b.do(ctx, func() error { return b.API.DownloadReport(ctx, reportURL, w) })
+}
+
+// Ensure BreakerClient implements API
+var _ API = (*BreakerClient)(nil)
@@ -0,0 +1,175 @@
+package pcf
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+)
+
+// recordingBreakerMetrics records every reported circuit state
+type recordingBreakerMetrics struct {
+	states []int
+}
+
+func (m *recordingBreakerMetrics) SetPCFCircuitState(state int) {
+	m.states = append(m.states, state)
+}
+
+// TestBreakerClient tests the closed, open, half-open, and closed
+// transitions as PCF goes down and recovers
+func TestBreakerClient(t *testing.T) {
+	var down atomic.Bool
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch {
+		case down.Load():
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case r.URL.Path == "/api/projects/missing":
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(ErrorResponse{Error: "project not found"})
+		default:
+			json.NewEncoder(w).Encode(Project{ID: "proj1"})
+		}
+	}))
+	defer server.Close()
+
+	inner, err := NewClient(config.PCFConfig{URL: server.URL, Timeout: 5 * time.Second, MaxRetries: 1})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	metrics := &recordingBreakerMetrics{}
+	client := NewBreakerClient(inner, BreakerSettings{FailureThreshold: 3, Cooldown: time.Minute, Metrics: metrics})
+
+	now := time.Now()
+	client.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// Errors PCF answers deliberately do not count as failures
+	down.Store(true)
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetProject(ctx, "proj1"); !errors.Is(err, ErrUnavailable) {
+			t.Fatalf("Expected ErrUnavailable, got %v", err)
+		}
+	}
+	down.Store(false)
+	if _, err := client.GetProject(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound, got %v", err)
+	}
+	down.Store(true)
+	for i := 0; i < 2; i++ {
+		client.GetProject(ctx, "proj1")
+	}
+	if state := client.State(); state != BreakerClosed {
+		t.Fatalf("Expected the 404 to reset the failure count, got state %s", state)
+	}
+
+	// The third consecutive failure opens the circuit
+	client.GetProject(ctx, "proj1")
+	if state := client.State(); state != BreakerOpen {
+		t.Fatalf("Expected state open, got %s", state)
+	}
+
+	before := requests.Load()
+	if _, err := client.ListProjects(ctx); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen while open, got %v", err)
+	}
+	if requests.Load() != before {
+		t.Error("Expected no request to PCF while the circuit is open")
+	}
+
+	// After the cooldown a failed probe reopens the circuit
+	now = now.Add(time.Minute)
+	if state := client.State(); state != BreakerHalfOpen {
+		t.Fatalf("Expected state half-open after the cooldown, got %s", state)
+	}
+	if _, err := client.GetProject(ctx, "proj1"); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("Expected the probe to reach PCF, got %v", err)
+	}
+	if state := client.State(); state != BreakerOpen {
+		t.Fatalf("Expected a failed probe to reopen the circuit, got %s", state)
+	}
+
+	// A successful probe closes it again
+	down.Store(false)
+	now = now.Add(time.Minute)
+	if _, err := client.GetProject(ctx, "proj1"); err != nil {
+		t.Fatalf("Expected the probe to succeed, got %v", err)
+	}
+	if state := client.State(); state != BreakerClosed {
+		t.Fatalf("Expected a successful probe to close the circuit, got %s", state)
+	}
+	if _, err := client.GetProject(ctx, "proj1"); err != nil {
+		t.Errorf("Expected calls to pass once closed, got %v", err)
+	}
+
+	expected := []int{0, 1, 2, 1, 2, 0}
+	if len(metrics.states) != len(expected) {
+		t.Fatalf("Expected reported states %v, got %v", expected, metrics.states)
+	}
+	for i := range expected {
+		if metrics.states[i] != expected[i] {
+			t.Fatalf("Expected reported states %v, got %v", expected, metrics.states)
+		}
+	}
+}
+
+// TestBreakerClientSingleProbe tests that only one call probes a half-open
+// circuit at a time
+func TestBreakerClientSingleProbe(t *testing.T) {
+	release := make(chan struct{})
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		json.NewEncoder(w).Encode(Project{ID: "proj1"})
+	}))
+	defer server.Close()
+	defer close(release)
+
+	inner, err := NewClient(config.PCFConfig{URL: server.URL, Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	client := NewBreakerClient(inner, BreakerSettings{FailureThreshold: 1, Cooldown: time.Minute})
+	now := time.Now()
+	client.now = func() time.Time { return now }
+
+	// Open the circuit directly, then let the cooldown pass
+	client.mu.Lock()
+	client.setState(context.Background(), BreakerOpen)
+	client.mu.Unlock()
+	now = now.Add(time.Minute)
+
+	probeDone := make(chan error, 1)
+	go func() {
+		_, err := client.GetProject(context.Background(), "proj1")
+		probeDone <- err
+	}()
+
+	// Wait for the probe to reach PCF
+	for requests.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	if _, err := client.GetProject(context.Background(), "proj1"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen while the probe is in flight, got %v", err)
+	}
+
+	release <- struct{}{}
+	if err := <-probeDone; err != nil {
+		t.Fatalf("Expected the probe to succeed, got %v", err)
+	}
+	if state := client.State(); state != BreakerClosed {
+		t.Errorf("Expected state closed after the probe, got %s", state)
+	}
+}
@@ -0,0 +1,162 @@
+package pcf
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Cache key prefixes
+const (
+	cacheKeyProjects = "projects"
+	cacheKeyHosts    = "hosts:"
+	cacheKeyIssues   = "issues:"
+)
+
+// cacheEntry is a cached list response
+type cacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// CachingClient wraps an API and caches ListProjects, ListHosts, and
+// ListIssues responses for a fixed TTL. Creates, updates, and deletes
+// invalidate the affected entries. It is safe for concurrent use.
+type CachingClient struct {
+	API
+
+	// ttl is how long list responses stay cached
+	ttl time.Duration
+
+	// entries holds cached responses keyed by operation and project ID
+	entries map[string]cacheEntry
+
+	// generation is bumped on every invalidation so fetches that overlap a
+	// write do not repopulate the cache with stale data
+	generation uint64
+
+	// mu protects entries and generation
+	mu sync.Mutex
+
+	// now returns the current time; replaced in tests
+	now func() time.Time
+}
+
+// NewCachingClient wraps inner with a response cache that keeps list results for ttl
+func NewCachingClient(inner API, ttl time.Duration) *CachingClient {
+	return &CachingClient{
+		API:     inner,
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+		now:     time.Now,
+	}
+}
+
+// ListProjects returns cached projects or fetches them from the wrapped client
+func (c *CachingClient) ListProjects(ctx context.Context) ([]Project, error) {
+	return cachedList(c, cacheKeyProjects, func() ([]Project, error) {
+		return c.API.ListProjects(ctx)
+	})
+}
+
+// CreateProject creates a project and invalidates the cached project list
+func (c *CachingClient) CreateProject(ctx context.Context, req CreateProjectRequest) (*Project, error) {
+	defer c.invalidate(cacheKeyProjects)
+	return c.API.CreateProject(ctx, req)
+}
+
+// UpdateProject updates a project and invalidates the cached project list
+func (c *CachingClient) UpdateProject(ctx context.Context, projectID string, req UpdateProjectRequest) (*Project, error) {
+	defer c.invalidate(cacheKeyProjects)
+	return c.API.UpdateProject(ctx, projectID, req)
+}
+
+// DeleteProject deletes a project and invalidates every cached entry for it
+func (c *CachingClient) DeleteProject(ctx context.Context, projectID string) error {
+	defer c.invalidate(cacheKeyProjects, cacheKeyHosts+projectID, cacheKeyIssues+projectID)
+	return c.API.DeleteProject(ctx, projectID)
+}
+
+// ListHosts returns cached hosts for a project or fetches them from the wrapped client
+func (c *CachingClient) ListHosts(ctx context.Context, projectID string) ([]Host, error) {
+	return cachedList(c, cacheKeyHosts+projectID, func() ([]Host, error) {
+		return c.API.ListHosts(ctx, projectID)
+	})
+}
+
+// AddHost adds a host and invalidates the project's cached hosts
+func (c *CachingClient) AddHost(ctx context.Context, projectID string, req CreateHostRequest) (*Host, error) {
+	defer c.invalidate(cacheKeyHosts + projectID)
+	return c.API.AddHost(ctx, projectID, req)
+}
+
+// AddHosts adds hosts in bulk and invalidates the project's cached hosts
+func (c *CachingClient) AddHosts(ctx context.Context, projectID string, reqs []CreateHostRequest) ([]Host, error) {
+	defer c.invalidate(cacheKeyHosts + projectID)
+	return c.API.AddHosts(ctx, projectID, reqs)
+}
+
+// ListIssues returns cached issues for a project or fetches them from the wrapped client
+func (c *CachingClient) ListIssues(ctx context.Context, projectID string) ([]Issue, error) {
+	return cachedList(c, cacheKeyIssues+projectID, func() ([]Issue, error) {
+		return c.API.ListIssues(ctx, projectID)
+	})
+}
+
+// CreateIssue creates an issue and invalidates the project's cached issues
+func (c *CachingClient) CreateIssue(ctx context.Context, projectID string, req CreateIssueRequest) (*Issue, error) {
+	defer c.invalidate(cacheKeyIssues + projectID)
+	return c.API.CreateIssue(ctx, projectID, req)
+}
+
+// UpdateIssue updates an issue and invalidates the project's cached issues
+func (c *CachingClient) UpdateIssue(ctx context.Context, projectID, issueID string, req UpdateIssueRequest) (*Issue, error) {
+	defer c.invalidate(cacheKeyIssues + projectID)
+	return c.API.UpdateIssue(ctx, projectID, issueID, req)
+}
+
+// LinkIssueToHost links an issue to a host and invalidates the project's cached issues
+func (c *CachingClient) LinkIssueToHost(ctx context.Context, projectID, issueID, hostID string) (*Issue, error) {
+	defer c.invalidate(cacheKeyIssues + projectID)
+	return c.API.LinkIssueToHost(ctx, projectID, issueID, hostID)
+}
+
+// invalidate removes the given cache keys
+func (c *CachingClient) invalidate(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+	c.generation++
+}
+
+// cachedList returns a copy of the cached slice for key, calling fetch and
+// caching its result on a miss or after expiry. Errors are not cached.
+func cachedList[T any](c *CachingClient, key string, fetch func() ([]T, error)) ([]T, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	generation := c.generation
+	c.mu.Unlock()
+
+	if ok && c.now().Before(entry.expires) {
+		return append([]T(nil), entry.value.([]T)...), nil
+	}
+
+	items, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if c.generation == generation {
+		c.entries[key] = cacheEntry{
+			value:   append([]T(nil), items...),
+			expires: c.now().Add(c.ttl),
+		}
+	}
+	c.mu.Unlock()
+
+	return items, nil
+}
@@ -0,0 +1,218 @@
+package pcf
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aRustyDev/pcf-mcp/internal/config"
+)
+
+// newCacheTestServer returns a PCF test server that counts GET requests per path
+func newCacheTestServer(t *testing.T, hits map[string]*int32) *httptest.Server {
+	var mu sync.Mutex
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodGet {
+			mu.Lock()
+			counter, ok := hits[r.URL.Path]
+			if !ok {
+				counter = new(int32)
+				hits[r.URL.Path] = counter
+			}
+			mu.Unlock()
+			atomic.AddInt32(counter, 1)
+		}
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/projects":
+			json.NewEncoder(w).Encode([]Project{{ID: "proj1", Name: "Project 1"}})
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/hosts"):
+			json.NewEncoder(w).Encode([]Host{{ID: "host1", IP: "10.0.0.1"}})
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/issues"):
+			json.NewEncoder(w).Encode([]Issue{{ID: "issue1", Title: "XSS"}})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/hosts"):
+			json.NewEncoder(w).Encode(Host{ID: "host2", IP: "10.0.0.2"})
+		case r.Method == http.MethodPatch && strings.Contains(r.URL.Path, "/issues/"):
+			json.NewEncoder(w).Encode(Issue{ID: "issue1", Status: "Resolved"})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/projects":
+			json.NewEncoder(w).Encode(Project{ID: "proj2"})
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+}
+
+// TestCachingClient tests that repeated listings within the TTL are served from cache
+func TestCachingClient(t *testing.T) {
+	hits := make(map[string]*int32)
+	server := newCacheTestServer(t, hits)
+	defer server.Close()
+
+	inner, err := NewClient(config.PCFConfig{URL: server.URL, Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	client := NewCachingClient(inner, time.Minute)
+	ctx := context.Background()
+
+	count := func(path string) int32 {
+		if counter, ok := hits[path]; ok {
+			return atomic.LoadInt32(counter)
+		}
+		return 0
+	}
+
+	// Second identical call is served from cache
+	for i := 0; i < 2; i++ {
+		if _, err := client.ListProjects(ctx); err != nil {
+			t.Fatalf("ListProjects failed: %v", err)
+		}
+		if _, err := client.ListHosts(ctx, "proj1"); err != nil {
+			t.Fatalf("ListHosts failed: %v", err)
+		}
+		if _, err := client.ListIssues(ctx, "proj1"); err != nil {
+			t.Fatalf("ListIssues failed: %v", err)
+		}
+	}
+
+	for _, path := range []string{"/api/projects", "/api/projects/proj1/hosts", "/api/projects/proj1/issues"} {
+		if got := count(path); got != 1 {
+			t.Errorf("Expected 1 request to %s, got %d", path, got)
+		}
+	}
+
+	// Entries are keyed by project
+	if _, err := client.ListHosts(ctx, "proj2"); err != nil {
+		t.Fatalf("ListHosts failed: %v", err)
+	}
+	if got := count("/api/projects/proj2/hosts"); got != 1 {
+		t.Errorf("Expected a separate request for proj2 hosts, got %d", got)
+	}
+
+	// Adding a host invalidates only that project's hosts
+	if _, err := client.AddHost(ctx, "proj1", CreateHostRequest{IP: "10.0.0.2"}); err != nil {
+		t.Fatalf("AddHost failed: %v", err)
+	}
+	client.ListHosts(ctx, "proj1")
+	client.ListIssues(ctx, "proj1")
+
+	if got := count("/api/projects/proj1/hosts"); got != 2 {
+		t.Errorf("Expected hosts to be refetched after AddHost, got %d requests", got)
+	}
+	if got := count("/api/projects/proj1/issues"); got != 1 {
+		t.Errorf("Expected issues to remain cached, got %d requests", got)
+	}
+
+	// Updating an issue invalidates that project's issues
+	status := "Resolved"
+	if _, err := client.UpdateIssue(ctx, "proj1", "issue1", UpdateIssueRequest{Status: &status}); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	client.ListIssues(ctx, "proj1")
+	if got := count("/api/projects/proj1/issues"); got != 2 {
+		t.Errorf("Expected issues to be refetched after UpdateIssue, got %d requests", got)
+	}
+
+	// Linking an issue to a host invalidates that project's issues
+	if _, err := client.LinkIssueToHost(ctx, "proj1", "issue1", "host1"); err != nil {
+		t.Fatalf("LinkIssueToHost failed: %v", err)
+	}
+	client.ListIssues(ctx, "proj1")
+	if got := count("/api/projects/proj1/issues"); got != 3 {
+		t.Errorf("Expected issues to be refetched after LinkIssueToHost, got %d requests", got)
+	}
+
+	// Creating a project invalidates the project list
+	if _, err := client.CreateProject(ctx, CreateProjectRequest{Name: "New"}); err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+	client.ListProjects(ctx)
+	if got := count("/api/projects"); got != 2 {
+		t.Errorf("Expected projects to be refetched after CreateProject, got %d requests", got)
+	}
+}
+
+// TestCachingClientExpiry tests that entries expire after the TTL
+func TestCachingClientExpiry(t *testing.T) {
+	hits := make(map[string]*int32)
+	server := newCacheTestServer(t, hits)
+	defer server.Close()
+
+	inner, err := NewClient(config.PCFConfig{URL: server.URL, Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	now := time.Now()
+	client := NewCachingClient(inner, time.Minute)
+	client.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	client.ListProjects(ctx)
+
+	now = now.Add(59 * time.Second)
+	client.ListProjects(ctx)
+
+	if got := atomic.LoadInt32(hits["/api/projects"]); got != 1 {
+		t.Errorf("Expected cached response within TTL, got %d requests", got)
+	}
+
+	now = now.Add(2 * time.Second)
+	client.ListProjects(ctx)
+
+	if got := atomic.LoadInt32(hits["/api/projects"]); got != 2 {
+		t.Errorf("Expected refetch after TTL, got %d requests", got)
+	}
+}
+
+// TestCachingClientConcurrent tests concurrent reads and writes under the race detector
+func TestCachingClientConcurrent(t *testing.T) {
+	hits := make(map[string]*int32)
+	server := newCacheTestServer(t, hits)
+	defer server.Close()
+
+	inner, err := NewClient(config.PCFConfig{URL: server.URL, Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	client := NewCachingClient(inner, time.Minute)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			hosts, err := client.ListHosts(ctx, "proj1")
+			if err != nil {
+				t.Errorf("ListHosts failed: %v", err)
+				return
+			}
+			// Mutating a returned slice must not affect the cache
+			hosts[0].IP = "mutated"
+		}()
+		go func() {
+			defer wg.Done()
+			client.AddHost(ctx, "proj1", CreateHostRequest{IP: "10.0.0.2"})
+		}()
+	}
+	wg.Wait()
+
+	hosts, err := client.ListHosts(ctx, "proj1")
+	if err != nil {
+		t.Fatalf("ListHosts failed: %v", err)
+	}
+	if hosts[0].IP != "10.0.0.1" {
+		t.Errorf("Cached data was mutated by a caller: %s", hosts[0].IP)
+	}
+}
@@ -5,15 +5,27 @@ package pcf
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/aRustyDev/pcf-mcp/internal/config"
+	"github.com/aRustyDev/pcf-mcp/internal/observability"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Client represents a PCF API client
@@ -21,14 +33,77 @@ type Client struct {
 	// baseURL is the base URL of the PCF instance
 	baseURL string
 
-	// httpClient is the underlying HTTP client
-	httpClient *http.Client
+	// apiBasePath prefixes every API request path, without a trailing slash
+	apiBasePath string
 
-	// apiKey is the authentication key for PCF API
+	// httpClient is the underlying HTTP client; replaced as a whole by
+	// SetTimeout so in-flight requests are unaffected
+	httpClient atomic.Pointer[http.Client]
+
+	// apiKey is the authentication value for PCF API, including any scheme
 	apiKey string
 
+	// apiKeyHeader is the request header that carries apiKey
+	apiKeyHeader string
+
+	// userAgent is sent as the User-Agent header of every request; empty
+	// means the default built from version
+	userAgent string
+
+	// version is the pcf-mcp version reported to PCF, if set
+	version string
+
 	// maxRetries is the maximum number of retry attempts
 	maxRetries int
+
+	// retryMaxDelay caps the wait before retrying a rate-limited request
+	retryMaxDelay time.Duration
+
+	// bulkWorkers caps concurrent requests made by bulk operations
+	bulkWorkers int
+
+	// requestSlots holds one entry per request in flight, bounding them to
+	// MaxConcurrentRequests; nil means unlimited
+	requestSlots chan struct{}
+
+	// allowedHosts lists the lowercased hosts requests may be sent to,
+	// including across redirects; empty allows any host
+	allowedHosts map[string]bool
+
+	// metrics records outbound request metrics, if set
+	metrics RequestMetrics
+}
+
+// RequestMetrics records metrics for outbound PCF API requests
+type RequestMetrics interface {
+	// RecordPCFRequest records one HTTP attempt. path is templated (e.g.
+	// /api/projects/:id/hosts) and status is 0 when no response was received.
+	RecordPCFRequest(method, path string, status int, duration time.Duration)
+
+	// RecordPCFRetry records a retry of a request to the templated path
+	RecordPCFRetry(path string)
+
+	// RecordPCFRetriesExhausted records a retryable request that failed on
+	// every attempt
+	RecordPCFRetriesExhausted()
+}
+
+// ClientOption configures optional Client behavior
+type ClientOption func(*Client)
+
+// WithMetrics records request counts and latency for every PCF request
+func WithMetrics(metrics RequestMetrics) ClientOption {
+	return func(c *Client) {
+		c.metrics = metrics
+	}
+}
+
+// WithVersion reports the pcf-mcp version to PCF in the default User-Agent
+// and the X-Client-Version header
+func WithVersion(version string) ClientOption {
+	return func(c *Client) {
+		c.version = version
+	}
 }
 
 // Project represents a PCF project
@@ -72,13 +147,31 @@ type Host struct {
 	// OS is the operating system
 	OS string `json:"os,omitempty"`
 
-	// Services is a list of discovered services
+	// Services is a list of discovered service names
 	Services []string `json:"services,omitempty"`
 
+	// ServiceDetails lists discovered services with their ports
+	ServiceDetails []Service `json:"service_details,omitempty"`
+
+	// Tags are analyst labels grouping hosts, e.g. dmz or out-of-scope
+	Tags []string `json:"tags,omitempty"`
+
 	// Status indicates if the host is active
 	Status string `json:"status,omitempty"`
 }
 
+// Service is a network service listening on a host
+type Service struct {
+	// Name is the service name (e.g. ssh, http)
+	Name string `json:"name,omitempty"`
+
+	// Port is the port number
+	Port int `json:"port"`
+
+	// Protocol is the transport protocol (tcp, udp, sctp)
+	Protocol string `json:"protocol,omitempty"`
+}
+
 // Issue represents a security issue or finding
 type Issue struct {
 	// ID is the unique identifier of the issue
@@ -107,6 +200,9 @@ type Issue struct {
 
 	// CVSS is the CVSS score (if applicable)
 	CVSS float64 `json:"cvss,omitempty"`
+
+	// UpdatedAt is the last update timestamp (zero if PCF did not report it)
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // Credential represents stored credentials
@@ -143,12 +239,24 @@ type CreateProjectRequest struct {
 	Team        []string `json:"team,omitempty"`
 }
 
+// UpdateProjectRequest represents a partial update to an existing project.
+// Nil fields are omitted from the request and left unchanged by PCF; a
+// non-nil Team pointing at an empty list clears the team.
+type UpdateProjectRequest struct {
+	Name        *string   `json:"name,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	Status      *string   `json:"status,omitempty"`
+	Team        *[]string `json:"team,omitempty"`
+}
+
 // CreateHostRequest represents a request to add a new host
 type CreateHostRequest struct {
-	IP       string   `json:"ip"`
-	Hostname string   `json:"hostname,omitempty"`
-	OS       string   `json:"os,omitempty"`
-	Services []string `json:"services,omitempty"`
+	IP             string    `json:"ip"`
+	Hostname       string    `json:"hostname,omitempty"`
+	OS             string    `json:"os,omitempty"`
+	Services       []string  `json:"services,omitempty"`
+	ServiceDetails []Service `json:"service_details,omitempty"`
+	Tags           []string  `json:"tags,omitempty"`
 }
 
 // CreateIssueRequest represents a request to create a new issue
@@ -161,6 +269,15 @@ type CreateIssueRequest struct {
 	CVSS        float64 `json:"cvss,omitempty"`
 }
 
+// UpdateIssueRequest represents a partial update to an existing issue.
+// Nil fields are omitted from the request and left unchanged by PCF.
+type UpdateIssueRequest struct {
+	Status      *string  `json:"status,omitempty"`
+	Severity    *string  `json:"severity,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	CVSS        *float64 `json:"cvss,omitempty"`
+}
+
 // AddCredentialRequest represents a request to add a new credential
 type AddCredentialRequest struct {
 	HostID   string `json:"host_id,omitempty"`
@@ -178,6 +295,10 @@ type GenerateReportRequest struct {
 	IncludeIssues      bool     `json:"include_issues"`
 	IncludeCredentials bool     `json:"include_credentials"`
 	Sections           []string `json:"sections,omitempty"`
+
+	// IdempotencyKey is sent as the Idempotency-Key header so PCF can
+	// deduplicate retried requests; a key is generated when empty
+	IdempotencyKey string `json:"-"`
 }
 
 // Report represents a generated report
@@ -191,6 +312,139 @@ type Report struct {
 	Size      int64     `json:"size,omitempty"`
 }
 
+// ListOptions controls pagination for list requests
+type ListOptions struct {
+	// Page is the 1-based page number to fetch
+	Page int
+
+	// PageSize is the maximum number of items per page
+	PageSize int
+}
+
+// PageInfo describes the pagination state of a list response
+type PageInfo struct {
+	// TotalCount is the total number of items reported by PCF (0 if unknown)
+	TotalCount int
+
+	// NextPageToken identifies the next page, empty when there are no more pages
+	NextPageToken string
+}
+
+// DefaultPageSize is the page size used when fetching all pages
+const DefaultPageSize = 100
+
+// maxListPages caps the pages fetched when listing everything, in case PCF
+// keeps reporting a next page
+const maxListPages = 1000
+
+// listItem is implemented by the resources returned by list endpoints, so
+// listAll can tell when a page repeats items it has already seen
+type listItem interface {
+	itemID() string
+}
+
+func (p Project) itemID() string    { return p.ID }
+func (h Host) itemID() string       { return h.ID }
+func (i Issue) itemID() string      { return i.ID }
+func (c Credential) itemID() string { return c.ID }
+
+// headerTotalCount is the response header PCF uses to report total item count
+const headerTotalCount = "X-Total-Count"
+
+// DefaultAPIKeyHeader is the request header that carries the API key when
+// none is configured
+const DefaultAPIKeyHeader = "X-API-Key"
+
+// DefaultAPIBasePath prefixes API request paths when none is configured
+const DefaultAPIBasePath = "/api"
+
+// DefaultRetryMaxDelay caps the wait before retrying a rate-limited request
+// when none is configured
+const DefaultRetryMaxDelay = 30 * time.Second
+
+// defaultUserAgentProduct is the User-Agent product name sent to PCF
+const defaultUserAgentProduct = "pcf-mcp"
+
+// headerClientVersion is the request header that reports the pcf-mcp version
+const headerClientVersion = "X-Client-Version"
+
+// headerIdempotencyKey is the request header that lets PCF deduplicate retried writes
+const headerIdempotencyKey = "Idempotency-Key"
+
+// DefaultBulkWorkers is the number of concurrent requests used by bulk
+// operations when none is configured
+const DefaultBulkWorkers = 4
+
+// Connection pool defaults used when none are configured. The per-host
+// limit matters most, since every request goes to the one PCF host and
+// net/http keeps only 2 idle connections per host by default.
+const (
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = 10
+	DefaultIdleConnTimeout     = 90 * time.Second
+)
+
+// maxRedirects caps the redirects followed by API requests, matching the
+// net/http default
+const maxRedirects = 10
+
+// maxReportRedirects caps the redirects followed by DownloadReport
+const maxReportRedirects = 10
+
+// maxErrorBodySize caps how much of a failed download's body is read for the error message
+const maxErrorBodySize = 4096
+
+// BulkItemError is a failure of a single item in a bulk operation
+type BulkItemError struct {
+	// Index is the position of the failed item in the request slice
+	Index int
+
+	// Err is the error returned for the item
+	Err error
+}
+
+// BulkError reports the items of a bulk operation that failed. Items not
+// listed in Failures succeeded.
+type BulkError struct {
+	// Total is the number of items in the bulk request
+	Total int
+
+	// Failures lists failed items in request order
+	Failures []BulkItemError
+}
+
+// Error implements the error interface
+func (e *BulkError) Error() string {
+	if len(e.Failures) == 0 {
+		return "bulk operation failed"
+	}
+	return fmt.Sprintf("%d of %d items failed: item %d: %v", len(e.Failures), e.Total, e.Failures[0].Index, e.Failures[0].Err)
+}
+
+// Unwrap returns the individual item errors
+func (e *BulkError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		errs = append(errs, f.Err)
+	}
+	return errs
+}
+
+// ErrNotFound is returned when PCF responds with 404 Not Found
+var ErrNotFound = errors.New("resource not found")
+
+// ErrRateLimited is returned when PCF keeps responding with 429 Too Many
+// Requests after all retries
+var ErrRateLimited = errors.New("rate limited by PCF")
+
+// ErrUnavailable is returned when PCF cannot be reached or keeps failing
+// with a 5xx server error after all retries
+var ErrUnavailable = errors.New("PCF unavailable")
+
+// ErrHostNotAllowed is returned when a request or redirect targets a host
+// missing from pcf.allowed_hosts
+var ErrHostNotAllowed = errors.New("host not in PCF allowed hosts")
+
 // ErrorResponse represents an error response from PCF API
 type ErrorResponse struct {
 	Error   string `json:"error"`
@@ -199,58 +453,227 @@ type ErrorResponse struct {
 }
 
 // NewClient creates a new PCF API client
-func NewClient(cfg config.PCFConfig) (*Client, error) {
+func NewClient(cfg config.PCFConfig, opts ...ClientOption) (*Client, error) {
 	// Validate URL
 	if cfg.URL == "" {
 		return nil, fmt.Errorf("PCF URL is required")
 	}
 
-	// Parse URL to validate it
-	_, err := url.Parse(cfg.URL)
+	// Parse URL to validate it; url.Parse accepts almost anything, so the
+	// scheme and host are checked explicitly
+	baseURL, err := url.Parse(cfg.URL)
 	if err != nil {
 		return nil, fmt.Errorf("invalid PCF URL: %w", err)
 	}
 
-	// Configure HTTP client
+	if baseURL.Scheme != "http" && baseURL.Scheme != "https" {
+		return nil, fmt.Errorf("invalid PCF URL %q: scheme must be http or https", cfg.URL)
+	}
+
+	if baseURL.Hostname() == "" {
+		return nil, fmt.Errorf("invalid PCF URL %q: host is required", cfg.URL)
+	}
+
+	allowedHosts := make(map[string]bool, len(cfg.AllowedHosts))
+	for _, host := range cfg.AllowedHosts {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			allowedHosts[host] = true
+		}
+	}
+	if err := checkAllowedHost(allowedHosts, baseURL); err != nil {
+		return nil, fmt.Errorf("invalid PCF URL %q: %w", cfg.URL, err)
+	}
+
+	// Configure HTTP client, keeping redirects within the allowed hosts
 	httpClient := &http.Client{
 		Timeout: cfg.Timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			return checkAllowedHost(allowedHosts, req.URL)
+		},
 	}
 
-	// Configure transport with TLS settings
-	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{
-			MinVersion:         tls.VersionTLS12,
-			InsecureSkipVerify: cfg.InsecureSkipVerify,
-		},
+	// Use the configured proxy, falling back to the proxy environment variables
+	proxy := http.ProxyFromEnvironment
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			// The parse error quotes the URL, which may contain credentials
+			return nil, fmt.Errorf("invalid PCF proxy URL: %w", errors.Unwrap(err))
+		}
+
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return nil, fmt.Errorf("invalid PCF proxy URL %q: scheme must be http, https, socks5 or socks5h", proxyURL.Redacted())
+		}
+
+		if proxyURL.Hostname() == "" {
+			return nil, fmt.Errorf("invalid PCF proxy URL %q: host is required", proxyURL.Redacted())
+		}
+
+		proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig, err := clientTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Configure transport with proxy, TLS, and connection pool settings,
+	// keeping the dial and handshake timeouts of the default transport
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxy
+	transport.TLSClientConfig = tlsConfig
+	transport.MaxIdleConns = DefaultMaxIdleConns
+	if cfg.MaxIdleConns > 0 {
+		transport.MaxIdleConns = cfg.MaxIdleConns
+	}
+	transport.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	if cfg.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	}
+	transport.IdleConnTimeout = DefaultIdleConnTimeout
+	if cfg.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = cfg.IdleConnTimeout
 	}
 	httpClient.Transport = transport
 
+	bulkWorkers := cfg.BulkWorkers
+	if bulkWorkers <= 0 {
+		bulkWorkers = DefaultBulkWorkers
+	}
+
+	retryMaxDelay := cfg.RetryMaxDelay
+	if retryMaxDelay <= 0 {
+		retryMaxDelay = DefaultRetryMaxDelay
+	}
+
+	apiKeyHeader := cfg.APIKeyHeader
+	if apiKeyHeader == "" {
+		apiKeyHeader = DefaultAPIKeyHeader
+	}
+
+	apiBasePath := cfg.APIBasePath
+	if apiBasePath == "" {
+		apiBasePath = DefaultAPIBasePath
+	}
+	if !strings.HasPrefix(apiBasePath, "/") {
+		return nil, fmt.Errorf("invalid PCF API base path %q: must start with /", cfg.APIBasePath)
+	}
+
+	apiKey := cfg.APIKey
+	if scheme := strings.TrimSpace(cfg.APIKeyScheme); scheme != "" && apiKey != "" {
+		apiKey = scheme + " " + apiKey
+	}
+
 	client := &Client{
-		baseURL:    cfg.URL,
-		httpClient: httpClient,
-		apiKey:     cfg.APIKey,
-		maxRetries: cfg.MaxRetries,
+		baseURL:       cfg.URL,
+		apiBasePath:   strings.TrimRight(apiBasePath, "/"),
+		apiKey:        apiKey,
+		apiKeyHeader:  apiKeyHeader,
+		userAgent:     cfg.UserAgent,
+		maxRetries:    cfg.MaxRetries,
+		retryMaxDelay: retryMaxDelay,
+		bulkWorkers:   bulkWorkers,
+		allowedHosts:  allowedHosts,
+	}
+
+	if cfg.MaxConcurrentRequests > 0 {
+		client.requestSlots = make(chan struct{}, cfg.MaxConcurrentRequests)
+	}
+
+	client.httpClient.Store(httpClient)
+
+	for _, opt := range opts {
+		opt(client)
 	}
 
 	return client, nil
 }
 
+// clientTLSConfig builds the TLS configuration for PCF requests, loading the
+// optional private CA and mutual TLS client certificate
+func clientTLSConfig(cfg config.PCFConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read PCF CA file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in PCF CA file: %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if (cfg.ClientCertFile == "") != (cfg.ClientKeyFile == "") {
+		return nil, fmt.Errorf("both PCF client cert file and key file must be provided")
+	}
+
+	if cfg.ClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.ClientCertFile, cfg.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load PCF client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
 // BaseURL returns the client's base URL
 func (c *Client) BaseURL() string {
 	return c.baseURL
 }
 
-// ListProjects retrieves all projects from PCF
+// apiPath formats an API request path under the configured base path
+func (c *Client) apiPath(format string, args ...interface{}) string {
+	return c.apiBasePath + fmt.Sprintf(format, args...)
+}
+
+// Timeout returns the current per-request timeout
+func (c *Client) Timeout() time.Duration {
+	return c.httpClient.Load().Timeout
+}
+
+// SetTimeout changes the per-request timeout. It is safe to call while
+// requests are in flight; they keep the timeout they started with.
+func (c *Client) SetTimeout(timeout time.Duration) {
+	next := *c.httpClient.Load()
+	next.Timeout = timeout
+	c.httpClient.Store(&next)
+}
+
+// Ping performs a lightweight request to verify PCF is reachable. It fetches
+// a single project, so it also verifies the API key.
+func (c *Client) Ping(ctx context.Context) error {
+	_, _, err := c.ListProjectsPage(ctx, ListOptions{Page: 1, PageSize: 1})
+	return err
+}
+
+// ListProjects retrieves all projects from PCF, fetching every page
 func (c *Client) ListProjects(ctx context.Context) ([]Project, error) {
-	var projects []Project
-	err := c.doRequest(ctx, "GET", "/api/projects", nil, &projects)
-	return projects, err
+	return listAll[Project](ctx, c, c.apiPath("/projects"))
+}
+
+// ListProjectsPage retrieves a single page of projects from PCF
+func (c *Client) ListProjectsPage(ctx context.Context, opts ListOptions) ([]Project, *PageInfo, error) {
+	return listPage[Project](ctx, c, c.apiPath("/projects"), opts)
 }
 
 // GetProject retrieves a specific project by ID
 func (c *Client) GetProject(ctx context.Context, projectID string) (*Project, error) {
 	var project Project
-	path := fmt.Sprintf("/api/projects/%s", projectID)
+	path := c.apiPath("/projects/%s", projectID)
 	err := c.doRequest(ctx, "GET", path, nil, &project)
 	return &project, err
 }
@@ -258,117 +681,509 @@ func (c *Client) GetProject(ctx context.Context, projectID string) (*Project, er
 // CreateProject creates a new project in PCF
 func (c *Client) CreateProject(ctx context.Context, req CreateProjectRequest) (*Project, error) {
 	var project Project
-	err := c.doRequest(ctx, "POST", "/api/projects", req, &project)
+	err := c.doRequest(ctx, "POST", c.apiPath("/projects"), req, &project)
+	return &project, err
+}
+
+// UpdateProject updates metadata of an existing project in PCF
+func (c *Client) UpdateProject(ctx context.Context, projectID string, req UpdateProjectRequest) (*Project, error) {
+	var project Project
+	path := c.apiPath("/projects/%s", projectID)
+	err := c.doRequest(ctx, "PUT", path, req, &project)
 	return &project, err
 }
 
-// ListHosts retrieves all hosts for a project
+// DeleteProject deletes a project from PCF
+func (c *Client) DeleteProject(ctx context.Context, projectID string) error {
+	path := c.apiPath("/projects/%s", projectID)
+	return c.doRequest(ctx, "DELETE", path, nil, nil)
+}
+
+// ListHosts retrieves all hosts for a project, fetching every page
 func (c *Client) ListHosts(ctx context.Context, projectID string) ([]Host, error) {
-	var hosts []Host
-	path := fmt.Sprintf("/api/projects/%s/hosts", projectID)
-	err := c.doRequest(ctx, "GET", path, nil, &hosts)
-	return hosts, err
+	path := c.apiPath("/projects/%s/hosts", projectID)
+	return listAll[Host](ctx, c, path)
+}
+
+// ListHostsPage retrieves a single page of hosts for a project
+func (c *Client) ListHostsPage(ctx context.Context, projectID string, opts ListOptions) ([]Host, *PageInfo, error) {
+	path := c.apiPath("/projects/%s/hosts", projectID)
+	return listPage[Host](ctx, c, path, opts)
+}
+
+// GetHost retrieves a single host by ID
+func (c *Client) GetHost(ctx context.Context, projectID, hostID string) (*Host, error) {
+	var host Host
+	path := c.apiPath("/projects/%s/hosts/%s", projectID, hostID)
+	err := c.doRequest(ctx, "GET", path, nil, &host)
+	return &host, err
 }
 
 // AddHost adds a new host to a project
 func (c *Client) AddHost(ctx context.Context, projectID string, req CreateHostRequest) (*Host, error) {
 	var host Host
-	path := fmt.Sprintf("/api/projects/%s/hosts", projectID)
+	path := c.apiPath("/projects/%s/hosts", projectID)
 	err := c.doRequest(ctx, "POST", path, req, &host)
 	return &host, err
 }
 
-// ListIssues retrieves all issues for a project
+// AddHosts adds several hosts to a project using a bounded pool of concurrent
+// requests. The returned slice is aligned with reqs; entries for hosts that
+// failed are left zero-valued and reported in a *BulkError.
+func (c *Client) AddHosts(ctx context.Context, projectID string, reqs []CreateHostRequest) ([]Host, error) {
+	hosts := make([]Host, len(reqs))
+	errs := make([]error, len(reqs))
+
+	workers := c.bulkWorkers
+	if workers <= 0 {
+		workers = DefaultBulkWorkers
+	}
+	if workers > len(reqs) {
+		workers = len(reqs)
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+					continue
+				}
+
+				host, err := c.AddHost(ctx, projectID, reqs[i])
+				if err != nil {
+					errs[i] = err
+					continue
+				}
+				hosts[i] = *host
+			}
+		}()
+	}
+
+	for i := range reqs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	var bulkErr BulkError
+	for i, err := range errs {
+		if err != nil {
+			bulkErr.Failures = append(bulkErr.Failures, BulkItemError{Index: i, Err: err})
+		}
+	}
+
+	if len(bulkErr.Failures) > 0 {
+		bulkErr.Total = len(reqs)
+		return hosts, &bulkErr
+	}
+
+	return hosts, nil
+}
+
+// ListIssues retrieves all issues for a project, fetching every page
 func (c *Client) ListIssues(ctx context.Context, projectID string) ([]Issue, error) {
-	var issues []Issue
-	path := fmt.Sprintf("/api/projects/%s/issues", projectID)
-	err := c.doRequest(ctx, "GET", path, nil, &issues)
-	return issues, err
+	path := c.apiPath("/projects/%s/issues", projectID)
+	return listAll[Issue](ctx, c, path)
+}
+
+// ListIssuesPage retrieves a single page of issues for a project
+func (c *Client) ListIssuesPage(ctx context.Context, projectID string, opts ListOptions) ([]Issue, *PageInfo, error) {
+	path := c.apiPath("/projects/%s/issues", projectID)
+	return listPage[Issue](ctx, c, path, opts)
+}
+
+// GetIssue retrieves a single issue by ID
+func (c *Client) GetIssue(ctx context.Context, projectID, issueID string) (*Issue, error) {
+	var issue Issue
+	path := c.apiPath("/projects/%s/issues/%s", projectID, issueID)
+	err := c.doRequest(ctx, "GET", path, nil, &issue)
+	return &issue, err
 }
 
 // CreateIssue creates a new issue in a project
 func (c *Client) CreateIssue(ctx context.Context, projectID string, req CreateIssueRequest) (*Issue, error) {
 	var issue Issue
-	path := fmt.Sprintf("/api/projects/%s/issues", projectID)
+	path := c.apiPath("/projects/%s/issues", projectID)
 	err := c.doRequest(ctx, "POST", path, req, &issue)
 	return &issue, err
 }
 
-// ListCredentials retrieves all credentials for a project
+// UpdateIssue applies a partial update to an existing issue in PCF
+func (c *Client) UpdateIssue(ctx context.Context, projectID, issueID string, req UpdateIssueRequest) (*Issue, error) {
+	var issue Issue
+	path := c.apiPath("/projects/%s/issues/%s", projectID, issueID)
+	err := c.doRequest(ctx, "PATCH", path, req, &issue)
+	return &issue, err
+}
+
+// LinkIssueToHost associates an existing issue with a host in the same project
+func (c *Client) LinkIssueToHost(ctx context.Context, projectID, issueID, hostID string) (*Issue, error) {
+	var issue Issue
+	path := c.apiPath("/projects/%s/issues/%s", projectID, issueID)
+	err := c.doRequest(ctx, "PATCH", path, map[string]string{"host_id": hostID}, &issue)
+	return &issue, err
+}
+
+// ListCredentials retrieves all credentials for a project, fetching every page
 func (c *Client) ListCredentials(ctx context.Context, projectID string) ([]Credential, error) {
-	var credentials []Credential
-	path := fmt.Sprintf("/api/projects/%s/credentials", projectID)
-	err := c.doRequest(ctx, "GET", path, nil, &credentials)
-	return credentials, err
+	path := c.apiPath("/projects/%s/credentials", projectID)
+	return listAll[Credential](ctx, c, path)
+}
+
+// ListCredentialsPage retrieves a single page of credentials for a project
+func (c *Client) ListCredentialsPage(ctx context.Context, projectID string, opts ListOptions) ([]Credential, *PageInfo, error) {
+	path := c.apiPath("/projects/%s/credentials", projectID)
+	return listPage[Credential](ctx, c, path, opts)
+}
+
+// GetCredential retrieves a single credential by ID
+func (c *Client) GetCredential(ctx context.Context, projectID, credID string) (*Credential, error) {
+	var cred Credential
+	path := c.apiPath("/projects/%s/credentials/%s", projectID, credID)
+	err := c.doRequest(ctx, "GET", path, nil, &cred)
+	return &cred, err
 }
 
 // AddCredential adds a new credential to a project
 func (c *Client) AddCredential(ctx context.Context, projectID string, req AddCredentialRequest) (*Credential, error) {
 	var credential Credential
-	path := fmt.Sprintf("/api/projects/%s/credentials", projectID)
+	path := c.apiPath("/projects/%s/credentials", projectID)
 	err := c.doRequest(ctx, "POST", path, req, &credential)
 	return &credential, err
 }
 
-// GenerateReport generates a report for a project
+// GenerateReport generates a report for a project. Report jobs are
+// expensive, so the request is retried like an idempotent one, with every
+// attempt carrying the same Idempotency-Key for PCF to deduplicate.
 func (c *Client) GenerateReport(ctx context.Context, projectID string, req GenerateReportRequest) (*Report, error) {
 	var report Report
-	path := fmt.Sprintf("/api/projects/%s/report", projectID)
-	err := c.doRequest(ctx, "POST", path, req, &report)
+	path := c.apiPath("/projects/%s/report", projectID)
+	err := c.doRequest(ctx, "POST", path, req, &report, withRetries(), withIdempotencyKey(req.IdempotencyKey))
 	return &report, err
 }
 
+// DownloadReport streams the report at reportURL to w without buffering it in
+// memory. reportURL may be absolute or relative to the PCF base URL, as
+// returned in Report.URL, and must be on the PCF host or one of
+// pcf.allowed_hosts. The API key is only sent to the PCF host, including
+// across redirects. The client timeout does not apply, since large reports can
+// take a while to transfer; use ctx to bound the download.
+func (c *Client) DownloadReport(ctx context.Context, reportURL string, w io.Writer) error {
+	base, err := url.Parse(c.baseURL)
+	if err != nil {
+		return fmt.Errorf("invalid PCF URL: %w", err)
+	}
+
+	ref, err := url.Parse(reportURL)
+	if err != nil {
+		return fmt.Errorf("invalid report URL: %w", err)
+	}
+	target := base.ResolveReference(ref)
+	if err := checkReportHost(c.allowedHosts, base, target); err != nil {
+		return fmt.Errorf("invalid report URL: %w", err)
+	}
+
+	ctx, span := observability.StartSpan(ctx, "pcf GET", trace.WithAttributes(
+		observability.StringAttribute(observability.AttributeHTTPMethod, http.MethodGet),
+		observability.StringAttribute(observability.AttributeHTTPPath, target.Path),
+	))
+	defer span.End()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		err = fmt.Errorf("failed to create request: %w", err)
+		observability.RecordError(span, err)
+		return err
+	}
+
+	if c.apiKey != "" && target.Host == base.Host {
+		req.Header.Set(c.apiKeyHeader, c.apiKey)
+	}
+	req.Header.Set("User-Agent", c.userAgentHeader())
+	if c.version != "" {
+		req.Header.Set(headerClientVersion, c.version)
+	}
+	if requestID := observability.RequestIDFromContext(ctx); requestID != "" {
+		req.Header.Set(observability.HeaderRequestID, requestID)
+	}
+
+	httpClient := *c.httpClient.Load()
+	httpClient.Timeout = 0
+	httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxReportRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxReportRedirects)
+		}
+		if err := checkAllowedHost(c.allowedHosts, req.URL); err != nil {
+			return err
+		}
+		// Redirected requests copy the original headers; keep the API key
+		// away from other hosts such as object storage
+		if req.URL.Host != base.Host {
+			req.Header.Del(c.apiKeyHeader)
+		}
+		return nil
+	}
+
+	// The download holds a request slot until the report is written
+	release, err := c.acquireRequestSlot(ctx)
+	if err != nil {
+		observability.RecordError(span, err)
+		return err
+	}
+	defer release()
+
+	start := time.Now()
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		c.recordRequest(http.MethodGet, reportDownloadPath, 0, time.Since(start))
+		err = fmt.Errorf("request failed: %w", err)
+		if ctx.Err() == nil && !errors.Is(err, ErrHostNotAllowed) {
+			err = fmt.Errorf("%w: %w", ErrUnavailable, err)
+		}
+		observability.RecordError(span, err)
+		return err
+	}
+	defer resp.Body.Close()
+	c.recordRequest(http.MethodGet, reportDownloadPath, resp.StatusCode, time.Since(start))
+
+	span.SetAttributes(observability.IntAttribute(observability.AttributeHTTPStatus, resp.StatusCode))
+
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		var errResp ErrorResponse
+		if err := json.Unmarshal(respBody, &errResp); err == nil && errResp.Error != "" {
+			err = fmt.Errorf("PCF API error: %s", errResp.Error)
+		} else {
+			err = fmt.Errorf("PCF API error: %s (status %d)", string(respBody), resp.StatusCode)
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			err = fmt.Errorf("%w: %w", ErrNotFound, err)
+		}
+		if resp.StatusCode >= 500 {
+			err = fmt.Errorf("%w: %w", ErrUnavailable, err)
+		}
+		return err
+	}
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		err = fmt.Errorf("failed to download report: %w", err)
+		observability.RecordError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+// listPage fetches a single page of items from a list endpoint
+func listPage[T any](ctx context.Context, c *Client, path string, opts ListOptions) ([]T, *PageInfo, error) {
+	page := opts.Page
+	if page < 1 {
+		page = 1
+	}
+
+	pageSize := opts.PageSize
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("per_page", strconv.Itoa(pageSize))
+
+	var items []T
+	headers, err := c.doRequestWithHeaders(ctx, "GET", path+"?"+query.Encode(), nil, &items)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info := &PageInfo{}
+	if total, err := strconv.Atoi(headers.Get(headerTotalCount)); err == nil {
+		info.TotalCount = total
+	}
+
+	// A short page means the server has no more items; an oversized page
+	// means the server ignored pagination and returned everything
+	if len(items) == pageSize && (info.TotalCount == 0 || page*pageSize < info.TotalCount) {
+		info.NextPageToken = strconv.Itoa(page + 1)
+	}
+
+	return items, info, nil
+}
+
+// listAll fetches every page of items from a list endpoint. A server that
+// ignores page and per_page returns the same full page every time, so
+// paging also stops at a page that repeats the previous page's first item or
+// adds nothing new, and fails after maxListPages pages.
+func listAll[T listItem](ctx context.Context, c *Client, path string) ([]T, error) {
+	var all []T
+	seen := make(map[string]bool)
+	previousFirstID := ""
+	opts := ListOptions{Page: 1, PageSize: DefaultPageSize}
+
+	for pages := 1; ; pages++ {
+		items, info, err := listPage[T](ctx, c, path, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(items) > 0 && pages > 1 && items[0].itemID() == previousFirstID {
+			return all, nil
+		}
+
+		added := 0
+		for _, item := range items {
+			id := item.itemID()
+			if id != "" && seen[id] {
+				continue
+			}
+			seen[id] = true
+			all = append(all, item)
+			added++
+		}
+
+		if info.NextPageToken == "" || added == 0 {
+			return all, nil
+		}
+
+		if pages == maxListPages {
+			return nil, fmt.Errorf("failed to list %s: more than %d pages", path, maxListPages)
+		}
+
+		if len(items) > 0 {
+			previousFirstID = items[0].itemID()
+		}
+		opts.Page, _ = strconv.Atoi(info.NextPageToken)
+	}
+}
+
+// requestOptions controls how a single PCF request is sent
+type requestOptions struct {
+	// retryable allows retrying on network errors and 5xx responses
+	retryable bool
+
+	// idempotencyKey is sent as the Idempotency-Key header on every attempt
+	idempotencyKey string
+}
+
+// requestOption customizes a single PCF request
+type requestOption func(*requestOptions)
+
+// withRetries opts a non-idempotent request into retries. Only use it for
+// endpoints where PCF deduplicates writes by Idempotency-Key.
+func withRetries() requestOption {
+	return func(o *requestOptions) {
+		o.retryable = true
+	}
+}
+
+// withIdempotencyKey sends key as the Idempotency-Key header instead of a
+// generated one; an empty key keeps the default
+func withIdempotencyKey(key string) requestOption {
+	return func(o *requestOptions) {
+		if key != "" {
+			o.idempotencyKey = key
+		}
+	}
+}
+
+// newRequestOptions returns the defaults for method with opts applied. Safe
+// and idempotent methods are retried; POSTs are not, because a 5xx may arrive
+// after PCF has already created the resource. POSTs carry an Idempotency-Key
+// so PCF can deduplicate them if it supports it.
+func newRequestOptions(method string, opts []requestOption) (requestOptions, error) {
+	var o requestOptions
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		o.retryable = true
+	case http.MethodPost:
+		key, err := NewIdempotencyKey()
+		if err != nil {
+			return o, err
+		}
+		o.idempotencyKey = key
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o, nil
+}
+
+// NewIdempotencyKey returns a random 128-bit key in hex, as sent in the
+// Idempotency-Key header
+func NewIdempotencyKey() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate idempotency key: %w", err)
+	}
+	return hex.EncodeToString(b[:]), nil
+}
+
 // doRequest performs an HTTP request with retries and error handling
-func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}, opts ...requestOption) error {
+	_, err := c.doRequestWithHeaders(ctx, method, path, body, result, opts...)
+	return err
+}
+
+// doRequestWithHeaders performs an HTTP request and returns the response headers
+func (c *Client) doRequestWithHeaders(ctx context.Context, method, path string, body interface{}, result interface{}, opts ...requestOption) (http.Header, error) {
 	// Build full URL
 	fullURL := c.baseURL + path
+	target, err := url.Parse(fullURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request URL: %w", err)
+	}
+	if err := checkAllowedHost(c.allowedHosts, target); err != nil {
+		return nil, err
+	}
+
+	reqOpts, err := newRequestOptions(method, opts)
+	if err != nil {
+		return nil, err
+	}
 
 	// Prepare request body
-	var bodyReader io.Reader
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
-			return fmt.Errorf("failed to marshal request body: %w", err)
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		bodyReader = bytes.NewReader(jsonBody)
 	}
 
 	// Retry loop
 	var lastErr error
 	maxRetries := c.maxRetries
-	if maxRetries <= 0 {
+	if maxRetries <= 0 || !reqOpts.retryable {
 		maxRetries = 1
 	}
 
 	for attempt := 0; attempt < maxRetries; attempt++ {
-		// Create new request for each attempt
-		req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
-		if err != nil {
-			return fmt.Errorf("failed to create request: %w", err)
-		}
-
-		// Set headers
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("Accept", "application/json")
-		if c.apiKey != "" {
-			req.Header.Set("X-API-Key", c.apiKey)
+		if attempt > 0 {
+			c.recordRetry(path)
 		}
 
-		// Perform request
-		resp, err := c.httpClient.Do(req)
+		resp, respBody, err := c.doAttempt(ctx, method, path, fullURL, jsonBody, reqOpts.idempotencyKey, attempt)
 		if err != nil {
-			lastErr = fmt.Errorf("request failed: %w", err)
+			// A cancelled caller, or a redirect off the allowed hosts,
+			// gets no more attempts
+			if ctx.Err() != nil || errors.Is(err, ErrHostNotAllowed) {
+				return nil, err
+			}
+			lastErr = err
 			// Retry on network errors
 			continue
 		}
-		defer resp.Body.Close()
-
-		// Read response body
-		respBody, err := io.ReadAll(resp.Body)
-		if err != nil {
-			lastErr = fmt.Errorf("failed to read response: %w", err)
-			continue
-		}
 
 		// Check for errors
 		if resp.StatusCode >= 400 {
@@ -379,24 +1194,315 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 				lastErr = fmt.Errorf("PCF API error: %s (status %d)", string(respBody), resp.StatusCode)
 			}
 
+			if resp.StatusCode == http.StatusNotFound {
+				lastErr = fmt.Errorf("%w: %w", ErrNotFound, lastErr)
+			}
+
+			// Wait as long as PCF asks before retrying a rate-limited request
+			if resp.StatusCode == http.StatusTooManyRequests {
+				lastErr = fmt.Errorf("%w: %w", ErrRateLimited, lastErr)
+				if attempt < maxRetries-1 {
+					if err := c.waitRetryAfter(ctx, resp.Header.Get("Retry-After"), attempt); err != nil {
+						return nil, err
+					}
+					continue
+				}
+				break
+			}
+
 			// Retry on 5xx errors
-			if resp.StatusCode >= 500 && attempt < maxRetries-1 {
-				time.Sleep(time.Duration(attempt+1) * time.Second)
-				continue
+			if resp.StatusCode >= 500 {
+				lastErr = fmt.Errorf("%w: %w", ErrUnavailable, lastErr)
+				if attempt < maxRetries-1 {
+					if err := sleepContext(ctx, time.Duration(attempt+1)*time.Second); err != nil {
+						return nil, err
+					}
+					continue
+				}
+				break
 			}
 
-			return lastErr
+			return nil, lastErr
 		}
 
 		// Parse successful response
 		if result != nil && len(respBody) > 0 {
 			if err := json.Unmarshal(respBody, result); err != nil {
-				return fmt.Errorf("failed to parse response: %w", err)
+				return nil, fmt.Errorf("failed to parse response: %w", err)
 			}
 		}
 
+		return resp.Header, nil
+	}
+
+	// Every attempt failed with a retryable error
+	if maxRetries > 1 {
+		c.recordRetriesExhausted()
+		return nil, fmt.Errorf("failed after %d attempts: %w", maxRetries, lastErr)
+	}
+
+	return nil, lastErr
+}
+
+// checkAllowedHost returns ErrHostNotAllowed unless u's host, with or
+// without its port, is in allowed. An empty allowlist permits every host.
+func checkAllowedHost(allowed map[string]bool, u *url.URL) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	host := strings.ToLower(u.Host)
+	if allowed[host] || allowed[strings.ToLower(u.Hostname())] {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrHostNotAllowed, host)
+}
+
+// checkReportHost returns an error wrapping ErrHostNotAllowed unless target
+// is on the PCF host or one of the allowed hosts. Report URLs come from tool
+// callers, so without allowed hosts only the PCF host may be fetched.
+func checkReportHost(allowed map[string]bool, base, target *url.URL) error {
+	if strings.EqualFold(target.Host, base.Host) {
+		return nil
+	}
+
+	if len(allowed) == 0 {
+		return fmt.Errorf("%w: %s", ErrHostNotAllowed, strings.ToLower(target.Host))
+	}
+
+	return checkAllowedHost(allowed, target)
+}
+
+// waitRetryAfter sleeps for the delay requested by a Retry-After header,
+// given in seconds or as an HTTP date, capped at retryMaxDelay. Without a
+// usable header it backs off like a 5xx retry. It returns early with the
+// context's error if the context is done.
+func (c *Client) waitRetryAfter(ctx context.Context, retryAfter string, attempt int) error {
+	delay, ok := parseRetryAfter(retryAfter, time.Now())
+	if !ok {
+		delay = time.Duration(attempt+1) * time.Second
+	}
+	if delay > c.retryMaxDelay {
+		delay = c.retryMaxDelay
+	}
+
+	return sleepContext(ctx, delay)
+}
+
+// sleepContext waits for delay, returning early with the context's error if
+// the context is done first
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
 		return nil
 	}
+}
+
+// parseRetryAfter parses a Retry-After header value relative to now. Dates
+// in the past yield a zero delay.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	delay := date.Sub(now)
+	if delay < 0 {
+		delay = 0
+	}
+	return delay, true
+}
+
+// acquireRequestSlot waits for one of the MaxConcurrentRequests request
+// slots and returns the function that frees it
+func (c *Client) acquireRequestSlot(ctx context.Context) (func(), error) {
+	if c.requestSlots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case c.requestSlots <- struct{}{}:
+		return func() { <-c.requestSlots }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for a PCF request slot: %w", ctx.Err())
+	}
+}
+
+// doAttempt performs a single HTTP request inside its own span and returns
+// the response with its body fully read. The request holds a request slot
+// while in flight, but not while waiting to retry.
+func (c *Client) doAttempt(ctx context.Context, method, path, fullURL string, body []byte, idempotencyKey string, attempt int) (*http.Response, []byte, error) {
+	release, err := c.acquireRequestSlot(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
+
+	// Record the path without its query string
+	spanPath, _, _ := strings.Cut(path, "?")
+
+	ctx, span := observability.StartSpan(ctx, "pcf "+method, trace.WithAttributes(
+		observability.StringAttribute(observability.AttributeHTTPMethod, method),
+		observability.StringAttribute(observability.AttributeHTTPPath, spanPath),
+		observability.IntAttribute(observability.AttributeAttempt, attempt+1),
+	))
+	defer span.End()
+
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
+	if err != nil {
+		err = fmt.Errorf("failed to create request: %w", err)
+		observability.RecordError(span, err)
+		return nil, nil, err
+	}
+
+	// Set headers
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set(c.apiKeyHeader, c.apiKey)
+	}
+	req.Header.Set("User-Agent", c.userAgentHeader())
+	if c.version != "" {
+		req.Header.Set(headerClientVersion, c.version)
+	}
+	if requestID := observability.RequestIDFromContext(ctx); requestID != "" {
+		req.Header.Set(observability.HeaderRequestID, requestID)
+	}
+	if idempotencyKey != "" {
+		req.Header.Set(headerIdempotencyKey, idempotencyKey)
+	}
+
+	// Continue the caller's trace in PCF, with this attempt's span as parent
+	traceHeaders := make(map[string]string)
+	observability.InjectHTTPHeaders(ctx, traceHeaders)
+	for name, value := range traceHeaders {
+		req.Header.Set(name, value)
+	}
+
+	// Perform request
+	start := time.Now()
+	resp, err := c.httpClient.Load().Do(req)
+	if err != nil {
+		c.recordRequest(method, path, 0, time.Since(start))
+		err = fmt.Errorf("request failed: %w", err)
+		if ctx.Err() == nil && !errors.Is(err, ErrHostNotAllowed) {
+			err = fmt.Errorf("%w: %w", ErrUnavailable, err)
+		}
+		observability.RecordError(span, err)
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	c.recordRequest(method, path, resp.StatusCode, time.Since(start))
+
+	observability.FromContext(ctx).DebugContext(ctx, "PCF request",
+		"method", method,
+		"path", spanPath,
+		"status", resp.StatusCode,
+		"attempt", attempt+1,
+		"duration", time.Since(start),
+	)
+
+	span.SetAttributes(observability.IntAttribute(observability.AttributeHTTPStatus, resp.StatusCode))
+
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		err = fmt.Errorf("failed to read response: %w", err)
+		observability.RecordError(span, err)
+		return nil, nil, err
+	}
+
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+
+	return resp, respBody, nil
+}
+
+// userAgentHeader returns the configured User-Agent, or pcf-mcp/<version>
+func (c *Client) userAgentHeader() string {
+	if c.userAgent != "" {
+		return c.userAgent
+	}
+	if c.version == "" {
+		return defaultUserAgentProduct
+	}
+	return defaultUserAgentProduct + "/" + c.version
+}
+
+// recordRequest reports a request attempt to the metrics hook, if configured
+func (c *Client) recordRequest(method, path string, status int, duration time.Duration) {
+	if c.metrics == nil {
+		return
+	}
+	c.metrics.RecordPCFRequest(method, templatePath(path), status, duration)
+}
+
+// recordRetry reports a retry to the metrics hook, if configured
+func (c *Client) recordRetry(path string) {
+	if c.metrics == nil {
+		return
+	}
+	c.metrics.RecordPCFRetry(templatePath(path))
+}
+
+// recordRetriesExhausted reports a request that failed on every attempt to
+// the metrics hook, if configured
+func (c *Client) recordRetriesExhausted() {
+	if c.metrics == nil {
+		return
+	}
+	c.metrics.RecordPCFRetriesExhausted()
+}
+
+// reportDownloadPath labels report download metrics. Report URLs are chosen
+// by PCF and may point at other hosts, so their paths are not templated.
+const reportDownloadPath = "/reports/:id/download"
+
+// pathCollections are path segments followed by a resource ID
+var pathCollections = map[string]bool{
+	"projects":    true,
+	"hosts":       true,
+	"issues":      true,
+	"credentials": true,
+	"reports":     true,
+}
+
+// templatePath replaces resource IDs and the query string in an API path so
+// it can be used as a low-cardinality metric label, e.g.
+// /api/projects/abc123/hosts?page=2 becomes /api/projects/:id/hosts
+func templatePath(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if segments[i] != "" && pathCollections[segments[i-1]] {
+			segments[i] = ":id"
+		}
+	}
 
-	return lastErr
+	return strings.Join(segments, "/")
 }
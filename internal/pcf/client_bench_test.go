@@ -126,6 +126,50 @@ func BenchmarkConcurrentRequests(b *testing.B) {
 	}
 }
 
+// BenchmarkConnectionPool compares concurrent request throughput with the
+// tuned connection pool against net/http's default of 2 idle connections
+// per host, which forces most concurrent requests to dial a new connection
+func BenchmarkConnectionPool(b *testing.B) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[{"id":"1","name":"Test Project"}]`))
+	}))
+	defer server.Close()
+
+	pools := []struct {
+		name                string
+		maxIdleConnsPerHost int
+	}{
+		{"net-http-default", http.DefaultMaxIdleConnsPerHost},
+		{"tuned", DefaultMaxIdleConnsPerHost},
+	}
+
+	for _, pool := range pools {
+		b.Run(pool.name, func(b *testing.B) {
+			client, err := NewClient(config.PCFConfig{
+				URL:                 server.URL,
+				APIKey:              "test-token",
+				Timeout:             30 * time.Second,
+				MaxIdleConnsPerHost: pool.maxIdleConnsPerHost,
+			})
+			if err != nil {
+				b.Fatal(err)
+			}
+			ctx := context.Background()
+
+			b.SetParallelism(DefaultMaxIdleConnsPerHost)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, _, err := client.ListProjectsPage(ctx, ListOptions{Page: 1, PageSize: 1}); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		})
+	}
+}
+
 // BenchmarkJSONParsing benchmarks JSON parsing performance
 func BenchmarkJSONParsing(b *testing.B) {
 	testCases := []struct {
@@ -0,0 +1,203 @@
+// Package issueimport converts spreadsheet findings into PCF issue requests
+package issueimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf/netutil"
+)
+
+// CSV columns. The header row names the columns in any order; title,
+// description, and severity are required.
+const (
+	ColumnTitle       = "title"
+	ColumnDescription = "description"
+	ColumnSeverity    = "severity"
+	ColumnCVE         = "cve"
+	ColumnCVSS        = "cvss"
+	ColumnHostIP      = "host_ip"
+)
+
+// knownColumns lists every column the header may name
+var knownColumns = map[string]bool{
+	ColumnTitle:       true,
+	ColumnDescription: true,
+	ColumnSeverity:    true,
+	ColumnCVE:         true,
+	ColumnCVSS:        true,
+	ColumnHostIP:      true,
+}
+
+// utf8BOM is written at the start of CSV files by some spreadsheet exports
+const utf8BOM = "\uFEFF"
+
+// requiredColumns lists the columns the header must name
+var requiredColumns = []string{ColumnTitle, ColumnDescription, ColumnSeverity}
+
+// severities lists the severity levels PCF accepts, in canonical case
+var severities = []string{"Critical", "High", "Medium", "Low", "Info"}
+
+// cvePattern matches CVE identifiers such as CVE-2021-44228
+var cvePattern = regexp.MustCompile(`^CVE-\d{4}-\d{4,}$`)
+
+// Row is one finding read from the CSV
+type Row struct {
+	// Line is the line of the CSV the row starts on; the header is line 1
+	Line int
+
+	// Issue is the issue to create, without a host ID
+	Issue pcf.CreateIssueRequest
+
+	// HostIP is the normalized address of the affected host, if any
+	HostIP string
+
+	// Err explains why the row cannot be imported; Issue is incomplete
+	// when it is set
+	Err error
+}
+
+// ParseCSV reads findings from CSV with a header row. Problems with a single
+// row, such as an unknown severity or a CVSS score out of range, are
+// reported in that row's Err so the remaining rows can still be imported.
+// An error is returned only when the CSV itself cannot be read or the
+// header is invalid.
+func ParseCSV(r io.Reader) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("CSV is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV header: %w", err)
+	}
+
+	columns, err := parseHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []Row
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CSV: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := Row{Line: line}
+		if len(record) != len(header) {
+			row.Err = fmt.Errorf("expected %d fields, got %d", len(header), len(record))
+		} else {
+			fields := make(map[string]string, len(columns))
+			for i, column := range columns {
+				fields[column] = strings.TrimSpace(record[i])
+			}
+			row.Issue, row.HostIP, row.Err = parseRow(fields)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// parseHeader returns the column name of each field, lowercased
+func parseHeader(header []string) ([]string, error) {
+	columns := make([]string, len(header))
+	seen := make(map[string]bool, len(header))
+
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, utf8BOM)))
+		if !knownColumns[name] {
+			return nil, fmt.Errorf("unknown CSV column %q (expected title, description, severity, cve, cvss, host_ip)", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate CSV column %q", name)
+		}
+		seen[name] = true
+		columns[i] = name
+	}
+
+	for _, name := range requiredColumns {
+		if !seen[name] {
+			return nil, fmt.Errorf("CSV header is missing the %s column", name)
+		}
+	}
+
+	return columns, nil
+}
+
+// parseRow validates the fields of one row
+func parseRow(fields map[string]string) (pcf.CreateIssueRequest, string, error) {
+	req := pcf.CreateIssueRequest{
+		Title:       fields[ColumnTitle],
+		Description: fields[ColumnDescription],
+	}
+
+	if req.Title == "" {
+		return req, "", fmt.Errorf("title cannot be empty")
+	}
+
+	if req.Description == "" {
+		return req, "", fmt.Errorf("description cannot be empty")
+	}
+
+	severity, ok := canonicalSeverity(fields[ColumnSeverity])
+	if !ok {
+		return req, "", fmt.Errorf("severity must be one of %s, got %q", strings.Join(severities, ", "), fields[ColumnSeverity])
+	}
+	req.Severity = severity
+
+	if cve := strings.ToUpper(fields[ColumnCVE]); cve != "" {
+		if !cvePattern.MatchString(cve) {
+			return req, "", fmt.Errorf("invalid CVE identifier %q", fields[ColumnCVE])
+		}
+		req.CVE = cve
+	}
+
+	if raw := fields[ColumnCVSS]; raw != "" {
+		cvss, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return req, "", fmt.Errorf("cvss must be a number, got %q", raw)
+		}
+		if cvss < 0 || cvss > 10 {
+			return req, "", fmt.Errorf("cvss must be between 0 and 10, got %g", cvss)
+		}
+		req.CVSS = cvss
+	}
+
+	var hostIP string
+	if raw := fields[ColumnHostIP]; raw != "" {
+		ip, err := netutil.NormalizeIP(raw)
+		if err != nil {
+			return req, "", fmt.Errorf("host_ip: %w", err)
+		}
+		hostIP = ip
+	}
+
+	return req, hostIP, nil
+}
+
+// canonicalSeverity matches a severity case-insensitively, since
+// spreadsheets rarely agree on capitalization
+func canonicalSeverity(value string) (string, bool) {
+	for _, severity := range severities {
+		if strings.EqualFold(value, severity) {
+			return severity, true
+		}
+	}
+	return "", false
+}
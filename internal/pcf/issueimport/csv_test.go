@@ -0,0 +1,99 @@
+package issueimport
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// TestParseCSV tests that valid rows are converted and invalid rows carry
+// their own error
+func TestParseCSV(t *testing.T) {
+	input := `title,description,severity,cve,cvss,host_ip
+Outdated Apache,Apache 2.4.49 is vulnerable,High,CVE-2021-41773,7.5,10.0.1.30
+"Weak password, admin",The admin account uses a default password,critical,,,
+No severity,Missing a severity,,,,
+Bad severity,Severity is not a PCF level,Severe,,,
+Bad CVSS,CVSS is not a number,Low,,high,
+CVSS out of range,CVSS is above 10,Low,,11,
+Bad CVE,CVE is malformed,Info,CVE-21-1,,
+Bad host,Host is a range,Medium,,,10.0.1.0/24
+Short row,Too few fields
+`
+
+	rows, err := ParseCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(rows) != 9 {
+		t.Fatalf("Expected 9 rows, got %d", len(rows))
+	}
+
+	want := Row{
+		Line:   2,
+		Issue:  pcf.CreateIssueRequest{Title: "Outdated Apache", Description: "Apache 2.4.49 is vulnerable", Severity: "High", CVE: "CVE-2021-41773", CVSS: 7.5},
+		HostIP: "10.0.1.30",
+	}
+	if rows[0] != want {
+		t.Errorf("Expected %+v, got %+v", want, rows[0])
+	}
+
+	if rows[1].Err != nil || rows[1].Issue.Title != "Weak password, admin" || rows[1].Issue.Severity != "Critical" {
+		t.Errorf("Expected a quoted title and canonical severity, got %+v", rows[1])
+	}
+
+	wantErrs := []string{
+		"severity must be one of",
+		"severity must be one of",
+		"cvss must be a number",
+		"cvss must be between 0 and 10",
+		"invalid CVE identifier",
+		"CIDR range",
+		"expected 6 fields, got 2",
+	}
+	for i, wantErr := range wantErrs {
+		row := rows[i+2]
+		if row.Err == nil || !strings.Contains(row.Err.Error(), wantErr) {
+			t.Errorf("Row on line %d: expected error containing %q, got %v", row.Line, wantErr, row.Err)
+		}
+		if row.Line != i+4 {
+			t.Errorf("Expected line %d, got %d", i+4, row.Line)
+		}
+	}
+}
+
+// TestParseCSVHeader tests header handling
+func TestParseCSVHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "Reordered and mixed case", input: "Severity, TITLE ,description\nLow,Title,Desc\n"},
+		{name: "Byte order mark", input: "\uFEFFtitle,description,severity\nLow,Title,Desc\n"},
+		{name: "Empty", input: "", wantErr: "CSV is empty"},
+		{name: "Missing required column", input: "title,severity\n", wantErr: "missing the description column"},
+		{name: "Unknown column", input: "title,description,severity,owner\n", wantErr: "unknown CSV column \"owner\""},
+		{name: "Duplicate column", input: "title,description,severity,title\n", wantErr: "duplicate CSV column"},
+		{name: "Malformed quoting", input: "title,description,severity\n\"Unclosed,Desc,Low\n", wantErr: "failed to parse CSV"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCSV(strings.NewReader(tt.input))
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
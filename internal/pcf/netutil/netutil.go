@@ -0,0 +1,89 @@
+// Package netutil validates and normalizes the host addresses stored in PCF
+package netutil
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// maxHostnameLength is the longest hostname DNS allows, without the
+// trailing dot
+const maxHostnameLength = 253
+
+// NormalizeIP parses a single IPv4 or IPv6 address and returns its
+// canonical form: IPv6 compressed and lowercased, and IPv4-mapped IPv6
+// addresses unmapped to IPv4. CIDR ranges and zoned addresses are rejected.
+func NormalizeIP(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", fmt.Errorf("IP address cannot be empty")
+	}
+
+	if strings.Contains(s, "/") {
+		return "", fmt.Errorf("%s is a CIDR range, not a single IP address", s)
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid IP address format: %s", s)
+	}
+
+	if addr.Zone() != "" {
+		return "", fmt.Errorf("IP address %s must not include a zone", s)
+	}
+
+	return addr.Unmap().String(), nil
+}
+
+// IsValidHost reports whether s is a single IP address accepted by
+// NormalizeIP or an RFC 1123 hostname
+func IsValidHost(s string) bool {
+	if _, err := NormalizeIP(s); err == nil {
+		return true
+	}
+
+	return isValidHostname(s)
+}
+
+// isValidHostname checks the RFC 1123 label rules. A final label made only
+// of digits is rejected so that malformed IPv4 addresses such as
+// 300.1.1.1 are not mistaken for hostnames.
+func isValidHostname(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	if s == "" || len(s) > maxHostnameLength {
+		return false
+	}
+
+	labels := strings.Split(s, ".")
+	for _, label := range labels {
+		if len(label) == 0 || len(label) > 63 {
+			return false
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			if !isAlphanumeric(c) && c != '-' {
+				return false
+			}
+		}
+	}
+
+	return !allDigits(labels[len(labels)-1])
+}
+
+// isAlphanumeric reports whether c is an ASCII letter or digit
+func isAlphanumeric(c rune) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// allDigits reports whether s consists only of ASCII digits
+func allDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package netutil
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestNormalizeIP tests canonicalization of valid addresses and the errors
+// for everything else
+func TestNormalizeIP(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{name: "IPv4", input: "192.168.1.100", want: "192.168.1.100"},
+		{name: "IPv4 with surrounding space", input: " 10.0.0.1\n", want: "10.0.0.1"},
+		{name: "IPv6 compressed", input: "2001:db8::1", want: "2001:db8::1"},
+		{name: "IPv6 expanded and uppercase", input: "2001:0DB8:0000:0000:0000:0000:0000:0001", want: "2001:db8::1"},
+		{name: "IPv6 loopback", input: "::1", want: "::1"},
+		{name: "IPv4-mapped IPv6", input: "::ffff:10.0.0.5", want: "10.0.0.5"},
+		{name: "Empty", input: "", wantErr: "cannot be empty"},
+		{name: "IPv4 CIDR", input: "10.0.0.0/24", wantErr: "CIDR range"},
+		{name: "IPv6 CIDR", input: "2001:db8::/32", wantErr: "CIDR range"},
+		{name: "Zoned IPv6", input: "fe80::1%eth0", wantErr: "zone"},
+		{name: "Hostname", input: "web01.example.com", wantErr: "invalid IP address format"},
+		{name: "Octet out of range", input: "256.1.1.1", wantErr: "invalid IP address format"},
+		{name: "Too few octets", input: "10.0.1", wantErr: "invalid IP address format"},
+		{name: "Leading zeros", input: "010.0.0.1", wantErr: "invalid IP address format"},
+		{name: "Malformed IPv6", input: "2001:db8:::1", wantErr: "invalid IP address format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeIP(tt.input)
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestIsValidHost tests that IP addresses and hostnames are accepted and
+// malformed inputs rejected
+func TestIsValidHost(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{input: "192.168.1.100", want: true},
+		{input: "2001:db8::1", want: true},
+		{input: "localhost", want: true},
+		{input: "web-01.corp.example.com", want: true},
+		{input: "example.com.", want: true},
+		{input: "3com.net", want: true},
+		{input: "", want: false},
+		{input: "10.0.0.0/24", want: false},
+		{input: "300.1.1.1", want: false},
+		{input: "-web.example.com", want: false},
+		{input: "web-.example.com", want: false},
+		{input: "web..example.com", want: false},
+		{input: "web_01.example.com", want: false},
+		{input: "web 01", want: false},
+		{input: strings.Repeat("a", 64) + ".example.com", want: false},
+		{input: strings.Repeat("a.", 127) + "com", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := IsValidHost(tt.input); got != tt.want {
+				t.Errorf("IsValidHost(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
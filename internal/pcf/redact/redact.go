@@ -0,0 +1,102 @@
+// Package redact masks sensitive credential fields before they leave the server
+package redact
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultPlaceholder replaces redacted values when no placeholder is configured
+const DefaultPlaceholder = "***REDACTED***"
+
+// FieldValue is the credential secret; it is always redacted
+const FieldValue = "value"
+
+// knownFields lists the credential fields that may be redacted
+var knownFields = map[string]bool{
+	FieldValue: true,
+	"username": true,
+	"notes":    true,
+	"service":  true,
+	"host_id":  true,
+	"type":     true,
+}
+
+// Redactor masks a configured set of credential fields with a placeholder.
+// A nil *Redactor behaves like Default().
+type Redactor struct {
+	placeholder string
+	fields      map[string]bool
+}
+
+// New creates a Redactor for the given credential fields. The value field is
+// always included, and an empty placeholder falls back to DefaultPlaceholder.
+func New(fields []string, placeholder string) (*Redactor, error) {
+	if placeholder == "" {
+		placeholder = DefaultPlaceholder
+	}
+
+	r := &Redactor{
+		placeholder: placeholder,
+		fields:      map[string]bool{FieldValue: true},
+	}
+
+	for _, field := range fields {
+		field = strings.TrimSpace(strings.ToLower(field))
+		if !knownFields[field] {
+			return nil, fmt.Errorf("unknown redact field: %q", field)
+		}
+		r.fields[field] = true
+	}
+
+	return r, nil
+}
+
+// Default returns a Redactor that masks only credential values with DefaultPlaceholder
+func Default() *Redactor {
+	return &Redactor{
+		placeholder: DefaultPlaceholder,
+		fields:      map[string]bool{FieldValue: true},
+	}
+}
+
+// Placeholder returns the string used in place of redacted values
+func (r *Redactor) Placeholder() string {
+	if r == nil {
+		return DefaultPlaceholder
+	}
+	return r.placeholder
+}
+
+// Fields returns the redacted field names in sorted order
+func (r *Redactor) Fields() []string {
+	if r == nil {
+		return []string{FieldValue}
+	}
+
+	fields := make([]string, 0, len(r.fields))
+	for field := range r.fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Redacts reports whether the named field is masked
+func (r *Redactor) Redacts(field string) bool {
+	if r == nil {
+		return field == FieldValue
+	}
+	return r.fields[field]
+}
+
+// Apply masks every configured field present in record, in place
+func (r *Redactor) Apply(record map[string]interface{}) map[string]interface{} {
+	for field := range record {
+		if r.Redacts(field) {
+			record[field] = r.Placeholder()
+		}
+	}
+	return record
+}
@@ -0,0 +1,109 @@
+package redact
+
+import (
+	"reflect"
+	"testing"
+)
+
+// credentialRecord returns a serialized credential with every redactable field set
+func credentialRecord() map[string]interface{} {
+	return map[string]interface{}{
+		"id":         "cred-1",
+		"project_id": "proj-1",
+		"host_id":    "host-1",
+		"type":       "password",
+		"username":   "admin",
+		"value":      "s3cret",
+		"service":    "ssh",
+		"notes":      "found in backup.zip",
+	}
+}
+
+// TestApply tests that configured fields are masked and others pass through
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name        string
+		fields      []string
+		placeholder string
+		masked      []string
+	}{
+		{
+			name:   "Default masks only the value",
+			masked: []string{"value"},
+		},
+		{
+			name:        "Custom fields and placeholder",
+			fields:      []string{"username", "notes"},
+			placeholder: "[hidden]",
+			masked:      []string{"notes", "username", "value"},
+		},
+		{
+			name:   "Field names are normalized",
+			fields: []string{" Service "},
+			masked: []string{"service", "value"},
+		},
+		{
+			name:   "Every known field",
+			fields: []string{"value", "username", "notes", "service", "host_id", "type"},
+			masked: []string{"host_id", "notes", "service", "type", "username", "value"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := New(tt.fields, tt.placeholder)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(r.Fields(), tt.masked) {
+				t.Errorf("Expected fields %v, got %v", tt.masked, r.Fields())
+			}
+
+			placeholder := tt.placeholder
+			if placeholder == "" {
+				placeholder = DefaultPlaceholder
+			}
+
+			original := credentialRecord()
+			record := r.Apply(credentialRecord())
+
+			masked := make(map[string]bool)
+			for _, field := range tt.masked {
+				masked[field] = true
+			}
+
+			for field, value := range original {
+				if masked[field] {
+					if record[field] != placeholder {
+						t.Errorf("Field %q should be masked, got %v", field, record[field])
+					}
+				} else if record[field] != value {
+					t.Errorf("Field %q should pass through, got %v", field, record[field])
+				}
+			}
+		})
+	}
+}
+
+// TestNewUnknownField tests that unknown field names are rejected
+func TestNewUnknownField(t *testing.T) {
+	if _, err := New([]string{"password"}, ""); err == nil {
+		t.Error("Expected error for unknown field")
+	}
+}
+
+// TestNilRedactor tests that a nil Redactor behaves like Default
+func TestNilRedactor(t *testing.T) {
+	var r *Redactor
+
+	record := r.Apply(credentialRecord())
+
+	if record["value"] != DefaultPlaceholder {
+		t.Errorf("Expected value to be masked, got %v", record["value"])
+	}
+
+	if record["username"] != "admin" {
+		t.Errorf("Expected username to pass through, got %v", record["username"])
+	}
+}
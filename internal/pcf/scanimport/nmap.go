@@ -0,0 +1,232 @@
+// Package scanimport converts scanner output into PCF host records
+package scanimport
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+	"github.com/aRustyDev/pcf-mcp/internal/pcf/netutil"
+)
+
+// Host is a live host discovered by a scan
+type Host struct {
+	// IP is the host IPv4 or IPv6 address
+	IP string
+
+	// Hostname is the first reported hostname, if any
+	Hostname string
+
+	// OS is the best operating system match, if any
+	OS string
+
+	// Services lists the open ports on the host
+	Services []Service
+}
+
+// Service is an open port discovered on a host
+type Service struct {
+	// Port is the port number
+	Port int
+
+	// Protocol is the transport protocol (tcp, udp, sctp)
+	Protocol string
+
+	// Name is the detected service name (e.g. ssh, http)
+	Name string
+
+	// Product is the detected software, if version detection was run
+	Product string
+
+	// Version is the detected software version
+	Version string
+}
+
+// ServiceNames returns a deduplicated list of service names for the host.
+// Ports without a detected service are reported as "port/protocol".
+func (h Host) ServiceNames() []string {
+	names := make([]string, 0, len(h.Services))
+	seen := make(map[string]bool)
+
+	for _, svc := range h.Services {
+		name := svc.Name
+		if name == "" || name == "unknown" {
+			name = fmt.Sprintf("%d/%s", svc.Port, svc.Protocol)
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// ServiceDetails returns every open port on the host as a PCF service
+func (h Host) ServiceDetails() []pcf.Service {
+	details := make([]pcf.Service, 0, len(h.Services))
+	for _, svc := range h.Services {
+		name := svc.Name
+		if name == "unknown" {
+			name = ""
+		}
+		details = append(details, pcf.Service{
+			Name:     name,
+			Port:     svc.Port,
+			Protocol: svc.Protocol,
+		})
+	}
+
+	return details
+}
+
+// CreateHostRequest converts the scanned host into a PCF host creation request
+func (h Host) CreateHostRequest() pcf.CreateHostRequest {
+	return pcf.CreateHostRequest{
+		IP:             h.IP,
+		Hostname:       h.Hostname,
+		OS:             h.OS,
+		Services:       h.ServiceNames(),
+		ServiceDetails: h.ServiceDetails(),
+	}
+}
+
+// nmapRun mirrors the subset of the nmap XML output format we consume
+type nmapRun struct {
+	XMLName xml.Name   `xml:"nmaprun"`
+	Hosts   []nmapHost `xml:"host"`
+}
+
+type nmapHost struct {
+	Status    nmapStatus     `xml:"status"`
+	Addresses []nmapAddress  `xml:"address"`
+	Hostnames []nmapHostname `xml:"hostnames>hostname"`
+	Ports     []nmapPort     `xml:"ports>port"`
+	OSMatches []nmapOSMatch  `xml:"os>osmatch"`
+}
+
+type nmapStatus struct {
+	State string `xml:"state,attr"`
+}
+
+type nmapAddress struct {
+	Addr     string `xml:"addr,attr"`
+	AddrType string `xml:"addrtype,attr"`
+}
+
+type nmapHostname struct {
+	Name string `xml:"name,attr"`
+}
+
+type nmapPort struct {
+	Protocol string      `xml:"protocol,attr"`
+	PortID   string      `xml:"portid,attr"`
+	State    nmapStatus  `xml:"state"`
+	Service  nmapService `xml:"service"`
+}
+
+type nmapService struct {
+	Name    string `xml:"name,attr"`
+	Product string `xml:"product,attr"`
+	Version string `xml:"version,attr"`
+}
+
+type nmapOSMatch struct {
+	Name     string `xml:"name,attr"`
+	Accuracy string `xml:"accuracy,attr"`
+}
+
+// ParseNmapXML parses nmap XML output (nmap -oX) and returns the hosts that
+// were up, with only their open ports. Hosts without a valid IP address are
+// skipped, and addresses are normalized with netutil.NormalizeIP.
+func ParseNmapXML(r io.Reader) ([]Host, error) {
+	var run nmapRun
+	if err := xml.NewDecoder(r).Decode(&run); err != nil {
+		return nil, fmt.Errorf("failed to parse nmap XML: %w", err)
+	}
+
+	hosts := make([]Host, 0, len(run.Hosts))
+	for _, nh := range run.Hosts {
+		if nh.Status.State != "" && nh.Status.State != "up" {
+			continue
+		}
+
+		host := Host{IP: hostIP(nh.Addresses)}
+		if host.IP == "" {
+			continue
+		}
+
+		for _, hn := range nh.Hostnames {
+			if hn.Name != "" {
+				host.Hostname = hn.Name
+				break
+			}
+		}
+
+		host.OS = bestOSMatch(nh.OSMatches)
+
+		for _, np := range nh.Ports {
+			if np.State.State != "open" {
+				continue
+			}
+
+			port, err := strconv.Atoi(np.PortID)
+			if err != nil {
+				return nil, fmt.Errorf("invalid port %q for host %s: %w", np.PortID, host.IP, err)
+			}
+
+			host.Services = append(host.Services, Service{
+				Port:     port,
+				Protocol: np.Protocol,
+				Name:     np.Service.Name,
+				Product:  np.Service.Product,
+				Version:  np.Service.Version,
+			})
+		}
+
+		hosts = append(hosts, host)
+	}
+
+	return hosts, nil
+}
+
+// hostIP returns the host's normalized IPv4 address, falling back to IPv6.
+// Addresses that do not parse are ignored.
+func hostIP(addresses []nmapAddress) string {
+	ipv6 := ""
+	for _, addr := range addresses {
+		ip, err := netutil.NormalizeIP(addr.Addr)
+		if err != nil {
+			continue
+		}
+		switch addr.AddrType {
+		case "ipv4", "":
+			return ip
+		case "ipv6":
+			if ipv6 == "" {
+				ipv6 = ip
+			}
+		}
+	}
+	return ipv6
+}
+
+// bestOSMatch returns the name of the most accurate OS match
+func bestOSMatch(matches []nmapOSMatch) string {
+	best := ""
+	bestAccuracy := -1
+	for _, m := range matches {
+		accuracy, err := strconv.Atoi(m.Accuracy)
+		if err != nil {
+			accuracy = 0
+		}
+		if accuracy > bestAccuracy {
+			best = m.Name
+			bestAccuracy = accuracy
+		}
+	}
+	return best
+}
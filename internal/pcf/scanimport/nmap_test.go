@@ -0,0 +1,191 @@
+package scanimport
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aRustyDev/pcf-mcp/internal/pcf"
+)
+
+// sampleNmapXML is trimmed output from `nmap -sV -O -oX - 10.0.1.0/24`
+const sampleNmapXML = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE nmaprun>
+<nmaprun scanner="nmap" args="nmap -sV -O -oX - 10.0.1.0/24" start="1700000000" version="7.94">
+  <host>
+    <status state="up" reason="arp-response"/>
+    <address addr="10.0.1.30" addrtype="ipv4"/>
+    <address addr="00:11:22:33:44:55" addrtype="mac" vendor="Dell"/>
+    <hostnames>
+      <hostname name="web01.corp.local" type="PTR"/>
+    </hostnames>
+    <ports>
+      <extraports state="closed" count="996"/>
+      <port protocol="tcp" portid="22">
+        <state state="open" reason="syn-ack"/>
+        <service name="ssh" product="OpenSSH" version="8.9p1"/>
+      </port>
+      <port protocol="tcp" portid="80">
+        <state state="open" reason="syn-ack"/>
+        <service name="http" product="Apache httpd" version="2.4.52"/>
+      </port>
+      <port protocol="tcp" portid="8080">
+        <state state="open" reason="syn-ack"/>
+        <service name="http" product="Jetty"/>
+      </port>
+      <port protocol="tcp" portid="443">
+        <state state="filtered" reason="no-response"/>
+        <service name="https"/>
+      </port>
+    </ports>
+    <os>
+      <osmatch name="Linux 4.15 - 5.8" accuracy="96"/>
+      <osmatch name="Linux 5.0 - 5.4" accuracy="98"/>
+    </os>
+  </host>
+  <host>
+    <status state="down" reason="no-response"/>
+    <address addr="10.0.1.31" addrtype="ipv4"/>
+  </host>
+  <host>
+    <status state="up" reason="echo-reply"/>
+    <address addr="10.0.1.40" addrtype="ipv4"/>
+    <ports>
+      <port protocol="udp" portid="161">
+        <state state="open" reason="udp-response"/>
+      </port>
+    </ports>
+  </host>
+</nmaprun>`
+
+// TestParseNmapXML tests parsing hosts, open ports, and services
+func TestParseNmapXML(t *testing.T) {
+	hosts, err := ParseNmapXML(strings.NewReader(sampleNmapXML))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(hosts) != 2 {
+		t.Fatalf("Expected 2 live hosts, got %d", len(hosts))
+	}
+
+	web := hosts[0]
+	if web.IP != "10.0.1.30" {
+		t.Errorf("Expected IP 10.0.1.30, got %s", web.IP)
+	}
+
+	if web.Hostname != "web01.corp.local" {
+		t.Errorf("Expected hostname web01.corp.local, got %s", web.Hostname)
+	}
+
+	if web.OS != "Linux 5.0 - 5.4" {
+		t.Errorf("Expected most accurate OS match, got %s", web.OS)
+	}
+
+	if len(web.Services) != 3 {
+		t.Fatalf("Expected 3 open ports, got %d", len(web.Services))
+	}
+
+	if web.Services[0].Port != 22 || web.Services[0].Protocol != "tcp" || web.Services[0].Product != "OpenSSH" {
+		t.Errorf("Unexpected first service: %+v", web.Services[0])
+	}
+
+	if names := web.ServiceNames(); !reflect.DeepEqual(names, []string{"ssh", "http"}) {
+		t.Errorf("Expected deduplicated service names [ssh http], got %v", names)
+	}
+
+	// Ports without a detected service fall back to port/protocol
+	snmp := hosts[1]
+	if names := snmp.ServiceNames(); !reflect.DeepEqual(names, []string{"161/udp"}) {
+		t.Errorf("Expected [161/udp], got %v", names)
+	}
+}
+
+// TestHostCreateHostRequest tests conversion to a PCF host request
+func TestHostCreateHostRequest(t *testing.T) {
+	host := Host{
+		IP:       "10.0.1.30",
+		Hostname: "web01",
+		OS:       "Linux",
+		Services: []Service{{Port: 2222, Protocol: "tcp", Name: "ssh"}, {Port: 9999, Protocol: "udp", Name: "unknown"}},
+	}
+
+	req := host.CreateHostRequest()
+
+	if req.IP != host.IP || req.Hostname != host.Hostname || req.OS != host.OS {
+		t.Errorf("Host fields not copied: %+v", req)
+	}
+
+	if !reflect.DeepEqual(req.Services, []string{"ssh", "9999/udp"}) {
+		t.Errorf("Expected services [ssh 9999/udp], got %v", req.Services)
+	}
+
+	wantDetails := []pcf.Service{{Name: "ssh", Port: 2222, Protocol: "tcp"}, {Port: 9999, Protocol: "udp"}}
+	if !reflect.DeepEqual(req.ServiceDetails, wantDetails) {
+		t.Errorf("Expected service details %v, got %v", wantDetails, req.ServiceDetails)
+	}
+}
+
+// TestParseNmapXMLErrors tests malformed input handling
+func TestParseNmapXMLErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "Empty document", input: ""},
+		{name: "Not XML", input: "Nmap scan report for 10.0.1.30"},
+		{name: "Wrong root element", input: `<scan><host/></scan>`},
+		{
+			name: "Invalid port number",
+			input: `<nmaprun><host><status state="up"/><address addr="10.0.1.1" addrtype="ipv4"/>
+				<ports><port protocol="tcp" portid="abc"><state state="open"/></port></ports></host></nmaprun>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseNmapXML(strings.NewReader(tt.input)); err == nil {
+				t.Error("Expected error but got none")
+			}
+		})
+	}
+}
+
+// TestParseNmapXMLIPv6 tests falling back to IPv6 addresses
+func TestParseNmapXMLIPv6(t *testing.T) {
+	input := `<nmaprun><host><status state="up"/><address addr="fe80::1" addrtype="ipv6"/></host></nmaprun>`
+
+	hosts, err := ParseNmapXML(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(hosts) != 1 || hosts[0].IP != "fe80::1" {
+		t.Errorf("Expected IPv6 host fe80::1, got %+v", hosts)
+	}
+}
+
+// TestParseNmapXMLNormalizesAddresses tests that addresses are normalized and
+// unparseable ones ignored
+func TestParseNmapXMLNormalizesAddresses(t *testing.T) {
+	input := `<nmaprun>
+<host><status state="up"/><address addr="2001:0DB8:0:0:0:0:0:1" addrtype="ipv6"/></host>
+<host><status state="up"/><address addr="10.0.0.999" addrtype="ipv4"/><address addr="fe80::2" addrtype="ipv6"/></host>
+<host><status state="up"/><address addr="not-an-ip" addrtype="ipv4"/></host>
+</nmaprun>`
+
+	hosts, err := ParseNmapXML(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(hosts) != 2 {
+		t.Fatalf("Expected 2 hosts, got %+v", hosts)
+	}
+	if hosts[0].IP != "2001:db8::1" {
+		t.Errorf("Expected normalized IPv6 2001:db8::1, got %q", hosts[0].IP)
+	}
+	if hosts[1].IP != "fe80::2" {
+		t.Errorf("Expected fallback to IPv6 fe80::2, got %q", hosts[1].IP)
+	}
+}
@@ -78,7 +78,7 @@ func TestEndToEndPentestWorkflow(t *testing.T) {
 
 	mcpServer.SetMetrics(metrics)
 
-	if err := tools.RegisterAllTools(mcpServer, pcfClient); err != nil {
+	if err := tools.RegisterAllTools(mcpServer, pcfClient, tools.Options{}); err != nil {
 		t.Fatalf("Failed to register tools: %v", err)
 	}
 
@@ -547,7 +547,7 @@ func TestStressTest(t *testing.T) {
 		t.Fatalf("Failed to create MCP server: %v", err)
 	}
 
-	if err := tools.RegisterAllTools(mcpServer, pcfClient); err != nil {
+	if err := tools.RegisterAllTools(mcpServer, pcfClient, tools.Options{}); err != nil {
 		t.Fatalf("Failed to register tools: %v", err)
 	}
 
@@ -326,7 +326,7 @@ func TestFullIntegration(t *testing.T) {
 	mcpServer.SetMetrics(metrics)
 
 	// Register all tools
-	if err := tools.RegisterAllTools(mcpServer, pcfClient); err != nil {
+	if err := tools.RegisterAllTools(mcpServer, pcfClient, tools.Options{}); err != nil {
 		t.Fatalf("Failed to register tools: %v", err)
 	}
 
@@ -399,8 +399,8 @@ func TestFullIntegration(t *testing.T) {
 			t.Fatal("Tools should be an array")
 		}
 
-		if len(tools) != 9 {
-			t.Errorf("Expected 9 tools, got %d", len(tools))
+		if len(tools) != 20 {
+			t.Errorf("Expected 20 tools, got %d", len(tools))
 		}
 	})
 
@@ -663,7 +663,7 @@ func TestConcurrentRequests(t *testing.T) {
 		t.Fatalf("Failed to create MCP server: %v", err)
 	}
 
-	if err := tools.RegisterAllTools(mcpServer, pcfClient); err != nil {
+	if err := tools.RegisterAllTools(mcpServer, pcfClient, tools.Options{}); err != nil {
 		t.Fatalf("Failed to register tools: %v", err)
 	}
 